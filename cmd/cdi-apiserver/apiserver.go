@@ -30,6 +30,7 @@ import (
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -37,6 +38,8 @@ import (
 	"k8s.io/klog/v2"
 	aggregatorclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 
@@ -131,7 +134,19 @@ func main() {
 
 	snapClient := snapclient.NewForConfigOrDie(cfg)
 
-	cluster, err := cluster.New(cfg)
+	// The API server only reads the worker pods exposing metrics, don't cache all the pods of the cluster
+	workerPodSelector, err := labels.Parse(common.PrometheusLabelKey)
+	if err != nil {
+		klog.Fatalf("Unable to parse worker pod selector: %v\n", errors.WithStack(err))
+	}
+
+	cluster, err := cluster.New(cfg, func(options *cluster.Options) {
+		options.Cache.ByObject = map[crclient.Object]cache.ByObject{
+			&corev1.Pod{}: {
+				Label: workerPodSelector,
+			},
+		}
+	})
 	if err != nil {
 		klog.Fatalf("Unable to create controller runtime cluster: %v\n", errors.WithStack(err))
 	}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/transfer"
+	prometheusutil "kubevirt.io/containerized-data-importer/pkg/util/prometheus"
+)
+
+const fanOutBufferSize = 1024 * 1024
+
+// fanOut copies a single source stream to multiple readers. A reader that is closed stops getting
+// the stream without affecting the others, the source is read as fast as the slowest remaining reader.
+type fanOut struct {
+	source  io.ReadCloser
+	writers []*io.PipeWriter
+}
+
+func newFanOut(source io.ReadCloser, n int) (*fanOut, []io.ReadCloser) {
+	f := &fanOut{source: source}
+	var readers []io.ReadCloser
+	for i := 0; i < n; i++ {
+		pr, pw := io.Pipe()
+		f.writers = append(f.writers, pw)
+		readers = append(readers, pr)
+	}
+	return f, readers
+}
+
+func (f *fanOut) run() {
+	defer f.source.Close()
+
+	buf := make([]byte, fanOutBufferSize)
+	errs := make([]error, len(f.writers))
+	alive := len(f.writers)
+	for alive > 0 {
+		n, err := f.source.Read(buf)
+		if n > 0 {
+			var wg sync.WaitGroup
+			for i, w := range f.writers {
+				if w == nil {
+					continue
+				}
+				wg.Add(1)
+				go func(i int, w *io.PipeWriter) {
+					defer wg.Done()
+					_, errs[i] = w.Write(buf[:n])
+				}(i, w)
+			}
+			wg.Wait()
+
+			for i, w := range f.writers {
+				if w != nil && errs[i] != nil {
+					klog.Errorf("Dropping clone target %d: %v", i, errs[i])
+					f.writers[i] = nil
+					alive--
+				}
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			for _, w := range f.writers {
+				if w != nil {
+					_ = w.CloseWithError(err)
+				}
+			}
+			return
+		}
+	}
+}
+
+func getClonerTargets(value string) ([]common.ClonerTarget, error) {
+	var targets []common.ClonerTarget
+	if err := json.Unmarshal([]byte(value), &targets); err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, errors.New("no clone targets")
+	}
+	return targets, nil
+}
+
+// compress is pipeToSnappy reporting the errors to the reader instead of exiting, and closing the input
+// when the reader goes away
+func compress(reader io.ReadCloser) *io.PipeReader {
+	pr, pw := io.Pipe()
+
+	go func() {
+		sbw := snappy.NewBufferedWriter(pw)
+		n, err := io.Copy(sbw, reader)
+		if err == nil {
+			err = sbw.Close()
+		}
+		_ = reader.Close()
+		_ = pw.CloseWithError(err)
+		klog.Infof("Wrote %d bytes\n", n)
+	}()
+
+	return pr
+}
+
+// cloneToTargets uploads the source to all targets at once, it returns the names of the targets that failed
+func cloneToTargets(client *http.Client, source io.ReadCloser, targets []common.ClonerTarget, totalBytes uint64) ([]string, error) {
+	if err := metrics.SetupMetrics(); err != nil {
+		return nil, err
+	}
+	if err := transfer.SetupMetrics(); err != nil {
+		return nil, err
+	}
+
+	f, readers := newFanOut(source, len(targets))
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		promReader := prometheusutil.NewProgressReader(readers[i], metrics.Progress(target.OwnerUID), transfer.Stats(target.OwnerUID), totalBytes)
+		promReader.StartTimedUpdate()
+		reader := compress(promReader)
+
+		wg.Add(1)
+		go func(i int, target common.ClonerTarget) {
+			defer wg.Done()
+			if errs[i] = upload(client, target.URL, reader); errs[i] != nil {
+				// Stops the stream to this target only
+				_ = reader.CloseWithError(errs[i])
+			}
+		}(i, target)
+	}
+
+	go f.run()
+	wg.Wait()
+
+	var failed []string
+	for i, target := range targets {
+		if errs[i] != nil {
+			klog.Errorf("Clone to %s failed: %v", target.Name, errs[i])
+			failed = append(failed, target.Name)
+		}
+	}
+
+	return failed, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/golang/snappy"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Batch clone", func() {
+	var (
+		data     []byte
+		mutex    sync.Mutex
+		received map[string][]byte
+	)
+
+	newUploadServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(snappy.NewReader(r.Body))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			mutex.Lock()
+			received[name] = body
+			mutex.Unlock()
+		}))
+	}
+
+	BeforeEach(func() {
+		data = make([]byte, 5*fanOutBufferSize+123)
+		_, err := rand.Read(data)
+		Expect(err).ToNot(HaveOccurred())
+		received = map[string][]byte{}
+	})
+
+	It("should fan out the source to all targets", func() {
+		var targets []common.ClonerTarget
+		for _, name := range []string{"ns/target1", "ns/target2", "other/target3"} {
+			ts := newUploadServer(name)
+			defer ts.Close()
+			targets = append(targets, common.ClonerTarget{Name: name, URL: ts.URL, OwnerUID: name})
+		}
+
+		failed, err := cloneToTargets(http.DefaultClient, io.NopCloser(bytes.NewReader(data)), targets, uint64(len(data)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(failed).To(BeEmpty())
+		Expect(received).To(HaveLen(3))
+		for _, body := range received {
+			Expect(body).To(Equal(data))
+		}
+	})
+
+	It("should keep cloning to the other targets when one fails", func() {
+		good := newUploadServer("ns/good")
+		defer good.Close()
+		bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.CopyN(io.Discard, r.Body, fanOutBufferSize)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer bad.Close()
+
+		targets := []common.ClonerTarget{
+			{Name: "ns/bad", URL: bad.URL, OwnerUID: "bad"},
+			{Name: "ns/good", URL: good.URL, OwnerUID: "good"},
+		}
+		failed, err := cloneToTargets(http.DefaultClient, io.NopCloser(bytes.NewReader(data)), targets, uint64(len(data)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(failed).To(Equal([]string{"ns/bad"}))
+		Expect(received["ns/good"]).To(Equal(data))
+	})
+
+	It("should parse the clone targets", func() {
+		targets, err := getClonerTargets(`[{"name":"ns/target","url":"https://upload","ownerUID":"uid"}]`)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(targets).To(Equal([]common.ClonerTarget{{Name: "ns/target", URL: "https://upload", OwnerUID: "uid"}}))
+
+		_, err = getClonerTargets(`[]`)
+		Expect(err).To(HaveOccurred())
+	})
+})
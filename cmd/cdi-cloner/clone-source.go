@@ -6,6 +6,7 @@ import (
 	"crypto/x509"
 	"errors"
 	"flag"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -18,6 +19,7 @@ import (
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/transfer"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 	prometheusutil "kubevirt.io/containerized-data-importer/pkg/util/prometheus"
 )
@@ -103,7 +105,10 @@ func createProgressReader(readCloser io.ReadCloser, ownerUID string, totalBytes
 	if err := metrics.SetupMetrics(); err != nil {
 		return nil, err
 	}
-	promReader := prometheusutil.NewProgressReader(readCloser, metrics.Progress(ownerUID), totalBytes)
+	if err := transfer.SetupMetrics(); err != nil {
+		return nil, err
+	}
+	promReader := prometheusutil.NewProgressReader(readCloser, metrics.Progress(ownerUID), transfer.Stats(ownerUID), totalBytes)
 	promReader.StartTimedUpdate()
 
 	return promReader, nil
@@ -239,18 +244,71 @@ func main() {
 		klog.V(3).Infof("Preallocation variable (%s) not set, defaulting to 'false'", common.Preallocation)
 	}
 
-	klog.V(1).Infoln("Starting cloner target")
+	client := createHTTPClient(clientKey, clientCert, serverCert)
 
-	progressReader, err := createProgressReader(getInputStream(preallocation), ownerUID, uploadBytes)
-	if err != nil {
-		klog.Fatalf("Error creating progress reader: %v", err)
-	}
-	reader := pipeToSnappy(progressReader)
+	var failed []string
+	if value := os.Getenv(common.ClonerTargets); value != "" {
+		targets, err := getClonerTargets(value)
+		if err != nil {
+			klog.Fatalf("Error parsing clone targets: %v", err)
+		}
 
-	startPrometheus()
+		klog.V(1).Infof("Starting cloner for %d targets", len(targets))
 
-	client := createHTTPClient(clientKey, clientCert, serverCert)
+		startPrometheus()
+
+		failed, err = cloneToTargets(client, getInputStream(preallocation), targets, uploadBytes)
+		if err != nil {
+			klog.Fatalf("Error cloning to targets: %v", err)
+		}
+		if len(failed) == len(targets) {
+			klog.Fatalf("Clone failed for all targets")
+		}
+	} else {
+		klog.V(1).Infoln("Starting cloner target")
+
+		progressReader, err := createProgressReader(getInputStream(preallocation), ownerUID, uploadBytes)
+		if err != nil {
+			klog.Fatalf("Error creating progress reader: %v", err)
+		}
+		reader := pipeToSnappy(progressReader)
+
+		startPrometheus()
+
+		if err := upload(client, url, reader); err != nil {
+			klog.Errorf("%v", err)
+			if err := util.WriteFailureTerminationMessage(fmt.Sprintf("Unable to clone data: %v", err), util.GetFailureReason(err)); err != nil {
+				klog.Errorf("%+v", err)
+			}
+			os.Exit(1)
+		}
+	}
 
+	klog.V(1).Infoln("clone complete")
+	message := "Clone Complete"
+	if preallocation {
+		message += ", " + common.PreallocationApplied
+	}
+	if len(failed) > 0 {
+		// The controller clones the failed members of the batch with their own source pod
+		termMsg := &common.TerminationMessage{Message: &message, FailedTargets: failed}
+		if preallocation {
+			termMsg.PreallocationApplied = &preallocation
+		}
+		message, err = termMsg.String()
+		if err != nil {
+			klog.Errorf("%+v", err)
+			os.Exit(1)
+		}
+	}
+	err = util.WriteTerminationMessage(message)
+	if err != nil {
+		klog.Errorf("%+v", err)
+		os.Exit(1)
+	}
+}
+
+func upload(client *http.Client, url string, reader io.Reader) error {
 	req, _ := http.NewRequest(http.MethodPost, url, reader)
 
 	if contentType != "" {
@@ -260,29 +318,21 @@ func main() {
 
 	response, err := client.Do(req)
 	if err != nil {
-		klog.Fatalf("Error %s POSTing to %s", err, url)
+		return fmt.Errorf("error %w POSTing to %s", err, url)
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		klog.Fatalf("Unexpected status code %d", response.StatusCode)
+		return util.NewHTTPStatusError(response.StatusCode, fmt.Errorf("unexpected status code %d from %s", response.StatusCode, url))
 	}
 
 	var buf bytes.Buffer
 	_, err = io.Copy(&buf, response.Body)
 	if err != nil {
-		klog.Fatalf("Error %s copying response body", err)
+		return fmt.Errorf("error %s copying response body", err)
 	}
 
 	klog.V(1).Infof("Response body:\n%s", buf.String())
 
-	klog.V(1).Infoln("clone complete")
-	message := "Clone Complete"
-	if preallocation {
-		message += ", " + common.PreallocationApplied
-	}
-	err = util.WriteTerminationMessage(message)
-	if err != nil {
-		klog.Errorf("%+v", err)
-		os.Exit(1)
-	}
+	return nil
 }
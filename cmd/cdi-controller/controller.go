@@ -300,6 +300,14 @@ func start() {
 		klog.Errorf("Unable to setup datasource controller: %v", err)
 		os.Exit(1)
 	}
+	if _, err := controller.NewDataVolumeBackupController(mgr, log, importerImage, pullPolicy, verbose, installerLabels); err != nil {
+		klog.Errorf("Unable to setup datavolumebackup controller: %v", err)
+		os.Exit(1)
+	}
+	if _, err := controller.NewSparsifyController(mgr, log, importerImage, pullPolicy, verbose, installerLabels); err != nil {
+		klog.Errorf("Unable to setup sparsify controller: %v", err)
+		os.Exit(1)
+	}
 	// Populator controllers and indexes
 	if err := populators.CreateCommonPopulatorIndexes(mgr); err != nil {
 		klog.Errorf("Unable to create common populator indexes: %v", err)
@@ -313,7 +321,7 @@ func start() {
 		klog.Errorf("Unable to setup upload populator: %v", err)
 		os.Exit(1)
 	}
-	if _, err := populators.NewClonePopulator(ctx, mgr, log, clonerImage, pullPolicy, installerLabels, getTokenPublicKey()); err != nil {
+	if _, err := populators.NewClonePopulator(ctx, mgr, log, clonerImage, importerImage, pullPolicy, installerLabels, getTokenPublicKey()); err != nil {
 		klog.Errorf("Unable to setup clone populator: %v", err)
 		os.Exit(1)
 	}
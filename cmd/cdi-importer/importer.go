@@ -13,10 +13,12 @@ package main
 //    ImporterSecretKey     Optional. Secret key is the password to your account.
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -36,7 +38,9 @@ import (
 )
 
 const (
-	completeMessage = "Import Complete"
+	completeMessage       = "Import Complete"
+	backupCompleteMessage = "Backup Complete"
+	patchImageName        = "patch.img"
 )
 
 func init() {
@@ -83,6 +87,62 @@ func getHTTPEp(ep string) string {
 	return strings.TrimSuffix(ep, common.DiskImageName) + string(imageName)
 }
 
+// getDifferencingDisks returns the URLs of the VHDX differencing disks applied on top of the endpoint, in order
+func getDifferencingDisks() []string {
+	value, _ := util.ParseEnvVar(common.ImporterDifferencingDisks, false)
+	if value == "" {
+		return nil
+	}
+	var disks []string
+	if err := json.Unmarshal([]byte(value), &disks); err != nil {
+		klog.Errorf("Failed parsing env var %s: %+v", common.ImporterDifferencingDisks, err)
+		os.Exit(1)
+	}
+	return disks
+}
+
+// getCustomizeRecipe returns the customization recipe of the ConfigMap mounted to the importer pod, if any
+func getCustomizeRecipe() (*image.CustomizeRecipe, error) {
+	if _, err := os.Stat(common.CustomizeRecipeDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(common.CustomizeRecipeDir, common.CustomizeRecipeKeyName))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the %q key of the customization ConfigMap: %w", common.CustomizeRecipeKeyName, err)
+	}
+	return image.ParseCustomizeRecipe(data)
+}
+
+// getS3Options returns the optional settings of the S3 client
+func getS3Options() *importer.S3Options {
+	region, _ := util.ParseEnvVar(common.ImporterS3Region, false)
+	addressingStyle, _ := util.ParseEnvVar(common.ImporterS3AddressingStyle, false)
+	roleARN, _ := util.ParseEnvVar(common.ImporterS3RoleARN, false)
+	stsEndpoint, _ := util.ParseEnvVar(common.ImporterS3STSEndpoint, false)
+	tokenFile, _ := util.ParseEnvVar(common.ImporterS3WebIdentityTokenFileVar, false)
+	return &importer.S3Options{
+		Region:               region,
+		VirtualHostStyle:     addressingStyle == string(cdiv1.S3AddressingStyleVirtualHost),
+		RoleARN:              roleARN,
+		WebIdentityTokenFile: tokenFile,
+		STSEndpoint:          stsEndpoint,
+	}
+}
+
+// getGCSOptions returns the optional settings of the GCS client
+func getGCSOptions() *importer.GCSOptions {
+	workloadIdentity, _ := util.ParseEnvVar(common.ImporterGCSWorkloadIdentity, false)
+	provider, _ := util.ParseEnvVar(common.ImporterGCSWorkloadIdentityProvider, false)
+	serviceAccount, _ := util.ParseEnvVar(common.ImporterGCSServiceAccount, false)
+	tokenFile, _ := util.ParseEnvVar(common.ImporterGCSWorkloadIdentityTokenFileVar, false)
+	return &importer.GCSOptions{
+		WorkloadIdentity:          workloadIdentity == "true",
+		WorkloadIdentityProvider:  provider,
+		ServiceAccount:            serviceAccount,
+		WorkloadIdentityTokenFile: tokenFile,
+	}
+}
+
 func touchDoneFile() {
 	doneFile, _ := util.ParseEnvVar(common.ImporterDoneFile, false)
 	if doneFile == "" {
@@ -115,12 +175,44 @@ func main() {
 		klog.Errorf(`the %s environment variable is with a wrong value "%s"; should be "true" or "false"`, common.Preallocation, os.Getenv(common.Preallocation))
 		os.Exit(1)
 	}
+	expandPartition, _ := strconv.ParseBool(os.Getenv(common.ExpandPartition))
+	sparsify, _ := strconv.ParseBool(os.Getenv(common.Sparsify))
 
 	volumeMode := v1.PersistentVolumeBlock
 	if _, err := os.Stat(common.WriteBlockPath); os.IsNotExist(err) {
 		volumeMode = v1.PersistentVolumeFilesystem
 	}
 
+	if backup, _ := strconv.ParseBool(os.Getenv(common.ImporterBackup)); backup {
+		if exitCode := handleBackup(source, volumeMode); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return
+	}
+
+	if expandOnly, _ := strconv.ParseBool(os.Getenv(common.ImporterExpandOnly)); expandOnly {
+		if exitCode := handleExpand(volumeMode, imageSize, filesystemOverhead, preallocation); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return
+	}
+
+	if sparsifyOnly, _ := strconv.ParseBool(os.Getenv(common.ImporterSparsifyOnly)); sparsifyOnly {
+		if exitCode := handleSparsify(volumeMode); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return
+	}
+
+	patchOffset, patchPartitionLabel := os.Getenv(common.ImporterPatchOffset), os.Getenv(common.ImporterPatchPartitionLabel)
+	if patchOffset != "" || patchPartitionLabel != "" {
+		waitForReadyFile()
+		if exitCode := handlePatch(source, contentType, volumeMode, patchOffset, patchPartitionLabel); exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return
+	}
+
 	// With writeback cache mode it's possible that the process will exit before all writes have been committed to storage.
 	// To guarantee that our write was committed to storage, we make a fsync syscall and ensure success.
 	// Also might be a good idea to sync any chmod's we might have done.
@@ -144,8 +236,16 @@ func main() {
 			os.Exit(1)
 		}
 	} else {
+		recipe, err := getCustomizeRecipe()
+		if err != nil {
+			klog.Errorf("%+v", err)
+			if err := util.WriteTerminationMessage(fmt.Sprintf("Invalid customization recipe: %v", err.Error())); err != nil {
+				klog.Errorf("%+v", err)
+			}
+			os.Exit(1)
+		}
 		waitForReadyFile()
-		exitCode := handleImport(source, contentType, volumeMode, imageSize, filesystemOverhead, preallocation)
+		exitCode := handleImport(source, contentType, volumeMode, imageSize, filesystemOverhead, preallocation, expandPartition, sparsify, availableDestSpace, recipe)
 		if exitCode != 0 {
 			os.Exit(exitCode)
 		}
@@ -166,6 +266,7 @@ func handleEmptyImage(contentType string, imageSize string, availableDestSpace i
 	msg := &common.TerminationMessage{
 		PreallocationApplied: ptr.To(preallocation),
 		Message:              ptr.To(completeMessage),
+		AvailableSpace:       filesystemAvailableSpace(volumeMode, availableDestSpace),
 	}
 
 	err := writeTerminationMessage(msg)
@@ -178,23 +279,35 @@ func handleImport(
 	volumeMode v1.PersistentVolumeMode,
 	imageSize string,
 	filesystemOverhead float64,
-	preallocation bool) int {
+	preallocation bool,
+	expandPartition bool,
+	sparsify bool,
+	availableDestSpace int64,
+	recipe *image.CustomizeRecipe) int {
 	klog.V(1).Infoln("begin import process")
 
 	ds := newDataSource(source, contentType, volumeMode)
 	defer ds.Close()
 
 	processor := newDataProcessor(contentType, volumeMode, ds, imageSize, filesystemOverhead, preallocation)
+	if recipe != nil {
+		processor.SetCustomizeRecipe(recipe)
+	}
+	processor.SetExpandPartition(expandPartition)
+	processor.SetSparsify(sparsify)
 	err := processor.ProcessData()
 
 	scratchSpaceRequired := errors.Is(err, importer.ErrRequiresScratchSpace)
 	if err != nil && !scratchSpaceRequired {
 		klog.Errorf("%+v", err)
-		if err := util.WriteTerminationMessage(fmt.Sprintf("Unable to process data: %v", err.Error())); err != nil {
+		if err := util.WriteFailureTerminationMessage(fmt.Sprintf("Unable to process data: %v", err.Error()), importer.GetFailureReason(err)); err != nil {
 			klog.Errorf("%+v", err)
 		}
 		return 1
 	}
+	if !scratchSpaceRequired {
+		importer.PruneChunkCache()
+	}
 
 	termMsg := ds.GetTerminationMessage()
 	if termMsg == nil {
@@ -203,6 +316,10 @@ func handleImport(
 	termMsg.ScratchSpaceRequired = &scratchSpaceRequired
 	termMsg.PreallocationApplied = ptr.To(processor.PreallocationApplied())
 	termMsg.Message = ptr.To(completeMessage)
+	termMsg.AvailableSpace = filesystemAvailableSpace(volumeMode, availableDestSpace)
+	if sparsify && !scratchSpaceRequired {
+		termMsg.ReclaimedBytes = processor.ReclaimedBytes()
+	}
 
 	touchDoneFile()
 	if err := writeTerminationMessage(termMsg); err != nil {
@@ -220,6 +337,170 @@ func handleImport(
 	return 0
 }
 
+// handlePatch converts the source into a raw image in scratch space and writes it into the existing disk,
+// at the offset or into the GPT partition with the label
+func handlePatch(source, contentType string, volumeMode v1.PersistentVolumeMode, offset, partitionLabel string) int {
+	klog.V(1).Infoln("begin patch process")
+
+	var patchOffset int64
+	if offset != "" {
+		var err error
+		if patchOffset, err = strconv.ParseInt(offset, 10, 64); err != nil {
+			klog.Errorf("%+v", err)
+			if err := util.WriteTerminationMessage(fmt.Sprintf("Invalid patch offset %q", offset)); err != nil {
+				klog.Errorf("%+v", err)
+			}
+			return 1
+		}
+	}
+
+	ds := newDataSource(source, contentType, volumeMode)
+	defer ds.Close()
+
+	// The disk is not resized, the patch is converted to its own size
+	patchFile := filepath.Join(common.ScratchDataDir, patchImageName)
+	processor := importer.NewDataProcessor(ds, patchFile, common.ScratchDataDir, common.ScratchDataDir, "", 0, false, os.Getenv(common.CacheMode))
+	err := processor.ProcessData()
+
+	scratchSpaceRequired := errors.Is(err, importer.ErrRequiresScratchSpace)
+	if err == nil {
+		err = importer.PatchDisk(patchFile, getImporterDestPath(contentType, volumeMode), patchOffset, partitionLabel)
+	}
+	if err != nil && !scratchSpaceRequired {
+		klog.Errorf("%+v", err)
+		if err := util.WriteFailureTerminationMessage(fmt.Sprintf("Unable to patch disk: %v", err.Error()), importer.GetFailureReason(err)); err != nil {
+			klog.Errorf("%+v", err)
+		}
+		return 1
+	}
+
+	termMsg := ds.GetTerminationMessage()
+	if termMsg == nil {
+		termMsg = &common.TerminationMessage{}
+	}
+	termMsg.ScratchSpaceRequired = &scratchSpaceRequired
+	termMsg.Message = ptr.To(completeMessage)
+
+	touchDoneFile()
+	if err := writeTerminationMessage(termMsg); err != nil {
+		klog.Errorf("%+v", err)
+		return 1
+	}
+	return 0
+}
+
+// handleExpand grows the disk already populated in the volume, its last partition and filesystem
+func handleExpand(volumeMode v1.PersistentVolumeMode, imageSize string, filesystemOverhead float64, preallocation bool) int {
+	klog.V(1).Infoln("begin expand process")
+
+	dataFile := common.ImporterWritePath
+	if volumeMode == v1.PersistentVolumeBlock {
+		dataFile = common.WriteBlockPath
+	}
+	if err := importer.ExpandDisk(dataFile, imageSize, filesystemOverhead, preallocation); err != nil {
+		klog.Errorf("%+v", err)
+		if err := util.WriteFailureTerminationMessage(fmt.Sprintf("Unable to expand disk: %v", err), importer.GetFailureReason(err)); err != nil {
+			klog.Errorf("%+v", err)
+		}
+		return 1
+	}
+
+	if err := writeTerminationMessage(&common.TerminationMessage{Message: ptr.To(completeMessage)}); err != nil {
+		klog.Errorf("%+v", err)
+		return 1
+	}
+	return 0
+}
+
+// handleSparsify deallocates the blocks of zeroes of the disk already populated in the volume
+func handleSparsify(volumeMode v1.PersistentVolumeMode) int {
+	klog.V(1).Infoln("begin sparsify process")
+
+	dataFile := common.ImporterWritePath
+	if volumeMode == v1.PersistentVolumeBlock {
+		dataFile = common.WriteBlockPath
+	}
+	reclaimed, err := importer.Sparsify(dataFile)
+	if err != nil {
+		klog.Errorf("%+v", err)
+		if err := util.WriteTerminationMessage(fmt.Sprintf("Unable to sparsify disk: %v", err)); err != nil {
+			klog.Errorf("%+v", err)
+		}
+		return 1
+	}
+
+	msg := &common.TerminationMessage{
+		Message:        ptr.To(completeMessage),
+		ReclaimedBytes: reclaimed,
+	}
+	if err := writeTerminationMessage(msg); err != nil {
+		klog.Errorf("%+v", err)
+		return 1
+	}
+	return 0
+}
+
+// handleBackup writes the content of the volume to the object storage endpoint
+func handleBackup(source string, volumeMode v1.PersistentVolumeMode) int {
+	klog.V(1).Infoln("begin backup process")
+	ep, _ := util.ParseEnvVar(common.ImporterEndpoint, false)
+
+	var dst importer.BackupStore
+	var err error
+	switch source {
+	case cc.SourceS3:
+		acc, _ := util.ParseEnvVar(common.ImporterAccessKeyID, false)
+		sec, _ := util.ParseEnvVar(common.ImporterSecretKey, false)
+		certDir, _ := util.ParseEnvVar(common.ImporterCertDirVar, false)
+		dst, err = importer.NewS3BackupStore(ep, acc, sec, certDir, getS3Options())
+	case cc.SourceGCS:
+		keyf, _ := util.ParseEnvVar(common.ImporterGoogleCredentialFileVar, false)
+		dst, err = importer.NewGCSBackupStore(ep, keyf, getGCSOptions())
+	default:
+		err = fmt.Errorf("unknown backup destination: %s", source)
+	}
+	if err != nil {
+		klog.Errorf("%+v", err)
+		if err := util.WriteTerminationMessage(fmt.Sprintf("Unable to connect to %s backup destination: %v", source, err)); err != nil {
+			klog.Errorf("%+v", err)
+		}
+		return 1
+	}
+	defer dst.Close()
+
+	srcPath := common.ImporterWritePath
+	if volumeMode == v1.PersistentVolumeBlock {
+		srcPath = common.WriteBlockPath
+	}
+	src, err := os.Open(srcPath)
+	if err == nil {
+		defer src.Close()
+		err = importer.WriteBackup(dst, src)
+	}
+	if err != nil {
+		klog.Errorf("%+v", err)
+		if err := util.WriteTerminationMessage(fmt.Sprintf("Unable to back up data: %v", err)); err != nil {
+			klog.Errorf("%+v", err)
+		}
+		return 1
+	}
+
+	if err := writeTerminationMessage(&common.TerminationMessage{Message: ptr.To(backupCompleteMessage)}); err != nil {
+		klog.Errorf("%+v", err)
+		return 1
+	}
+	return 0
+}
+
+// filesystemAvailableSpace reports the space available on a Filesystem volume before the import,
+// so the controller can calibrate the filesystem overhead of its storage class
+func filesystemAvailableSpace(volumeMode v1.PersistentVolumeMode, availableDestSpace int64) *int64 {
+	if volumeMode != v1.PersistentVolumeFilesystem || availableDestSpace <= 0 {
+		return nil
+	}
+	return ptr.To(availableDestSpace)
+}
+
 func writeTerminationMessage(termMsg *common.TerminationMessage) error {
 	msg, err := termMsg.String()
 	if err != nil {
@@ -267,9 +548,17 @@ func newDataSource(source string, contentType string, volumeMode v1.PersistentVo
 	currentCheckpoint, _ := util.ParseEnvVar(common.ImporterCurrentCheckpoint, false)
 	previousCheckpoint, _ := util.ParseEnvVar(common.ImporterPreviousCheckpoint, false)
 	finalCheckpoint, _ := util.ParseEnvVar(common.ImporterFinalCheckpoint, false)
+	cdiBackupManifest, _ := strconv.ParseBool(os.Getenv(common.ImporterCDIBackupManifest))
 
 	switch source {
 	case cc.SourceHTTP:
+		if differencingDisks := getDifferencingDisks(); len(differencingDisks) > 0 {
+			ds, err := importer.NewVHDXChainDataSource(getHTTPEp(ep), differencingDisks, acc, sec, certDir)
+			if err != nil {
+				errorCannotConnectDataSource(err, "http")
+			}
+			return ds
+		}
 		ds, err := importer.NewHTTPDataSource(getHTTPEp(ep), acc, sec, certDir, cdiv1.DataVolumeContentType(contentType))
 		if err != nil {
 			errorCannotConnectDataSource(err, "http")
@@ -285,17 +574,33 @@ func newDataSource(source string, contentType string, volumeMode v1.PersistentVo
 		ds := importer.NewRegistryDataSource(ep, acc, sec, registryImageArchitecture, certDir, insecureTLS)
 		return ds
 	case cc.SourceS3:
-		ds, err := importer.NewS3DataSource(ep, acc, sec, certDir)
+		if cdiBackupManifest {
+			return newCDIBackupDataSource(source, ep, acc, sec, keyf, certDir)
+		}
+		ds, err := importer.NewS3DataSource(ep, acc, sec, certDir, getS3Options())
 		if err != nil {
 			errorCannotConnectDataSource(err, "s3")
 		}
 		return ds
 	case cc.SourceGCS:
-		ds, err := importer.NewGCSDataSource(ep, keyf)
+		if cdiBackupManifest {
+			return newCDIBackupDataSource(source, ep, acc, sec, keyf, certDir)
+		}
+		ds, err := importer.NewGCSDataSource(ep, keyf, getGCSOptions())
 		if err != nil {
 			errorCannotConnectDataSource(err, "gcs")
 		}
 		return ds
+	case cc.SourceAzureBlob:
+		account, _ := util.ParseEnvVar(common.ImporterAzureBlobAccount, false)
+		credentialDir, _ := util.ParseEnvVar(common.ImporterAzureBlobCredentialDirVar, false)
+		ds, err := importer.NewAzureBlobDataSource(ep, account, credentialDir, certDir)
+		if err != nil {
+			errorCannotConnectDataSource(err, "azure-blob")
+		}
+		return ds
+	case cc.SourceCDIBackup:
+		return newCDIBackupDataSource(source, ep, acc, sec, keyf, certDir)
 	case cc.SourceVDDK:
 		ds, err := importer.NewVDDKDataSource(ep, acc, sec, thumbprint, uuid, backingFile, currentCheckpoint, previousCheckpoint, finalCheckpoint, volumeMode)
 		if err != nil {
@@ -314,6 +619,22 @@ func newDataSource(source string, contentType string, volumeMode v1.PersistentVo
 	return nil
 }
 
+// newCDIBackupDataSource creates the data source restoring a chunked CDI backup from S3 or GCS, for both the
+// DataVolumeBackup restores and the cdiBackup import source
+func newCDIBackupDataSource(source, ep, acc, sec, keyf, certDir string) importer.DataSourceInterface {
+	var ds importer.DataSourceInterface
+	var err error
+	if source == cc.SourceGCS {
+		ds, err = importer.NewGCSCDIBackupDataSource(ep, keyf, getGCSOptions())
+	} else {
+		ds, err = importer.NewCDIBackupDataSource(ep, acc, sec, certDir, getS3Options())
+	}
+	if err != nil {
+		errorCannotConnectDataSource(err, "cdi-backup")
+	}
+	return ds
+}
+
 func createBlankImage(imageSize string, availableDestSpace int64, preallocation bool, volumeMode v1.PersistentVolumeMode, filesystemOverhead float64) {
 	requestImageSizeQuantity := resource.MustParse(imageSize)
 	minSizeQuantity := util.MinQuantity(resource.NewScaledQuantity(availableDestSpace, 0), &requestImageSizeQuantity)
@@ -335,9 +656,7 @@ func createBlankImage(imageSize string, availableDestSpace int64, preallocation
 
 	if err != nil {
 		klog.Errorf("%+v", err)
-		message := fmt.Sprintf("Unable to create blank image: %v", err)
-		err = util.WriteTerminationMessage(message)
-		if err != nil {
+		if err := util.WriteFailureTerminationMessage(fmt.Sprintf("Unable to create blank image: %v", err), importer.GetFailureReason(err)); err != nil {
 			klog.Errorf("%+v", err)
 		}
 		os.Exit(1)
@@ -346,8 +665,7 @@ func createBlankImage(imageSize string, availableDestSpace int64, preallocation
 
 func errorCannotConnectDataSource(err error, dsName string) {
 	klog.Errorf("%+v", err)
-	err = util.WriteTerminationMessage(fmt.Sprintf("Unable to connect to %s data source: %v", dsName, err))
-	if err != nil {
+	if err := util.WriteFailureTerminationMessage(fmt.Sprintf("Unable to connect to %s data source: %v", dsName, err), importer.GetFailureReason(err)); err != nil {
 		klog.Errorf("%+v", err)
 	}
 	os.Exit(1)
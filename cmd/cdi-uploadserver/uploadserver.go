@@ -21,6 +21,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/importer"
 	"kubevirt.io/containerized-data-importer/pkg/uploadserver"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 	cryptowatch "kubevirt.io/containerized-data-importer/pkg/util/tls-crypto-watch"
@@ -60,6 +62,8 @@ func main() {
 
 	filesystemOverhead, _ := strconv.ParseFloat(os.Getenv(common.FilesystemOverheadVar), 64)
 	preallocation, _ := strconv.ParseBool(os.Getenv(common.Preallocation))
+	expandPartition, _ := strconv.ParseBool(os.Getenv(common.ExpandPartition))
+	sparsify, _ := strconv.ParseBool(os.Getenv(common.Sparsify))
 
 	config := &uploadserver.Config{
 		BindAddress:        listenAddress,
@@ -72,6 +76,9 @@ func main() {
 		ImageSize:          os.Getenv(common.UploadImageSize),
 		FilesystemOverhead: filesystemOverhead,
 		Preallocation:      preallocation,
+		ExpandPartition:    expandPartition,
+		Sparsify:           sparsify,
+		CacheMode:          os.Getenv(common.CacheMode),
 		CryptoConfig:       cryptoConfig,
 		Deadline:           deadline,
 	}
@@ -83,6 +90,9 @@ func main() {
 	result, err := server.Run()
 	if err != nil {
 		klog.Errorf("UploadServer failed: %s", err)
+		if err := util.WriteFailureTerminationMessage(fmt.Sprintf("Unable to process data: %v", err), importer.GetFailureReason(err)); err != nil {
+			klog.Errorf("%+v", err)
+		}
 		os.Exit(1)
 	}
 
@@ -95,6 +105,7 @@ func main() {
 			termMsg.Message = ptr.To("Upload Complete")
 		}
 		termMsg.PreallocationApplied = ptr.To(result.PreallocationApplied)
+		termMsg.ReclaimedBytes = result.ReclaimedBytes
 	} else {
 		termMsg.Message = ptr.To("Deadline Passed")
 		termMsg.DeadlinePassed = ptr.To(true)
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/image/v2/imagedata"
+
+	"k8s.io/klog/v2"
+)
+
+// Cinder volumes and snapshots are exported through a temporary image, snapshots through a temporary volume
+// first, and the image is streamed to the target. The stages of a multi-stage population copy Cinder snapshots
+// of the source volume. Cinder has no API to read the blocks that changed between two snapshots, so every stage
+// is a full copy of its snapshot: the whole image is downloaded, and only the writes of the blocks that did
+// not change are skipped.
+
+const (
+	volumeStatusAvailable = "available"
+	imageStatusActive     = "active"
+	statusError           = "error"
+
+	// compareBlockSize is the size of the blocks compared with the target
+	compareBlockSize = 1 << 20
+)
+
+var (
+	exportPollInterval = 5 * time.Second
+	exportTimeout      = 6 * time.Hour
+)
+
+type cinderVolume struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type glanceImage struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// populateFromCinder copies the Cinder volume or snapshot to the target
+func populateFromCinder(provider *gophercloud.ProviderClient, config *appConfig) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	klog.Infof("Copying Cinder volume %q, snapshot %q", config.volumeID, config.snapshotID)
+	imageReader, cleanup, err := exportCinderSource(ctx, provider, config, config.volumeID, config.snapshotID)
+	defer cleanup()
+	if err != nil {
+		klog.Fatal(err)
+	}
+	defer imageReader.Close()
+
+	file := openFile(config.volumePath)
+	defer file.Close()
+
+	writeData(imageReader, file, config)
+}
+
+// populateFromSnapshot copies the whole snapshot of a checkpoint to the target
+func populateFromSnapshot(provider *gophercloud.ProviderClient, config *appConfig) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	klog.Infof("Copying snapshot %s", config.currentCheckpoint)
+	imageReader, cleanup, err := exportCinderSource(ctx, provider, config, "", config.currentCheckpoint)
+	defer cleanup()
+	if err != nil {
+		klog.Fatal(err)
+	}
+	defer imageReader.Close()
+
+	file := openFile(config.volumePath)
+	defer file.Close()
+
+	tracker := newProgressTracker(config)
+	written, err := writeChangedBlocks(tracker.NewReader(imageReader), file)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	tracker.Finish()
+	klog.Infof("Wrote %d changed bytes", written)
+}
+
+// exportCinderSource uploads the volume, or a temporary volume created from the snapshot, to a temporary image.
+// It returns a reader of the image and a function deleting the temporary volume and image.
+func exportCinderSource(ctx context.Context, provider *gophercloud.ProviderClient, config *appConfig, volumeID, snapshotID string) (io.ReadCloser, func(), error) {
+	var cleanups []func()
+	cleanup := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+
+	volumeService, err := openstack.NewBlockStorageV3(provider, getEndpointOpts())
+	if err != nil {
+		return nil, cleanup, err
+	}
+	imageService, err := openstack.NewImageV2(provider, getEndpointOpts())
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	name := fmt.Sprintf("cdi-populator-%s-%s%s", config.ownerUID, volumeID, snapshotID)
+	if snapshotID != "" {
+		var created struct {
+			Volume cinderVolume `json:"volume"`
+		}
+		createOpts := map[string]interface{}{
+			"volume": map[string]interface{}{
+				"name":        name,
+				"snapshot_id": snapshotID,
+			},
+		}
+		if _, err := volumeService.Post(ctx, volumeService.ServiceURL("volumes"), createOpts, &created, &gophercloud.RequestOpts{OkCodes: []int{202}}); err != nil {
+			return nil, cleanup, fmt.Errorf("failed to create a volume from snapshot %s: %w", snapshotID, err)
+		}
+		volumeID = created.Volume.ID
+		volumeURL := volumeService.ServiceURL("volumes", volumeID)
+		cleanups = append(cleanups, func() {
+			if _, err := volumeService.Delete(context.Background(), volumeURL, nil); err != nil {
+				klog.Errorf("Failed to delete temporary volume %s: %v", volumeID, err)
+			}
+		})
+		klog.Infof("Created temporary volume %s", volumeID)
+
+		err := waitForStatus(ctx, volumeStatusAvailable, func() (string, error) {
+			var volume struct {
+				Volume cinderVolume `json:"volume"`
+			}
+			_, err := volumeService.Get(ctx, volumeURL, &volume, nil)
+			return volume.Volume.Status, err
+		})
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("temporary volume %s is not available: %w", volumeID, err)
+		}
+	}
+
+	var uploaded struct {
+		Upload struct {
+			ImageID string `json:"image_id"`
+		} `json:"os-volume_upload_image"`
+	}
+	uploadOpts := map[string]interface{}{
+		"os-volume_upload_image": map[string]interface{}{
+			"image_name":       name,
+			"disk_format":      "raw",
+			"container_format": "bare",
+			"force":            true,
+		},
+	}
+	if _, err := volumeService.Post(ctx, volumeService.ServiceURL("volumes", volumeID, "action"), uploadOpts, &uploaded, &gophercloud.RequestOpts{OkCodes: []int{202}}); err != nil {
+		return nil, cleanup, fmt.Errorf("failed to upload volume %s to an image: %w", volumeID, err)
+	}
+	imageID := uploaded.Upload.ImageID
+	imageURL := imageService.ServiceURL("images", imageID)
+	cleanups = append(cleanups, func() {
+		if _, err := imageService.Delete(context.Background(), imageURL, nil); err != nil {
+			klog.Errorf("Failed to delete temporary image %s: %v", imageID, err)
+		}
+	})
+	klog.Infof("Uploading volume %s to temporary image %s", volumeID, imageID)
+
+	err = waitForStatus(ctx, imageStatusActive, func() (string, error) {
+		var image glanceImage
+		_, err := imageService.Get(ctx, imageURL, &image, nil)
+		return image.Status, err
+	})
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("temporary image %s is not active: %w", imageID, err)
+	}
+
+	reader, err := imagedata.Download(ctx, imageService, imageID).Extract()
+	if err != nil {
+		return nil, cleanup, err
+	}
+	return reader, cleanup, nil
+}
+
+func waitForStatus(ctx context.Context, status string, getStatus func() (string, error)) error {
+	for {
+		current, err := getStatus()
+		if err != nil {
+			return err
+		}
+		switch current {
+		case status:
+			return nil
+		case statusError:
+			return fmt.Errorf("status is %s", current)
+		}
+		klog.V(1).Infof("Status is %s, waiting for %s", current, status)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(exportPollInterval):
+		}
+	}
+}
+
+// writeChangedBlocks copies reader to target, only writing the blocks that differ from the current content of
+// target, and returns the number of bytes written
+func writeChangedBlocks(reader io.Reader, target interface {
+	io.ReaderAt
+	io.WriterAt
+}) (int64, error) {
+	data := make([]byte, compareBlockSize)
+	current := make([]byte, compareBlockSize)
+	var offset, written int64
+	for {
+		n, err := io.ReadFull(reader, data)
+		if n > 0 {
+			m, readErr := target.ReadAt(current[:n], offset)
+			if readErr != nil && readErr != io.EOF {
+				return written, readErr
+			}
+			if m < n || !bytes.Equal(data[:n], current[:n]) {
+				if _, err := target.WriteAt(data[:n], offset); err != nil {
+					return written, err
+				}
+				written += int64(n)
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return written, nil
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"k8s.io/klog/v2"
 
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/openstack-populator"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/transfer"
+	"kubevirt.io/containerized-data-importer/pkg/util/progress"
 	prometheusutil "kubevirt.io/containerized-data-importer/pkg/util/prometheus"
 )
 
@@ -63,24 +65,15 @@ var supportedAuthTypes = map[string]clientconfig.AuthType{
 }
 
 type appConfig struct {
-	identityEndpoint string
-	imageID          string
-	secretName       string
-	ownerUID         string
-	pvcSize          int64
-	volumePath       string
-}
-
-type countingReader struct {
-	reader io.ReadCloser
-	total  int64
-	read   *int64
-}
-
-func (cr *countingReader) Read(p []byte) (int, error) {
-	n, err := cr.reader.Read(p)
-	cr.total += int64(n)
-	return n, err
+	identityEndpoint  string
+	imageID           string
+	secretName        string
+	ownerUID          string
+	pvcSize           int64
+	volumePath        string
+	volumeID          string
+	snapshotID        string
+	currentCheckpoint string
 }
 
 func main() {
@@ -90,9 +83,12 @@ func main() {
 	flag.StringVar(&config.identityEndpoint, "endpoint", "", "endpoint URL (https://openstack.example.com:5000/v2.0)")
 	flag.StringVar(&config.secretName, "secret-name", "", "secret containing OpenStack credentials")
 	flag.StringVar(&config.imageID, "image-id", "", "Openstack image ID")
+	flag.StringVar(&config.volumeID, "volume-id", "", "Cinder volume ID")
+	flag.StringVar(&config.snapshotID, "snapshot-id", "", "Cinder volume snapshot ID")
 	flag.StringVar(&config.volumePath, "volume-path", "", "Path to populate")
 	flag.StringVar(&config.ownerUID, "owner-uid", "", "Owner UID (usually PVC UID)")
 	flag.Int64Var(&config.pvcSize, "pvc-size", 0, "Size of pvc (in bytes)")
+	flag.StringVar(&config.currentCheckpoint, "current-checkpoint", "", "Cinder snapshot to copy in a multi-stage population")
 	flag.Parse()
 
 	certsDirectory, err := os.MkdirTemp("", "certsdir")
@@ -113,6 +109,15 @@ func populate(config *appConfig) {
 		klog.Fatal(err)
 	}
 
+	switch {
+	case config.currentCheckpoint != "":
+		populateFromSnapshot(provider, config)
+		return
+	case config.volumeID != "" || config.snapshotID != "":
+		populateFromCinder(provider, config)
+		return
+	}
+
 	imageReader, err := setupImageService(provider, config)
 	if err != nil {
 		klog.Fatal(err)
@@ -127,7 +132,6 @@ func downloadAndSaveImage(config *appConfig, imageReader io.ReadCloser) {
 	file := openFile(config.volumePath)
 	defer file.Close()
 
-	createProgressCounter()
 	writeData(imageReader, file, config)
 }
 
@@ -158,70 +162,32 @@ func getEndpointOpts() gophercloud.EndpointOpts {
 }
 
 func writeData(reader io.ReadCloser, file *os.File, config *appConfig) {
-	countingReader := &countingReader{reader: reader, total: config.pvcSize, read: new(int64)}
-	done := make(chan bool)
-
-	go reportProgress(done, countingReader, config)
-
-	if _, err := io.Copy(file, countingReader); err != nil {
+	tracker := newProgressTracker(config)
+	if _, err := io.Copy(file, tracker.NewReader(reader)); err != nil {
 		klog.Fatal(err)
 	}
-	done <- true
-}
-
-func reportProgress(done chan bool, countingReader *countingReader, config *appConfig) {
-	for {
-		select {
-		case <-done:
-			finalizeProgress(config.ownerUID)
-			return
-		default:
-			updateProgress(countingReader, config.ownerUID)
-			time.Sleep(1 * time.Second)
-		}
-	}
+	tracker.Finish()
+	klog.Info("Finished populating the volume. Progress: 100%")
 }
 
-func createProgressCounter() {
+// newProgressTracker registers the populator metrics and starts reporting the progress, throughput and
+// estimated time remaining of the population
+func newProgressTracker(config *appConfig) *progress.Tracker {
 	if err := metrics.SetupMetrics(); err != nil {
 		klog.Error("Prometheus progress counter not registered:", err)
 	} else {
 		klog.Info("Prometheus progress counter registered.")
 	}
-}
-
-func finalizeProgress(ownerUID string) {
-	progress, err := metrics.GetPopulatorProgress(ownerUID)
-	if err != nil {
-		klog.Error("Error reading current progress:", err)
-		return
-	}
-
-	remainingProgress := 100 - progress
-	if remainingProgress > 0 {
-		metrics.AddPopulatorProgress(ownerUID, remainingProgress)
-	}
-
-	klog.Info("Finished populating the volume. Progress: 100%")
-}
-
-func updateProgress(countingReader *countingReader, ownerUID string) {
-	if countingReader.total <= 0 {
-		return
-	}
-
-	progress, err := metrics.GetPopulatorProgress(ownerUID)
-	if err != nil {
-		klog.Errorf("updateProgress: failed to get metric; %v", err)
+	if err := transfer.SetupMetrics(); err != nil {
+		klog.Error("Prometheus transfer gauges not registered:", err)
 	}
-
-	currentProgress := (float64(*countingReader.read) / float64(countingReader.total)) * 100
-
-	if currentProgress > progress {
-		metrics.AddPopulatorProgress(ownerUID, currentProgress-progress)
+	var total uint64
+	if config.pvcSize > 0 {
+		total = uint64(config.pvcSize)
 	}
-
-	klog.Info("Progress: ", int64(currentProgress), "%")
+	tracker := progress.NewTracker(metrics.Progress(config.ownerUID), transfer.Stats(config.ownerUID), total)
+	tracker.Start()
+	return tracker
 }
 
 func openFile(volumePath string) *os.File {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net"
@@ -12,6 +13,11 @@ import (
 	"github.com/onsi/gomega"
 )
 
+const snapshotData = "snapshot_data\n"
+
+// deletedResources records the temporary resources deleted by the mock server
+var deletedResources []string
+
 func setupMockServer() (*httptest.Server, string, int, error) {
 	listener, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
@@ -46,6 +52,48 @@ func setupMockServer() (*httptest.Server, string, int, error) {
 		fmt.Fprintln(w, `mock_data`)
 	})
 
+	mux.HandleFunc("/v2/images/v2/images/snapshot-image", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletedResources = append(deletedResources, "image")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "snapshot-image", "status": "active"}`)
+	})
+
+	mux.HandleFunc("/v2/images/v2/images/snapshot-image/file", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, snapshotData)
+	})
+
+	mux.HandleFunc("/volume/v3/project/volumes", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"volume": {"id": "snapshot-volume", "status": "creating"}}`)
+	})
+
+	mux.HandleFunc("/volume/v3/project/volumes/snapshot-volume", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletedResources = append(deletedResources, "volume")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"volume": {"id": "snapshot-volume", "status": "available"}}`)
+	})
+
+	mux.HandleFunc("/volume/v3/project/volumes/snapshot-volume/action", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"os-volume_upload_image": {"image_id": "snapshot-image"}}`)
+	})
+
+	mux.HandleFunc("/volume/v3/project/volumes/source-volume/action", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"os-volume_upload_image": {"image_id": "snapshot-image"}}`)
+	})
+
 	mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.Header().Set("X-Subject-Token", "MIIFvgY")
@@ -71,6 +119,16 @@ func setupMockServer() (*httptest.Server, string, int, error) {
 							"interface": "public",
 							"id": "29beb2f1567642eb810b042b6719ea88"
 						}]
+					},
+					{
+						"type": "volumev3",
+						"name": "cinderv3",
+						"endpoints": [{
+							"url": "http://localhost:%d/volume/v3/project",
+							"region": "RegionOne",
+							"interface": "public",
+							"id": "5f5e0bd2a1d14ea4a8e3ecdbb6b2e0a4"
+						}]
 					}
 				],
 				"user": {
@@ -83,7 +141,7 @@ func setupMockServer() (*httptest.Server, string, int, error) {
 				},
 				"issued_at": "201406-10T20:55:16.806027Z"
 			}
-		}`, port)
+		}`, port, port)
 		fmt.Fprint(w, response)
 	})
 
@@ -146,5 +204,111 @@ var _ = ginkgo.Describe("Populate Test", func() {
 			err = os.Remove(fileName)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
+
+		ginkgo.It("should populate a checkpoint from a Cinder snapshot", func() {
+			fileName := "disk.img"
+			deletedResources = nil
+			config := &appConfig{
+				identityEndpoint:  identityServerURL,
+				secretName:        "test-secret",
+				ownerUID:          "test-uid",
+				pvcSize:           100,
+				volumePath:        fileName,
+				currentCheckpoint: "snapshot-2",
+			}
+			defer os.Remove(fileName)
+
+			populate(config)
+
+			content, err := os.ReadFile(fileName)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(string(content)).To(gomega.Equal(snapshotData))
+			gomega.Expect(deletedResources).To(gomega.Equal([]string{"image", "volume"}))
+		})
+	})
+
+	ginkgo.Describe("Testing the population of Cinder data", func() {
+		ginkgo.It("should populate a Cinder volume through a temporary image", func() {
+			fileName := "disk.img"
+			deletedResources = nil
+			config := &appConfig{
+				identityEndpoint: identityServerURL,
+				secretName:       "test-secret",
+				ownerUID:         "test-uid",
+				pvcSize:          100,
+				volumePath:       fileName,
+				volumeID:         "source-volume",
+			}
+			defer os.Remove(fileName)
+
+			populate(config)
+
+			content, err := os.ReadFile(fileName)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(string(content)).To(gomega.Equal(snapshotData))
+			gomega.Expect(deletedResources).To(gomega.Equal([]string{"image"}))
+		})
+
+		ginkgo.It("should populate a Cinder snapshot through a temporary volume and image", func() {
+			fileName := "disk.img"
+			deletedResources = nil
+			config := &appConfig{
+				identityEndpoint: identityServerURL,
+				secretName:       "test-secret",
+				ownerUID:         "test-uid",
+				pvcSize:          100,
+				volumePath:       fileName,
+				snapshotID:       "snapshot-1",
+			}
+			defer os.Remove(fileName)
+
+			populate(config)
+
+			content, err := os.ReadFile(fileName)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(string(content)).To(gomega.Equal(snapshotData))
+			gomega.Expect(deletedResources).To(gomega.Equal([]string{"image", "volume"}))
+		})
+	})
+
+	ginkgo.Describe("Testing the incremental write of changed blocks", func() {
+		ginkgo.It("should only write the blocks that changed", func() {
+			previous := bytes.Repeat([]byte{1}, 3*compareBlockSize)
+			current := bytes.Clone(previous)
+			current[compareBlockSize+10] = 2
+			current = append(current, 3)
+
+			target := &recordingTarget{data: previous}
+			written, err := writeChangedBlocks(bytes.NewReader(current), target)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(written).To(gomega.Equal(int64(compareBlockSize + 1)))
+			gomega.Expect(target.writes).To(gomega.Equal([]int64{compareBlockSize, 3 * compareBlockSize}))
+			gomega.Expect(target.data).To(gomega.Equal(current))
+		})
 	})
 })
+
+// recordingTarget is an in memory target recording the offsets of the writes
+type recordingTarget struct {
+	data   []byte
+	writes []int64
+}
+
+func (t *recordingTarget) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(t.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, t.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (t *recordingTarget) WriteAt(p []byte, off int64) (int, error) {
+	t.writes = append(t.writes, off)
+	if end := off + int64(len(p)); end > int64(len(t.data)) {
+		t.data = append(t.data, make([]byte, end-int64(len(t.data)))...)
+	}
+	return copy(t.data[off:], p), nil
+}
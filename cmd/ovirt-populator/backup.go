@@ -0,0 +1,262 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	ovirtsdk4 "github.com/ovirt/go-ovirt"
+
+	"k8s.io/klog/v2"
+)
+
+// Warm migration stages copy oVirt VM backups. The populator opens an image transfer of the disk in the
+// backup and only copies the extents reported by imageio: the dirty extents of an incremental backup, or
+// the data extents of the full backup of the first stage.
+
+const (
+	zeroBufferSize         = 1 << 20
+	transferPhaseTimeout   = 5 * time.Minute
+	transferInactivityTime = 3600
+)
+
+// backupExtent is an extent of the imageio extents API
+type backupExtent struct {
+	Start  int64 `json:"start"`
+	Length int64 `json:"length"`
+	Zero   bool  `json:"zero"`
+	Dirty  bool  `json:"dirty"`
+}
+
+func populateFromBackup(config *engineConfig, diskID, backupID, previousCheckpoint, volPath, ownerUID string) {
+	incremental := previousCheckpoint != ""
+	klog.Infof("Copying disk %s from backup %s, incremental: %t", diskID, backupID, incremental)
+
+	apiURL := strings.TrimSuffix(config.URL, "/")
+	if !strings.HasSuffix(apiURL, "/ovirt-engine/api") {
+		apiURL += "/ovirt-engine/api"
+	}
+	builder := ovirtsdk4.NewConnectionBuilder().
+		URL(apiURL).
+		Username(config.username).
+		Password(config.password)
+	if config.insecure {
+		builder = builder.Insecure(true)
+	} else {
+		builder = builder.CACert([]byte(config.cacert))
+	}
+	conn, err := builder.Build()
+	if err != nil {
+		klog.Fatal(err)
+	}
+	defer conn.Close()
+
+	transferService, transferURL, err := startBackupTransfer(conn, diskID, backupID)
+	if err != nil {
+		klog.Fatal(err)
+	}
+
+	err = copyBackup(newImageioClient(config), transferURL, volPath, ownerUID, incremental)
+	if err != nil {
+		if _, cancelErr := transferService.Cancel().Send(); cancelErr != nil {
+			klog.Errorf("Failed to cancel image transfer: %v", cancelErr)
+		}
+		klog.Fatal(err)
+	}
+	if _, err := transferService.Finalize().Send(); err != nil {
+		klog.Fatal(err)
+	}
+}
+
+// startBackupTransfer creates a download image transfer of the disk in the backup, and waits for it to be ready
+func startBackupTransfer(conn *ovirtsdk4.Connection, diskID, backupID string) (*ovirtsdk4.ImageTransferService, string, error) {
+	imageTransfer, err := ovirtsdk4.NewImageTransferBuilder().
+		Disk(ovirtsdk4.NewDiskBuilder().Id(diskID).MustBuild()).
+		Backup(ovirtsdk4.NewBackupBuilder().Id(backupID).MustBuild()).
+		Direction(ovirtsdk4.IMAGETRANSFERDIRECTION_DOWNLOAD).
+		Format(ovirtsdk4.DISKFORMAT_RAW).
+		InactivityTimeout(transferInactivityTime).
+		Build()
+	if err != nil {
+		return nil, "", err
+	}
+	transfersService := conn.SystemService().ImageTransfersService()
+	response, err := transfersService.Add().ImageTransfer(imageTransfer).Send()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create image transfer of disk %s in backup %s: %w", diskID, backupID, err)
+	}
+	transfer, ok := response.ImageTransfer()
+	if !ok {
+		return nil, "", errors.New("image transfer not available")
+	}
+	transferID, _ := transfer.Id()
+	transferService := transfersService.ImageTransferService(transferID)
+
+	deadline := time.Now().Add(transferPhaseTimeout)
+	for {
+		phase, _ := transfer.Phase()
+		switch phase {
+		case ovirtsdk4.IMAGETRANSFERPHASE_TRANSFERRING:
+			if transferURL, ok := transfer.TransferUrl(); ok && transferURL != "" {
+				return transferService, transferURL, nil
+			}
+			if proxyURL, ok := transfer.ProxyUrl(); ok && proxyURL != "" {
+				return transferService, proxyURL, nil
+			}
+			return transferService, "", errors.New("image transfer has no transfer url")
+		case ovirtsdk4.IMAGETRANSFERPHASE_INITIALIZING, "":
+		default:
+			return transferService, "", fmt.Errorf("unexpected image transfer phase %s", phase)
+		}
+		if time.Now().After(deadline) {
+			return transferService, "", errors.New("timed out waiting for the image transfer")
+		}
+		time.Sleep(time.Second)
+		getResponse, err := transferService.Get().Send()
+		if err != nil {
+			return transferService, "", err
+		}
+		if transfer, ok = getResponse.ImageTransfer(); !ok {
+			return transferService, "", errors.New("image transfer not available")
+		}
+	}
+}
+
+func newImageioClient(config *engineConfig) *http.Client {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config.insecure {
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+	} else if config.cacert != "" {
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM([]byte(config.cacert)) {
+			klog.Fatal("CA certificate is malformed")
+		}
+		tlsConfig.RootCAs = roots
+	}
+	return &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig}}
+}
+
+// copyBackup copies the extents of the image transfer to the volume
+func copyBackup(client *http.Client, transferURL, volPath, ownerUID string, incremental bool) error {
+	extents, err := getBackupExtents(client, transferURL, incremental)
+	if err != nil {
+		return err
+	}
+
+	blockDevice := !strings.HasSuffix(volPath, "disk.img")
+	flags := os.O_RDWR
+	if !blockDevice {
+		flags |= os.O_CREATE
+	}
+	file, err := os.OpenFile(volPath, flags, 0650)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var total, copied, size int64
+	for _, extent := range extents {
+		if extentNeedsCopy(extent, incremental) {
+			total += extent.Length
+		}
+		size = max(size, extent.Start+extent.Length)
+	}
+	if !blockDevice && !incremental {
+		// The data extents are written to a sparse file
+		if err := file.Truncate(size); err != nil {
+			return err
+		}
+	}
+
+	tracker := newProgressTracker(ownerUID, total)
+	for _, extent := range extents {
+		if !extentNeedsCopy(extent, incremental) {
+			continue
+		}
+		if extent.Zero {
+			if blockDevice || incremental {
+				err = writeZeros(file, extent.Start, extent.Length)
+			}
+		} else {
+			err = copyExtent(client, transferURL, file, extent)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to copy extent at offset %d: %w", extent.Start, err)
+		}
+		copied += extent.Length
+		tracker.Add(uint64(extent.Length))
+	}
+	tracker.Finish()
+	klog.Infof("Copied %d bytes of %d extents", copied, len(extents))
+	return file.Sync()
+}
+
+// extentNeedsCopy returns true if the extent changed since the previous checkpoint. All the extents of a full
+// backup are copied, zero extents are only written to block devices.
+func extentNeedsCopy(extent backupExtent, incremental bool) bool {
+	return !incremental || extent.Dirty
+}
+
+func getBackupExtents(client *http.Client, transferURL string, incremental bool) ([]backupExtent, error) {
+	extentsContext := "zero"
+	if incremental {
+		extentsContext = "dirty"
+	}
+	response, err := client.Get(transferURL + "/extents?context=" + extentsContext)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get %s extents: %s", extentsContext, response.Status)
+	}
+	var extents []backupExtent
+	if err := json.NewDecoder(response.Body).Decode(&extents); err != nil {
+		return nil, fmt.Errorf("failed to decode %s extents: %w", extentsContext, err)
+	}
+	return extents, nil
+}
+
+func copyExtent(client *http.Client, transferURL string, file *os.File, extent backupExtent) error {
+	request, err := http.NewRequest(http.MethodGet, transferURL, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", extent.Start, extent.Start+extent.Length-1))
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("unexpected range response: %s", response.Status)
+	}
+	written, err := io.Copy(io.NewOffsetWriter(file, extent.Start), io.LimitReader(response.Body, extent.Length))
+	if err != nil {
+		return err
+	}
+	if written != extent.Length {
+		return fmt.Errorf("copied %d bytes, expected %d", written, extent.Length)
+	}
+	return nil
+}
+
+func writeZeros(file *os.File, start, length int64) error {
+	zeros := make([]byte, min(length, zeroBufferSize))
+	for length > 0 {
+		n, err := file.WriteAt(zeros[:min(length, int64(len(zeros)))], start)
+		if err != nil {
+			return err
+		}
+		start += int64(n)
+		length -= int64(n)
+	}
+	return nil
+}
@@ -13,6 +13,8 @@ import (
 	"k8s.io/klog/v2"
 
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/ovirt-populator"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/transfer"
+	"kubevirt.io/containerized-data-importer/pkg/util/progress"
 	prometheusutil "kubevirt.io/containerized-data-importer/pkg/util/prometheus"
 )
 
@@ -32,7 +34,7 @@ type transferProgress struct {
 }
 
 func main() {
-	var engineURL, diskID, volPath, secretName, crName, crNamespace, ownerUID string
+	var engineURL, diskID, volPath, secretName, crName, crNamespace, ownerUID, currentCheckpoint, previousCheckpoint string
 	var pvcSize *int64
 
 	flag.StringVar(&engineURL, "engine-url", "", "ovirt-engine url (https://engine.fqdn)")
@@ -43,6 +45,8 @@ func main() {
 	flag.StringVar(&crNamespace, "cr-namespace", "", "Custom Resource instance namespace")
 	flag.StringVar(&ownerUID, "owner-uid", "", "Owner UID (usually PVC UID)")
 	pvcSize = flag.Int64("pvc-size", 0, "Size of pvc (in bytes)")
+	flag.StringVar(&currentCheckpoint, "current-checkpoint", "", "oVirt VM backup to copy in a warm migration stage")
+	flag.StringVar(&previousCheckpoint, "previous-checkpoint", "", "Checkpoint the backup was taken from, empty for a full backup")
 
 	flag.Parse()
 
@@ -57,11 +61,15 @@ func main() {
 
 	prometheusutil.StartPrometheusEndpoint(certsDirectory)
 
-	populate(engineURL, diskID, volPath, ownerUID, *pvcSize)
+	populate(engineURL, diskID, volPath, ownerUID, currentCheckpoint, previousCheckpoint, *pvcSize)
 }
 
-func populate(engineURL, diskID, volPath, ownerUID string, pvcSize int64) {
+func populate(engineURL, diskID, volPath, ownerUID, currentCheckpoint, previousCheckpoint string, pvcSize int64) {
 	config := loadEngineConfig(engineURL)
+	if currentCheckpoint != "" {
+		populateFromBackup(config, diskID, currentCheckpoint, previousCheckpoint, volPath, ownerUID)
+		return
+	}
 	prepareCredentials(config)
 	executePopulationProcess(config, diskID, volPath, ownerUID, pvcSize)
 }
@@ -107,13 +115,7 @@ func executePopulationProcess(config *engineConfig, diskID, volPath, ownerUID st
 }
 
 func monitorProgress(scanner *bufio.Scanner, ownerUID string, pvcSize int64, done chan struct{}) {
-	if err := metrics.SetupMetrics(); err != nil {
-		klog.Error("Prometheus progress gauge not registered:", err)
-		return
-	}
-
-	var currentProgress float64
-	total := pvcSize
+	tracker := newProgressTracker(ownerUID, pvcSize)
 
 	for scanner.Scan() {
 		progressOutput := transferProgress{}
@@ -124,28 +126,27 @@ func monitorProgress(scanner *bufio.Scanner, ownerUID string, pvcSize int64, don
 			if !errors.As(err, &syntaxError) {
 				klog.Error(err)
 			}
+			continue
 		}
-		if total > 0 {
-			currentProgress = (float64(progressOutput.Transferred) / float64(total)) * 100
-			if progress, err := metrics.GetPopulatorProgress(ownerUID); err != nil {
-				klog.Error(err)
-			} else if currentProgress > progress {
-				metrics.AddPopulatorProgress(ownerUID, currentProgress-progress)
-			}
-		}
+		tracker.Set(progressOutput.Transferred)
 	}
 
-	progress, err := metrics.GetPopulatorProgress(ownerUID)
-	if err != nil {
-		klog.Error(err)
-	}
+	tracker.Finish()
+	done <- struct{}{}
+}
 
-	remaining := 100 - int64(progress)
-	if remaining > 0 {
-		metrics.AddPopulatorProgress(ownerUID, float64(remaining))
+// newProgressTracker registers the populator metrics and starts reporting the progress, throughput and
+// estimated time remaining of a transfer of total bytes
+func newProgressTracker(ownerUID string, total int64) *progress.Tracker {
+	if err := metrics.SetupMetrics(); err != nil {
+		klog.Error("Prometheus progress gauge not registered:", err)
 	}
-
-	done <- struct{}{}
+	if err := transfer.SetupMetrics(); err != nil {
+		klog.Error("Prometheus transfer gauges not registered:", err)
+	}
+	tracker := progress.NewTracker(metrics.Progress(ownerUID), transfer.Stats(ownerUID), uint64(max(total, 0)))
+	tracker.Start()
+	return tracker
 }
 
 func createCommandArguments(config *engineConfig, diskID, volPath string) []string {
@@ -53,6 +53,7 @@ nbdkit-gzip-filter
 qemu-img
 python3-pycurl
 python3-six
+guestfs-tools
 "
 
 cdi_importer_extra_x86_64="
@@ -72,6 +73,7 @@ python3-ovirt-engine-sdk4
 cdi_uploadserver="
 libnbd
 qemu-img
+guestfs-tools
 "
 
 testimage="
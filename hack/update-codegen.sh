@@ -127,6 +127,7 @@ openapi-gen \
     --go-header-file "${SCRIPT_ROOT}/hack/custom-boilerplate.go.txt" \
     --output-file openapi_generated.go \
     kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1 \
+    k8s.io/apimachinery/pkg/api/resource \
     k8s.io/apimachinery/pkg/apis/meta/v1 \
     k8s.io/api/core/v1
 
@@ -136,6 +137,7 @@ openapi-gen \
     --go-header-file "${SCRIPT_ROOT}/hack/custom-boilerplate.go.txt" \
     --output-file openapi_generated.go \
     kubevirt.io/containerized-data-importer-api/pkg/apis/forklift/v1beta1 \
+    k8s.io/apimachinery/pkg/api/resource \
     k8s.io/apimachinery/pkg/apis/meta/v1 \
     k8s.io/api/core/v1
 
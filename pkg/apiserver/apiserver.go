@@ -51,6 +51,7 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/apiserver/webhooks"
 	cdiclient "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned"
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	"kubevirt.io/containerized-data-importer/pkg/keys"
 	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util"
@@ -106,6 +107,11 @@ type cdiAPIApp struct {
 	snapClient              snapclient.Interface
 	controllerRuntimeClient client.Client
 
+	// progressHTTPClient scrapes the metrics of the worker pods, and progressSampler samples the watched
+	// DataVolumes progress for all their watchers
+	progressHTTPClient *http.Client
+	progressSampler    *progressSampler
+
 	privateSigningKey *rsa.PrivateKey
 
 	container *restful.Container
@@ -155,7 +161,9 @@ func NewCdiAPIServer(bindAddress string,
 		cdiConfigTLSWatcher:     cdiConfigTLSWatcher,
 		certWarcher:             certWatcher,
 		installerLabels:         installerLabels,
+		progressHTTPClient:      cc.BuildHTTPClient(nil),
 	}
+	app.progressSampler = newProgressSampler(app.getDataVolumeProgress)
 
 	err = app.getKeysAndCerts()
 	if err != nil {
@@ -423,6 +431,28 @@ func (app *cdiAPIApp) composeUploadTokenAPI() {
 			Returns(http.StatusUnauthorized, "Unauthorized", "").
 			Param(uploadTokenWs.PathParameter("namespace", "Object name and auth scope, such as for teams and projects").Required(true)))
 
+		uploadTokenWs.Route(uploadTokenWs.GET("/"+dataSourceCatalogResource).
+			Produces("application/json").
+			Operation("listDataSourceCatalogEntry-"+v).
+			To(app.dataSourceCatalogHandler).Writes(cdiuploadv1.DataSourceCatalogEntryList{}).
+			Doc("List the DataSources the user is allowed to clone from.").
+			Returns(http.StatusOK, "OK", cdiuploadv1.DataSourceCatalogEntryList{}).
+			Returns(http.StatusUnauthorized, "Unauthorized", "").
+			Param(uploadTokenWs.QueryParameter(labelSelectorParam, "A selector to restrict the list of returned DataSources by their labels")).
+			Param(uploadTokenWs.QueryParameter(targetNamespaceParam, "The namespace the DataSources are cloned into")))
+
+		uploadTokenWs.Route(uploadTokenWs.GET(fmt.Sprintf("/namespaces/{namespace:[a-z0-9][a-z0-9\\-]*}/%s/{name}", dataVolumeProgressResource)).
+			Produces("application/json", "text/event-stream").
+			Operation("readNamespacedDataVolumeProgressReport-"+v).
+			To(app.dataVolumeProgressHandler).Writes(cdiuploadv1.DataVolumeProgressReport{}).
+			Doc("Read the data transfer progress of a DataVolume, streamed as server-sent events when watching.").
+			Returns(http.StatusOK, "OK", cdiuploadv1.DataVolumeProgressReport{}).
+			Returns(http.StatusUnauthorized, "Unauthorized", "").
+			Returns(http.StatusNotFound, "Not Found", "").
+			Param(uploadTokenWs.PathParameter("namespace", "Object name and auth scope, such as for teams and projects").Required(true)).
+			Param(uploadTokenWs.PathParameter("name", "Name of the DataVolume").Required(true)).
+			Param(uploadTokenWs.QueryParameter(watchParam, "Stream the progress reports as server-sent events until the DataVolume completes").DataType("boolean")))
+
 		uploadTokenWs.Route(uploadTokenWs.GET("/").
 			Produces("application/json").Writes(metav1.APIResourceList{}).
 			To(func(request *restful.Request, response *restful.Response) {
@@ -440,6 +470,24 @@ func (app *cdiAPIApp) composeUploadTokenAPI() {
 					Verbs:        []string{"create"},
 					ShortNames:   []string{"utr", "utrs"},
 				})
+				list.APIResources = append(list.APIResources, metav1.APIResource{
+					Name:         dataSourceCatalogResource,
+					SingularName: "datasourcecatalogentry",
+					Namespaced:   false,
+					Group:        uploadTokenGroup,
+					Version:      uploadTokenVersion,
+					Kind:         "DataSourceCatalogEntry",
+					Verbs:        []string{"list"},
+				})
+				list.APIResources = append(list.APIResources, metav1.APIResource{
+					Name:         dataVolumeProgressResource,
+					SingularName: "datavolumeprogressreport",
+					Namespaced:   true,
+					Group:        uploadTokenGroup,
+					Version:      uploadTokenVersion,
+					Kind:         "DataVolumeProgressReport",
+					Verbs:        []string{"get", "watch"},
+				})
 				writeJSONResponse(response, list)
 			}).
 			Operation("getAPIResources-"+v).
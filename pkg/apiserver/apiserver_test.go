@@ -33,6 +33,7 @@ import (
 
 	restful "github.com/emicklei/go-restful/v3"
 
+	authentication "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -45,15 +46,20 @@ import (
 )
 
 type testAuthorizer struct {
-	allowed bool
-	reason  string
-	err     error
+	allowed  bool
+	reason   string
+	err      error
+	userInfo authentication.UserInfo
 }
 
 func (a *testAuthorizer) Authorize(req *restful.Request) (bool, string, error) {
 	return a.allowed, a.reason, a.err
 }
 
+func (a *testAuthorizer) UserInfo(req *restful.Request) (authentication.UserInfo, error) {
+	return a.userInfo, nil
+}
+
 func signingKeySecretGetAction() core.Action {
 	return core.NewGetAction(
 		schema.GroupVersionResource{
@@ -236,6 +242,24 @@ var _ = Describe("API server tests", func() {
 					Verbs:        []string{"create"},
 					ShortNames:   []string{"utr", "utrs"},
 				},
+				{
+					Name:         "datasourcecatalogentries",
+					SingularName: "datasourcecatalogentry",
+					Namespaced:   false,
+					Group:        "upload.cdi.kubevirt.io",
+					Version:      version,
+					Kind:         "DataSourceCatalogEntry",
+					Verbs:        []string{"list"},
+				},
+				{
+					Name:         "datavolumeprogressreports",
+					SingularName: "datavolumeprogressreport",
+					Namespaced:   true,
+					Group:        "upload.cdi.kubevirt.io",
+					Version:      version,
+					Kind:         "DataVolumeProgressReport",
+					Verbs:        []string{"get", "watch"},
+				},
 			},
 		}
 
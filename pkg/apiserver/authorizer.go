@@ -24,10 +24,12 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/emicklei/go-restful/v3"
 
+	authentication "k8s.io/api/authentication/v1"
 	authorization "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	authorizationclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
@@ -46,6 +48,7 @@ const (
 // CdiAPIAuthorizer defines methods to authorize api requests
 type CdiAPIAuthorizer interface {
 	Authorize(req *restful.Request) (bool, string, error)
+	UserInfo(req *restful.Request) (authentication.UserInfo, error)
 }
 
 type authorizor struct {
@@ -91,9 +94,51 @@ func (a *authorizor) getUserExtras(headers http.Header, toMatch []string) map[st
 	return extras
 }
 
-// only supporting create for now
+// only supporting create and list for now
 var verbMap = map[string]string{
 	"POST": "create",
+	"GET":  "list",
+}
+
+// resourceScope maps the served resources to whether they are namespaced
+var resourceScope = map[string]bool{
+	"uploadtokenrequests":      true,
+	dataSourceCatalogResource:  false,
+	dataVolumeProgressResource: true,
+}
+
+// namedResources are the served resources read by name, with the get and watch verbs
+var namedResources = map[string]bool{
+	dataVolumeProgressResource: true,
+}
+
+func (a *authorizor) getUserInfo(headers http.Header) (authentication.UserInfo, error) {
+	authConfig := a.authConfigWatcher.GetAuthConfig()
+
+	users, err := a.matchHeaders(headers, authConfig.UserHeaders)
+	if err != nil {
+		return authentication.UserInfo{}, err
+	}
+
+	if len(users) == 0 {
+		return authentication.UserInfo{}, fmt.Errorf("no user header found")
+	}
+
+	userGroups, err := a.matchHeaders(headers, authConfig.GroupHeaders)
+	if err != nil {
+		return authentication.UserInfo{}, err
+	}
+
+	userExtras := map[string]authentication.ExtraValue{}
+	for k, v := range a.getUserExtras(headers, authConfig.ExtraPrefixHeaders) {
+		userExtras[k] = authentication.ExtraValue(v)
+	}
+
+	return authentication.UserInfo{
+		Username: users[0],
+		Groups:   userGroups,
+		Extra:    userExtras,
+	}, nil
 }
 
 func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.SubjectAccessReview, error) {
@@ -109,39 +154,42 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 		return nil, fmt.Errorf("no URL in http request")
 	}
 
-	// URL example
+	// URL examples
 	// /apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/uploadtokenrequest(s)
+	// /apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/datavolumeprogressreports/test
+	// /apis/upload.cdi.kubevirt.io/v1beta1/datasourcecatalogentries
 	pathSplit := strings.Split(url.Path, "/")
-	if len(pathSplit) != 7 {
+	var namespace, resource, name string
+	switch len(pathSplit) {
+	case 8:
+		namespace = pathSplit[5]
+		resource = pathSplit[6]
+		name = pathSplit[7]
+	case 7:
+		namespace = pathSplit[5]
+		resource = pathSplit[6]
+	case 5:
+		resource = pathSplit[4]
+	default:
 		return nil, fmt.Errorf("unknown api endpoint %s", url.Path)
 	}
 
-	authConfig := a.authConfigWatcher.GetAuthConfig()
-
 	group := pathSplit[2]
 	version := pathSplit[3]
-	namespace := pathSplit[5]
-	resource := pathSplit[6]
-	userExtras := a.getUserExtras(headers, authConfig.ExtraPrefixHeaders)
 
 	if group != uploadTokenGroup {
 		return nil, fmt.Errorf("unknown api group %s", group)
 	}
 
-	if resource != "uploadtokenrequests" {
+	if namespaced, ok := resourceScope[resource]; !ok || namespaced != (namespace != "") {
 		return nil, fmt.Errorf("unknown resource type %s", resource)
 	}
 
-	users, err := a.matchHeaders(headers, authConfig.UserHeaders)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(users) == 0 {
-		return nil, fmt.Errorf("no user header found")
+	if namedResources[resource] != (name != "") {
+		return nil, fmt.Errorf("unknown api endpoint %s", url.Path)
 	}
 
-	userGroups, err := a.matchHeaders(headers, authConfig.GroupHeaders)
+	userInfo, err := a.getUserInfo(headers)
 	if err != nil {
 		return nil, err
 	}
@@ -151,11 +199,22 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 	if !exists {
 		return nil, fmt.Errorf("unsupported HTTP method %s", method)
 	}
+	if name != "" && verb == "list" {
+		verb = "get"
+		if watch, _ := strconv.ParseBool(url.Query().Get(watchParam)); watch {
+			verb = "watch"
+		}
+	}
+
+	userExtras := map[string]authorization.ExtraValue{}
+	for k, v := range userInfo.Extra {
+		userExtras[k] = authorization.ExtraValue(v)
+	}
 
 	r := &authorization.SubjectAccessReview{}
 	r.Spec = authorization.SubjectAccessReviewSpec{
-		User:   users[0],
-		Groups: userGroups,
+		User:   userInfo.Username,
+		Groups: userInfo.Groups,
 		Extra:  userExtras,
 	}
 
@@ -169,6 +228,7 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 		Group:     group,
 		Version:   version,
 		Resource:  resource,
+		Name:      name,
 	}
 
 	return r, nil
@@ -245,6 +305,13 @@ func (a *authorizor) Authorize(req *restful.Request) (bool, string, error) {
 	return allowed, reason, nil
 }
 
+func (a *authorizor) UserInfo(req *restful.Request) (authentication.UserInfo, error) {
+	if req.Request == nil {
+		return authentication.UserInfo{}, fmt.Errorf("empty http request")
+	}
+	return a.getUserInfo(req.Request.Header)
+}
+
 // NewAuthorizorFromConfig creates a new CdiAPIAuthorizor
 func NewAuthorizorFromConfig(config *restclient.Config, authConfigWatcher AuthConfigWatcher) (CdiAPIAuthorizer, error) {
 	client, err := authorizationclient.NewForConfig(config)
@@ -30,6 +30,7 @@ import (
 
 	"github.com/emicklei/go-restful/v3"
 
+	authentication "k8s.io/api/authentication/v1"
 	authorization "k8s.io/api/authorization/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
@@ -136,6 +137,64 @@ var _ = Describe("Authorizer test", func() {
 		Expect(authReview).To(BeNil())
 	})
 
+	It("Generate access review for the cluster scoped DataSource catalog", func() {
+		app := newAuthorizor()
+		req := fakeRequest()
+		req.Request.Method = "GET"
+		req.Request.URL.Path = "/apis/upload.cdi.kubevirt.io/v1beta1/datasourcecatalogentries"
+		authReview, err := app.generateAccessReview(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authReview.Spec.ResourceAttributes.Namespace).To(BeEmpty())
+		Expect(authReview.Spec.ResourceAttributes.Resource).To(Equal("datasourcecatalogentries"))
+		Expect(authReview.Spec.ResourceAttributes.Verb).To(Equal("list"))
+	})
+
+	It("Generate access review path err namespaced DataSource catalog", func() {
+		app := newAuthorizor()
+		req := fakeRequest()
+		req.Request.Method = "GET"
+		req.Request.URL.Path = "/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/datasourcecatalogentries"
+		authReview, err := app.generateAccessReview(req)
+		Expect(err).To(HaveOccurred())
+		Expect(authReview).To(BeNil())
+	})
+
+	DescribeTable("Generate access review for the DataVolume progress", func(query, verb string) {
+		app := newAuthorizor()
+		req := fakeRequest()
+		req.Request.Method = "GET"
+		req.Request.URL.Path = "/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/datavolumeprogressreports/test-dv"
+		req.Request.URL.RawQuery = query
+		authReview, err := app.generateAccessReview(req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authReview.Spec.ResourceAttributes.Namespace).To(Equal("default"))
+		Expect(authReview.Spec.ResourceAttributes.Resource).To(Equal("datavolumeprogressreports"))
+		Expect(authReview.Spec.ResourceAttributes.Name).To(Equal("test-dv"))
+		Expect(authReview.Spec.ResourceAttributes.Verb).To(Equal(verb))
+	},
+		Entry("when reading it", "", "get"),
+		Entry("when watching it", "watch=true", "watch"),
+	)
+
+	It("Generate access review path err DataVolume progress without name", func() {
+		app := newAuthorizor()
+		req := fakeRequest()
+		req.Request.Method = "GET"
+		req.Request.URL.Path = "/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/datavolumeprogressreports"
+		authReview, err := app.generateAccessReview(req)
+		Expect(err).To(HaveOccurred())
+		Expect(authReview).To(BeNil())
+	})
+
+	It("Get user info", func() {
+		app := newAuthorizor()
+		userInfo, err := app.UserInfo(fakeRequest())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(userInfo.Username).To(Equal("user"))
+		Expect(userInfo.Groups).To(Equal([]string{"userGroup"}))
+		Expect(userInfo.Extra).To(HaveKeyWithValue("test", authentication.ExtraValue{"userExtraValue"}))
+	})
+
 	It("Access review success", func() {
 		app := newAuthorizor()
 		req := fakeRequest()
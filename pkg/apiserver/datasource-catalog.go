@@ -0,0 +1,244 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	restful "github.com/emicklei/go-restful/v3"
+
+	authentication "k8s.io/api/authentication/v1"
+	authorization "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const (
+	dataSourceCatalogResource = "datasourcecatalogentries"
+
+	// targetNamespaceParam restricts the catalog to DataSources that can be cloned into the given namespace
+	targetNamespaceParam = "targetNamespace"
+	labelSelectorParam   = "labelSelector"
+)
+
+// catalogAuthProxy serves the clone authorization of an already fetched DataSource
+type catalogAuthProxy struct {
+	client     kubernetes.Interface
+	dataSource *cdiv1.DataSource
+}
+
+func (p *catalogAuthProxy) CreateSar(sar *authorization.SubjectAccessReview) (*authorization.SubjectAccessReview, error) {
+	return p.client.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), sar, metav1.CreateOptions{})
+}
+
+func (p *catalogAuthProxy) GetNamespace(name string) (*corev1.Namespace, error) {
+	return p.client.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+func (p *catalogAuthProxy) GetDataSource(namespace, name string) (*cdiv1.DataSource, error) {
+	if namespace != p.dataSource.Namespace || name != p.dataSource.Name {
+		return nil, k8serrors.NewNotFound(cdiv1.SchemeGroupVersion.WithResource("datasources").GroupResource(), name)
+	}
+	return p.dataSource, nil
+}
+
+func (app *cdiAPIApp) dataSourceCatalogHandler(request *restful.Request, response *restful.Response) {
+	allowed, reason, err := app.authorizer.Authorize(request)
+	if err != nil {
+		klog.Error(err)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	} else if !allowed {
+		klog.Infof("Rejected Request: %s", reason)
+		writeErr := response.WriteErrorString(http.StatusUnauthorized, reason)
+		if writeErr != nil {
+			klog.Error("dataSourceCatalogHandler: failed to send response", writeErr)
+		}
+		return
+	}
+
+	userInfo, err := app.authorizer.UserInfo(request)
+	if err != nil {
+		writeErrorResponse(response, http.StatusBadRequest, err)
+		return
+	}
+
+	ctx := request.Request.Context()
+	dataSources, err := app.cdiClient.CdiV1beta1().DataSources(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		LabelSelector: request.QueryParameter(labelSelectorParam),
+	})
+	if err != nil {
+		if k8serrors.IsBadRequest(err) {
+			writeErrorResponse(response, http.StatusBadRequest, err)
+			return
+		}
+		writeErrorResponse(response, http.StatusInternalServerError, err)
+		return
+	}
+
+	targetNamespace := request.QueryParameter(targetNamespaceParam)
+	list := &cdiuploadv1.DataSourceCatalogEntryList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DataSourceCatalogEntryList",
+			APIVersion: cdiuploadv1.SchemeGroupVersion.String(),
+		},
+		Items: []cdiuploadv1.DataSourceCatalogEntry{},
+	}
+	for i := range dataSources.Items {
+		dataSource := &dataSources.Items[i]
+		allowed, err := app.canCloneDataSource(dataSource, targetNamespace, userInfo)
+		if err != nil {
+			writeErrorResponse(response, http.StatusInternalServerError, err)
+			return
+		}
+		if allowed {
+			list.Items = append(list.Items, app.newDataSourceCatalogEntry(ctx, dataSource))
+		}
+	}
+
+	writeJSONResponse(response, list)
+}
+
+// canCloneDataSource uses the DataVolume clone authorization to check if the user may clone from the DataSource
+func (app *cdiAPIApp) canCloneDataSource(dataSource *cdiv1.DataSource, targetNamespace string, userInfo authentication.UserInfo) (bool, error) {
+	dv := &cdiv1.DataVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: targetNamespace,
+		},
+		Spec: cdiv1.DataVolumeSpec{
+			SourceRef: &cdiv1.DataVolumeSourceRef{
+				Kind:      cdiv1.DataVolumeDataSource,
+				Namespace: &dataSource.Namespace,
+				Name:      dataSource.Name,
+			},
+		},
+	}
+
+	proxy := &catalogAuthProxy{client: app.client, dataSource: withSourceNamespace(dataSource)}
+	response, err := dv.AuthorizeUser(targetNamespace, "", proxy, userInfo)
+	if err != nil {
+		// No clone source yet, or the source namespace is gone; nothing to clone from
+		if errors.Is(err, cdiv1.ErrNoTokenOkay) || k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return response.Allowed, nil
+}
+
+// withSourceNamespace defaults the source namespaces to the DataSource namespace, so the
+// clone authorization does not fall back to the target namespace
+func withSourceNamespace(dataSource *cdiv1.DataSource) *cdiv1.DataSource {
+	dataSource = dataSource.DeepCopy()
+	for _, source := range []*cdiv1.DataSourceSource{&dataSource.Spec.Source, &dataSource.Status.Source} {
+		if source.PVC != nil && source.PVC.Namespace == "" {
+			source.PVC.Namespace = dataSource.Namespace
+		}
+		if source.Snapshot != nil && source.Snapshot.Namespace == "" {
+			source.Snapshot.Namespace = dataSource.Namespace
+		}
+	}
+	return dataSource
+}
+
+func (app *cdiAPIApp) newDataSourceCatalogEntry(ctx context.Context, dataSource *cdiv1.DataSource) cdiuploadv1.DataSourceCatalogEntry {
+	entry := cdiuploadv1.DataSourceCatalogEntry{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DataSourceCatalogEntry",
+			APIVersion: cdiuploadv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              dataSource.Name,
+			Namespace:         dataSource.Namespace,
+			Labels:            dataSource.Labels,
+			CreationTimestamp: dataSource.CreationTimestamp,
+		},
+		Status: cdiuploadv1.DataSourceCatalogEntryStatus{
+			OperatingSystem: dataSource.Labels[cc.LabelOperatingSystem],
+			Architecture:    dataSource.Labels[cc.LabelArchitecture],
+			Version:         dataSource.Labels[cc.LabelOperatingSystemVersion],
+		},
+	}
+
+	for _, condition := range dataSource.Status.Conditions {
+		if condition.Type == cdiv1.DataSourceReady {
+			entry.Status.Ready = condition.Status == corev1.ConditionTrue
+			lastUpdateTime := condition.LastTransitionTime
+			entry.Status.LastUpdateTime = &lastUpdateTime
+			break
+		}
+	}
+
+	if entry.Status.Ready {
+		entry.Status.Size = app.getDataSourceSize(ctx, dataSource)
+	}
+
+	return entry
+}
+
+// getDataSourceSize returns the size of the DataSource clone source, nil if unknown
+func (app *cdiAPIApp) getDataSourceSize(ctx context.Context, dataSource *cdiv1.DataSource) *resource.Quantity {
+	source := dataSource.Spec.Source
+	if source.DataSource != nil || cdiv1.IsDataSourceImportSource(&source) {
+		source = dataSource.Status.Source
+	}
+
+	switch {
+	case source.PVC != nil:
+		namespace := source.PVC.Namespace
+		if namespace == "" {
+			namespace = dataSource.Namespace
+		}
+		pvc, err := app.client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, source.PVC.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.V(3).Infof("Unable to get source PVC %s/%s of DataSource %s/%s: %v", namespace, source.PVC.Name, dataSource.Namespace, dataSource.Name, err)
+			return nil
+		}
+		if size, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			return &size
+		}
+	case source.Snapshot != nil:
+		namespace := source.Snapshot.Namespace
+		if namespace == "" {
+			namespace = dataSource.Namespace
+		}
+		snapshot, err := app.snapClient.SnapshotV1().VolumeSnapshots(namespace).Get(ctx, source.Snapshot.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.V(3).Infof("Unable to get source snapshot %s/%s of DataSource %s/%s: %v", namespace, source.Snapshot.Name, dataSource.Namespace, dataSource.Name, err)
+			return nil
+		}
+		if snapshot.Status != nil && snapshot.Status.RestoreSize != nil {
+			return snapshot.Status.RestoreSize
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	snapfake "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned/fake"
+
+	authentication "k8s.io/api/authentication/v1"
+	authorization "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+	cdifake "kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/fake"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const (
+	goldenNamespace  = "golden-images"
+	privateNamespace = "private"
+)
+
+var catalogTransitionTime = metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+func newCatalogNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func newCatalogPvc(namespace, name, size string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse(size),
+			},
+		},
+	}
+}
+
+func newCatalogDataSource(namespace, name string, ready bool, labels map[string]string) *cdiv1.DataSource {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &cdiv1.DataSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: cdiv1.DataSourceSpec{
+			Source: cdiv1.DataSourceSource{
+				PVC: &cdiv1.DataVolumeSourcePVC{Name: name},
+			},
+		},
+		Status: cdiv1.DataSourceStatus{
+			Conditions: []cdiv1.DataSourceCondition{
+				{
+					Type: cdiv1.DataSourceReady,
+					ConditionState: cdiv1.ConditionState{
+						Status:             status,
+						LastTransitionTime: catalogTransitionTime,
+					},
+				},
+			},
+		},
+	}
+}
+
+// allowCloneFrom makes the fake SubjectAccessReviews allow clone from the given namespace only
+func allowCloneFrom(client *k8sfake.Clientset, namespace string) {
+	client.Fake.PrependReactor("create", "subjectaccessreviews", func(action core.Action) (bool, runtime.Object, error) {
+		sar := action.(core.CreateAction).GetObject().(*authorization.SubjectAccessReview)
+		Expect(sar.Spec.User).To(Equal("user"))
+		sar.Status.Allowed = sar.Spec.ResourceAttributes.Namespace == namespace
+		return true, sar, nil
+	})
+}
+
+func getCatalog(app *cdiAPIApp, query string) (*cdiuploadv1.DataSourceCatalogEntryList, int) {
+	app.composeUploadTokenAPI()
+
+	req, err := http.NewRequest(http.MethodGet, "/apis/upload.cdi.kubevirt.io/v1beta1/datasourcecatalogentries"+query, nil)
+	Expect(err).ToNot(HaveOccurred())
+	rr := httptest.NewRecorder()
+
+	app.container.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		return nil, rr.Code
+	}
+
+	list := &cdiuploadv1.DataSourceCatalogEntryList{}
+	Expect(json.Unmarshal(rr.Body.Bytes(), list)).To(Succeed())
+	return list, rr.Code
+}
+
+var _ = Describe("DataSource catalog", func() {
+	authorizer := &testAuthorizer{allowed: true, userInfo: authentication.UserInfo{Username: "user"}}
+
+	It("should reject unauthorized users", func() {
+		app := &cdiAPIApp{authorizer: &testAuthorizer{allowed: false, reason: "bad person"}}
+		_, status := getCatalog(app, "")
+		Expect(status).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should list only the DataSources the user may clone from", func() {
+		labels := map[string]string{
+			cc.LabelOperatingSystem:        "fedora",
+			cc.LabelArchitecture:           "amd64",
+			cc.LabelOperatingSystemVersion: "40",
+		}
+		client := k8sfake.NewSimpleClientset(
+			newCatalogNamespace(goldenNamespace),
+			newCatalogNamespace(privateNamespace),
+			newCatalogPvc(goldenNamespace, "fedora", "5Gi"),
+		)
+		allowCloneFrom(client, goldenNamespace)
+		cdiClient := cdifake.NewSimpleClientset(
+			newCatalogDataSource(goldenNamespace, "fedora", true, labels),
+			newCatalogDataSource(privateNamespace, "secret", true, nil),
+		)
+		app := &cdiAPIApp{client: client, cdiClient: cdiClient, authorizer: authorizer}
+
+		list, status := getCatalog(app, "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(list.Items).To(HaveLen(1))
+		entry := list.Items[0]
+		Expect(entry.Namespace).To(Equal(goldenNamespace))
+		Expect(entry.Name).To(Equal("fedora"))
+		Expect(entry.Labels).To(Equal(labels))
+		Expect(entry.Status.OperatingSystem).To(Equal("fedora"))
+		Expect(entry.Status.Architecture).To(Equal("amd64"))
+		Expect(entry.Status.Version).To(Equal("40"))
+		Expect(entry.Status.Ready).To(BeTrue())
+		Expect(entry.Status.Size.Cmp(resource.MustParse("5Gi"))).To(Equal(0))
+		Expect(entry.Status.LastUpdateTime.Equal(&catalogTransitionTime)).To(BeTrue())
+	})
+
+	It("should list DataSources in the target namespace without further checks", func() {
+		client := k8sfake.NewSimpleClientset(newCatalogNamespace(goldenNamespace), newCatalogNamespace(privateNamespace))
+		allowCloneFrom(client, goldenNamespace)
+		cdiClient := cdifake.NewSimpleClientset(
+			newCatalogDataSource(goldenNamespace, "fedora", false, nil),
+			newCatalogDataSource(privateNamespace, "secret", true, nil),
+		)
+		app := &cdiAPIApp{client: client, cdiClient: cdiClient, authorizer: authorizer}
+
+		list, status := getCatalog(app, "?targetNamespace="+privateNamespace)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(list.Items).To(HaveLen(2))
+		for _, entry := range list.Items {
+			Expect(entry.Status.Ready).To(Equal(entry.Namespace == privateNamespace))
+			Expect(entry.Status.Size).To(BeNil())
+		}
+	})
+
+	It("should filter DataSources by label selector", func() {
+		client := k8sfake.NewSimpleClientset(newCatalogNamespace(goldenNamespace))
+		allowCloneFrom(client, goldenNamespace)
+		cdiClient := cdifake.NewSimpleClientset(
+			newCatalogDataSource(goldenNamespace, "fedora", true, map[string]string{cc.LabelOperatingSystem: "fedora"}),
+			newCatalogDataSource(goldenNamespace, "centos", true, map[string]string{cc.LabelOperatingSystem: "centos"}),
+		)
+		app := &cdiAPIApp{client: client, cdiClient: cdiClient, authorizer: authorizer}
+
+		list, status := getCatalog(app, "?labelSelector="+cc.LabelOperatingSystem+"%3Dcentos")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Name).To(Equal("centos"))
+	})
+
+	It("should skip DataSources without a clone source", func() {
+		client := k8sfake.NewSimpleClientset(newCatalogNamespace(goldenNamespace))
+		allowCloneFrom(client, goldenNamespace)
+		dataSource := newCatalogDataSource(goldenNamespace, "fedora", false, nil)
+		dataSource.Spec.Source.PVC = nil
+		cdiClient := cdifake.NewSimpleClientset(dataSource)
+		app := &cdiAPIApp{client: client, cdiClient: cdiClient, authorizer: authorizer}
+
+		list, status := getCatalog(app, "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(list.Items).To(BeEmpty())
+	})
+
+	It("should report the restore size of snapshot sources", func() {
+		client := k8sfake.NewSimpleClientset(newCatalogNamespace(goldenNamespace))
+		allowCloneFrom(client, goldenNamespace)
+		dataSource := newCatalogDataSource(goldenNamespace, "fedora", true, nil)
+		dataSource.Spec.Source.PVC = nil
+		dataSource.Spec.Source.Snapshot = &cdiv1.DataVolumeSourceSnapshot{Name: "fedora-snapshot"}
+		cdiClient := cdifake.NewSimpleClientset(dataSource)
+		restoreSize := resource.MustParse("10Gi")
+		snapClient := snapfake.NewSimpleClientset(&snapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "fedora-snapshot",
+				Namespace: goldenNamespace,
+			},
+			Status: &snapshotv1.VolumeSnapshotStatus{
+				RestoreSize: &restoreSize,
+			},
+		})
+		app := &cdiAPIApp{client: client, cdiClient: cdiClient, snapClient: snapClient, authorizer: authorizer}
+
+		list, status := getCatalog(app, "")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Status.Size.Cmp(restoreSize)).To(Equal(0))
+	})
+})
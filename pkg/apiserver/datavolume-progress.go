@@ -0,0 +1,365 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	restful "github.com/emicklei/go-restful/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	clonerMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
+	importerMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
+	openstackMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/openstack-populator"
+	ovirtMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/ovirt-populator"
+)
+
+const (
+	dataVolumeProgressResource = "datavolumeprogressreports"
+
+	// watchParam streams the progress reports as server-sent events until the DataVolume completes
+	watchParam = "watch"
+)
+
+var (
+	// progressPollInterval is how often a watched DataVolume progress is sampled, the worker pods update their
+	// metrics every second
+	progressPollInterval = time.Second
+	// progressHeartbeatInterval is how often a stream without progress changes sends a comment, so proxies
+	// between the client and the API server don't close it
+	progressHeartbeatInterval = 30 * time.Second
+
+	// workerProgressMetrics matches the progress metrics of all the worker pods
+	workerProgressMetrics = strings.Join([]string{
+		importerMetrics.ImportProgressMetricName,
+		clonerMetrics.CloneProgressMetricName,
+		openstackMetrics.OpenStackPopulatorProgressMetricName,
+		ovirtMetrics.OvirtPopulatorProgressMetricName,
+	}, "|")
+)
+
+type progressSampleFunc func(ctx context.Context, namespace, name string) (*cdiuploadv1.DataVolumeProgressReport, bool, error)
+
+// progressSampler samples the progress of the watched DataVolumes once per interval, whatever the number of
+// watchers of each DataVolume
+type progressSampler struct {
+	mutex   sync.Mutex
+	samples map[types.NamespacedName]*progressSample
+	sample  progressSampleFunc
+}
+
+// progressSample is the last progress sampled for a watched DataVolume
+type progressSample struct {
+	report *cdiuploadv1.DataVolumeProgressReport
+	done   bool
+	err    error
+	// updated is closed when the sample is updated
+	updated  chan struct{}
+	watchers int
+	cancel   context.CancelFunc
+}
+
+func newProgressSampler(sample progressSampleFunc) *progressSampler {
+	return &progressSampler{
+		samples: map[types.NamespacedName]*progressSample{},
+		sample:  sample,
+	}
+}
+
+// watch registers a watcher of the DataVolume, and starts sampling it for the first watcher
+func (s *progressSampler) watch(key types.NamespacedName) *progressSample {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	sample, ok := s.samples[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		sample = &progressSample{updated: make(chan struct{}), cancel: cancel}
+		s.samples[key] = sample
+		go s.run(ctx, key, sample)
+	}
+	sample.watchers++
+	return sample
+}
+
+// unwatch unregisters a watcher of the DataVolume, and stops sampling it after the last watcher
+func (s *progressSampler) unwatch(key types.NamespacedName, sample *progressSample) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	sample.watchers--
+	if sample.watchers == 0 {
+		sample.cancel()
+		if s.samples[key] == sample {
+			delete(s.samples, key)
+		}
+	}
+}
+
+// get returns the last sampled progress, and a channel closed on the next update
+func (s *progressSampler) get(sample *progressSample) (*cdiuploadv1.DataVolumeProgressReport, bool, <-chan struct{}, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return sample.report, sample.done, sample.updated, sample.err
+}
+
+// run samples the DataVolume progress until it completes, fails to be sampled, or has no more watchers
+func (s *progressSampler) run(ctx context.Context, key types.NamespacedName, sample *progressSample) {
+	ticker := time.NewTicker(progressPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		report, done, err := s.sample(ctx, key.Namespace, key.Name)
+		if ctx.Err() != nil {
+			return
+		}
+		final := done || err != nil
+		s.mutex.Lock()
+		sample.report, sample.done, sample.err = report, done, err
+		close(sample.updated)
+		sample.updated = make(chan struct{})
+		// The next watchers of a completed DataVolume start a new sample
+		if final && s.samples[key] == sample {
+			delete(s.samples, key)
+		}
+		s.mutex.Unlock()
+		if final {
+			return
+		}
+	}
+}
+
+func (app *cdiAPIApp) dataVolumeProgressHandler(request *restful.Request, response *restful.Response) {
+	allowed, reason, err := app.authorizer.Authorize(request)
+	if err != nil {
+		klog.Error(err)
+		response.WriteHeader(http.StatusInternalServerError)
+		return
+	} else if !allowed {
+		klog.Infof("Rejected Request: %s", reason)
+		writeErr := response.WriteErrorString(http.StatusUnauthorized, reason)
+		if writeErr != nil {
+			klog.Error("dataVolumeProgressHandler: failed to send response", writeErr)
+		}
+		return
+	}
+
+	ctx := request.Request.Context()
+	key := types.NamespacedName{Namespace: request.PathParameter("namespace"), Name: request.PathParameter("name")}
+	report, done, err := app.getDataVolumeProgress(ctx, key.Namespace, key.Name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			writeErrorResponse(response, http.StatusNotFound, err)
+			return
+		}
+		writeErrorResponse(response, http.StatusInternalServerError, err)
+		return
+	}
+
+	if watch, _ := strconv.ParseBool(request.QueryParameter(watchParam)); !watch {
+		writeJSONResponse(response, report)
+		return
+	}
+
+	response.Header().Set("Content-Type", "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.WriteHeader(http.StatusOK)
+
+	sample := app.progressSampler.watch(key)
+	defer app.progressSampler.unwatch(key, sample)
+	_, _, updated, _ := app.progressSampler.get(sample)
+
+	var last *cdiuploadv1.DataVolumeProgressReport
+	heartbeat := time.NewTimer(progressHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		if last == nil || !equality.Semantic.DeepEqual(last.Status, report.Status) {
+			if err := writeProgressEvent(response, "progress", report); err != nil {
+				klog.V(3).Infof("Stopped streaming the progress of DataVolume %s: %v", key, err)
+				return
+			}
+			last = report
+			heartbeat.Reset(progressHeartbeatInterval)
+		}
+		if done {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(response, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			response.Flush()
+			heartbeat.Reset(progressHeartbeatInterval)
+		case <-updated:
+			if report, done, updated, err = app.progressSampler.get(sample); err != nil {
+				_ = writeProgressEvent(response, "error", metav1.Status{
+					Status:  metav1.StatusFailure,
+					Message: err.Error(),
+					Reason:  k8serrors.ReasonForError(err),
+				})
+				return
+			}
+		}
+	}
+}
+
+// writeProgressEvent sends value as a server-sent event
+func writeProgressEvent(response *restful.Response, event string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(response, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	response.Flush()
+	return nil
+}
+
+// getDataVolumeProgress returns the progress of the DataVolume, and whether it completed
+func (app *cdiAPIApp) getDataVolumeProgress(ctx context.Context, namespace, name string) (*cdiuploadv1.DataVolumeProgressReport, bool, error) {
+	dv := &cdiv1.DataVolume{}
+	if err := app.controllerRuntimeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, dv); err != nil {
+		return nil, false, err
+	}
+
+	report := &cdiuploadv1.DataVolumeProgressReport{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DataVolumeProgressReport",
+			APIVersion: cdiuploadv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dv.Name,
+			Namespace: dv.Namespace,
+			UID:       dv.UID,
+		},
+		Status: cdiuploadv1.DataVolumeProgressReportStatus{
+			Phase:                  string(dv.Status.Phase),
+			Progress:               string(dv.Status.Progress),
+			Throughput:             dv.Status.Throughput,
+			EstimatedTimeRemaining: dv.Status.EstimatedTimeRemaining,
+		},
+	}
+
+	done := dv.Status.Phase == cdiv1.Succeeded || dv.Status.Phase == cdiv1.Failed
+	if done {
+		return report, true, nil
+	}
+
+	// The status of the DataVolume is the fallback when its worker pod can't be reached
+	if workerReport := app.getWorkerProgressReport(ctx, dv); workerReport != nil {
+		status := &report.Status
+		if f, err := strconv.ParseFloat(workerReport.Progress, 64); err == nil {
+			status.Progress = fmt.Sprintf("%.2f%%", f)
+		}
+		if throughput, eta := workerReport.TransferStats(); throughput != nil || eta != nil {
+			status.Throughput, status.EstimatedTimeRemaining = throughput, eta
+		}
+		status.Transferred, status.Total = workerReport.ByteCounts()
+	}
+
+	return report, false, nil
+}
+
+// getWorkerProgressReport scrapes the metrics of the running worker pod populating the DataVolume, nil if there is none
+func (app *cdiAPIApp) getWorkerProgressReport(ctx context.Context, dv *cdiv1.DataVolume) *cc.ProgressReport {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := app.controllerRuntimeClient.Get(ctx, types.NamespacedName{Namespace: dv.Namespace, Name: dv.Name}, pvc); err != nil {
+		klog.V(3).Infof("Unable to get PVC of DataVolume %s/%s: %v", dv.Namespace, dv.Name, err)
+		return nil
+	}
+
+	// The worker pods of the populators are owned by the PVC', and report the progress of the target PVC
+	owners := []types.UID{pvc.UID}
+	ownerUID := string(dv.UID)
+	if primeName, ok := pvc.Annotations[cc.AnnPVCPrimeName]; ok {
+		prime := &corev1.PersistentVolumeClaim{}
+		if err := app.controllerRuntimeClient.Get(ctx, types.NamespacedName{Namespace: dv.Namespace, Name: primeName}, prime); err != nil {
+			klog.V(3).Infof("Unable to get PVC' of DataVolume %s/%s: %v", dv.Namespace, dv.Name, err)
+			return nil
+		}
+		owners = append(owners, prime.UID)
+		ownerUID = string(pvc.UID)
+	}
+
+	pod, err := app.getWorkerPod(ctx, dv.Namespace, owners)
+	if err != nil || pod == nil {
+		return nil
+	}
+	url, err := cc.GetMetricsURL(pod)
+	if err != nil || url == "" {
+		return nil
+	}
+	report, err := cc.GetTransferReportFromURL(ctx, url, app.progressHTTPClient, workerProgressMetrics, ownerUID)
+	if err != nil {
+		klog.V(3).Infof("Unable to get the progress of DataVolume %s/%s from pod %s: %v", dv.Namespace, dv.Name, pod.Name, err)
+		return nil
+	}
+	return report
+}
+
+// getWorkerPod returns the running worker pod owned by one of the owners, nil if there is none
+func (app *cdiAPIApp) getWorkerPod(ctx context.Context, namespace string, owners []types.UID) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := app.controllerRuntimeClient.List(ctx, pods, client.InNamespace(namespace), client.HasLabels{common.PrometheusLabelKey}); err != nil {
+		klog.V(3).Infof("Unable to list worker pods in namespace %s: %v", namespace, err)
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		for _, ref := range pod.OwnerReferences {
+			for _, owner := range owners {
+				if ref.UID == owner {
+					return pod, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
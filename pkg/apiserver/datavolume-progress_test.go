@@ -0,0 +1,344 @@
+/*
+ * This file is part of the CDI project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2026 Red Hat, Inc.
+ *
+ */
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cdiuploadv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/upload/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const progressDvName = "test-dv"
+
+func newProgressDataVolume(phase cdiv1.DataVolumePhase, progress string) *cdiv1.DataVolume {
+	return &cdiv1.DataVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      progressDvName,
+			Namespace: metav1.NamespaceDefault,
+			UID:       types.UID("dv-uid"),
+		},
+		Status: cdiv1.DataVolumeStatus{
+			Phase:    phase,
+			Progress: cdiv1.DataVolumeProgress(progress),
+		},
+	}
+}
+
+func newProgressPvc(name string, uid types.UID, annotations map[string]string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   metav1.NamespaceDefault,
+			UID:         uid,
+			Annotations: annotations,
+		},
+	}
+}
+
+// newWorkerPod creates a running worker pod owned by ownerUID, serving the metrics of the passed server
+func newWorkerPod(ownerUID types.UID, metricsServer *httptest.Server) *corev1.Pod {
+	ep, err := url.Parse(metricsServer.URL)
+	Expect(err).ToNot(HaveOccurred())
+	port, err := strconv.ParseInt(ep.Port(), 10, 32)
+	Expect(err).ToNot(HaveOccurred())
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "importer-" + progressDvName,
+			Namespace:       metav1.NamespaceDefault,
+			Labels:          map[string]string{common.PrometheusLabelKey: common.PrometheusLabelValue},
+			OwnerReferences: []metav1.OwnerReference{{UID: ownerUID}},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "importer",
+				Ports: []corev1.ContainerPort{{Name: "metrics", ContainerPort: int32(port)}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: ep.Hostname(),
+		},
+	}
+}
+
+// newMetricsServer serves the progress metrics of a worker pod transferring to ownerUID
+func newMetricsServer(ownerUID types.UID) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "kubevirt_cdi_import_progress_total{ownerUID=%q} 25\n", ownerUID)
+		_, _ = fmt.Fprintf(w, "kubevirt_cdi_transfer_throughput_bytes{ownerUID=%q} 1.048576e+06\n", ownerUID)
+		_, _ = fmt.Fprintf(w, "kubevirt_cdi_transfer_eta_seconds{ownerUID=%q} 30\n", ownerUID)
+		_, _ = fmt.Fprintf(w, "kubevirt_cdi_transfer_transferred_bytes{ownerUID=%q} 1.048576e+07\n", ownerUID)
+		_, _ = fmt.Fprintf(w, "kubevirt_cdi_transfer_total_bytes{ownerUID=%q} 4.194304e+07\n", ownerUID)
+	}))
+}
+
+// newProgressApp creates an API server reading the passed objects, the interceptors may override the reads
+func newProgressApp(funcs interceptor.Funcs, objs ...runtime.Object) *cdiAPIApp {
+	s := runtime.NewScheme()
+	Expect(corev1.AddToScheme(s)).To(Succeed())
+	Expect(cdiv1.AddToScheme(s)).To(Succeed())
+	app := &cdiAPIApp{
+		controllerRuntimeClient: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).WithInterceptorFuncs(funcs).Build(),
+		authorizer:              &testAuthorizer{allowed: true},
+		progressHTTPClient:      cc.BuildHTTPClient(nil),
+	}
+	app.progressSampler = newProgressSampler(app.getDataVolumeProgress)
+	return app
+}
+
+func getDataVolumeProgress(app *cdiAPIApp, query string) *httptest.ResponseRecorder {
+	app.composeUploadTokenAPI()
+
+	req, err := http.NewRequest(http.MethodGet, "/apis/upload.cdi.kubevirt.io/v1beta1/namespaces/default/datavolumeprogressreports/"+progressDvName+query, nil)
+	Expect(err).ToNot(HaveOccurred())
+	rr := httptest.NewRecorder()
+	app.container.ServeHTTP(rr, req)
+	return rr
+}
+
+func parseProgressReport(data []byte) *cdiuploadv1.DataVolumeProgressReport {
+	report := &cdiuploadv1.DataVolumeProgressReport{}
+	Expect(json.Unmarshal(data, report)).To(Succeed())
+	return report
+}
+
+var _ = Describe("DataVolume progress", func() {
+	var origPollInterval time.Duration
+
+	BeforeEach(func() {
+		origPollInterval = progressPollInterval
+		progressPollInterval = 10 * time.Millisecond
+	})
+
+	AfterEach(func() {
+		progressPollInterval = origPollInterval
+	})
+
+	It("should reject unauthorized users", func() {
+		app := &cdiAPIApp{authorizer: &testAuthorizer{allowed: false, reason: "bad person"}}
+		rr := getDataVolumeProgress(app, "")
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should not find a missing DataVolume", func() {
+		app := newProgressApp(interceptor.Funcs{})
+		rr := getDataVolumeProgress(app, "")
+		Expect(rr.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should report the status of a completed DataVolume", func() {
+		app := newProgressApp(interceptor.Funcs{}, newProgressDataVolume(cdiv1.Succeeded, "100.0%"))
+		rr := getDataVolumeProgress(app, "")
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		report := parseProgressReport(rr.Body.Bytes())
+		Expect(report.Name).To(Equal(progressDvName))
+		Expect(report.Status.Phase).To(Equal(string(cdiv1.Succeeded)))
+		Expect(report.Status.Progress).To(Equal("100.0%"))
+		Expect(report.Status.Transferred).To(BeNil())
+	})
+
+	It("should fall back to the DataVolume status without a worker pod", func() {
+		app := newProgressApp(interceptor.Funcs{},
+			newProgressDataVolume(cdiv1.ImportInProgress, "10.00%"),
+			newProgressPvc(progressDvName, "pvc-uid", nil))
+		rr := getDataVolumeProgress(app, "")
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		report := parseProgressReport(rr.Body.Bytes())
+		Expect(report.Status.Phase).To(Equal(string(cdiv1.ImportInProgress)))
+		Expect(report.Status.Progress).To(Equal("10.00%"))
+		Expect(report.Status.Transferred).To(BeNil())
+	})
+
+	DescribeTable("should report the progress from the worker pod", func(populator bool) {
+		dv := newProgressDataVolume(cdiv1.ImportInProgress, "10.00%")
+		pvc := newProgressPvc(progressDvName, "pvc-uid", nil)
+		objs := []runtime.Object{dv, pvc}
+		podOwner, metricsOwner := pvc.UID, dv.UID
+		if populator {
+			pvc.Annotations = map[string]string{cc.AnnPVCPrimeName: "prime-pvc-uid"}
+			objs = append(objs, newProgressPvc("prime-pvc-uid", "prime-uid", nil))
+			podOwner, metricsOwner = "prime-uid", pvc.UID
+		}
+		ts := newMetricsServer(metricsOwner)
+		defer ts.Close()
+		objs = append(objs, newWorkerPod(podOwner, ts))
+		app := newProgressApp(interceptor.Funcs{}, objs...)
+
+		rr := getDataVolumeProgress(app, "")
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		status := parseProgressReport(rr.Body.Bytes()).Status
+		Expect(status.Phase).To(Equal(string(cdiv1.ImportInProgress)))
+		Expect(status.Progress).To(Equal("25.00%"))
+		Expect(status.Transferred.Cmp(resource.MustParse("10Mi"))).To(Equal(0))
+		Expect(status.Total.Cmp(resource.MustParse("40Mi"))).To(Equal(0))
+		Expect(status.Throughput.Cmp(resource.MustParse("1Mi"))).To(Equal(0))
+		Expect(status.EstimatedTimeRemaining).To(HaveValue(Equal(metav1.Duration{Duration: 30 * time.Second})))
+	},
+		Entry("of an import", false),
+		Entry("of a populator", true),
+	)
+
+	Context("when watching", func() {
+		// serveDataVolumes returns the DataVolumes in order on each get, and not found when there is no more
+		serveDataVolumes := func(dvs ...*cdiv1.DataVolume) interceptor.Funcs {
+			return interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					dv, ok := obj.(*cdiv1.DataVolume)
+					if !ok || len(dvs) == 0 {
+						return c.Get(ctx, key, obj, opts...)
+					}
+					dvs[0].DeepCopyInto(dv)
+					dvs = dvs[1:]
+					return nil
+				},
+			}
+		}
+
+		getEvents := func(rr *httptest.ResponseRecorder) []string {
+			return strings.Split(strings.TrimSpace(rr.Body.String()), "\n\n")
+		}
+
+		It("should stream the progress changes until the DataVolume completes", func() {
+			app := newProgressApp(serveDataVolumes(
+				newProgressDataVolume(cdiv1.ImportInProgress, "10.00%"),
+				newProgressDataVolume(cdiv1.ImportInProgress, "10.00%"),
+				newProgressDataVolume(cdiv1.ImportInProgress, "50.00%"),
+				newProgressDataVolume(cdiv1.Succeeded, "100.0%"),
+			), newProgressPvc(progressDvName, "pvc-uid", nil))
+
+			rr := getDataVolumeProgress(app, "?watch=true")
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(rr.Header().Get("Content-Type")).To(Equal("text/event-stream"))
+			events := getEvents(rr)
+			Expect(events).To(HaveLen(3))
+			var progress []string
+			for _, event := range events {
+				lines := strings.Split(event, "\n")
+				Expect(lines).To(HaveLen(2))
+				Expect(lines[0]).To(Equal("event: progress"))
+				Expect(lines[1]).To(HavePrefix("data: "))
+				progress = append(progress, parseProgressReport([]byte(strings.TrimPrefix(lines[1], "data: "))).Status.Progress)
+			}
+			Expect(progress).To(Equal([]string{"10.00%", "50.00%", "100.0%"}))
+			Expect(app.progressSampler.samples).To(BeEmpty())
+		})
+
+		It("should send an error event when the DataVolume is deleted", func() {
+			app := newProgressApp(serveDataVolumes(newProgressDataVolume(cdiv1.ImportInProgress, "10.00%")),
+				newProgressPvc(progressDvName, "pvc-uid", nil))
+
+			rr := getDataVolumeProgress(app, "?watch=true")
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			events := getEvents(rr)
+			Expect(events).To(HaveLen(2))
+			Expect(events[1]).To(HavePrefix("event: error\n"))
+			Expect(events[1]).To(ContainSubstring(`"reason":"NotFound"`))
+			Expect(app.progressSampler.samples).To(BeEmpty())
+		})
+	})
+
+	Context("sampler", func() {
+		key := types.NamespacedName{Namespace: metav1.NamespaceDefault, Name: progressDvName}
+
+		// countingSampler completes the DataVolume on the last sample, and counts the samples
+		countingSampler := func(last int32, samples *atomic.Int32) *progressSampler {
+			return newProgressSampler(func(ctx context.Context, namespace, name string) (*cdiuploadv1.DataVolumeProgressReport, bool, error) {
+				n := samples.Add(1)
+				report := &cdiuploadv1.DataVolumeProgressReport{}
+				report.Status.Progress = fmt.Sprintf("%d", n)
+				return report, n == last, nil
+			})
+		}
+
+		// waitDone follows the updates of the sample until the DataVolume completes, and returns its last progress
+		waitDone := func(sampler *progressSampler, sample *progressSample) string {
+			_, _, updated, _ := sampler.get(sample)
+			for {
+				Eventually(updated).Should(BeClosed())
+				report, done, next, err := sampler.get(sample)
+				Expect(err).ToNot(HaveOccurred())
+				if done {
+					return report.Status.Progress
+				}
+				updated = next
+			}
+		}
+
+		It("should sample a DataVolume once for all its watchers", func() {
+			samples := &atomic.Int32{}
+			sampler := countingSampler(3, samples)
+			first := sampler.watch(key)
+			second := sampler.watch(key)
+			Expect(second).To(BeIdenticalTo(first))
+
+			results := make(chan string, 2)
+			for _, sample := range []*progressSample{first, second} {
+				go func() {
+					defer GinkgoRecover()
+					results <- waitDone(sampler, sample)
+				}()
+			}
+			Eventually(results).Should(Receive(Equal("3")))
+			Eventually(results).Should(Receive(Equal("3")))
+			Expect(samples.Load()).To(Equal(int32(3)))
+			sampler.unwatch(key, first)
+			sampler.unwatch(key, second)
+			Expect(sampler.samples).To(BeEmpty())
+		})
+
+		It("should stop sampling after the last watcher leaves", func() {
+			samples := &atomic.Int32{}
+			sampler := countingSampler(-1, samples)
+			sample := sampler.watch(key)
+			_, _, updated, _ := sampler.get(sample)
+			Eventually(updated).Should(BeClosed())
+			sampler.unwatch(key, sample)
+			Expect(sampler.samples).To(BeEmpty())
+
+			stopped := samples.Load()
+			Consistently(samples.Load).WithTimeout(10 * progressPollInterval).Should(BeNumerically("<=", stopped+1))
+		})
+	})
+})
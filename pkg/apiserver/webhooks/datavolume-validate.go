@@ -28,6 +28,8 @@ import (
 	snapclient "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authentication "k8s.io/api/authentication/v1"
+	authorization "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -154,6 +156,26 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 		})
 		return causes
 	}
+	if spec.Patch != nil {
+		if causes := validatePatch(spec, field); causes != nil {
+			return causes
+		}
+	}
+	if spec.Customization != nil {
+		if causes := validateCustomization(spec, field); causes != nil {
+			return causes
+		}
+	}
+	if spec.ExpandPartition != nil && *spec.ExpandPartition {
+		if causes := validateExpandPartition(spec, field); causes != nil {
+			return causes
+		}
+	}
+	if spec.Sparsify != nil && *spec.Sparsify {
+		if causes := validateSparsify(spec, field); causes != nil {
+			return causes
+		}
+	}
 	if spec.SourceRef != nil {
 		cause := wh.validateSourceRef(request, spec, field, namespace)
 		if cause != nil {
@@ -187,6 +209,11 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 			return causes
 		}
 	}
+	if blob := spec.Source.AzureBlob; blob != nil {
+		if causes := validateAzureBlobSource(blob, field); causes != nil {
+			return causes
+		}
+	}
 	if blank := spec.Source.Blank; blank != nil {
 		if causes := validateBlankSource(spec.ContentType, field); causes != nil {
 			return causes
@@ -207,6 +234,14 @@ func (wh *dataVolumeValidatingWebhook) validateDataVolumeSpec(request *admission
 			return causes
 		}
 	}
+	if backup := spec.Source.Backup; backup != nil && backup.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s source backup name is missing", field.Child("source", "backup").String()),
+			Field:   field.Child("source", "backup").String(),
+		})
+		return causes
+	}
 
 	// Validate clone sources
 	if spec.Source.PVC != nil {
@@ -363,8 +398,9 @@ func validateStorageSize(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path) (*met
 
 	// The storage size of a DataVolume can only be empty when two conditios are met:
 	//	1. The 'Storage' spec API is used, which allows for additional logic in CDI.
-	//	2. The 'PVC'/'Snapshot' source or SourceRef is used, so the original size can be extracted from the source.
-	isClone := spec.SourceRef != nil || (spec.Source != nil && spec.Source.PVC != nil) || (spec.Source != nil && spec.Source.Snapshot != nil)
+	//	2. The 'PVC'/'Snapshot' source or SourceRef is used, so the original size can be extracted from the source,
+	//	   or the DataVolume patches an existing PVC.
+	isClone := spec.SourceRef != nil || (spec.Source != nil && spec.Source.PVC != nil) || (spec.Source != nil && spec.Source.Snapshot != nil) || spec.Patch != nil
 	if pvcSize, ok := resources.Requests["storage"]; ok {
 		if pvcSize.IsZero() || pvcSize.Value() < 0 {
 			cause := metav1.StatusCause{
@@ -396,6 +432,110 @@ func validateStorageSize(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path) (*met
 	return nil, true
 }
 
+// validatePatch validates a DataVolume writing its import source into an existing PVC
+func validatePatch(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path) []metav1.StatusCause {
+	patch := spec.Patch
+	patchField := field.Child("patch")
+	invalid := func(message string, field *k8sfield.Path) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.String(),
+		}}
+	}
+
+	if (patch.Offset == nil) == (patch.PartitionLabel == "") {
+		return invalid(fmt.Sprintf("%s must set exactly one of offset and partitionLabel", patchField.String()), patchField)
+	}
+	if patch.Offset != nil && *patch.Offset < 0 {
+		return invalid(fmt.Sprintf("%s can't be negative", patchField.Child("offset").String()), patchField.Child("offset"))
+	}
+	source := spec.Source
+	if source == nil || (source.HTTP == nil && source.S3 == nil && source.GCS == nil && source.AzureBlob == nil && source.Registry == nil) {
+		return invalid(fmt.Sprintf("%s requires an http, s3, gcs, azureBlob or registry source", patchField.String()), field.Child("source"))
+	}
+	if spec.ContentType == cdiv1.DataVolumeArchive {
+		return invalid(fmt.Sprintf("%s requires the kubevirt content type", patchField.String()), field.Child("contentType"))
+	}
+	if len(spec.Checkpoints) > 0 {
+		return invalid(fmt.Sprintf("%s can't be a multi-stage import", patchField.String()), field.Child("checkpoints"))
+	}
+	return nil
+}
+
+// validateCustomization validates a DataVolume customizing its imported disk image
+func validateCustomization(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path) []metav1.StatusCause {
+	customizationField := field.Child("customization")
+	invalid := func(message string, field *k8sfield.Path) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.String(),
+		}}
+	}
+
+	if spec.Customization.ConfigMap == "" {
+		return invalid(fmt.Sprintf("%s can't be empty", customizationField.Child("configMap").String()), customizationField.Child("configMap"))
+	}
+	source := spec.Source
+	if source == nil || (source.HTTP == nil && source.S3 == nil && source.GCS == nil && source.AzureBlob == nil && source.Registry == nil) {
+		return invalid(fmt.Sprintf("%s requires an http, s3, gcs, azureBlob or registry source", customizationField.String()), field.Child("source"))
+	}
+	if spec.ContentType == cdiv1.DataVolumeArchive {
+		return invalid(fmt.Sprintf("%s requires the kubevirt content type", customizationField.String()), field.Child("contentType"))
+	}
+	if len(spec.Checkpoints) > 0 {
+		return invalid(fmt.Sprintf("%s can't be a multi-stage import", customizationField.String()), field.Child("checkpoints"))
+	}
+	if spec.Patch != nil {
+		return invalid(fmt.Sprintf("%s can't be combined with a patch", customizationField.String()), field.Child("patch"))
+	}
+	return nil
+}
+
+// validateExpandPartition validates a DataVolume growing the last partition of its disk image
+func validateExpandPartition(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path) []metav1.StatusCause {
+	expandField := field.Child("expandPartition")
+	invalid := func(message string, field *k8sfield.Path) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.String(),
+		}}
+	}
+
+	if spec.Source != nil && spec.Source.Blank != nil {
+		return invalid(fmt.Sprintf("%s can't be set for a blank disk", expandField.String()), field.Child("source"))
+	}
+	if spec.ContentType == cdiv1.DataVolumeArchive {
+		return invalid(fmt.Sprintf("%s requires the kubevirt content type", expandField.String()), field.Child("contentType"))
+	}
+	if spec.Patch != nil {
+		return invalid(fmt.Sprintf("%s can't be combined with a patch", expandField.String()), field.Child("patch"))
+	}
+	return nil
+}
+
+// validateSparsify validates a DataVolume deallocating the blocks of zeroes of its disk image
+func validateSparsify(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path) []metav1.StatusCause {
+	sparsifyField := field.Child("sparsify")
+	invalid := func(message string, field *k8sfield.Path) []metav1.StatusCause {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: message,
+			Field:   field.String(),
+		}}
+	}
+
+	if spec.Preallocation != nil && *spec.Preallocation {
+		return invalid(fmt.Sprintf("%s can't be combined with preallocation", sparsifyField.String()), field.Child("preallocation"))
+	}
+	if spec.ContentType == cdiv1.DataVolumeArchive {
+		return invalid(fmt.Sprintf("%s requires the kubevirt content type", sparsifyField.String()), field.Child("contentType"))
+	}
+	return nil
+}
+
 // validateExternalPopulation validates a DataVolume meant to be externally populated
 func validateExternalPopulation(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path, dataSource *v1.TypedLocalObjectReference, dataSourceRef *v1.TypedObjectReference) []metav1.StatusCause {
 	var causes []metav1.StatusCause
@@ -427,6 +567,45 @@ func validateExternalPopulation(spec *cdiv1.DataVolumeSpec, field *k8sfield.Path
 	return causes
 }
 
+// canUserUpdatePVC checks that the requesting user may update the PVC a patch DataVolume writes to,
+// since patching adopts a PVC the DataVolume controller does not otherwise manage
+func (wh *dataVolumeValidatingWebhook) canUserUpdatePVC(userInfo authentication.UserInfo, namespace, name string) (bool, string, error) {
+	var extra map[string]authorization.ExtraValue
+	if len(userInfo.Extra) > 0 {
+		extra = make(map[string]authorization.ExtraValue)
+		for k, v := range userInfo.Extra {
+			extra[k] = authorization.ExtraValue(v)
+		}
+	}
+
+	sar := &authorization.SubjectAccessReview{
+		Spec: authorization.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			Groups: userInfo.Groups,
+			UID:    userInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorization.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "update",
+				Resource:  "persistentvolumeclaims",
+				Name:      name,
+			},
+		},
+	}
+
+	klog.V(3).Infof("Sending SubjectAccessReview %+v", sar)
+	response, err := wh.k8sClient.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, "", err
+	}
+	klog.V(3).Infof("SubjectAccessReview response %+v", response)
+
+	if !response.Status.Allowed {
+		return false, fmt.Sprintf("User %s has insufficient permissions to update PVC %s/%s", userInfo.Username, namespace, name), nil
+	}
+	return true, "", nil
+}
+
 func (wh *dataVolumeValidatingWebhook) Admit(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 	var causes []metav1.StatusCause
 
@@ -491,6 +670,22 @@ func (wh *dataVolumeValidatingWebhook) Admit(ar admissionv1.AdmissionReview) *ad
 			if !k8serrors.IsNotFound(err) {
 				return toAdmissionResponseError(err)
 			}
+		} else if dv.Spec.Patch != nil {
+			allowed, reason, err := wh.canUserUpdatePVC(ar.Request.UserInfo, pvc.GetNamespace(), pvc.GetName())
+			if err != nil {
+				return toAdmissionResponseError(err)
+			}
+			if !allowed {
+				klog.Errorf("rejected patch of PVC %s/%s: %s", pvc.GetNamespace(), pvc.GetName(), reason)
+				var causes []metav1.StatusCause
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: reason,
+					Field:   k8sfield.NewPath("spec", "patch").String(),
+				})
+				return toRejectedAdmissionResponse(causes)
+			}
+			klog.Infof("Patching PVC %s with DataVolume %s", pvc.GetName(), dv.GetName())
 		} else {
 			// We are planning to remove the Claim adoption feature gate
 			// https://github.com/kubevirt/containerized-data-importer/issues/3480
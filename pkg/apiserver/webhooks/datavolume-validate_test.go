@@ -33,12 +33,14 @@ import (
 	snapclientfake "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned/fake"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authorization "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -62,6 +64,56 @@ var _ = Describe("Validating Webhook", func() {
 			Expect(resp.Allowed).To(BeTrue())
 		})
 
+		DescribeTable("should validate DataVolume with HTTP differencing disks on create", func(disk string, allowed bool) {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com/disk.vhdx")
+			dataVolume.Spec.Source.HTTP.DifferencingDisks = []string{"http://www.example.com/disk_1.avhdx", disk}
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept valid URL", "http://www.example.com/disk_2.avhdx", true),
+			Entry("reject empty URL", "", false),
+			Entry("reject invalid scheme", "ftp://www.example.com/disk_2.avhdx", false),
+		)
+
+		DescribeTable("should validate DataVolume with S3 source on create", func(source *cdiv1.DataVolumeSourceS3, allowed bool) {
+			dataVolume := newDataVolume("testDV", cdiv1.DataVolumeSource{S3: source}, newPVCSpec(pvcSizeDefault))
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept a region and virtual host style", &cdiv1.DataVolumeSourceS3{URL: "https://s3.example.com/bucket/disk.img", Region: "eu-west-3", AddressingStyle: cdiv1.S3AddressingStyleVirtualHost}, true),
+			Entry("reject an invalid addressing style", &cdiv1.DataVolumeSourceS3{URL: "https://s3.example.com/bucket/disk.img", AddressingStyle: "dns"}, false),
+			Entry("accept a web identity", &cdiv1.DataVolumeSourceS3{URL: "http://minio:9000/bucket/disk.img", WebIdentity: &cdiv1.S3WebIdentity{RoleARN: "arn:minio:iam:::role/importer", STSEndpoint: "http://minio:9000"}}, true),
+			Entry("reject a web identity without role", &cdiv1.DataVolumeSourceS3{URL: "http://minio:9000/bucket/disk.img", WebIdentity: &cdiv1.S3WebIdentity{}}, false),
+			Entry("reject an invalid STS endpoint", &cdiv1.DataVolumeSourceS3{URL: "http://minio:9000/bucket/disk.img", WebIdentity: &cdiv1.S3WebIdentity{RoleARN: "arn:minio:iam:::role/importer", STSEndpoint: "ftp://minio"}}, false),
+		)
+
+		DescribeTable("should validate DataVolume with GCS source on create", func(source *cdiv1.DataVolumeSourceGCS, allowed bool) {
+			dataVolume := newDataVolume("testDV", cdiv1.DataVolumeSource{GCS: source}, newPVCSpec(pvcSizeDefault))
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept an anonymous source", &cdiv1.DataVolumeSourceGCS{URL: "gs://bucket/disk.img"}, true),
+			Entry("accept application default credentials", &cdiv1.DataVolumeSourceGCS{URL: "gs://bucket/disk.img", WorkloadIdentity: &cdiv1.GCSWorkloadIdentity{}}, true),
+			Entry("accept a workload identity provider", &cdiv1.DataVolumeSourceGCS{URL: "gs://bucket/disk.img", WorkloadIdentity: &cdiv1.GCSWorkloadIdentity{
+				Provider:       "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/providers/cluster",
+				ServiceAccount: "importer@project.iam.gserviceaccount.com",
+			}}, true),
+			Entry("reject a workload identity with a secret", &cdiv1.DataVolumeSourceGCS{URL: "gs://bucket/disk.img", SecretRef: "gcs-secret", WorkloadIdentity: &cdiv1.GCSWorkloadIdentity{}}, false),
+			Entry("reject a service account without provider", &cdiv1.DataVolumeSourceGCS{URL: "gs://bucket/disk.img", WorkloadIdentity: &cdiv1.GCSWorkloadIdentity{ServiceAccount: "importer@project.iam.gserviceaccount.com"}}, false),
+			Entry("reject an invalid provider", &cdiv1.DataVolumeSourceGCS{URL: "gs://bucket/disk.img", WorkloadIdentity: &cdiv1.GCSWorkloadIdentity{Provider: "cluster"}}, false),
+		)
+
+		DescribeTable("should validate DataVolume with AzureBlob source on create", func(source *cdiv1.DataVolumeSourceAzureBlob, allowed bool) {
+			dataVolume := newDataVolume("testDV", cdiv1.DataVolumeSource{AzureBlob: source}, newPVCSpec(pvcSizeDefault))
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept a blob", &cdiv1.DataVolumeSourceAzureBlob{Account: "account", Container: "images", Blob: "disk.img"}, true),
+			Entry("accept a blob with endpoint", &cdiv1.DataVolumeSourceAzureBlob{Account: "devstoreaccount1", Container: "images", Blob: "disk.img", Endpoint: "http://azurite:10000/devstoreaccount1"}, true),
+			Entry("reject a missing blob", &cdiv1.DataVolumeSourceAzureBlob{Account: "account", Container: "images"}, false),
+			Entry("reject an invalid endpoint", &cdiv1.DataVolumeSourceAzureBlob{Account: "account", Container: "images", Blob: "disk.img", Endpoint: "ftp://azurite"}, false),
+		)
+
 		It("should accept DataVolume with GS source on create", func() {
 			dataVolume := newGCSDataVolume("testDV", "gs://www.example.com")
 			resp := validateDataVolumeCreate(dataVolume)
@@ -74,6 +126,18 @@ var _ = Describe("Validating Webhook", func() {
 			Expect(resp.Allowed).To(BeFalse())
 		})
 
+		DescribeTable("should validate DataVolume with backup source on create", func(name string, allowed bool) {
+			source := cdiv1.DataVolumeSource{
+				Backup: &cdiv1.DataVolumeSourceBackup{Name: name},
+			}
+			dataVolume := newDataVolume("testDV", source, newPVCSpec(pvcSizeDefault))
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept with backup name", "test-backup", true),
+			Entry("reject without backup name", "", false),
+		)
+
 		It("should reject DataVolume when target pvc exists", func() {
 			dataVolume := newPVCDataVolume("testDV", "testNamespace", "test")
 			pvc := &corev1.PersistentVolumeClaim{
@@ -87,6 +151,123 @@ var _ = Describe("Validating Webhook", func() {
 			Expect(resp.Allowed).To(BeFalse())
 		})
 
+		DescribeTable("should validate DataVolume patching a PVC on create", func(mutate func(*cdiv1.DataVolume), isAuthorized, allowed bool) {
+			dataVolume := newDataVolumeWithStorageSpec("testDV", &cdiv1.DataVolumeSource{
+				HTTP: &cdiv1.DataVolumeSourceHTTP{URL: "http://www.example.com/efi.img"},
+			}, nil, &cdiv1.StorageSpec{})
+			dataVolume.Spec.Patch = &cdiv1.DataVolumePatch{PartitionLabel: "EFI system partition"}
+			mutate(dataVolume)
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      dataVolume.Name,
+					Namespace: dataVolume.Namespace,
+				},
+			}
+			client := fakeclient.NewSimpleClientset(pvc)
+			client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				sar := action.(k8stesting.CreateAction).GetObject().(*authorization.SubjectAccessReview)
+				Expect(sar.Spec.ResourceAttributes.Verb).To(Equal("update"))
+				Expect(sar.Spec.ResourceAttributes.Resource).To(Equal("persistentvolumeclaims"))
+				Expect(sar.Spec.ResourceAttributes.Name).To(Equal(pvc.Name))
+				sar.Status.Allowed = isAuthorized
+				return true, sar, nil
+			})
+			resp := validateDataVolumeCreateWithClient(dataVolume, client, nil, nil, nil)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept a partition label", func(*cdiv1.DataVolume) {}, true, true),
+			Entry("accept an offset", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Patch = &cdiv1.DataVolumePatch{Offset: ptr.To[int64](1024 * 1024)}
+			}, true, true),
+			Entry("reject a user not allowed to update the PVC", func(*cdiv1.DataVolume) {}, false, false),
+			Entry("reject both offset and partition label", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Patch.Offset = ptr.To[int64](0)
+			}, true, false),
+			Entry("reject neither offset nor partition label", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Patch = &cdiv1.DataVolumePatch{}
+			}, true, false),
+			Entry("reject a negative offset", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Patch = &cdiv1.DataVolumePatch{Offset: ptr.To[int64](-1)}
+			}, true, false),
+			Entry("reject a blank source", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Source = &cdiv1.DataVolumeSource{Blank: &cdiv1.DataVolumeBlankImage{}}
+			}, true, false),
+			Entry("reject the archive content type", func(dv *cdiv1.DataVolume) {
+				dv.Spec.ContentType = cdiv1.DataVolumeArchive
+			}, true, false),
+		)
+
+		DescribeTable("should validate DataVolume customizing its disk image on create", func(mutate func(*cdiv1.DataVolume), allowed bool) {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com/disk.qcow2")
+			dataVolume.Spec.Customization = &cdiv1.DataVolumeCustomization{ConfigMap: "recipe"}
+			mutate(dataVolume)
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept an http source", func(*cdiv1.DataVolume) {}, true),
+			Entry("accept a registry source", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Source = &cdiv1.DataVolumeSource{Registry: &cdiv1.DataVolumeSourceRegistry{URL: ptr.To("docker://registry:5000/test")}}
+			}, true),
+			Entry("reject an empty ConfigMap name", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Customization.ConfigMap = ""
+			}, false),
+			Entry("reject a blank source", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Source = &cdiv1.DataVolumeSource{Blank: &cdiv1.DataVolumeBlankImage{}}
+			}, false),
+			Entry("reject the archive content type", func(dv *cdiv1.DataVolume) {
+				dv.Spec.ContentType = cdiv1.DataVolumeArchive
+			}, false),
+			Entry("reject a multi-stage import", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Checkpoints = []cdiv1.DataVolumeCheckpoint{{Previous: "", Current: "snapshot-1"}}
+			}, false),
+		)
+
+		DescribeTable("should validate DataVolume expanding its partition on create", func(mutate func(*cdiv1.DataVolume), allowed bool) {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com/disk.qcow2")
+			dataVolume.Spec.ExpandPartition = ptr.To(true)
+			mutate(dataVolume)
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept an http source", func(*cdiv1.DataVolume) {}, true),
+			Entry("accept a customized disk image", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Customization = &cdiv1.DataVolumeCustomization{ConfigMap: "recipe"}
+			}, true),
+			Entry("accept a blank source when not expanding", func(dv *cdiv1.DataVolume) {
+				dv.Spec.ExpandPartition = ptr.To(false)
+				dv.Spec.Source = &cdiv1.DataVolumeSource{Blank: &cdiv1.DataVolumeBlankImage{}}
+			}, true),
+			Entry("reject a blank source", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Source = &cdiv1.DataVolumeSource{Blank: &cdiv1.DataVolumeBlankImage{}}
+			}, false),
+			Entry("reject the archive content type", func(dv *cdiv1.DataVolume) {
+				dv.Spec.ContentType = cdiv1.DataVolumeArchive
+			}, false),
+		)
+
+		DescribeTable("should validate DataVolume sparsifying its disk image on create", func(mutate func(*cdiv1.DataVolume), allowed bool) {
+			dataVolume := newHTTPDataVolume("testDV", "http://www.example.com/disk.qcow2")
+			dataVolume.Spec.Sparsify = ptr.To(true)
+			mutate(dataVolume)
+			resp := validateDataVolumeCreate(dataVolume)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept an http source", func(*cdiv1.DataVolume) {}, true),
+			Entry("accept disabled preallocation", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Preallocation = ptr.To(false)
+			}, true),
+			Entry("accept preallocation when not sparsifying", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Sparsify = ptr.To(false)
+				dv.Spec.Preallocation = ptr.To(true)
+			}, true),
+			Entry("reject preallocation", func(dv *cdiv1.DataVolume) {
+				dv.Spec.Preallocation = ptr.To(true)
+			}, false),
+			Entry("reject the archive content type", func(dv *cdiv1.DataVolume) {
+				dv.Spec.ContentType = cdiv1.DataVolumeArchive
+			}, false),
+		)
+
 		It("should accept DataVolume with Registry source URL on create", func() {
 			dataVolume := newRegistryDataVolume("testDV", "docker://registry:5000/test")
 			resp := validateDataVolumeCreate(dataVolume)
@@ -1165,7 +1346,10 @@ func validateDataVolumeCreate(dv *cdiv1.DataVolume, objects ...runtime.Object) *
 }
 
 func validateDataVolumeCreateEx(dv *cdiv1.DataVolume, k8sObjects, cdiObjects, snapObjects []runtime.Object, featureGates []string) *admissionv1.AdmissionResponse {
-	client := fakeclient.NewSimpleClientset(k8sObjects...)
+	return validateDataVolumeCreateWithClient(dv, fakeclient.NewSimpleClientset(k8sObjects...), cdiObjects, snapObjects, featureGates)
+}
+
+func validateDataVolumeCreateWithClient(dv *cdiv1.DataVolume, client *fakeclient.Clientset, cdiObjects, snapObjects []runtime.Object, featureGates []string) *admissionv1.AdmissionResponse {
 	cdiClient := cdiclientfake.NewSimpleClientset(cdiObjects...)
 	snapClient := snapclientfake.NewSimpleClientset(snapObjects...)
 	s := runtime.NewScheme()
@@ -186,6 +186,9 @@ func (wh *populatorValidatingWebhook) validateVolumeImportSourceSpec(field *k8sf
 	if gcs := spec.Source.GCS; gcs != nil {
 		return validateGCSSource(gcs, field)
 	}
+	if blob := spec.Source.AzureBlob; blob != nil {
+		return validateAzureBlobSource(blob, field)
+	}
 	if blank := spec.Source.Blank; blank != nil {
 		return validateBlankSource(spec.ContentType, field)
 	}
@@ -198,6 +201,9 @@ func (wh *populatorValidatingWebhook) validateVolumeImportSourceSpec(field *k8sf
 	if vddk := spec.Source.VDDK; vddk != nil {
 		return validateVDDKSource(vddk, field)
 	}
+	if backup := spec.Source.CDIBackup; backup != nil {
+		return validateCDIBackupSource(backup, field)
+	}
 	// Should never reach this return
 	return nil
 }
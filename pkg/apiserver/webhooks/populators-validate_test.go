@@ -181,6 +181,22 @@ var _ = Describe("Validating Webhook", func() {
 			Expect(resp.Allowed).To(BeFalse())
 		})
 
+		DescribeTable("should validate VolumeImportSource with CDI backup source on create", func(url string, allowed bool) {
+			source := &cdiv1.ImportSourceType{
+				CDIBackup: &cdiv1.DataVolumeSourceCDIBackup{
+					URL: url,
+				},
+			}
+			importCR := newVolumeImportSource(cdiv1.DataVolumeKubeVirt, source)
+			resp := validateVolumeImportSourceCreate(importCR)
+			Expect(resp.Allowed).To(Equal(allowed))
+		},
+			Entry("accept an http manifest url", "http://s3.example.com/bucket/manifest.json", true),
+			Entry("accept an https manifest url", "https://s3.example.com/bucket/manifest.json", true),
+			Entry("reject an empty url", "", false),
+			Entry("reject a gs url", "gs://bucket/manifest.json", false),
+		)
+
 		It("should reject VolumeImportSource with incomplete VDDK source", func() {
 			source := &cdiv1.ImportSourceType{
 				VDDK: &cdiv1.DataVolumeSourceVDDK{
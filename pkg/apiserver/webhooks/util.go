@@ -23,6 +23,7 @@ import (
 	"fmt"
 	neturl "net/url"
 	"reflect"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	field "k8s.io/apimachinery/pkg/util/validation/field"
@@ -163,15 +164,125 @@ func validateDataVolumeSourceRegistry(sourceRegistry *cdiv1.DataVolumeSourceRegi
 // if source types are HTTP, Imageio, S3, GCS or VDDK, check if URL is valid
 
 func validateHTTPSource(http *cdiv1.DataVolumeSourceHTTP, field *field.Path) []metav1.StatusCause {
-	return checkSourceURL(http.URL, "HTTP", field)
+	if causes := checkSourceURL(http.URL, "HTTP", field); causes != nil {
+		return causes
+	}
+	for i, disk := range http.DifferencingDisks {
+		if errString := validateSourceURL(disk); errString != "" {
+			return []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s differencing disk %s", field.Child("source").String(), errString),
+				Field:   field.Child("source", "HTTP", "differencingDisks").Index(i).String(),
+			}}
+		}
+	}
+	return nil
 }
 
 func validateS3Source(s3 *cdiv1.DataVolumeSourceS3, field *field.Path) []metav1.StatusCause {
-	return checkSourceURL(s3.URL, "S3", field)
+	if causes := checkSourceURL(s3.URL, "S3", field); causes != nil {
+		return causes
+	}
+	switch s3.AddressingStyle {
+	case "", cdiv1.S3AddressingStylePath, cdiv1.S3AddressingStyleVirtualHost:
+	default:
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s Invalid S3 addressing style: %s", field.Child("source").String(), s3.AddressingStyle),
+			Field:   field.Child("source", "S3", "addressingStyle").String(),
+		}}
+	}
+	identity := s3.WebIdentity
+	if identity == nil {
+		return nil
+	}
+	if identity.RoleARN == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s S3 web identity requires roleARN", field.Child("source").String()),
+			Field:   field.Child("source", "S3", "webIdentity", "roleARN").String(),
+		}}
+	}
+	if identity.STSEndpoint != "" {
+		if errString := validateSourceURL(identity.STSEndpoint); errString != "" || strings.HasPrefix(identity.STSEndpoint, "gs:") {
+			return []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s Invalid S3 web identity STS endpoint: %s", field.Child("source").String(), identity.STSEndpoint),
+				Field:   field.Child("source", "S3", "webIdentity", "stsEndpoint").String(),
+			}}
+		}
+	}
+	return nil
+}
+
+func validateCDIBackupSource(backup *cdiv1.DataVolumeSourceCDIBackup, field *field.Path) []metav1.StatusCause {
+	if causes := checkSourceURL(backup.URL, "CDIBackup", field); causes != nil {
+		return causes
+	}
+	// The manifest and its chunks are read with an S3 client
+	if strings.HasPrefix(backup.URL, "gs:") {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s Invalid source URL scheme: %s", field.Child("source").String(), backup.URL),
+			Field:   field.Child("source", "CDIBackup", "url").String(),
+		}}
+	}
+	return nil
 }
 
 func validateGCSSource(gcs *cdiv1.DataVolumeSourceGCS, field *field.Path) []metav1.StatusCause {
-	return checkSourceURL(gcs.URL, "GCS", field)
+	if causes := checkSourceURL(gcs.URL, "GCS", field); causes != nil {
+		return causes
+	}
+	identity := gcs.WorkloadIdentity
+	if identity == nil {
+		return nil
+	}
+	if gcs.SecretRef != "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s GCS workloadIdentity and secretRef are mutually exclusive", field.Child("source").String()),
+			Field:   field.Child("source", "GCS", "workloadIdentity").String(),
+		}}
+	}
+	if identity.Provider == "" && (identity.ServiceAccount != "" || identity.Audience != "") {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s GCS workload identity serviceAccount and audience require provider", field.Child("source").String()),
+			Field:   field.Child("source", "GCS", "workloadIdentity", "provider").String(),
+		}}
+	}
+	// The full resource name of the provider, //iam.googleapis.com/projects/...
+	if identity.Provider != "" && !strings.HasPrefix(identity.Provider, "//") {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s Invalid GCS workload identity provider: %s", field.Child("source").String(), identity.Provider),
+			Field:   field.Child("source", "GCS", "workloadIdentity", "provider").String(),
+		}}
+	}
+	return nil
+}
+
+func validateAzureBlobSource(blob *cdiv1.DataVolumeSourceAzureBlob, field *field.Path) []metav1.StatusCause {
+	// Account, Container and Blob are required
+	if blob.Account == "" || blob.Container == "" || blob.Blob == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s source AzureBlob requires account, container and blob", field.Child("source", "AzureBlob").String()),
+			Field:   field.Child("source", "AzureBlob").String(),
+		}}
+	}
+	if blob.Endpoint == "" {
+		return nil
+	}
+	if errString := validateSourceURL(blob.Endpoint); errString != "" || strings.HasPrefix(blob.Endpoint, "gs:") {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s Invalid AzureBlob endpoint: %s", field.Child("source").String(), blob.Endpoint),
+			Field:   field.Child("source", "AzureBlob", "endpoint").String(),
+		}}
+	}
+	return nil
 }
 
 func validateImageIOSource(imageio *cdiv1.DataVolumeSourceImageIO, field *field.Path) []metav1.StatusCause {
@@ -32,6 +32,7 @@ type CdiV1beta1Interface interface {
 	CDIConfigsGetter
 	DataImportCronsGetter
 	DataSourcesGetter
+	DataVolumeBackupsGetter
 	DataVolumesGetter
 	ObjectTransfersGetter
 	StorageProfilesGetter
@@ -61,6 +62,10 @@ func (c *CdiV1beta1Client) DataSources(namespace string) DataSourceInterface {
 	return newDataSources(c, namespace)
 }
 
+func (c *CdiV1beta1Client) DataVolumeBackups(namespace string) DataVolumeBackupInterface {
+	return newDataVolumeBackups(c, namespace)
+}
+
 func (c *CdiV1beta1Client) DataVolumes(namespace string) DataVolumeInterface {
 	return newDataVolumes(c, namespace)
 }
@@ -44,6 +44,10 @@ func (c *FakeCdiV1beta1) DataSources(namespace string) v1beta1.DataSourceInterfa
 	return &FakeDataSources{c, namespace}
 }
 
+func (c *FakeCdiV1beta1) DataVolumeBackups(namespace string) v1beta1.DataVolumeBackupInterface {
+	return &FakeDataVolumeBackups{c, namespace}
+}
+
 func (c *FakeCdiV1beta1) DataVolumes(namespace string) v1beta1.DataVolumeInterface {
 	return &FakeDataVolumes{c, namespace}
 }
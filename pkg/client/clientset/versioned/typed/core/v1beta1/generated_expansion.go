@@ -26,6 +26,8 @@ type DataImportCronExpansion interface{}
 
 type DataSourceExpansion interface{}
 
+type DataVolumeBackupExpansion interface{}
+
 type DataVolumeExpansion interface{}
 
 type ObjectTransferExpansion interface{}
@@ -32,6 +32,8 @@ type Interface interface {
 	DataImportCrons() DataImportCronInformer
 	// DataSources returns a DataSourceInformer.
 	DataSources() DataSourceInformer
+	// DataVolumeBackups returns a DataVolumeBackupInformer.
+	DataVolumeBackups() DataVolumeBackupInformer
 	// DataVolumes returns a DataVolumeInformer.
 	DataVolumes() DataVolumeInformer
 	// ObjectTransfers returns a ObjectTransferInformer.
@@ -77,6 +79,11 @@ func (v *version) DataSources() DataSourceInformer {
 	return &dataSourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DataVolumeBackups returns a DataVolumeBackupInformer.
+func (v *version) DataVolumeBackups() DataVolumeBackupInformer {
+	return &dataVolumeBackupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DataVolumes returns a DataVolumeInformer.
 func (v *version) DataVolumes() DataVolumeInformer {
 	return &dataVolumeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
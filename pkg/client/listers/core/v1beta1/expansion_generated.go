@@ -42,6 +42,14 @@ type DataSourceListerExpansion interface{}
 // DataSourceNamespaceLister.
 type DataSourceNamespaceListerExpansion interface{}
 
+// DataVolumeBackupListerExpansion allows custom methods to be added to
+// DataVolumeBackupLister.
+type DataVolumeBackupListerExpansion interface{}
+
+// DataVolumeBackupNamespaceListerExpansion allows custom methods to be added to
+// DataVolumeBackupNamespaceLister.
+type DataVolumeBackupNamespaceListerExpansion interface{}
+
 // DataVolumeListerExpansion allows custom methods to be added to
 // DataVolumeLister.
 type DataVolumeListerExpansion interface{}
@@ -100,6 +100,8 @@ const (
 	ImportProxyConfigMapKey = "ca.crt"
 	// ImporterProxyCertDir is where the configmap containing proxy certs will be mounted
 	ImporterProxyCertDir = "/proxycerts/"
+	// ImporterChunkCacheDir is where the import chunk cache will be mounted
+	ImporterChunkCacheDir = "/chunk-cache"
 
 	// PullPolicy provides a constant to capture our env variable "PULL_POLICY" (only used by cmd/cdi-controller/controller.go)
 	PullPolicy = "PULL_POLICY"
@@ -143,12 +145,28 @@ const (
 	ImporterPreviousCheckpoint = "IMPORTER_PREVIOUS_CHECKPOINT"
 	// ImporterFinalCheckpoint provides a constant to capture our env variable "IMPORTER_FINAL_CHECKPOINT"
 	ImporterFinalCheckpoint = "IMPORTER_FINAL_CHECKPOINT"
+	// ImporterChunkCacheDirVar provides a constant to capture our env variable "IMPORTER_CHUNK_CACHE_DIR", set when the import chunk cache is enabled
+	ImporterChunkCacheDirVar = "IMPORTER_CHUNK_CACHE_DIR"
+	// ImporterChunkCacheLimit provides a constant to capture our env variable "IMPORTER_CHUNK_CACHE_LIMIT", the size in bytes the chunk cache is pruned to
+	ImporterChunkCacheLimit = "IMPORTER_CHUNK_CACHE_LIMIT"
+	// ImporterChunkCacheScope provides a constant to capture our env variable "IMPORTER_CHUNK_CACHE_SCOPE", the namespace the indexes of unverified sources are restricted to in a cache shared by several namespaces
+	ImporterChunkCacheScope = "IMPORTER_CHUNK_CACHE_SCOPE"
+	// ImporterBackup provides a constant to capture our env variable "IMPORTER_BACKUP", set when the importer writes the volume to IMPORTER_ENDPOINT instead of reading it
+	ImporterBackup = "IMPORTER_BACKUP"
 	// CacheMode provides a constant to capture our env variable "CACHE_MODE"
 	CacheMode = "CACHE_MODE"
 	// CacheModeTryNone provides a constant to capture our env variable value for "CACHE_MODE" that tries O_DIRECT writing if target supports it
 	CacheModeTryNone = "TRYNONE"
+	// WriteBlockSize provides a constant to capture our env variable "WRITE_BLOCK_SIZE", the qemu-img write granularity in bytes
+	WriteBlockSize = "WRITE_BLOCK_SIZE"
+	// ZeroDetection provides a constant to capture our env variable "ZERO_DETECTION"
+	ZeroDetection = "ZERO_DETECTION"
 	// Preallocation provides a constant to capture out env variable "PREALLOCATION"
 	Preallocation = "PREALLOCATION"
+	// ExpandPartition provides a constant to capture our env variable "EXPAND_PARTITION", set to grow the last partition of the disk and its filesystem along with the disk image
+	ExpandPartition = "EXPAND_PARTITION"
+	// Sparsify provides a constant to capture our env variable "SPARSIFY", set to deallocate the blocks of zeroes of the disk image once it is written
+	Sparsify = "SPARSIFY"
 	// ImportProxyHTTP provides a constant to capture our env variable "http_proxy"
 	ImportProxyHTTP = "http_proxy"
 	// ImportProxyHTTPS provides a constant to capture our env variable "https_proxy"
@@ -167,6 +185,41 @@ const (
 	ImporterSecretExtraHeadersDir = "/extraheaders"
 	// ImporterRegistryImageArchitecture provides a constant to capture our env variable "IMPORTER_REGISTRY_IMAGE_ARCHITECTURE"
 	ImporterRegistryImageArchitecture = "IMPORTER_REGISTRY_IMAGE_ARCHITECTURE"
+	// ImporterDifferencingDisks provides a constant to capture our env variable "IMPORTER_DIFFERENCING_DISKS", a JSON list of the VHDX differencing disk URLs applied on top of IMPORTER_ENDPOINT
+	ImporterDifferencingDisks = "IMPORTER_DIFFERENCING_DISKS"
+	// ImporterPatchOffset provides a constant to capture our env variable "IMPORTER_PATCH_OFFSET", the byte offset the source is written at into the existing disk
+	ImporterPatchOffset = "IMPORTER_PATCH_OFFSET"
+	// ImporterPatchPartitionLabel provides a constant to capture our env variable "IMPORTER_PATCH_PARTITION_LABEL", the GPT partition of the existing disk the source is written into
+	ImporterPatchPartitionLabel = "IMPORTER_PATCH_PARTITION_LABEL"
+	// ImporterExpandOnly provides a constant to capture our env variable "IMPORTER_EXPAND_ONLY", set when the importer only grows the existing disk of the volume to its size
+	ImporterExpandOnly = "IMPORTER_EXPAND_ONLY"
+	// ImporterCDIBackupManifest provides a constant to capture our env variable "IMPORTER_CDI_BACKUP_MANIFEST", set when the S3 or GCS endpoint is the manifest of a chunked CDI backup
+	ImporterCDIBackupManifest = "IMPORTER_CDI_BACKUP_MANIFEST"
+	// ImporterSparsifyOnly provides a constant to capture our env variable "IMPORTER_SPARSIFY_ONLY", set when the importer only sparsifies the existing disk of the volume
+	ImporterSparsifyOnly = "IMPORTER_SPARSIFY_ONLY"
+
+	// ImporterAzureBlobAccount provides a constant to capture our env variable "IMPORTER_AZURE_BLOB_ACCOUNT"
+	ImporterAzureBlobAccount = "IMPORTER_AZURE_BLOB_ACCOUNT"
+	// ImporterAzureBlobCredentialDirVar provides a constant to capture our env variable "IMPORTER_AZURE_BLOB_CREDENTIAL_DIR"
+	ImporterAzureBlobCredentialDirVar = "IMPORTER_AZURE_BLOB_CREDENTIAL_DIR"
+	// ImporterAzureBlobCredentialDir provides a constant to capture our Azure Blob secret mount Dir
+	ImporterAzureBlobCredentialDir = "/azure"
+
+	// ImporterS3Region provides a constant to capture our env variable "IMPORTER_S3_REGION"
+	ImporterS3Region = "IMPORTER_S3_REGION"
+	// ImporterS3AddressingStyle provides a constant to capture our env variable "IMPORTER_S3_ADDRESSING_STYLE"
+	ImporterS3AddressingStyle = "IMPORTER_S3_ADDRESSING_STYLE"
+	// ImporterS3RoleARN provides a constant to capture our env variable "IMPORTER_S3_ROLE_ARN"
+	ImporterS3RoleARN = "IMPORTER_S3_ROLE_ARN"
+	// ImporterS3STSEndpoint provides a constant to capture our env variable "IMPORTER_S3_STS_ENDPOINT"
+	ImporterS3STSEndpoint = "IMPORTER_S3_STS_ENDPOINT"
+	// ImporterS3WebIdentityTokenFileVar provides a constant to capture our env variable "IMPORTER_S3_WEB_IDENTITY_TOKEN_FILE"
+	ImporterS3WebIdentityTokenFileVar = "IMPORTER_S3_WEB_IDENTITY_TOKEN_FILE"
+	// ImporterS3WebIdentityTokenDir provides a constant to capture our projected service account token mount Dir
+	ImporterS3WebIdentityTokenDir = "/var/run/secrets/cdi.kubevirt.io/s3"
+	// ImporterS3WebIdentityTokenFile provides a constant to capture our projected service account token file
+	//nolint:gosec // This is not the credential itself
+	ImporterS3WebIdentityTokenFile = "/var/run/secrets/cdi.kubevirt.io/s3/token"
 
 	// ImporterGoogleCredentialFileVar provides a constant to capture our env variable "GOOGLE_APPLICATION_CREDENTIALS"
 	//nolint:gosec // This is not a real credential
@@ -176,6 +229,19 @@ const (
 	// ImporterGoogleCredentialFile provides a constant to capture our credentials.json file
 	//nolint:gosec // This is not the credential itself
 	ImporterGoogleCredentialFile = "/google/credentials.json"
+	// ImporterGCSWorkloadIdentity provides a constant to capture our env variable "IMPORTER_GCS_WORKLOAD_IDENTITY"
+	ImporterGCSWorkloadIdentity = "IMPORTER_GCS_WORKLOAD_IDENTITY"
+	// ImporterGCSWorkloadIdentityProvider provides a constant to capture our env variable "IMPORTER_GCS_WORKLOAD_IDENTITY_PROVIDER"
+	ImporterGCSWorkloadIdentityProvider = "IMPORTER_GCS_WORKLOAD_IDENTITY_PROVIDER"
+	// ImporterGCSServiceAccount provides a constant to capture our env variable "IMPORTER_GCS_SERVICE_ACCOUNT"
+	ImporterGCSServiceAccount = "IMPORTER_GCS_SERVICE_ACCOUNT"
+	// ImporterGCSWorkloadIdentityTokenFileVar provides a constant to capture our env variable "IMPORTER_GCS_WORKLOAD_IDENTITY_TOKEN_FILE"
+	ImporterGCSWorkloadIdentityTokenFileVar = "IMPORTER_GCS_WORKLOAD_IDENTITY_TOKEN_FILE"
+	// ImporterGCSWorkloadIdentityTokenDir provides a constant to capture our projected service account token mount Dir
+	ImporterGCSWorkloadIdentityTokenDir = "/var/run/secrets/cdi.kubevirt.io/gcs"
+	// ImporterGCSWorkloadIdentityTokenFile provides a constant to capture our projected service account token file
+	//nolint:gosec // This is not the credential itself
+	ImporterGCSWorkloadIdentityTokenFile = "/var/run/secrets/cdi.kubevirt.io/gcs/token"
 
 	// CloningLabelValue provides a constant to use as a label value for pod affinity (controller pkg only)
 	CloningLabelValue = "host-assisted-cloning"
@@ -223,6 +289,9 @@ const (
 	// OwnerUID provides the UID of the owner entity (either PVC or DV)
 	OwnerUID = "OWNER_UID"
 
+	// ClonerTargets provides a constant to capture the env variable listing the targets of a batch clone source pod
+	ClonerTargets = "CLONER_TARGETS"
+
 	// KeyAccess provides a constant to the accessKeyId label using in controller pkg and transport_test.go
 	KeyAccess = "accessKeyId"
 	// KeySecret provides a constant to the secretKey label using in controller pkg and transport_test.go
@@ -264,6 +333,12 @@ const (
 	VddkArgsVolName = "vddk-extra-args"
 	// VddkArgsKeyName is the name of the key that must be present in the VDDK arguments ConfigMap
 	VddkArgsKeyName = "vddk-config-file"
+	// CustomizeRecipeDir is the path to the volume mount containing the customization recipe
+	CustomizeRecipeDir = "/customize"
+	// CustomizeRecipeVolName is the name of the volume referencing the customization recipe ConfigMap
+	CustomizeRecipeVolName = "customize-recipe"
+	// CustomizeRecipeKeyName is the name of the key that must be present in the customization recipe ConfigMap
+	CustomizeRecipeKeyName = "recipe"
 
 	// UploadContentTypeHeader is the header upload clients may use to set the content type explicitly
 	UploadContentTypeHeader = "x-cdi-content-type"
@@ -371,6 +446,66 @@ type VddkInfo struct {
 	Host    string
 }
 
+// ClonerTarget is a target of a batch clone source pod
+type ClonerTarget struct {
+	// Name is the namespace/name of the target PVC
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	OwnerUID string `json:"ownerUID"`
+}
+
+// FailureReason is the machine-readable cause of a failed transfer, reported by the worker pods in their termination
+// message and set as the reason of the DataVolume conditions
+type FailureReason string
+
+const (
+	// FailureAuthFailed indicates the source rejected the credentials, or that they are missing
+	FailureAuthFailed FailureReason = "AuthFailed"
+	// FailureNotFound indicates the source image, object or disk does not exist
+	FailureNotFound FailureReason = "NotFound"
+	// FailureTLSError indicates the certificate of the source could not be verified
+	FailureTLSError FailureReason = "TLSError"
+	// FailureConnectionFailed indicates the source endpoint could not be reached
+	FailureConnectionFailed FailureReason = "ConnectionFailed"
+	// FailureInsufficientSpace indicates the volume is too small to contain the image
+	FailureInsufficientSpace FailureReason = "InsufficientSpace"
+	// FailureQuotaExceeded indicates a storage quota prevented the transfer
+	FailureQuotaExceeded FailureReason = "QuotaExceeded"
+	// FailureUnsupportedFormat indicates the image is not in a format CDI can convert
+	FailureUnsupportedFormat FailureReason = "UnsupportedFormat"
+	// FailureChecksumMismatch indicates the content read from the source does not match its expected checksum
+	FailureChecksumMismatch FailureReason = "ChecksumMismatch"
+	// FailureScratchUnavailable indicates the scratch space required by the transfer could not be provisioned
+	FailureScratchUnavailable FailureReason = "ScratchUnavailable"
+	// FailureImagePullFailed indicates the registry image could not be pulled
+	FailureImagePullFailed FailureReason = "ImagePullFailed"
+)
+
+// failureRemediationHints are the actions that usually fix a failure, appended to the message of the conditions
+var failureRemediationHints = map[FailureReason]string{
+	FailureAuthFailed:         "Check the credentials in the secret referenced by the DataVolume source",
+	FailureNotFound:           "Check that the URL, image or object of the DataVolume source exists",
+	FailureTLSError:           "Add the CA of the source to the certConfigMap of the DataVolume source, or fix the certificate of the source",
+	FailureConnectionFailed:   "Check that the source endpoint is reachable from the cluster, including DNS, network policies and proxy settings",
+	FailureInsufficientSpace:  "Increase the requested storage of the DataVolume",
+	FailureQuotaExceeded:      "Increase the storage quota of the namespace, or request less storage",
+	FailureUnsupportedFormat:  "Provide the image in one of the raw, qcow2, vmdk, vdi, vpc or vhdx formats",
+	FailureChecksumMismatch:   "The source content is corrupted or was modified during the transfer, verify it and retry",
+	FailureScratchUnavailable: "Check that the scratch space storage class of the CDIConfig exists and can provision volumes",
+	FailureImagePullFailed:    "Check the name and tag of the registry image, and the pull credentials",
+}
+
+// IsFailureReason returns true if the reason is in the catalog of the failure reasons
+func IsFailureReason(reason string) bool {
+	_, ok := failureRemediationHints[FailureReason(reason)]
+	return ok
+}
+
+// RemediationHint returns the action that usually fixes the failure, empty for an unknown reason
+func (r FailureReason) RemediationHint() string {
+	return failureRemediationHints[r]
+}
+
 // TerminationMessage contains data to be serialized and used as the termination message of the importer.
 type TerminationMessage struct {
 	ScratchSpaceRequired *bool             `json:"scratchSpaceRequired,omitempty"`
@@ -379,6 +514,14 @@ type TerminationMessage struct {
 	VddkInfo             *VddkInfo         `json:"vddkInfo,omitempty"`
 	Labels               map[string]string `json:"labels,omitempty"`
 	Message              *string           `json:"message,omitempty"`
+	// AvailableSpace is the free space of the Filesystem volume before the import, used to calibrate the filesystem overhead
+	AvailableSpace *int64 `json:"availableSpace,omitempty"`
+	// ReclaimedBytes is the size of the blocks of zeroes deallocated by the sparsify of the disk image
+	ReclaimedBytes *int64 `json:"reclaimedBytes,omitempty"`
+	// FailureReason is the machine-readable cause of a failed transfer, Message holds the details
+	FailureReason *FailureReason `json:"failureReason,omitempty"`
+	// FailedTargets are the namespace/name of the targets a clone batch source pod failed to clone
+	FailedTargets []string `json:"failedTargets,omitempty"`
 }
 
 func (it *TerminationMessage) String() (string, error) {
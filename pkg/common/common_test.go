@@ -42,3 +42,28 @@ var _ = Describe("TerminationMessage", func() {
 		Expect(err).To(MatchError(fmt.Sprintf("Termination message length %d exceeds maximum length of 4096 bytes", length)))
 	})
 })
+
+var _ = Describe("FailureReason", func() {
+	It("Should serialize the failure reason of a TerminationMessage", func() {
+		termMsg := TerminationMessage{
+			Message:       ptr.To("Unable to process data"),
+			FailureReason: ptr.To(FailureChecksumMismatch),
+		}
+
+		serialized, err := termMsg.String()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(serialized).To(Equal(`{"message":"Unable to process data","failureReason":"ChecksumMismatch"}`))
+	})
+
+	It("Should have a remediation hint for every reason of the catalog", func() {
+		for reason := range failureRemediationHints {
+			Expect(IsFailureReason(string(reason))).To(BeTrue())
+			Expect(reason.RemediationHint()).ToNot(BeEmpty())
+		}
+	})
+
+	It("Should not know reasons outside of the catalog", func() {
+		Expect(IsFailureReason(GenericError)).To(BeFalse())
+		Expect(FailureReason(GenericError).RemediationHint()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,296 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const (
+	// cloneBatchField indexes the PVCs by the clone batch they belong to
+	cloneBatchField = "metadata.annotations.cloneBatch"
+	// cloneSourcePodField indexes the PVCs by the source pod cloning them
+	cloneSourcePodField = "metadata.annotations.cloneSourcePod"
+)
+
+// addCloneBatchIndexes indexes the PVCs by batch and source pod, so the members of a batch are found without
+// listing all the PVCs
+func addCloneBatchIndexes(mgr manager.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &corev1.PersistentVolumeClaim{}, cloneBatchField, indexByCloneBatch); err != nil {
+		return err
+	}
+	return mgr.GetFieldIndexer().IndexField(context.TODO(), &corev1.PersistentVolumeClaim{}, cloneSourcePodField, indexByCloneSourcePod)
+}
+
+func indexByCloneBatch(obj client.Object) []string {
+	if batch := obj.GetAnnotations()[cc.AnnCloneBatch]; batch != "" {
+		return []string{batch}
+	}
+	return nil
+}
+
+func indexByCloneSourcePod(obj client.Object) []string {
+	if pod := obj.GetAnnotations()[cc.AnnCloneSourcePod]; pod != "" {
+		return []string{pod}
+	}
+	return nil
+}
+
+// cloneBatchSourcePodName returns the name of the source pod shared by the members of a clone batch
+func cloneBatchSourcePodName(sourcePvc *corev1.PersistentVolumeClaim, batch string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(batch))
+	return fmt.Sprintf("%s-%08x%s", sourcePvc.UID, h.Sum32(), common.ClonerSourcePodNameSuffix)
+}
+
+// joinCloneBatch assigns the ready members of a clone batch to a shared source pod. The members wait for each
+// other up to cloneBatchSettleTime, the ones left out, or coming once the batch started, get their own source pod.
+func (r *CloneReconciler) joinCloneBatch(ctx context.Context, pvc *corev1.PersistentVolumeClaim, batch string, log logr.Logger) (time.Duration, bool, error) {
+	sourcePvc, err := r.getCloneRequestSourcePVC(pvc)
+	if err != nil {
+		return 0, false, err
+	}
+
+	podName := cloneBatchSourcePodName(sourcePvc, batch)
+	pod := &corev1.Pod{}
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: sourcePvc.Namespace, Name: podName}, pod)
+	if err == nil {
+		log.V(3).Info("Clone batch already started", "pod", podName)
+		return 0, false, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return 0, false, err
+	}
+
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.client.List(ctx, pvcList, client.InNamespace(pvc.Namespace), client.MatchingFields{cloneBatchField: batch}); err != nil {
+		return 0, false, errors.Wrap(err, "error listing PVCs")
+	}
+
+	var members []*corev1.PersistentVolumeClaim
+	oldest := pvc.CreationTimestamp.Time
+	pending := false
+	for i := range pvcList.Items {
+		member := &pvcList.Items[i]
+		if !isCloneBatchCandidate(member, pvc) {
+			continue
+		}
+		if member.CreationTimestamp.Time.Before(oldest) {
+			oldest = member.CreationTimestamp.Time
+		}
+		ready, err := r.waitTargetPodRunningOrSucceeded(member, log)
+		if err != nil || !ready || !r.shouldReconcile(member, log) {
+			pending = true
+			continue
+		}
+		if err := r.validateSourceAndTarget(ctx, sourcePvc, member); err != nil {
+			log.V(1).Info("Not adding PVC to clone batch", "PVC", member.Namespace+"/"+member.Name, "error", err.Error())
+			continue
+		}
+		members = append(members, member)
+	}
+
+	if pending && time.Since(oldest) < cloneBatchSettleTime {
+		log.V(3).Info("Waiting for the members of the clone batch", "batch", batch)
+		return 2 * time.Second, false, nil
+	}
+
+	if len(members) < 2 {
+		return 0, false, nil
+	}
+
+	for _, member := range members {
+		member.Annotations[cc.AnnCloneSourcePod] = podName
+		// add finalizer before creating clone source pod
+		cc.AddFinalizer(member, cloneSourcePodFinalizer)
+		if err := r.updatePVC(member); err != nil {
+			return 0, false, err
+		}
+	}
+
+	log.V(1).Info("Cloning batch with shared source pod", "batch", batch, "pod", podName, "members", len(members))
+
+	// will reconcile again after PVC update notification
+	return 0, true, nil
+}
+
+// isCloneBatchCandidate returns true if the PVC is an unassigned member of the clone batch of pvc
+func isCloneBatchCandidate(member, pvc *corev1.PersistentVolumeClaim) bool {
+	if member.DeletionTimestamp != nil {
+		return false
+	}
+	if _, ok := member.Annotations[cc.AnnCloneSourcePod]; ok {
+		return false
+	}
+	for _, ann := range []string{cc.AnnCloneBatch, cc.AnnCloneRequest, cc.AnnPreallocationRequested} {
+		if member.Annotations[ann] != pvc.Annotations[ann] {
+			return false
+		}
+	}
+	return true
+}
+
+// setCloneBatchTargets makes the source pod clone to all PVCs assigned to it
+func (r *CloneReconciler) setCloneBatchTargets(pod *corev1.Pod, pvc *corev1.PersistentVolumeClaim) error {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.client.List(context.TODO(), pvcList, client.InNamespace(pvc.Namespace), client.MatchingFields{cloneSourcePodField: pod.Name}); err != nil {
+		return errors.Wrap(err, "error listing PVCs")
+	}
+
+	members := []*corev1.PersistentVolumeClaim{pvc}
+	for i := range pvcList.Items {
+		member := &pvcList.Items[i]
+		if member.UID == pvc.UID ||
+			member.DeletionTimestamp != nil ||
+			member.Annotations[cc.AnnCloneSourcePod] != pod.Name ||
+			member.Annotations[cc.AnnCloneRequest] != pvc.Annotations[cc.AnnCloneRequest] ||
+			podSucceededFromPVC(member) {
+			continue
+		}
+		members = append(members, member)
+	}
+
+	var targets []common.ClonerTarget
+	for _, member := range members {
+		targets = append(targets, common.ClonerTarget{
+			Name:     member.Namespace + "/" + member.Name,
+			URL:      GetUploadServerURL(member.Namespace, member.Name, common.UploadPathSync),
+			OwnerUID: getCloneOwnerUID(member),
+		})
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+
+	value, err := json.Marshal(targets)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, target := range targets {
+		names = append(names, target.Name)
+	}
+
+	pod.Annotations[cc.AnnCloneBatchTargets] = strings.Join(names, ",")
+	pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:  common.ClonerTargets,
+		Value: string(value),
+	})
+
+	return nil
+}
+
+// leftOutOfCloneBatch returns true if the PVC is not going to be cloned by the shared source pod of its batch
+func leftOutOfCloneBatch(sourcePod *corev1.Pod, pvc *corev1.PersistentVolumeClaim) bool {
+	if sourcePod == nil {
+		return false
+	}
+	targets, ok := sourcePod.Annotations[cc.AnnCloneBatchTargets]
+	if !ok {
+		return false
+	}
+
+	name := pvc.Namespace + "/" + pvc.Name
+	if !slices.Contains(strings.Split(targets, ","), name) {
+		return true
+	}
+
+	if sourcePod.Status.Phase != corev1.PodSucceeded {
+		return false
+	}
+
+	return slices.Contains(getCloneBatchFailedTargets(sourcePod), name)
+}
+
+// getCloneBatchFailedTargets returns the targets listed as failed in the termination message of the shared source pod
+func getCloneBatchFailedTargets(sourcePod *corev1.Pod) []string {
+	for _, status := range sourcePod.Status.ContainerStatuses {
+		if status.State.Terminated == nil {
+			continue
+		}
+		// Batches cloned to all their members end with a plain message
+		termMsg := &common.TerminationMessage{}
+		if err := json.Unmarshal([]byte(status.State.Terminated.Message), termMsg); err == nil {
+			return termMsg.FailedTargets
+		}
+	}
+	return nil
+}
+
+// cloneBatchPodInUse returns true if other members of the batch still use its shared source pod
+func (r *CloneReconciler) cloneBatchPodInUse(pod *corev1.Pod, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	if _, ok := pod.Annotations[cc.AnnCloneBatchTargets]; !ok {
+		return false, nil
+	}
+
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.client.List(context.TODO(), pvcList, client.InNamespace(pvc.Namespace), client.MatchingFields{cloneSourcePodField: pod.Name}); err != nil {
+		return false, errors.Wrap(err, "error listing PVCs")
+	}
+
+	for i := range pvcList.Items {
+		member := &pvcList.Items[i]
+		if member.UID != pvc.UID &&
+			member.DeletionTimestamp == nil &&
+			member.Annotations[cc.AnnCloneSourcePod] == pod.Name &&
+			cc.HasFinalizer(member, cloneSourcePodFinalizer) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func cloneBatchTargetRequests(targets string) []reconcile.Request {
+	var reqs []reconcile.Request
+	for _, target := range strings.Split(targets, ",") {
+		namespace, name, err := cache.SplitMetaNamespaceKey(target)
+		if err != nil {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      name,
+			},
+		})
+	}
+	return reqs
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+var _ = Describe("Clone batch", func() {
+	var (
+		reconciler *CloneReconciler
+	)
+
+	AfterEach(func() {
+		if reconciler != nil {
+			close(reconciler.recorder.(*record.FakeRecorder).Events)
+			reconciler = nil
+		}
+	})
+
+	sourcePvc := func() *corev1.PersistentVolumeClaim {
+		return cc.CreatePvc("source", "default", map[string]string{}, nil)
+	}
+
+	batchPodName := cloneBatchSourcePodName(sourcePvc(), "golden")
+
+	member := func(name string, ready bool, created time.Time) *corev1.PersistentVolumeClaim {
+		pvc := cc.CreatePvc(name, "default", map[string]string{
+			cc.AnnCloneRequest:  "default/source",
+			cc.AnnCloneBatch:    "golden",
+			AnnUploadClientName: "default/source-batch/golden",
+		}, nil)
+		if ready {
+			pvc.Annotations[cc.AnnPodReady] = "true"
+		}
+		pvc.CreationTimestamp = metav1.NewTime(created)
+		return pvc
+	}
+
+	reconcilePvc := func(name string) reconcile.Result {
+		result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		return result
+	}
+
+	getPvc := func(name string) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, pvc)
+		Expect(err).ToNot(HaveOccurred())
+		return pvc
+	}
+
+	batchPod := func(targets string, phase corev1.PodPhase, message string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      batchPodName,
+				Namespace: "default",
+				Labels: map[string]string{
+					cc.CloneUniqueID: batchPodName,
+				},
+				Annotations: map[string]string{
+					cc.AnnCloneBatchTargets: targets,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+			},
+		}
+		if phase == corev1.PodSucceeded {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: message},
+					},
+				},
+			}
+		}
+		return pod
+	}
+
+	It("Should clone the ready members of a batch with a shared source pod", func() {
+		reconciler = createCloneReconciler(member("testPvc1", true, time.Now()), member("testPvc2", true, time.Now()), sourcePvc())
+		reconcilePvc("testPvc1")
+
+		By("Verifying both members are assigned to the batch source pod")
+		for _, name := range []string{"testPvc1", "testPvc2"} {
+			pvc := getPvc(name)
+			Expect(pvc.Annotations[cc.AnnCloneSourcePod]).To(Equal(batchPodName))
+			Expect(cc.HasFinalizer(pvc, cloneSourcePodFinalizer)).To(BeTrue())
+		}
+
+		By("Verifying the batch source pod clones to both members")
+		reconcilePvc("testPvc1")
+		reconcilePvc("testPvc2")
+		sourcePod, err := reconciler.findCloneSourcePod(getPvc("testPvc2"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sourcePod).ToNot(BeNil())
+		Expect(sourcePod.Name).To(Equal(batchPodName))
+		Expect(sourcePod.Annotations[cc.AnnCloneBatchTargets]).To(Equal("default/testPvc1,default/testPvc2"))
+
+		var targets []common.ClonerTarget
+		for _, env := range sourcePod.Spec.Containers[0].Env {
+			if env.Name == common.ClonerTargets {
+				Expect(json.Unmarshal([]byte(env.Value), &targets)).To(Succeed())
+			}
+		}
+		Expect(targets).To(HaveLen(2))
+		Expect(targets[1].Name).To(Equal("default/testPvc2"))
+		Expect(targets[1].URL).To(Equal(GetUploadServerURL("default", "testPvc2", common.UploadPathSync)))
+	})
+
+	It("Should wait for the members of a batch that are not ready", func() {
+		reconciler = createCloneReconciler(member("testPvc1", true, time.Now()), member("testPvc2", false, time.Now()), sourcePvc())
+		result := reconcilePvc("testPvc1")
+		Expect(result.RequeueAfter).ToNot(BeZero())
+		Expect(getPvc("testPvc1").Annotations).ToNot(HaveKey(cc.AnnCloneSourcePod))
+	})
+
+	It("Should clone with its own source pod once the batch settled without other ready members", func() {
+		created := time.Now().Add(-2 * cloneBatchSettleTime)
+		reconciler = createCloneReconciler(member("testPvc1", true, created), member("testPvc2", false, created), sourcePvc())
+		reconcilePvc("testPvc1")
+		Expect(getPvc("testPvc1").Annotations[cc.AnnCloneSourcePod]).To(Equal("default-testPvc1-source-pod"))
+	})
+
+	It("Should clone with its own source pod when joining after the batch started", func() {
+		reconciler = createCloneReconciler(member("testPvc1", true, time.Now()), sourcePvc(),
+			batchPod("default/testPvc2", corev1.PodRunning, ""))
+		reconcilePvc("testPvc1")
+		Expect(getPvc("testPvc1").Annotations[cc.AnnCloneSourcePod]).To(Equal("default-testPvc1-source-pod"))
+	})
+
+	It("Should clone with its own source pod when the batch failed for the member", func() {
+		pvc := member("testPvc1", true, time.Now())
+		pvc.Annotations[cc.AnnCloneSourcePod] = batchPodName
+		cc.AddFinalizer(pvc, cloneSourcePodFinalizer)
+		reconciler = createCloneReconciler(pvc, sourcePvc(),
+			batchPod("default/testPvc1,default/testPvc2", corev1.PodSucceeded, failedTargetsMessage("default/testPvc1")))
+		reconcilePvc("testPvc1")
+		Expect(getPvc("testPvc1").Annotations[cc.AnnCloneSourcePod]).To(Equal("default-testPvc1-source-pod"))
+	})
+
+	DescribeTable("Should tell if a member is left out of the batch", func(pod *corev1.Pod, expected bool) {
+		Expect(leftOutOfCloneBatch(pod, member("testPvc1", true, time.Now()))).To(Equal(expected))
+	},
+		Entry("not a batch pod", &corev1.Pod{}, false),
+		Entry("not a target", batchPod("default/testPvc2", corev1.PodRunning, ""), true),
+		Entry("running", batchPod("default/testPvc1,default/testPvc2", corev1.PodRunning, ""), false),
+		Entry("succeeded", batchPod("default/testPvc1,default/testPvc2", corev1.PodSucceeded, "Clone Complete"), false),
+		Entry("failed for another member", batchPod("default/testPvc1,default/testPvc2", corev1.PodSucceeded,
+			failedTargetsMessage("default/testPvc2")), false),
+		Entry("failed for the member", batchPod("default/testPvc1,default/testPvc2", corev1.PodSucceeded,
+			failedTargetsMessage("default/testPvc2", "default/testPvc1")), true),
+	)
+
+	It("Should keep the batch source pod while other members use it", func() {
+		done := member("testPvc1", true, time.Now())
+		done.Annotations[cc.AnnCloneSourcePod] = batchPodName
+		done.Annotations[cc.AnnCloneOf] = "true"
+		cc.AddFinalizer(done, cloneSourcePodFinalizer)
+		other := member("testPvc2", true, time.Now())
+		other.Annotations[cc.AnnCloneSourcePod] = batchPodName
+		cc.AddFinalizer(other, cloneSourcePodFinalizer)
+		reconciler = createCloneReconciler(done, other, sourcePvc(),
+			batchPod("default/testPvc1,default/testPvc2", corev1.PodPending, ""))
+
+		reconcilePvc("testPvc1")
+		Expect(cc.HasFinalizer(getPvc("testPvc1"), cloneSourcePodFinalizer)).To(BeFalse())
+		sourcePod, err := reconciler.findCloneSourcePod(other)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sourcePod).ToNot(BeNil())
+
+		By("Deleting the batch source pod with its last member")
+		other.Annotations[cc.AnnCloneOf] = "true"
+		Expect(reconciler.client.Update(context.TODO(), other)).To(Succeed())
+		reconcilePvc("testPvc2")
+		sourcePod, err = reconciler.findCloneSourcePod(other)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sourcePod).To(BeNil())
+	})
+})
+
+func failedTargetsMessage(targets ...string) string {
+	termMsg := &common.TerminationMessage{Message: ptr.To("Clone Complete"), FailedTargets: targets}
+	msg, err := termMsg.String()
+	Expect(err).ToNot(HaveOccurred())
+	return msg
+}
@@ -56,6 +56,9 @@ const (
 	cloneSourcePodFinalizer = "cdi.kubevirt.io/cloneSource"
 
 	hostAssistedCloneSource = "cdi.kubevirt.io/hostAssistedSourcePodCloneSource"
+
+	// cloneBatchSettleTime is how long the members of a clone batch wait for each other
+	cloneBatchSettleTime = time.Minute
 )
 
 // CloneReconciler members
@@ -102,6 +105,9 @@ func NewCloneController(mgr manager.Manager,
 	if err != nil {
 		return nil, err
 	}
+	if err := addCloneBatchIndexes(mgr); err != nil {
+		return nil, err
+	}
 	if err := addCloneControllerWatches(mgr, cloneController); err != nil {
 		return nil, err
 	}
@@ -120,6 +126,9 @@ func addCloneControllerWatches(mgr manager.Manager, cloneController controller.C
 	}
 	if err := cloneController.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{}, handler.TypedEnqueueRequestsFromMapFunc[*corev1.Pod](
 		func(ctx context.Context, obj *corev1.Pod) []reconcile.Request {
+			if targets, ok := obj.GetAnnotations()[cc.AnnCloneBatchTargets]; ok {
+				return cloneBatchTargetRequests(targets)
+			}
 			target, ok := obj.GetAnnotations()[AnnOwnerRef]
 			if !ok {
 				return nil
@@ -201,6 +210,13 @@ func (r *CloneReconciler) Reconcile(ctx context.Context, req reconcile.Request)
 
 	_, nameExists := pvc.Annotations[cc.AnnCloneSourcePod]
 	if !nameExists && sourcePod == nil {
+		if batch := pvc.Annotations[cc.AnnCloneBatch]; batch != "" {
+			requeueAfter, joined, err := r.joinCloneBatch(ctx, pvc, batch, log)
+			if requeueAfter != 0 || joined || err != nil {
+				return reconcile.Result{RequeueAfter: requeueAfter}, err
+			}
+		}
+
 		pvc.Annotations[cc.AnnCloneSourcePod] = cc.CreateCloneSourcePodName(pvc)
 
 		// add finalizer before creating clone source pod
@@ -338,13 +354,21 @@ func (r *CloneReconciler) updatePvcFromPod(sourcePod *corev1.Pod, pvc *corev1.Pe
 
 	log.V(3).Info("Pod phase for PVC", "PVC phase", pvc.Annotations[cc.AnnPodPhase])
 
+	if !podSucceededFromPVC(pvc) && leftOutOfCloneBatch(sourcePod, pvc) {
+		log.V(1).Info("Clone batch did not clone the PVC, cloning it with its own source pod", "pod", sourcePod.Name)
+		pvc.Annotations[cc.AnnCloneSourcePod] = cc.CreateCloneSourcePodName(pvc)
+		return r.updatePVC(pvc)
+	}
+
 	if podSucceededFromPVC(pvc) && pvc.Annotations[cc.AnnCloneOf] != "true" && sourcePodFinished(sourcePod) {
 		log.V(1).Info("Adding CloneOf annotation to PVC")
 		pvc.Annotations[cc.AnnCloneOf] = "true"
 		r.recorder.Event(pvc, corev1.EventTypeNormal, CloneSucceededPVC, cc.CloneComplete)
 	}
 
-	setAnnotationsFromPodWithPrefix(pvc.Annotations, sourcePod, nil, cc.AnnSourceRunningCondition)
+	// The source pod reports a structured termination message only when it fails
+	termMsg, _ := parseTerminationMessage(sourcePod)
+	setAnnotationsFromPodWithPrefix(pvc.Annotations, sourcePod, termMsg, cc.AnnSourceRunningCondition)
 
 	if !reflect.DeepEqual(currentPvcCopy, pvc) {
 		return r.updatePVC(pvc)
@@ -461,7 +485,11 @@ func (r *CloneReconciler) cleanup(pvc *corev1.PersistentVolumeClaim, log logr.Lo
 			log.V(3).Info("Clone succeeded, waiting for source pod to stop running", "pod.Namespace", pod.Namespace, "pod.Name", pod.Name)
 			return nil
 		}
-		if cc.ShouldDeletePod(pvc) {
+		inUse, err := r.cloneBatchPodInUse(pod, pvc)
+		if err != nil {
+			return err
+		}
+		if cc.ShouldDeletePod(pvc) && !inUse {
 			log.V(3).Info("Deleting pod", "pod.Name", pod.Name)
 			if err = r.client.Delete(context.TODO(), pod); err != nil {
 				if !k8serrors.IsNotFound(err) {
@@ -523,7 +551,19 @@ func (r *CloneReconciler) CreateCloneSourcePod(image, pullPolicy string, pvc *co
 	pod := MakeCloneSourcePodSpec(sourceVolumeMode, image, pullPolicy, ownerKey, imagePullSecrets, serverCABundle, pvc, sourcePvc, podResourceRequirements, workloadNodePlacement)
 	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
 
+	batch := pvc.Annotations[cc.AnnCloneBatch]
+	isBatchPod := batch != "" && pod.Name == cloneBatchSourcePodName(sourcePvc, batch)
+	if isBatchPod {
+		if err := r.setCloneBatchTargets(pod, pvc); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := r.client.Create(context.TODO(), pod); err != nil {
+		// Another member of the batch created it first
+		if isBatchPod && k8serrors.IsAlreadyExists(err) {
+			return pod, nil
+		}
 		return nil, errors.Wrap(err, "source pod API create errored")
 	}
 
@@ -540,18 +580,9 @@ func MakeCloneSourcePodSpec(sourceVolumeMode corev1.PersistentVolumeMode, image,
 	sourcePvcNamespace := sourcePvc.GetNamespace()
 	sourcePvcUID := string(sourcePvc.GetUID())
 
-	var ownerID string
 	cloneSourcePodName := targetPvc.Annotations[cc.AnnCloneSourcePod]
 	url := GetUploadServerURL(targetPvc.Namespace, targetPvc.Name, common.UploadPathSync)
-	pvcOwner := metav1.GetControllerOf(targetPvc)
-	if pvcOwner != nil && pvcOwner.Kind == "DataVolume" {
-		ownerID = string(pvcOwner.UID)
-	} else {
-		ouid, ok := targetPvc.Annotations[cc.AnnOwnerUID]
-		if ok {
-			ownerID = ouid
-		}
-	}
+	ownerID := getCloneOwnerUID(targetPvc)
 
 	preallocationRequested := targetPvc.Annotations[cc.AnnPreallocationRequested]
 
@@ -738,6 +769,15 @@ func MakeCloneSourcePodSpec(sourceVolumeMode corev1.PersistentVolumeMode, image,
 	return pod
 }
 
+// getCloneOwnerUID returns the UID the clone progress of the target PVC is reported for
+func getCloneOwnerUID(targetPvc *corev1.PersistentVolumeClaim) string {
+	pvcOwner := metav1.GetControllerOf(targetPvc)
+	if pvcOwner != nil && pvcOwner.Kind == "DataVolume" {
+		return string(pvcOwner.UID)
+	}
+	return targetPvc.Annotations[cc.AnnOwnerUID]
+}
+
 // ParseCloneRequestAnnotation parses the clone request annotation
 func ParseCloneRequestAnnotation(pvc *corev1.PersistentVolumeClaim) (exists bool, namespace, name string) {
 	var ann string
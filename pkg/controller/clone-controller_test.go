@@ -782,7 +782,10 @@ func createCloneReconciler(objects ...runtime.Object) *CloneReconciler {
 
 	rec := record.NewFakeRecorder(1)
 	// Create a fake client to mock API calls.
-	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).
+		WithIndex(&corev1.PersistentVolumeClaim{}, cloneBatchField, indexByCloneBatch).
+		WithIndex(&corev1.PersistentVolumeClaim{}, cloneSourcePodField, indexByCloneSourcePod).
+		Build()
 
 	// Create a ReconcileMemcached object with the scheme and fake client.
 	return &CloneReconciler{
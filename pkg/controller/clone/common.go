@@ -3,6 +3,8 @@ package clone
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
@@ -32,6 +34,12 @@ const (
 
 	// ErrorPhaseName is the phase when the clone is in error
 	ErrorPhaseName = "Error"
+
+	// cloneBatchLabel labels the resources shared by the members of a clone batch
+	cloneBatchLabel = cc.AnnAPIGroup + "/cloneBatch"
+
+	// cloneBatchMemberLabelPrefix labels a resource shared by a clone batch with the UIDs of the members using it
+	cloneBatchMemberLabelPrefix = cc.AnnAPIGroup + "/cloneBatchMember-"
 )
 
 // IsDataSourcePVC checks for PersistentVolumeClaim source kind
@@ -68,17 +76,50 @@ func AddOwnershipLabel(label string, obj, owner metav1.Object) {
 	obj.GetLabels()[label] = string(owner.GetUID())
 }
 
+// cloneBatchID returns an ID of the clone batch usable in names and label values
+func cloneBatchID(batch string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(batch))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// addCloneBatchMemberLabel adds the owner to the members using a resource shared by its clone batch,
+// it returns false if the owner was already a member
+func addCloneBatchMemberLabel(obj, owner metav1.Object) bool {
+	if obj.GetLabels() == nil {
+		obj.SetLabels(make(map[string]string))
+	}
+	key := cloneBatchMemberLabelPrefix + string(owner.GetUID())
+	if _, ok := obj.GetLabels()[key]; ok {
+		return false
+	}
+	obj.GetLabels()[key] = ""
+	return true
+}
+
+// getCloneBatchMembers returns the UIDs of the members using a resource shared by a clone batch
+func getCloneBatchMembers(obj metav1.Object) []string {
+	var uids []string
+	for key := range obj.GetLabels() {
+		if uid, ok := strings.CutPrefix(key, cloneBatchMemberLabelPrefix); ok {
+			uids = append(uids, uid)
+		}
+	}
+	return uids
+}
+
 // IsSourceClaimReadyArgs are arguments for IsSourceClaimReady
 type IsSourceClaimReadyArgs struct {
 	Target          client.Object
 	SourceNamespace string
 	SourceName      string
+	AllowInUse      bool
 	Client          client.Client
 	Log             logr.Logger
 	Recorder        record.EventRecorder
 }
 
-// IsSourceClaimReady checks that PVC exists, is bound, and is not being used
+// IsSourceClaimReady checks that PVC exists, is bound, and is not being used unless AllowInUse is set
 func IsSourceClaimReady(ctx context.Context, args *IsSourceClaimReadyArgs) (bool, error) {
 	claim := &corev1.PersistentVolumeClaim{}
 	exists, err := getResource(ctx, args.Client, args.SourceNamespace, args.SourceName, claim)
@@ -94,6 +135,10 @@ func IsSourceClaimReady(ctx context.Context, args *IsSourceClaimReadyArgs) (bool
 		return false, nil
 	}
 
+	if args.AllowInUse {
+		return cdiv1.IsPopulated(claim, dataVolumeGetter(ctx, args.Client))
+	}
+
 	pods, err := cc.GetPodsUsingPVCs(ctx, args.Client, args.SourceNamespace, sets.New(args.SourceName), true)
 	if err != nil {
 		return false, err
@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// FreezeFailed reports that the workloads using the clone source could not be frozen (reason)
+	FreezeFailed = "FreezeFailed"
+
+	// MessageFreezeFailed reports that the workloads using the clone source could not be frozen (message)
+	MessageFreezeFailed = "Could not freeze the workloads using the source, the clone is crash consistent: %s"
+
+	// ThawFailed reports that the workloads using the clone source could not be thawed (reason)
+	ThawFailed = "ThawFailed"
+
+	// MessageThawFailed reports that the workloads using the clone source could not be thawed (message)
+	MessageThawFailed = "Could not thaw the workloads using the source: %s"
+
+	freezeHookTimeout = 30 * time.Second
+
+	// KubeVirt unfreezes the guest by itself if the thaw never comes
+	vmiUnfreezeTimeout = 5 * time.Minute
+
+	vmiSubresourcesPath = "/apis/subresources.kubevirt.io/v1"
+)
+
+// Freezer freezes and thaws the filesystems of the workloads using a clone source
+type Freezer interface {
+	// Freeze returns false if the workloads could not be frozen and the snapshot will be crash consistent
+	Freeze(ctx context.Context, namespace, claimName string, pods []corev1.Pod) (bool, error)
+	Thaw(ctx context.Context, namespace, claimName string, pods []corev1.Pod) error
+}
+
+// FreezeHookRequest is the body posted to a freeze hook
+type FreezeHookRequest struct {
+	Action    string   `json:"action"`
+	Namespace string   `json:"namespace"`
+	ClaimName string   `json:"claimName"`
+	Pods      []string `json:"pods,omitempty"`
+}
+
+// HookFreezer calls a webhook endpoint to freeze and thaw the workloads
+type HookFreezer struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+var _ Freezer = &HookFreezer{}
+
+// Freeze posts a freeze action to the hook
+func (f *HookFreezer) Freeze(ctx context.Context, namespace, claimName string, pods []corev1.Pod) (bool, error) {
+	if err := f.post(ctx, "freeze", namespace, claimName, pods); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Thaw posts a thaw action to the hook
+func (f *HookFreezer) Thaw(ctx context.Context, namespace, claimName string, pods []corev1.Pod) error {
+	return f.post(ctx, "thaw", namespace, claimName, pods)
+}
+
+func (f *HookFreezer) post(ctx context.Context, action, namespace, claimName string, pods []corev1.Pod) error {
+	u, err := url.Parse(f.URL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported freeze hook scheme %q", u.Scheme)
+	}
+
+	req := &FreezeHookRequest{
+		Action:    action,
+		Namespace: namespace,
+		ClaimName: claimName,
+	}
+	for _, pod := range pods {
+		req.Pods = append(req.Pods, pod.Name)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, freezeHookTimeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpClient := f.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("freeze hook returned %s for %s", resp.Status, action)
+	}
+
+	return nil
+}
+
+// VMIFreezer freezes the guest filesystems of the KubeVirt VMIs using the source
+type VMIFreezer struct {
+	Client client.Client
+	Config *rest.Config
+}
+
+var _ Freezer = &VMIFreezer{}
+
+// Freeze freezes all VMIs using the source, it returns false if some pods using the source are not VMIs
+// or if the KubeVirt freeze subresource is not present
+func (f *VMIFreezer) Freeze(ctx context.Context, namespace, claimName string, pods []corev1.Pod) (bool, error) {
+	vmis, ok, err := f.getVMIs(ctx, namespace, pods)
+	if err != nil || !ok || f.Config == nil {
+		return false, err
+	}
+
+	body, err := json.Marshal(map[string]metav1.Duration{"unfreezeTimeout": {Duration: vmiUnfreezeTimeout}})
+	if err != nil {
+		return false, err
+	}
+
+	for i, vmi := range vmis {
+		if err := f.put(ctx, namespace, vmi, "freeze", body); err != nil {
+			// Don't leave the guests frozen until the unfreeze timeout
+			if thawErr := f.thaw(ctx, namespace, vmis[:i]); thawErr != nil {
+				return false, thawErr
+			}
+			if k8serrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// Thaw unfreezes all VMIs using the source
+func (f *VMIFreezer) Thaw(ctx context.Context, namespace, claimName string, pods []corev1.Pod) error {
+	vmis, _, err := f.getVMIs(ctx, namespace, pods)
+	if err != nil || f.Config == nil {
+		return err
+	}
+
+	return f.thaw(ctx, namespace, vmis)
+}
+
+func (f *VMIFreezer) thaw(ctx context.Context, namespace string, vmis []string) error {
+	for _, vmi := range vmis {
+		// A VMI that went away meanwhile has nothing to thaw
+		if err := f.put(ctx, namespace, vmi, "unfreeze", nil); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *VMIFreezer) put(ctx context.Context, namespace, vmi, subresource string, body []byte) error {
+	cs, err := kubernetes.NewForConfig(f.Config)
+	if err != nil {
+		return err
+	}
+
+	req := cs.CoreV1().RESTClient().Put().
+		AbsPath(vmiSubresourcesPath, "namespaces", namespace, "virtualmachineinstances", vmi, subresource)
+	if body != nil {
+		req = req.Body(body).SetHeader("Content-Type", "application/json")
+	}
+
+	return req.Do(ctx).Error()
+}
+
+// getVMIs returns the VMIs owning the pods, directly or through the pod of a hotplugged volume
+func (f *VMIFreezer) getVMIs(ctx context.Context, namespace string, pods []corev1.Pod) ([]string, bool, error) {
+	var vmis []string
+	seen := map[string]bool{}
+	for i := range pods {
+		vmi, err := f.getVMI(ctx, namespace, &pods[i])
+		if err != nil {
+			return nil, false, err
+		}
+		if vmi == "" {
+			return nil, false, nil
+		}
+		if !seen[vmi] {
+			seen[vmi] = true
+			vmis = append(vmis, vmi)
+		}
+	}
+
+	return vmis, true, nil
+}
+
+func (f *VMIFreezer) getVMI(ctx context.Context, namespace string, pod *corev1.Pod) (string, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "", nil
+	}
+
+	switch owner.Kind {
+	case "VirtualMachineInstance":
+		return owner.Name, nil
+	case "Pod":
+		ownerPod := &corev1.Pod{}
+		exists, err := getResource(ctx, f.Client, namespace, owner.Name, ownerPod)
+		if err != nil || !exists {
+			return "", err
+		}
+		if owner := metav1.GetControllerOf(ownerPod); owner != nil && owner.Kind == "VirtualMachineInstance" {
+			return owner.Name, nil
+		}
+	}
+
+	return "", nil
+}
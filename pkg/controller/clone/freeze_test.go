@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Freezer test", func() {
+	const namespace = "ns"
+
+	var (
+		ts       *httptest.Server
+		requests []string
+		bodies   []string
+		status   int
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		bodies = nil
+		status = http.StatusOK
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			bodies = append(bodies, string(body))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			if status == http.StatusNotFound {
+				_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	ownedPod := func(name, kind, owner string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Kind:       kind,
+						Name:       owner,
+						Controller: ptr.To[bool](true),
+					},
+				},
+			},
+		}
+	}
+
+	Context("HookFreezer", func() {
+		It("should post freeze and thaw to the hook", func() {
+			f := &HookFreezer{URL: ts.URL + "/hook"}
+			pods := []corev1.Pod{ownedPod("pod", "ReplicaSet", "rs")}
+			frozen, err := f.Freeze(context.Background(), namespace, "source", pods)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(frozen).To(BeTrue())
+			Expect(f.Thaw(context.Background(), namespace, "source", pods)).To(Succeed())
+			Expect(requests).To(Equal([]string{"POST /hook", "POST /hook"}))
+
+			req := &FreezeHookRequest{}
+			Expect(json.Unmarshal([]byte(bodies[0]), req)).To(Succeed())
+			Expect(*req).To(Equal(FreezeHookRequest{Action: "freeze", Namespace: namespace, ClaimName: "source", Pods: []string{"pod"}}))
+			Expect(json.Unmarshal([]byte(bodies[1]), req)).To(Succeed())
+			Expect(req.Action).To(Equal("thaw"))
+		})
+
+		It("should fail if the hook fails", func() {
+			status = http.StatusInternalServerError
+			f := &HookFreezer{URL: ts.URL}
+			frozen, err := f.Freeze(context.Background(), namespace, "source", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(frozen).To(BeFalse())
+		})
+
+		It("should refuse hooks that are not http", func() {
+			f := &HookFreezer{URL: "file:///etc/hook"}
+			_, err := f.Freeze(context.Background(), namespace, "source", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	Context("VMIFreezer", func() {
+		createFreezer := func(objects ...runtime.Object) *VMIFreezer {
+			cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objects...).Build()
+			return &VMIFreezer{
+				Client: cl,
+				Config: &rest.Config{Host: ts.URL},
+			}
+		}
+
+		It("should freeze and unfreeze the VMIs using the source", func() {
+			launcher := ownedPod("virt-launcher-vm", "VirtualMachineInstance", "vm")
+			hotplug := ownedPod("hp-volume", "Pod", launcher.Name)
+			f := createFreezer(&launcher)
+			pods := []corev1.Pod{launcher, hotplug}
+			frozen, err := f.Freeze(context.Background(), namespace, "source", pods)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(frozen).To(BeTrue())
+			Expect(f.Thaw(context.Background(), namespace, "source", pods)).To(Succeed())
+			Expect(requests).To(Equal([]string{
+				"PUT /apis/subresources.kubevirt.io/v1/namespaces/ns/virtualmachineinstances/vm/freeze",
+				"PUT /apis/subresources.kubevirt.io/v1/namespaces/ns/virtualmachineinstances/vm/unfreeze",
+			}))
+			Expect(bodies[0]).To(MatchJSON(`{"unfreezeTimeout":"5m0s"}`))
+		})
+
+		It("should not freeze if a pod using the source is not a VMI", func() {
+			f := createFreezer()
+			pods := []corev1.Pod{ownedPod("virt-launcher-vm", "VirtualMachineInstance", "vm"), ownedPod("pod", "ReplicaSet", "rs")}
+			frozen, err := f.Freeze(context.Background(), namespace, "source", pods)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(frozen).To(BeFalse())
+			Expect(requests).To(BeEmpty())
+		})
+
+		It("should not freeze if the freeze subresource is not present", func() {
+			status = http.StatusNotFound
+			f := createFreezer()
+			pods := []corev1.Pod{ownedPod("virt-launcher-vm", "VirtualMachineInstance", "vm")}
+			frozen, err := f.Freeze(context.Background(), namespace, "source", pods)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(frozen).To(BeFalse())
+		})
+	})
+})
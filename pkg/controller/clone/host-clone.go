@@ -199,6 +199,9 @@ func (p *HostClonePhase) createClaim(ctx context.Context) (*corev1.PersistentVol
 	if p.PriorityClassName != "" {
 		cc.AddAnnotation(claim, cc.AnnPriorityClassName, p.PriorityClassName)
 	}
+	if batch := p.Owner.GetAnnotations()[cc.AnnCloneBatch]; batch != "" {
+		cc.AddAnnotation(claim, cc.AnnCloneBatch, batch)
+	}
 	cc.AddLabel(claim, cc.LabelExcludeFromVeleroBackup, "true")
 
 	if err := p.Client.Create(ctx, claim); err != nil {
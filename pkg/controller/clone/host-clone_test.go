@@ -127,6 +127,17 @@ var _ = Describe("HostClonePhase test", func() {
 		Expect(pvc.Annotations[cc.AnnPriorityClassName]).To(Equal("priority"))
 	})
 
+	It("should create pvc in the clone batch of the owner", func() {
+		p := creatHostClonePhase()
+		cc.AddAnnotation(p.Owner, cc.AnnCloneBatch, "golden")
+
+		_, err := p.Reconcile(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+
+		pvc := getDesiredClaim(p)
+		Expect(pvc.Annotations[cc.AnnCloneBatch]).To(Equal("golden"))
+	})
+
 	Context("with desired claim created", func() {
 		getCliam := func() *corev1.PersistentVolumeClaim {
 			return &corev1.PersistentVolumeClaim{
@@ -6,16 +6,20 @@ import (
 	"reflect"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -26,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 )
 
@@ -79,12 +84,14 @@ type Planner struct {
 	OwnershipLabel  string
 	UIDField        string
 	Image           string
+	ImporterImage   string
 	PullPolicy      corev1.PullPolicy
 	InstallerLabels map[string]string
 	Client          client.Client
 	Recorder        record.EventRecorder
 	Controller      controller.Controller
 	GetCache        func() cache.Cache
+	RESTConfig      *rest.Config
 
 	watchingCore      bool
 	watchingSnapshots bool
@@ -151,6 +158,8 @@ type ChooseStrategyArgs struct {
 type ChooseStrategyResult struct {
 	Strategy       cdiv1.CDICloneStrategy
 	FallbackReason *string
+	// SnapshotSource is set when a host assisted clone of a PVC reads from a snapshot of the source
+	SnapshotSource bool
 }
 
 // ChooseStrategy picks the strategy for a clone op
@@ -172,18 +181,24 @@ type PlanArgs struct {
 	TargetClaim *corev1.PersistentVolumeClaim
 	DataSource  *cdiv1.VolumeCloneSource
 	Strategy    cdiv1.CDICloneStrategy
+	// SnapshotSource makes a host assisted clone of a PVC read from a snapshot of the source
+	SnapshotSource bool
 }
 
 // Plan creates phases for populator clone
 func (p *Planner) Plan(ctx context.Context, args *PlanArgs) ([]Phase, error) {
-	if args.Strategy == cdiv1.CloneStrategySnapshot {
+	if args.Strategy == cdiv1.CloneStrategySnapshot || args.SnapshotSource {
 		if err := p.watchSnapshots(ctx, args.Log); err != nil {
 			return nil, err
 		}
 	}
 
 	if IsDataSourcePVC(args.DataSource.Spec.Source.Kind) {
-		if args.Strategy == cdiv1.CloneStrategyHostAssisted {
+		if args.Strategy == cdiv1.CloneStrategyHostAssisted && args.SnapshotSource {
+			args.Log.V(3).Info("Planning host assisted clone from PVC snapshot")
+
+			return p.planHostAssistedFromPVCSnapshot(ctx, args)
+		} else if args.Strategy == cdiv1.CloneStrategyHostAssisted {
 			args.Log.V(3).Info("Planning host assisted clone from PVC")
 
 			return p.planHostAssistedFromPVC(ctx, args)
@@ -217,6 +232,10 @@ func (p *Planner) Plan(ctx context.Context, args *PlanArgs) ([]Phase, error) {
 func (p *Planner) Cleanup(ctx context.Context, log logr.Logger, owner client.Object) error {
 	log.V(3).Info("Cleaning up for obj", "obj", owner)
 
+	if err := p.thawOwnedSnapshots(ctx, log, owner); err != nil {
+		return err
+	}
+
 	for _, lt := range listTypesToDelete {
 		ls, err := labels.Parse(fmt.Sprintf("%s=%s", p.OwnershipLabel, string(owner.GetUID())))
 		if err != nil {
@@ -231,6 +250,71 @@ func (p *Planner) Cleanup(ctx context.Context, log logr.Logger, owner client.Obj
 		}
 	}
 
+	return p.releaseBatchSnapshots(ctx, log, owner)
+}
+
+// thawOwnedSnapshots thaws the sources of the snapshots of the owner that are about to be deleted while frozen
+func (p *Planner) thawOwnedSnapshots(ctx context.Context, log logr.Logger, owner client.Object) error {
+	ls, err := labels.Parse(fmt.Sprintf("%s=%s", p.OwnershipLabel, string(owner.GetUID())))
+	if err != nil {
+		return err
+	}
+
+	vsl := &snapshotv1.VolumeSnapshotList{}
+	if err := p.Client.List(ctx, vsl, &client.ListOptions{LabelSelector: ls}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	for i := range vsl.Items {
+		if err := p.thawSnapshotSource(ctx, log, owner, &vsl.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseBatchSnapshots removes the owner from the members of the snapshots shared by its clone batch,
+// the last member deletes them
+func (p *Planner) releaseBatchSnapshots(ctx context.Context, log logr.Logger, owner client.Object) error {
+	memberLabel := cloneBatchMemberLabelPrefix + string(owner.GetUID())
+	ls, err := labels.Parse(memberLabel)
+	if err != nil {
+		return err
+	}
+
+	vsl := &snapshotv1.VolumeSnapshotList{}
+	if err := p.Client.List(ctx, vsl, &client.ListOptions{LabelSelector: ls}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	for i := range vsl.Items {
+		snapshot := &vsl.Items[i]
+		delete(snapshot.Labels, memberLabel)
+		if len(getCloneBatchMembers(snapshot)) > 0 {
+			if err := p.Client.Update(ctx, snapshot); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := p.thawSnapshotSource(ctx, log, owner, snapshot); err != nil {
+			return err
+		}
+		log.V(3).Info("Deleting snapshot shared by the clone batch", "snapshot", snapshot.Name)
+		// Fails if a member joined meanwhile
+		precondition := client.Preconditions{ResourceVersion: &snapshot.ResourceVersion}
+		if err := p.Client.Delete(ctx, snapshot, precondition); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -263,28 +347,30 @@ func (p *Planner) watchOwned(log logr.Logger, obj client.Object) error {
 	objList := p.RootObjectType.DeepCopyObject().(client.ObjectList)
 	if err := p.Controller.Watch(source.Kind(p.GetCache(), obj, handler.EnqueueRequestsFromMapFunc(
 		func(ctx context.Context, obj client.Object) []reconcile.Request {
-			uid, ok := obj.GetLabels()[p.OwnershipLabel]
-			if !ok {
-				return nil
-			}
-			matchingFields := client.MatchingFields{
-				p.UIDField: uid,
+			uids := getCloneBatchMembers(obj)
+			if uid, ok := obj.GetLabels()[p.OwnershipLabel]; ok {
+				uids = append(uids, uid)
 			}
-			if err := p.Client.List(ctx, objList, matchingFields); err != nil {
-				log.Error(err, "Unable to list resource", "matchingFields", matchingFields)
-				return nil
-			}
-			sv := reflect.ValueOf(objList).Elem()
-			iv := sv.FieldByName("Items")
 			var reqs []reconcile.Request
-			for i := 0; i < iv.Len(); i++ {
-				o := iv.Index(i).Addr().Interface().(client.Object)
-				reqs = append(reqs, reconcile.Request{
-					NamespacedName: types.NamespacedName{
-						Namespace: o.GetNamespace(),
-						Name:      o.GetName(),
-					},
-				})
+			for _, uid := range uids {
+				matchingFields := client.MatchingFields{
+					p.UIDField: uid,
+				}
+				if err := p.Client.List(ctx, objList, matchingFields); err != nil {
+					log.Error(err, "Unable to list resource", "matchingFields", matchingFields)
+					return nil
+				}
+				sv := reflect.ValueOf(objList).Elem()
+				iv := sv.FieldByName("Items")
+				for i := 0; i < iv.Len(); i++ {
+					o := iv.Index(i).Addr().Interface().(client.Object)
+					reqs = append(reqs, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Namespace: o.GetNamespace(),
+							Name:      o.GetName(),
+						},
+					})
+				}
 			}
 			return reqs
 		}),
@@ -328,6 +414,9 @@ func (p *Planner) computeStrategyForSourcePVC(ctx context.Context, args *ChooseS
 
 	if cs != nil {
 		strategy = *cs
+	} else if forced := getForcedCloneStrategy(args.TargetClaim); forced != nil {
+		args.Log.V(3).Info("Using clone strategy forced by annotation", "strategy", *forced)
+		strategy = *forced
 	} else if args.TargetClaim.Spec.StorageClassName != nil {
 		sp := &cdiv1.StorageProfile{}
 		exists, err := getResource(ctx, p.Client, metav1.NamespaceNone, *args.TargetClaim.Spec.StorageClassName, sp)
@@ -342,6 +431,14 @@ func (p *Planner) computeStrategyForSourcePVC(ctx context.Context, args *ChooseS
 		if exists && sp.Status.CloneStrategy != nil {
 			strategy = *sp.Status.CloneStrategy
 		}
+
+		if exists && sp.Spec.CloneStrategySelection != nil &&
+			*sp.Spec.CloneStrategySelection == cdiv1.CloneStrategySelectionMeasured && sourceClaim.Spec.StorageClassName != nil {
+			if measured := getMeasuredCloneStrategy(sp.Status.CloneStatistics, *sourceClaim.Spec.StorageClassName, time.Now()); measured != nil {
+				args.Log.V(3).Info("Using the fastest measured clone strategy", "strategy", *measured)
+				strategy = *measured
+			}
+		}
 	}
 
 	if strategy == cdiv1.CloneStrategySnapshot {
@@ -352,7 +449,7 @@ func (p *Planner) computeStrategyForSourcePVC(ctx context.Context, args *ChooseS
 
 		if n == nil {
 			p.fallbackToHostAssisted(args.TargetClaim, res, NoVolumeSnapshotClass, MessageNoVolumeSnapshotClass)
-			return res, nil
+			return p.snapshotSourceForHostAssisted(ctx, args, res, sourceClaim)
 		}
 	}
 
@@ -362,11 +459,46 @@ func (p *Planner) computeStrategyForSourcePVC(ctx context.Context, args *ChooseS
 		if err := p.validateAdvancedClonePVC(ctx, args, res, sourceClaim); err != nil {
 			return nil, err
 		}
+
+		if res.Strategy == cdiv1.CloneStrategyHostAssisted {
+			return p.snapshotSourceForHostAssisted(ctx, args, res, sourceClaim)
+		}
+	}
+
+	return res, nil
+}
+
+// snapshotSourceForHostAssisted makes a fallback host assisted clone read from a snapshot of the source
+// when the source driver can snapshot it, so the source is only held while the snapshot is taken
+func (p *Planner) snapshotSourceForHostAssisted(ctx context.Context, args *ChooseStrategyArgs, res *ChooseStrategyResult, sourceClaim *corev1.PersistentVolumeClaim) (*ChooseStrategyResult, error) {
+	vsc, err := p.getSourceVolumeSnapshotClass(ctx, args.Log, sourceClaim, args.TargetClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	if vsc != nil {
+		args.Log.V(3).Info("Host assisted clone will read from a snapshot of the source", "volumeSnapshotClass", *vsc)
+		res.SnapshotSource = true
 	}
 
 	return res, nil
 }
 
+// getSourceVolumeSnapshotClass returns a volumesnapshotclass for the source driver regardless of the target
+func (p *Planner) getSourceVolumeSnapshotClass(ctx context.Context, log logr.Logger, sourceClaim, targetClaim *corev1.PersistentVolumeClaim) (*string, error) {
+	driver, err := GetCommonDriver(ctx, p.Client, sourceClaim)
+	if err != nil || driver == nil {
+		return nil, err
+	}
+
+	snapshotClassName, err := getCommonSnapshotClass(ctx, p.Client, sourceClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	return cc.GetVolumeSnapshotClass(ctx, p.Client, targetClaim, *driver, snapshotClassName, log, p.Recorder)
+}
+
 func (p *Planner) computeStrategyForSourceSnapshot(ctx context.Context, args *ChooseStrategyArgs) (*ChooseStrategyResult, error) {
 	res := &ChooseStrategyResult{}
 
@@ -533,7 +665,7 @@ func (p *Planner) planHostAssistedFromPVC(ctx context.Context, args *PlanArgs) (
 		DesiredClaim:   desiredClaim,
 		ImmediateBind:  true,
 		OwnershipLabel: p.OwnershipLabel,
-		Preallocation:  cc.GetPreallocation(ctx, p.Client, args.DataSource.Spec.Preallocation),
+		Preallocation:  cc.GetPreallocation(ctx, p.Client, args.DataSource.Spec.Preallocation, args.TargetClaim.Spec.StorageClassName),
 		Client:         p.Client,
 		Log:            args.Log,
 		Recorder:       p.Recorder,
@@ -556,6 +688,104 @@ func (p *Planner) planHostAssistedFromPVC(ctx context.Context, args *PlanArgs) (
 	return []Phase{hcp, rp}, nil
 }
 
+func (p *Planner) planHostAssistedFromPVCSnapshot(ctx context.Context, args *PlanArgs) ([]Phase, error) {
+	sourceClaim := &corev1.PersistentVolumeClaim{}
+	exists, err := getResource(ctx, p.Client, args.DataSource.Namespace, args.DataSource.Spec.Source.Name, sourceClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("source claim does not exist")
+	}
+
+	vsc, err := p.getSourceVolumeSnapshotClass(ctx, args.Log, sourceClaim, args.TargetClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	if vsc == nil {
+		return nil, fmt.Errorf("no compatible volumesnapshotclass")
+	}
+
+	freezer, err := p.getFreezer(ctx, args.TargetClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &SnapshotPhase{
+		Owner:               args.TargetClaim,
+		SourceNamespace:     args.DataSource.Namespace,
+		SourceName:          args.DataSource.Spec.Source.Name,
+		TargetName:          getSnapshotName(sourceClaim, args.TargetClaim),
+		VolumeSnapshotClass: *vsc,
+		OwnershipLabel:      p.OwnershipLabel,
+		BatchID:             getCloneBatchID(args.TargetClaim),
+		Freezer:             freezer,
+		Client:              p.Client,
+		Log:                 args.Log,
+		Recorder:            p.Recorder,
+	}
+
+	sourceClaimForDumbClone, err := createTempSourceClaimFromPVC(ctx, args.DataSource.Namespace, args.TargetClaim, sourceClaim, p.Client)
+	if err != nil {
+		return nil, err
+	}
+	cfsp := &SnapshotClonePhase{
+		Owner:          args.TargetClaim,
+		Namespace:      args.DataSource.Namespace,
+		SourceName:     sp.TargetName,
+		DesiredClaim:   sourceClaimForDumbClone,
+		OwnershipLabel: p.OwnershipLabel,
+		Client:         p.Client,
+		Log:            args.Log,
+		Recorder:       p.Recorder,
+	}
+
+	pcp := &PrepClaimPhase{
+		Owner:           args.TargetClaim,
+		DesiredClaim:    sourceClaimForDumbClone.DeepCopy(),
+		Image:           p.Image,
+		PullPolicy:      p.PullPolicy,
+		InstallerLabels: p.InstallerLabels,
+		OwnershipLabel:  p.OwnershipLabel,
+		Client:          p.Client,
+		Log:             args.Log,
+		Recorder:        p.Recorder,
+	}
+
+	desiredClaim := createDesiredClaim(args.DataSource.Namespace, args.TargetClaim)
+
+	hcp := &HostClonePhase{
+		Owner:          args.TargetClaim,
+		Namespace:      sourceClaimForDumbClone.Namespace,
+		SourceName:     sourceClaimForDumbClone.Name,
+		DesiredClaim:   desiredClaim,
+		ImmediateBind:  true,
+		OwnershipLabel: p.OwnershipLabel,
+		Preallocation:  cc.GetPreallocation(ctx, p.Client, args.DataSource.Spec.Preallocation, args.TargetClaim.Spec.StorageClassName),
+		Client:         p.Client,
+		Log:            args.Log,
+		Recorder:       p.Recorder,
+	}
+
+	if args.DataSource.Spec.PriorityClassName != nil {
+		hcp.PriorityClassName = *args.DataSource.Spec.PriorityClassName
+	}
+
+	rp := &RebindPhase{
+		SourceNamespace: desiredClaim.Namespace,
+		SourceName:      desiredClaim.Name,
+		TargetNamespace: args.TargetClaim.Namespace,
+		TargetName:      args.TargetClaim.Name,
+		Client:          p.Client,
+		Log:             args.Log,
+		Recorder:        p.Recorder,
+	}
+
+	return []Phase{sp, cfsp, pcp, hcp, rp}, nil
+}
+
 func (p *Planner) planHostAssistedFromSnapshot(ctx context.Context, args *PlanArgs) ([]Phase, error) {
 	sourceSnapshot := &snapshotv1.VolumeSnapshot{}
 	exists, err := getResource(ctx, p.Client, args.DataSource.Namespace, args.DataSource.Spec.Source.Name, sourceSnapshot)
@@ -653,6 +883,8 @@ func (p *Planner) planSmartCloneFromSnapshot(ctx context.Context, args *PlanArgs
 		PullPolicy:      p.PullPolicy,
 		InstallerLabels: p.InstallerLabels,
 		OwnershipLabel:  p.OwnershipLabel,
+		ExpandPartition: args.TargetClaim.Annotations[cc.AnnExpandPartition] == "true",
+		ImporterImage:   p.ImporterImage,
 		Client:          p.Client,
 		Log:             args.Log,
 		Recorder:        p.Recorder,
@@ -691,13 +923,20 @@ func (p *Planner) planSnapshotFromPVC(ctx context.Context, args *PlanArgs) ([]Ph
 		return nil, fmt.Errorf("no compatible volumesnapshotclass")
 	}
 
+	freezer, err := p.getFreezer(ctx, args.TargetClaim)
+	if err != nil {
+		return nil, err
+	}
+
 	sp := &SnapshotPhase{
 		Owner:               args.TargetClaim,
 		SourceNamespace:     args.DataSource.Namespace,
 		SourceName:          args.DataSource.Spec.Source.Name,
-		TargetName:          fmt.Sprintf("tmp-snapshot-%s", string(args.TargetClaim.UID)),
+		TargetName:          getSnapshotName(sourceClaim, args.TargetClaim),
 		VolumeSnapshotClass: *vsc,
 		OwnershipLabel:      p.OwnershipLabel,
+		BatchID:             getCloneBatchID(args.TargetClaim),
+		Freezer:             freezer,
 		Client:              p.Client,
 		Log:                 args.Log,
 		Recorder:            p.Recorder,
@@ -722,6 +961,8 @@ func (p *Planner) planSnapshotFromPVC(ctx context.Context, args *PlanArgs) ([]Ph
 		PullPolicy:      p.PullPolicy,
 		InstallerLabels: p.InstallerLabels,
 		OwnershipLabel:  p.OwnershipLabel,
+		ExpandPartition: args.TargetClaim.Annotations[cc.AnnExpandPartition] == "true",
+		ImporterImage:   p.ImporterImage,
 		Client:          p.Client,
 		Log:             args.Log,
 		Recorder:        p.Recorder,
@@ -760,6 +1001,8 @@ func (p *Planner) planCSIClone(ctx context.Context, args *PlanArgs) ([]Phase, er
 		PullPolicy:      p.PullPolicy,
 		InstallerLabels: p.InstallerLabels,
 		OwnershipLabel:  p.OwnershipLabel,
+		ExpandPartition: args.TargetClaim.Annotations[cc.AnnExpandPartition] == "true",
+		ImporterImage:   p.ImporterImage,
 		Client:          p.Client,
 		Log:             args.Log,
 		Recorder:        p.Recorder,
@@ -778,6 +1021,76 @@ func (p *Planner) planCSIClone(ctx context.Context, args *PlanArgs) ([]Phase, er
 	return []Phase{cp, pcp, rp}, nil
 }
 
+// getFreezer returns the Freezer of a consistent clone
+func (p *Planner) getFreezer(ctx context.Context, targetClaim *corev1.PersistentVolumeClaim) (Freezer, error) {
+	if targetClaim.Annotations[cc.AnnConsistentClone] != "true" {
+		return nil, nil
+	}
+
+	return p.newFreezer(ctx)
+}
+
+// newFreezer returns the freeze hook set by the admin in the CDIConfig, the KubeVirt VMI freeze subresources by
+// default. The hook is never taken from the clone, the controller would post to any endpoint its user names.
+func (p *Planner) newFreezer(ctx context.Context) (Freezer, error) {
+	config := &cdiv1.CDIConfig{}
+	exists, err := getResource(ctx, p.Client, "", common.ConfigName, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists && config.Spec.ConsistentCloneHook != nil && *config.Spec.ConsistentCloneHook != "" {
+		return &HookFreezer{URL: *config.Spec.ConsistentCloneHook}, nil
+	}
+
+	return &VMIFreezer{Client: p.Client, Config: p.RESTConfig}, nil
+}
+
+// thawSnapshotSource thaws the workloads using the source of a snapshot deleted while they are frozen
+func (p *Planner) thawSnapshotSource(ctx context.Context, log logr.Logger, owner client.Object, snapshot *snapshotv1.VolumeSnapshot) error {
+	if _, frozen := snapshot.Annotations[annSourceFrozen]; !frozen || snapshot.Spec.Source.PersistentVolumeClaimName == nil {
+		return nil
+	}
+
+	freezer, err := p.newFreezer(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.V(3).Info("Thawing the source of a frozen snapshot", "snapshot", snapshot.Name)
+	if err := thawSource(ctx, p.Client, freezer, snapshot.Namespace, *snapshot.Spec.Source.PersistentVolumeClaimName); err != nil {
+		// Don't block the cleanup, the workloads are thawed by the freeze timeout
+		p.Recorder.Eventf(owner, corev1.EventTypeWarning, ThawFailed, MessageThawFailed, err.Error())
+	}
+
+	return nil
+}
+
+// getSnapshotName returns the name of the temporary snapshot of the source, shared by the members of a clone batch
+func getSnapshotName(sourceClaim, targetClaim *corev1.PersistentVolumeClaim) string {
+	if id := getCloneBatchID(targetClaim); id != "" {
+		return fmt.Sprintf("tmp-snapshot-%s-%s", string(sourceClaim.UID), id)
+	}
+	return fmt.Sprintf("tmp-snapshot-%s", string(targetClaim.UID))
+}
+
+// getForcedCloneStrategy returns the clone strategy forced on the target claim, if valid
+func getForcedCloneStrategy(targetClaim *corev1.PersistentVolumeClaim) *cdiv1.CDICloneStrategy {
+	strategy := cdiv1.CDICloneStrategy(targetClaim.Annotations[cc.AnnCloneStrategy])
+	switch strategy {
+	case cdiv1.CloneStrategyHostAssisted, cdiv1.CloneStrategySnapshot, cdiv1.CloneStrategyCsiClone:
+		return &strategy
+	}
+	return nil
+}
+
+func getCloneBatchID(targetClaim *corev1.PersistentVolumeClaim) string {
+	if batch := targetClaim.Annotations[cc.AnnCloneBatch]; batch != "" {
+		return cloneBatchID(batch)
+	}
+	return ""
+}
+
 func createDesiredClaim(namespace string, targetClaim *corev1.PersistentVolumeClaim) *corev1.PersistentVolumeClaim {
 	targetCpy := targetClaim.DeepCopy()
 	desiredClaim := &corev1.PersistentVolumeClaim{
@@ -800,7 +1113,7 @@ func createTempSourceClaim(ctx context.Context, log logr.Logger, namespace strin
 	if err != nil {
 		return nil, err
 	}
-	scName, err := getStorageClassNameForTempSourceClaim(ctx, vsc, client)
+	scName, err := getStorageClassNameForTempSourceClaim(ctx, vsc.Spec.Driver, client)
 	if err != nil {
 		return nil, err
 	}
@@ -819,9 +1132,45 @@ func createTempSourceClaim(ctx context.Context, log logr.Logger, namespace strin
 		reqSize := targetCpy.Spec.Resources.Requests[corev1.ResourceStorage]
 		restoreSize = &reqSize
 	}
+
+	return newTempSourceClaim(namespace, targetClaim, scName, volumeMode, *restoreSize), nil
+}
+
+// createTempSourceClaimFromPVC creates the claim restored from a snapshot of the source PVC,
+// its request is updated with the snapshot restore size once the snapshot is ready
+func createTempSourceClaimFromPVC(ctx context.Context, namespace string, targetClaim, sourceClaim *corev1.PersistentVolumeClaim, client client.Client) (*corev1.PersistentVolumeClaim, error) {
+	driver, err := GetCommonDriver(ctx, client, sourceClaim)
+	if err != nil {
+		return nil, err
+	}
+	if driver == nil {
+		return nil, fmt.Errorf("unable to find the driver of the source claim")
+	}
+
+	var scName string
+	sc, err := GetStorageClassForClaim(ctx, client, sourceClaim)
+	if err != nil {
+		return nil, err
+	}
+	if sc != nil && sc.Provisioner == *driver {
+		scName = sc.Name
+	} else if scName, err = getStorageClassNameForTempSourceClaim(ctx, *driver, client); err != nil {
+		return nil, err
+	}
+
+	size, ok := sourceClaim.Status.Capacity[corev1.ResourceStorage]
+	if !ok {
+		size = sourceClaim.Spec.Resources.Requests[corev1.ResourceStorage]
+	}
+
+	return newTempSourceClaim(namespace, targetClaim, scName, sourceClaim.Spec.VolumeMode, size), nil
+}
+
+func newTempSourceClaim(namespace string, targetClaim *corev1.PersistentVolumeClaim, scName string, volumeMode *corev1.PersistentVolumeMode, size resource.Quantity) *corev1.PersistentVolumeClaim {
+	targetCpy := targetClaim.DeepCopy()
 	delete(targetCpy.Annotations, cc.AnnSelectedNode)
 
-	desiredClaim := &corev1.PersistentVolumeClaim{
+	return &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:   namespace,
 			Name:        fmt.Sprintf("tmp-source-pvc-%s", string(targetClaim.UID)),
@@ -838,16 +1187,14 @@ func createTempSourceClaim(ctx context.Context, log logr.Logger, namespace strin
 			VolumeMode: volumeMode,
 			Resources: corev1.VolumeResourceRequirements{
 				Requests: corev1.ResourceList{
-					corev1.ResourceStorage: *restoreSize,
+					corev1.ResourceStorage: size,
 				},
 			},
 		},
 	}
-
-	return desiredClaim, nil
 }
 
-func getStorageClassNameForTempSourceClaim(ctx context.Context, vsc *snapshotv1.VolumeSnapshotContent, client client.Client) (string, error) {
+func getStorageClassNameForTempSourceClaim(ctx context.Context, driver string, client client.Client) (string, error) {
 	var matches []string
 
 	// Attempting to get a storageClass compatible with the source snapshot
@@ -856,7 +1203,7 @@ func getStorageClassNameForTempSourceClaim(ctx context.Context, vsc *snapshotv1.
 		return "", err
 	}
 	for _, storageClass := range storageClasses.Items {
-		if storageClass.Provisioner == vsc.Spec.Driver {
+		if storageClass.Provisioner == driver {
 			matches = append(matches, storageClass.Name)
 		}
 	}
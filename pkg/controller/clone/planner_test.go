@@ -18,7 +18,11 @@ package clone
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -41,6 +45,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 )
 
@@ -355,6 +360,32 @@ var _ = Describe("Planner test", func() {
 				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
 				Expect(csr.FallbackReason).ToNot(BeNil())
 				Expect(*csr.FallbackReason).To(Equal(MessageNoVolumeSnapshotClass))
+				Expect(csr.SnapshotSource).To(BeFalse())
+				expectEvent(planner, NoVolumeSnapshotClass)
+			})
+
+			It("should return host assisted from a snapshot of the source if source is different driver", func() {
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  createPVCDataSource(),
+					Log:         log,
+				}
+				sourceClaim := createSourceClaim()
+				sourceClaim.Spec.StorageClassName = ptr.To[string]("foo")
+				sourceVolume := createSourceVolume()
+				sourceVolume.Spec.StorageClassName = "foo"
+				sourceVolume.Spec.PersistentVolumeSource.CSI.Driver = "baz"
+				sourceSnapshotClass := createVolumeSnapshotClass()
+				sourceSnapshotClass.Name = "baz-vsc"
+				sourceSnapshotClass.Driver = "baz"
+				planner = createPlanner(createStorageClass(), createVolumeSnapshotClass(), sourceSnapshotClass, sourceClaim, sourceVolume)
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+				Expect(csr.FallbackReason).ToNot(BeNil())
+				Expect(*csr.FallbackReason).To(Equal(MessageNoVolumeSnapshotClass))
+				Expect(csr.SnapshotSource).To(BeTrue())
 				expectEvent(planner, NoVolumeSnapshotClass)
 			})
 
@@ -524,6 +555,86 @@ var _ = Describe("Planner test", func() {
 				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
 				Expect(csr.FallbackReason).ToNot(BeNil())
 				Expect(*csr.FallbackReason).To(Equal(MessageIncompatibleProvisioners))
+				Expect(csr.SnapshotSource).To(BeFalse())
+			})
+
+			It("should return the fastest measured strategy if the storage profile selects it", func() {
+				cs := cdiv1.CloneStrategySnapshot
+				selection := cdiv1.CloneStrategySelectionMeasured
+				args := &ChooseStrategyArgs{
+					TargetClaim: createTargetClaim(),
+					DataSource:  createPVCDataSource(),
+					Log:         log,
+				}
+				sp := &cdiv1.StorageProfile{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: storageClassName,
+					},
+					Spec: cdiv1.StorageProfileSpec{
+						CloneStrategySelection: &selection,
+					},
+					Status: cdiv1.StorageProfileStatus{
+						CloneStrategy: &cs,
+						CloneStatistics: []cdiv1.CloneStatistics{
+							{SourceStorageClass: storageClassName, Strategy: cdiv1.CloneStrategySnapshot, Count: 5, AverageThroughput: resource.MustParse("100Mi"), LastCompletionTime: metav1.Now()},
+							{SourceStorageClass: storageClassName, Strategy: cdiv1.CloneStrategyHostAssisted, Count: 5, AverageThroughput: resource.MustParse("500Mi"), LastCompletionTime: metav1.Now()},
+							{SourceStorageClass: "other", Strategy: cdiv1.CloneStrategyCsiClone, Count: 5, AverageThroughput: resource.MustParse("1Gi"), LastCompletionTime: metav1.Now()},
+						},
+					},
+				}
+				planner = createPlanner(sp, createStorageClass(), createVolumeSnapshotClass(), createSourceClaim())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+				Expect(csr.FallbackReason).To(BeNil())
+			})
+
+			It("should return the strategy forced on the target over the storage profile", func() {
+				cs := cdiv1.CloneStrategySnapshot
+				target := createTargetClaim()
+				target.Annotations = map[string]string{cc.AnnCloneStrategy: string(cdiv1.CloneStrategyHostAssisted)}
+				args := &ChooseStrategyArgs{
+					TargetClaim: target,
+					DataSource:  createPVCDataSource(),
+					Log:         log,
+				}
+				sp := &cdiv1.StorageProfile{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: storageClassName,
+					},
+					Status: cdiv1.StorageProfileStatus{
+						CloneStrategy: &cs,
+					},
+				}
+				planner = createPlanner(sp, createStorageClass(), createVolumeSnapshotClass(), createSourceClaim())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+				Expect(csr.SnapshotSource).To(BeFalse())
+			})
+
+			It("should return the global override over the strategy forced on the target", func() {
+				cs := cdiv1.CloneStrategyCsiClone
+				target := createTargetClaim()
+				target.Annotations = map[string]string{cc.AnnCloneStrategy: string(cdiv1.CloneStrategyHostAssisted)}
+				args := &ChooseStrategyArgs{
+					TargetClaim: target,
+					DataSource:  createPVCDataSource(),
+					Log:         log,
+				}
+				planner = createPlanner(createStorageClass(), createSourceClaim())
+				cdi := &cdiv1.CDI{}
+				err := planner.Client.Get(context.Background(), client.ObjectKeyFromObject(cc.MakeEmptyCDICR()), cdi)
+				Expect(err).ToNot(HaveOccurred())
+				cdi.Spec.CloneStrategyOverride = &cs
+				err = planner.Client.Update(context.Background(), cdi)
+				Expect(err).ToNot(HaveOccurred())
+				csr, err := planner.ChooseStrategy(context.Background(), args)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(csr).ToNot(BeNil())
+				Expect(csr.Strategy).To(Equal(cdiv1.CloneStrategyCsiClone))
 			})
 		})
 
@@ -709,7 +820,7 @@ var _ = Describe("Planner test", func() {
 			Expect(hc).ToNot(BeNil())
 			Expect(hc.Owner).To(Equal(args.TargetClaim))
 			Expect(hc.Namespace).To(Equal(namespace))
-			if IsDataSourceSnapshot(args.DataSource.Spec.Source.Kind) && args.Strategy == cdiv1.CloneStrategyHostAssisted {
+			if (IsDataSourceSnapshot(args.DataSource.Spec.Source.Kind) || args.SnapshotSource) && args.Strategy == cdiv1.CloneStrategyHostAssisted {
 				Expect(hc.SourceName).To(Equal(tmpSourceClaimName(args.TargetClaim.UID)))
 			} else {
 				Expect(hc.SourceName).To(Equal(sourceName))
@@ -748,7 +859,7 @@ var _ = Describe("Planner test", func() {
 			} else {
 				Expect(scp.SourceName).To(Equal(args.DataSource.Spec.Source.Name))
 			}
-			if IsDataSourceSnapshot(args.DataSource.Spec.Source.Kind) && args.Strategy == cdiv1.CloneStrategyHostAssisted {
+			if args.Strategy == cdiv1.CloneStrategyHostAssisted {
 				Expect(scp.DesiredClaim.Name).To(Equal(tmpSourceClaimName(args.TargetClaim.UID)))
 			} else {
 				Expect(scp.DesiredClaim.Name).To(Equal(tmpClaimName(args.TargetClaim.UID)))
@@ -760,7 +871,7 @@ var _ = Describe("Planner test", func() {
 			pcp := p.(*PrepClaimPhase)
 			Expect(pcp).ToNot(BeNil())
 			Expect(pcp.Owner).To(Equal(args.TargetClaim))
-			if IsDataSourceSnapshot(args.DataSource.Spec.Source.Kind) && args.Strategy == cdiv1.CloneStrategyHostAssisted {
+			if args.Strategy == cdiv1.CloneStrategyHostAssisted {
 				Expect(pcp.DesiredClaim.Name).To(Equal(tmpSourceClaimName(args.TargetClaim.UID)))
 			} else {
 				Expect(pcp.DesiredClaim.Name).To(Equal(tmpClaimName(args.TargetClaim.UID)))
@@ -799,6 +910,48 @@ var _ = Describe("Planner test", func() {
 			validateRebindPhase(planner, args, plan[1])
 		})
 
+		It("should plan host assisted from a snapshot of the source", func() {
+			source := createSourceClaim()
+			source.Spec.VolumeMode = ptr.To[corev1.PersistentVolumeMode](corev1.PersistentVolumeBlock)
+			target := createTargetClaim()
+			args := &PlanArgs{
+				Strategy:       cdiv1.CloneStrategyHostAssisted,
+				SnapshotSource: true,
+				TargetClaim:    target,
+				DataSource:     createPVCDataSource(),
+				Log:            log,
+			}
+			planner = createPlanner(cdiConfig, createStorageClass(), createVolumeSnapshotClass(), source, createSourceVolume())
+			plan, err := planner.Plan(context.Background(), args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).ToNot(BeNil())
+			Expect(plan).To(HaveLen(5))
+			validateSnapshotPhase(planner, args, plan[0])
+			validateSnapshotClonePhase(planner, args, plan[1])
+			validatePrepClaimPhase(planner, args, plan[2])
+			validateHostClonePhase(planner, args, plan[3])
+			validateRebindPhase(planner, args, plan[4])
+			tempSource := plan[1].(*SnapshotClonePhase).DesiredClaim
+			Expect(*tempSource.Spec.StorageClassName).To(Equal(storageClassName))
+			Expect(*tempSource.Spec.VolumeMode).To(Equal(corev1.PersistentVolumeBlock))
+			Expect(tempSource.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(medium))
+		})
+
+		It("should fail planning host assisted from a snapshot of the source without volumesnapshotclass", func() {
+			args := &PlanArgs{
+				Strategy:       cdiv1.CloneStrategyHostAssisted,
+				SnapshotSource: true,
+				TargetClaim:    createTargetClaim(),
+				DataSource:     createPVCDataSource(),
+				Log:            log,
+			}
+			planner = createPlanner(cdiConfig, createStorageClass(), createSourceClaim(), createSourceVolume())
+			plan, err := planner.Plan(context.Background(), args)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("no compatible volumesnapshotclass"))
+			Expect(plan).To(BeNil())
+		})
+
 		It("should plan snapshot", func() {
 			source := createSourceClaim()
 			target := createTargetClaim()
@@ -817,8 +970,59 @@ var _ = Describe("Planner test", func() {
 			validateSnapshotClonePhase(planner, args, plan[1])
 			validatePrepClaimPhase(planner, args, plan[2])
 			validateRebindPhase(planner, args, plan[3])
+			Expect(plan[0].(*SnapshotPhase).Freezer).To(BeNil())
 		})
 
+		It("should plan a snapshot shared by the clone batch", func() {
+			source := createSourceClaim()
+			target := createTargetClaim()
+			cc.AddAnnotation(target, cc.AnnCloneBatch, "golden")
+			args := &PlanArgs{
+				Strategy:    cdiv1.CloneStrategySnapshot,
+				TargetClaim: target,
+				DataSource:  createPVCDataSource(),
+				Log:         log,
+			}
+			planner = createPlanner(cdiConfig, createStorageClass(), createVolumeSnapshotClass(), source)
+			plan, err := planner.Plan(context.Background(), args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).To(HaveLen(4))
+			sp := plan[0].(*SnapshotPhase)
+			Expect(sp.BatchID).To(Equal(cloneBatchID("golden")))
+			Expect(sp.TargetName).To(Equal("tmp-snapshot-" + string(source.UID) + "-" + cloneBatchID("golden")))
+			Expect(plan[1].(*SnapshotClonePhase).SourceName).To(Equal(sp.TargetName))
+		})
+
+		DescribeTable("should plan a consistent snapshot with", func(hook string, expected Freezer) {
+			target := createTargetClaim()
+			cc.AddAnnotation(target, cc.AnnConsistentClone, "true")
+			// The hook is only taken from the CDIConfig
+			cc.AddAnnotation(target, cc.AnnAPIGroup+"/consistentCloneHook", "http://10.0.0.1/freeze")
+			config := cdiConfig.DeepCopy()
+			if hook != "" {
+				config.Spec.ConsistentCloneHook = &hook
+			}
+			args := &PlanArgs{
+				Strategy:    cdiv1.CloneStrategySnapshot,
+				TargetClaim: target,
+				DataSource:  createPVCDataSource(),
+				Log:         log,
+			}
+			planner = createPlanner(config, createStorageClass(), createVolumeSnapshotClass(), createSourceClaim())
+			plan, err := planner.Plan(context.Background(), args)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).To(HaveLen(4))
+			validateSnapshotPhase(planner, args, plan[0])
+			freezer := plan[0].(*SnapshotPhase).Freezer
+			Expect(freezer).To(BeAssignableToTypeOf(expected))
+			if hf, ok := freezer.(*HookFreezer); ok {
+				Expect(hf.URL).To(Equal(hook))
+			}
+		},
+			Entry("the KubeVirt VMI freeze", "", &VMIFreezer{}),
+			Entry("the freeze hook of the CDIConfig", "https://hook.example.com/freeze", &HookFreezer{}),
+		)
+
 		It("should plan csi-clone", func() {
 			source := createSourceClaim()
 			target := createTargetClaim()
@@ -1017,5 +1221,72 @@ var _ = Describe("Planner test", func() {
 				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
 			}
 		})
+
+		It("should delete the snapshot of the clone batch with its last member", func() {
+			target := createTargetClaim()
+			batchSnapshot := &snapshotv1.VolumeSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "batchSnapshot",
+					Labels: map[string]string{
+						cloneBatchLabel: cloneBatchID("golden"),
+						cloneBatchMemberLabelPrefix + string(target.UID): "",
+						cloneBatchMemberLabelPrefix + "other":            "",
+					},
+				},
+			}
+			planner = createPlanner(batchSnapshot)
+			Expect(planner.Cleanup(context.Background(), log, target)).To(Succeed())
+			snapshot := &snapshotv1.VolumeSnapshot{}
+			Expect(planner.Client.Get(context.Background(), client.ObjectKeyFromObject(batchSnapshot), snapshot)).To(Succeed())
+			Expect(getCloneBatchMembers(snapshot)).To(ConsistOf("other"))
+
+			other := createTargetClaim()
+			other.UID = "other"
+			Expect(planner.Cleanup(context.Background(), log, other)).To(Succeed())
+			err := planner.Client.Get(context.Background(), client.ObjectKeyFromObject(batchSnapshot), snapshot)
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should thaw the source of a frozen snapshot before deleting it", func() {
+			var actions []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req := &FreezeHookRequest{}
+				Expect(json.NewDecoder(r.Body).Decode(req)).To(Succeed())
+				actions = append(actions, req.Action+" "+req.ClaimName)
+			}))
+			defer ts.Close()
+
+			target := createTargetClaim()
+			config := &cdiv1.CDIConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: common.ConfigName},
+				Spec:       cdiv1.CDIConfigSpec{ConsistentCloneHook: &ts.URL},
+			}
+			frozenSnapshot := func(name string, labels map[string]string) *snapshotv1.VolumeSnapshot {
+				return &snapshotv1.VolumeSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   namespace,
+						Name:        name,
+						Labels:      labels,
+						Annotations: map[string]string{annSourceFrozen: time.Now().UTC().Format(time.RFC3339)},
+					},
+					Spec: snapshotv1.VolumeSnapshotSpec{
+						Source: snapshotv1.VolumeSnapshotSource{PersistentVolumeClaimName: ptr.To(sourceName)},
+					},
+				}
+			}
+			owned := frozenSnapshot("ownedSnapshot", map[string]string{ownerLabel: string(target.UID)})
+			batch := frozenSnapshot("batchSnapshot", map[string]string{
+				cloneBatchLabel: cloneBatchID("golden"),
+				cloneBatchMemberLabelPrefix + string(target.UID): "",
+			})
+			planner = createPlanner(config, owned, batch)
+			Expect(planner.Cleanup(context.Background(), log, target)).To(Succeed())
+			Expect(actions).To(Equal([]string{"thaw " + sourceName, "thaw " + sourceName}))
+			for _, snapshot := range []client.Object{owned, batch} {
+				err := planner.Client.Get(context.Background(), client.ObjectKeyFromObject(snapshot), snapshot)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}
+		})
 	})
 })
@@ -2,11 +2,14 @@ package clone
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/go-logr/logr"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 
@@ -29,6 +32,8 @@ type PrepClaimPhase struct {
 	PullPolicy      corev1.PullPolicy
 	InstallerLabels map[string]string
 	OwnershipLabel  string
+	ExpandPartition bool
+	ImporterImage   string
 	Client          client.Client
 	Log             logr.Logger
 	Recorder        record.EventRecorder
@@ -99,6 +104,9 @@ func (p *PrepClaimPhase) Reconcile(ctx context.Context) (*reconcile.Result, erro
 	p.Log.V(3).Info("Prep status", "podRequired", podRequired, "podExists", podExists)
 
 	if !podRequired && !podExists {
+		if p.ExpandPartition && actualClaim.Annotations[cc.AnnPartitionExpanded] != "true" {
+			return p.expandPartition(ctx, actualClaim, requestedSize)
+		}
 		// all done finally
 		return nil, nil
 	}
@@ -114,7 +122,14 @@ func (p *PrepClaimPhase) Reconcile(ctx context.Context) (*reconcile.Result, erro
 	if podRequired && !podExists {
 		p.Log.V(3).Info("creating prep pod")
 
-		if err := p.createPod(ctx, podName, actualClaim); err != nil {
+		container := corev1.Container{
+			Name:            "dummy",
+			Image:           p.Image,
+			ImagePullPolicy: p.PullPolicy,
+			Command:         []string{"/bin/bash"},
+			Args:            []string{"-c", "echo", "'hello cdi'"},
+		}
+		if err := p.createPod(ctx, podName, actualClaim, container, common.ClonerMountPath, corev1.RestartPolicyOnFailure); err != nil {
 			return nil, err
 		}
 	}
@@ -123,7 +138,86 @@ func (p *PrepClaimPhase) Reconcile(ctx context.Context) (*reconcile.Result, erro
 	return &reconcile.Result{}, nil
 }
 
-func (p *PrepClaimPhase) createPod(ctx context.Context, name string, pvc *corev1.PersistentVolumeClaim) error {
+// expandPartition runs an importer pod growing the disk in the claim, its last partition and filesystem up to size
+func (p *PrepClaimPhase) expandPartition(ctx context.Context, pvc *corev1.PersistentVolumeClaim, size resource.Quantity) (*reconcile.Result, error) {
+	podName := fmt.Sprintf("expand-%s", string(p.Owner.GetUID()))
+	pod := &corev1.Pod{}
+	podExists, err := getResource(ctx, p.Client, pvc.Namespace, podName, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	if !podExists {
+		p.Log.V(3).Info("creating expand pod")
+
+		fsOverhead, err := cc.GetFilesystemOverheadForStorageClass(ctx, p.Client, pvc.Spec.StorageClassName)
+		if err != nil {
+			return nil, err
+		}
+		if cc.GetVolumeMode(pvc) != corev1.PersistentVolumeFilesystem {
+			fsOverhead = "0"
+		}
+		preallocation := pvc.Annotations[cc.AnnPreallocationRequested] == "true"
+
+		container := corev1.Container{
+			Name:            "expand",
+			Image:           p.ImporterImage,
+			ImagePullPolicy: p.PullPolicy,
+			Args:            []string{"-v=1"},
+			Env: []corev1.EnvVar{
+				{Name: common.ImporterExpandOnly, Value: "true"},
+				{Name: common.ImporterImageSize, Value: size.String()},
+				{Name: common.FilesystemOverheadVar, Value: string(fsOverhead)},
+				{Name: common.Preallocation, Value: strconv.FormatBool(preallocation)},
+			},
+		}
+		if err := p.createPod(ctx, podName, pvc, container, common.ImporterVolumePath, corev1.RestartPolicyNever); err != nil {
+			return nil, err
+		}
+		return &reconcile.Result{}, nil
+	}
+
+	if pod.Status.Phase == corev1.PodFailed {
+		// The pod is kept for inspection, the error sets the running condition of the target
+		return nil, fmt.Errorf("expanding the partition of claim %s/%s failed: %s", pvc.Namespace, pvc.Name, podFailureMessage(pod))
+	}
+
+	if pod.Status.Phase != corev1.PodSucceeded {
+		// pod is running
+		return &reconcile.Result{}, nil
+	}
+
+	p.Log.V(3).Info("Expand pod succeeded, deleting")
+
+	cc.AddAnnotation(pvc, cc.AnnPartitionExpanded, "true")
+	if err := p.Client.Update(ctx, pvc); err != nil {
+		return nil, err
+	}
+
+	if err := p.Client.Delete(ctx, pod); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// podFailureMessage returns the termination message of the failed container of the pod
+func podFailureMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		terminated := status.State.Terminated
+		if terminated == nil || terminated.ExitCode == 0 || terminated.Message == "" {
+			continue
+		}
+		termMsg := &common.TerminationMessage{}
+		if err := json.Unmarshal([]byte(terminated.Message), termMsg); err == nil && termMsg.Message != nil {
+			return *termMsg.Message
+		}
+		return terminated.Message
+	}
+	return "pod " + pod.Name + " failed"
+}
+
+func (p *PrepClaimPhase) createPod(ctx context.Context, name string, pvc *corev1.PersistentVolumeClaim, container corev1.Container, mountPath string, restartPolicy corev1.RestartPolicy) error {
 	resourceRequirements, err := cc.GetDefaultPodResourceRequirements(p.Client)
 	if err != nil {
 		return err
@@ -152,17 +246,9 @@ func (p *PrepClaimPhase) createPod(ctx context.Context, name string, pvc *corev1
 			},
 		},
 		Spec: corev1.PodSpec{
-			Containers: []corev1.Container{
-				{
-					Name:            "dummy",
-					Image:           p.Image,
-					ImagePullPolicy: p.PullPolicy,
-					Command:         []string{"/bin/bash"},
-					Args:            []string{"-c", "echo", "'hello cdi'"},
-				},
-			},
+			Containers:       []corev1.Container{container},
 			ImagePullSecrets: imagePullSecrets,
-			RestartPolicy:    corev1.RestartPolicyOnFailure,
+			RestartPolicy:    restartPolicy,
 			Volumes: []corev1.Volume{
 				{
 					Name: cc.DataVolName,
@@ -186,7 +272,7 @@ func (p *PrepClaimPhase) createPod(ctx context.Context, name string, pvc *corev1
 		pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
 			{
 				Name:      cc.DataVolName,
-				MountPath: common.ClonerMountPath,
+				MountPath: mountPath,
 			},
 		}
 	}
@@ -35,6 +35,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 )
 
@@ -295,5 +296,102 @@ var _ = Describe("PrepClaimPhase test", func() {
 				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
 			})
 		})
+
+		Context("with partition expansion", func() {
+			getExpandPod := func(p *PrepClaimPhase) *corev1.Pod {
+				pod := &corev1.Pod{}
+				key := client.ObjectKey{Namespace: namespace, Name: "expand-" + string(p.Owner.GetUID())}
+				err := p.Client.Get(context.Background(), key, pod)
+				Expect(err).ToNot(HaveOccurred())
+				return pod
+			}
+
+			createExpandPhase := func(claim *corev1.PersistentVolumeClaim, objects ...runtime.Object) *PrepClaimPhase {
+				claim.Spec.Resources.Requests[corev1.ResourceStorage] = defaultRequestSize
+				claim.Status.Capacity[corev1.ResourceStorage] = defaultRequestSize
+				p := createPrepClaimPhase(append(objects, claim)...)
+				p.ExpandPartition = true
+				p.ImporterImage = "importer"
+				return p
+			}
+
+			It("should create expand pod once the claim is resized", func() {
+				claim := getClaim()
+				cc.AddAnnotation(claim, cc.AnnPreallocationRequested, "true")
+				p := createExpandPhase(claim)
+
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).ToNot(BeNil())
+
+				pod := getExpandPod(p)
+				Expect(pod.Labels[p.OwnershipLabel]).To(Equal("uid"))
+				container := pod.Spec.Containers[0]
+				Expect(container.Image).To(Equal("importer"))
+				Expect(container.Env).To(ContainElements(
+					corev1.EnvVar{Name: common.ImporterExpandOnly, Value: "true"},
+					corev1.EnvVar{Name: common.ImporterImageSize, Value: defaultRequestSize.String()},
+					corev1.EnvVar{Name: common.Preallocation, Value: "true"},
+				))
+				Expect(container.VolumeMounts[0].MountPath).To(Equal(common.ImporterVolumePath))
+				Expect(pod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			})
+
+			It("should return the termination message of a failed expand pod", func() {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "expand-uid",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodFailed,
+						ContainerStatuses: []corev1.ContainerStatus{{
+							State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+								ExitCode: 1,
+								Message:  `{"message":"Unable to expand disk: no partition table"}`,
+							}},
+						}},
+					},
+				}
+				p := createExpandPhase(getClaim(), pod)
+
+				result, err := p.Reconcile(context.Background())
+				Expect(err).To(MatchError(ContainSubstring("Unable to expand disk: no partition table")))
+				Expect(result).To(BeNil())
+				Expect(getDesiredClaim(p).Annotations).ToNot(HaveKey(cc.AnnPartitionExpanded))
+
+				err = p.Client.Get(context.Background(), client.ObjectKeyFromObject(pod), pod)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should mark the claim expanded and delete expand pod if succeeded", func() {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "expand-uid",
+					},
+					Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+				}
+				p := createExpandPhase(getClaim(), pod)
+
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeNil())
+				Expect(getDesiredClaim(p).Annotations[cc.AnnPartitionExpanded]).To(Equal("true"))
+
+				err = p.Client.Get(context.Background(), client.ObjectKeyFromObject(pod), pod)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should do nothing if the partition is already expanded", func() {
+				claim := getClaim()
+				cc.AddAnnotation(claim, cc.AnnPartitionExpanded, "true")
+				p := createExpandPhase(claim)
+
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeNil())
+			})
+		})
 	})
 })
@@ -2,22 +2,38 @@ package clone
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 )
 
-// SnapshotPhaseName is the name of the snapshot phase
-const SnapshotPhaseName = "Snapshot"
+const (
+	// SnapshotPhaseName is the name of the snapshot phase
+	SnapshotPhaseName = "Snapshot"
+
+	// annSourceFrozen marks a snapshot taken while the workloads using the source were frozen and not thawed yet,
+	// with the time of the freeze
+	annSourceFrozen = cc.AnnAPIGroup + "/sourceFrozen"
+
+	// maxFreezeDuration is how long the workloads stay frozen waiting for the point in time of the snapshot
+	maxFreezeDuration = time.Minute
+)
 
-// SnapshotPhase snapshots a PVC
+// SnapshotPhase snapshots a PVC, with a Freezer it snapshots an in-use PVC with its workloads frozen.
+// The snapshot of a clone batch (BatchID) is shared by its members and deleted when the last one is done.
 type SnapshotPhase struct {
 	Owner               client.Object
 	SourceNamespace     string
@@ -25,6 +41,8 @@ type SnapshotPhase struct {
 	TargetName          string
 	VolumeSnapshotClass string
 	OwnershipLabel      string
+	BatchID             string
+	Freezer             Freezer
 	Client              client.Client
 	Log                 logr.Logger
 	Recorder            record.EventRecorder
@@ -32,6 +50,8 @@ type SnapshotPhase struct {
 
 var _ Phase = &SnapshotPhase{}
 
+var _ StatusReporter = &SnapshotPhase{}
+
 // Name returns the name of the phase
 func (p *SnapshotPhase) Name() string {
 	return SnapshotPhaseName
@@ -45,11 +65,24 @@ func (p *SnapshotPhase) Reconcile(ctx context.Context) (*reconcile.Result, error
 		return nil, err
 	}
 
+	if exists && p.BatchID != "" {
+		if snapshot.DeletionTimestamp != nil {
+			// Released by the last member of an earlier batch
+			return &reconcile.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+		if addCloneBatchMemberLabel(snapshot, p.Owner) {
+			if err := p.Client.Update(ctx, snapshot); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if !exists {
 		args := &IsSourceClaimReadyArgs{
 			Target:          p.Owner,
 			SourceNamespace: p.SourceNamespace,
 			SourceName:      p.SourceName,
+			AllowInUse:      p.Freezer != nil,
 			Client:          p.Client,
 			Log:             p.Log,
 			Recorder:        p.Recorder,
@@ -65,12 +98,22 @@ func (p *SnapshotPhase) Reconcile(ctx context.Context) (*reconcile.Result, error
 			return &reconcile.Result{RequeueAfter: 2 * time.Second}, nil
 		}
 
-		snapshot, err = p.createSnapshot(ctx)
+		if p.Freezer != nil {
+			snapshot, err = p.createFrozenSnapshot(ctx)
+		} else {
+			snapshot, err = p.createSnapshot(ctx, nil)
+		}
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if _, frozen := snapshot.Annotations[annSourceFrozen]; frozen && p.Freezer != nil {
+		if result, err := p.reconcileFrozen(ctx, snapshot); result != nil || err != nil {
+			return result, err
+		}
+	}
+
 	if snapshot.Status == nil ||
 		snapshot.Status.CreationTime.IsZero() {
 		return &reconcile.Result{}, nil
@@ -79,11 +122,125 @@ func (p *SnapshotPhase) Reconcile(ctx context.Context) (*reconcile.Result, error
 	return nil, nil
 }
 
-func (p *SnapshotPhase) createSnapshot(ctx context.Context) (*snapshotv1.VolumeSnapshot, error) {
+// reconcileFrozen thaws the workloads using the source once the point in time of the snapshot is set. They are
+// also thawed when the snapshot fails or is not taken within maxFreezeDuration, the snapshot is then crash consistent.
+func (p *SnapshotPhase) reconcileFrozen(ctx context.Context, snapshot *snapshotv1.VolumeSnapshot) (*reconcile.Result, error) {
+	status := snapshot.Status
+	switch {
+	case status != nil && !status.CreationTime.IsZero():
+		// The point in time of the snapshot is set, the workloads can go on
+	case status != nil && status.Error != nil:
+		message := "snapshot failed"
+		if status.Error.Message != nil {
+			message = fmt.Sprintf("snapshot failed: %s", *status.Error.Message)
+		}
+		p.Recorder.Eventf(p.Owner, corev1.EventTypeWarning, FreezeFailed, MessageFreezeFailed, message)
+		snapshot.Annotations[cc.AnnCloneApplicationConsistent] = "false"
+	default:
+		frozenAt, err := time.Parse(time.RFC3339, snapshot.Annotations[annSourceFrozen])
+		if remaining := maxFreezeDuration - time.Since(frozenAt); err == nil && remaining > 0 {
+			return &reconcile.Result{RequeueAfter: remaining}, nil
+		}
+		p.Recorder.Eventf(p.Owner, corev1.EventTypeWarning, FreezeFailed, MessageFreezeFailed, "the snapshot was not taken in time")
+		snapshot.Annotations[cc.AnnCloneApplicationConsistent] = "false"
+	}
+
+	if err := p.thaw(ctx); err != nil {
+		return nil, err
+	}
+	delete(snapshot.Annotations, annSourceFrozen)
+	if err := p.Client.Update(ctx, snapshot); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// Status reports whether the snapshot of a consistent clone is application consistent
+func (p *SnapshotPhase) Status(ctx context.Context) (*PhaseStatus, error) {
+	result := &PhaseStatus{}
+	if p.Freezer == nil {
+		return result, nil
+	}
+
+	snapshot := &snapshotv1.VolumeSnapshot{}
+	exists, err := getResource(ctx, p.Client, p.SourceNamespace, p.TargetName, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := snapshot.Annotations[cc.AnnCloneApplicationConsistent]; exists && ok {
+		result.Annotations = map[string]string{
+			cc.AnnCloneApplicationConsistent: v,
+		}
+	}
+
+	return result, nil
+}
+
+// createFrozenSnapshot freezes the workloads using the source, if any, while the snapshot is taken.
+// A source nobody uses is consistent as is, a failed freeze falls back to a crash consistent snapshot.
+func (p *SnapshotPhase) createFrozenSnapshot(ctx context.Context) (*snapshotv1.VolumeSnapshot, error) {
+	pods, err := cc.GetPodsUsingPVCs(ctx, p.Client, p.SourceNamespace, sets.New(p.SourceName), true)
+	if err != nil {
+		return nil, err
+	}
+
+	consistent := true
+	frozen := false
+	if len(pods) > 0 {
+		frozen, err = p.Freezer.Freeze(ctx, p.SourceNamespace, p.SourceName, pods)
+		if err != nil {
+			p.Log.V(1).Info("Failed to freeze the workloads using the source", "error", err.Error())
+			p.Recorder.Eventf(p.Owner, corev1.EventTypeWarning, FreezeFailed, MessageFreezeFailed, err.Error())
+		} else if !frozen {
+			p.Recorder.Eventf(p.Owner, corev1.EventTypeWarning, FreezeFailed, MessageFreezeFailed, "no freeze hook for the pods using the source")
+		}
+		consistent = frozen
+	}
+
+	annotations := map[string]string{
+		cc.AnnCloneApplicationConsistent: strconv.FormatBool(consistent),
+	}
+	if frozen {
+		annotations[annSourceFrozen] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	snapshot, err := p.createSnapshot(ctx, annotations)
+	if err != nil && frozen {
+		if thawErr := p.thaw(ctx); thawErr != nil {
+			return nil, fmt.Errorf("%w, and thaw failed: %v", err, thawErr)
+		}
+	}
+
+	return snapshot, err
+}
+
+func (p *SnapshotPhase) thaw(ctx context.Context) error {
+	if err := thawSource(ctx, p.Client, p.Freezer, p.SourceNamespace, p.SourceName); err != nil {
+		p.Recorder.Eventf(p.Owner, corev1.EventTypeWarning, ThawFailed, MessageThawFailed, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// thawSource thaws the workloads using the source claim
+func thawSource(ctx context.Context, c client.Client, freezer Freezer, namespace, claimName string) error {
+	pods, err := cc.GetPodsUsingPVCs(ctx, c, namespace, sets.New(claimName), true)
+	if err != nil {
+		return err
+	}
+
+	return freezer.Thaw(ctx, namespace, claimName, pods)
+}
+
+func (p *SnapshotPhase) createSnapshot(ctx context.Context, annotations map[string]string) (*snapshotv1.VolumeSnapshot, error) {
 	snapshot := &snapshotv1.VolumeSnapshot{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: p.SourceNamespace,
-			Name:      p.TargetName,
+			Namespace:   p.SourceNamespace,
+			Name:        p.TargetName,
+			Annotations: annotations,
 		},
 		Spec: snapshotv1.VolumeSnapshotSpec{
 			Source: snapshotv1.VolumeSnapshotSource{
@@ -94,7 +251,10 @@ func (p *SnapshotPhase) createSnapshot(ctx context.Context) (*snapshotv1.VolumeS
 	}
 
 	AddCommonLabels(snapshot)
-	if p.OwnershipLabel != "" {
+	if p.BatchID != "" {
+		snapshot.Labels[cloneBatchLabel] = p.BatchID
+		addCloneBatchMemberLabel(snapshot, p.Owner)
+	} else if p.OwnershipLabel != "" {
 		AddOwnershipLabel(p.OwnershipLabel, snapshot, p.Owner)
 	}
 
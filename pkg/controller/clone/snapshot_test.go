@@ -18,6 +18,8 @@ package clone
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -28,6 +30,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -138,6 +142,160 @@ var _ = Describe("SnapshotPhase test", func() {
 			snapshot := getSnapshot(p)
 			Expect(*snapshot.Spec.Source.PersistentVolumeClaimName).To(Equal(sourceName))
 			Expect(*snapshot.Spec.VolumeSnapshotClassName).To(Equal(snapClass))
+			Expect(snapshot.Annotations).ToNot(HaveKey(cc.AnnCloneApplicationConsistent))
+		})
+
+		Context("consistent clone", func() {
+			sourcePod := func() *corev1.Pod {
+				return &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "virt-launcher",
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "compute",
+								VolumeMounts: []corev1.VolumeMount{
+									{
+										Name:      "disk",
+										MountPath: "/disk",
+									},
+								},
+							},
+						},
+						Volumes: []corev1.Volume{
+							{
+								Name: "disk",
+								VolumeSource: corev1.VolumeSource{
+									PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+										ClaimName: sourceName,
+									},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			createConsistentSnapshotPhase := func(freezer *fakeFreezer, objects ...runtime.Object) *SnapshotPhase {
+				p := createSnapshotPhase(objects...)
+				p.Freezer = freezer
+				p.Recorder = record.NewFakeRecorder(10)
+				return p
+			}
+
+			createSnapshotTime := func(p *SnapshotPhase) {
+				snapshot := getSnapshot(p)
+				t := metav1.Now()
+				snapshot.Status = &snapshotv1.VolumeSnapshotStatus{CreationTime: &t}
+				Expect(p.Client.Update(context.Background(), snapshot)).To(Succeed())
+			}
+
+			It("should freeze an in-use source until the snapshot is created", func() {
+				freezer := &fakeFreezer{frozen: true}
+				p := createConsistentSnapshotPhase(freezer, sourceClaim(), sourcePod())
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).ToNot(BeNil())
+				Expect(freezer.calls).To(Equal([]string{"freeze virt-launcher"}))
+				snapshot := getSnapshot(p)
+				Expect(snapshot.Annotations).To(HaveKeyWithValue(cc.AnnCloneApplicationConsistent, "true"))
+				Expect(snapshot.Annotations).To(HaveKey(annSourceFrozen))
+
+				createSnapshotTime(p)
+				result, err = p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeNil())
+				Expect(freezer.calls).To(Equal([]string{"freeze virt-launcher", "thaw virt-launcher"}))
+				Expect(getSnapshot(p).Annotations).ToNot(HaveKey(annSourceFrozen))
+
+				status, err := p.Status(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status.Annotations).To(HaveKeyWithValue(cc.AnnCloneApplicationConsistent, "true"))
+			})
+
+			It("should not freeze a source nobody uses", func() {
+				freezer := &fakeFreezer{frozen: true}
+				p := createConsistentSnapshotPhase(freezer, sourceClaim())
+				_, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(freezer.calls).To(BeEmpty())
+				snapshot := getSnapshot(p)
+				Expect(snapshot.Annotations).To(HaveKeyWithValue(cc.AnnCloneApplicationConsistent, "true"))
+				Expect(snapshot.Annotations).ToNot(HaveKey(annSourceFrozen))
+			})
+
+			It("should take a crash consistent snapshot if the freeze fails", func() {
+				freezer := &fakeFreezer{err: fmt.Errorf("hook down")}
+				p := createConsistentSnapshotPhase(freezer, sourceClaim(), sourcePod())
+				_, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				snapshot := getSnapshot(p)
+				Expect(snapshot.Annotations).To(HaveKeyWithValue(cc.AnnCloneApplicationConsistent, "false"))
+				Expect(snapshot.Annotations).ToNot(HaveKey(annSourceFrozen))
+				event := <-p.Recorder.(*record.FakeRecorder).Events
+				Expect(event).To(ContainSubstring(FreezeFailed))
+
+				createSnapshotTime(p)
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).To(BeNil())
+				Expect(freezer.calls).To(Equal([]string{"freeze virt-launcher"}))
+			})
+
+			It("should keep the source frozen until the freeze deadline", func() {
+				freezer := &fakeFreezer{frozen: true}
+				p := createConsistentSnapshotPhase(freezer, sourceClaim(), sourcePod())
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).ToNot(BeNil())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				Expect(result.RequeueAfter).To(BeNumerically("<=", maxFreezeDuration))
+				Expect(freezer.calls).To(Equal([]string{"freeze virt-launcher"}))
+			})
+
+			It("should thaw the source when the snapshot is not taken before the freeze deadline", func() {
+				freezer := &fakeFreezer{frozen: true}
+				p := createConsistentSnapshotPhase(freezer, sourceClaim(), sourcePod())
+				_, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				snapshot := getSnapshot(p)
+				snapshot.Annotations[annSourceFrozen] = time.Now().Add(-maxFreezeDuration).UTC().Format(time.RFC3339)
+				Expect(p.Client.Update(context.Background(), snapshot)).To(Succeed())
+
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).ToNot(BeNil())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(freezer.calls).To(Equal([]string{"freeze virt-launcher", "thaw virt-launcher"}))
+				snapshot = getSnapshot(p)
+				Expect(snapshot.Annotations).ToNot(HaveKey(annSourceFrozen))
+				Expect(snapshot.Annotations).To(HaveKeyWithValue(cc.AnnCloneApplicationConsistent, "false"))
+				event := <-p.Recorder.(*record.FakeRecorder).Events
+				Expect(event).To(ContainSubstring("the snapshot was not taken in time"))
+			})
+
+			It("should thaw the source when the snapshot fails", func() {
+				freezer := &fakeFreezer{frozen: true}
+				p := createConsistentSnapshotPhase(freezer, sourceClaim(), sourcePod())
+				_, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				snapshot := getSnapshot(p)
+				snapshot.Status = &snapshotv1.VolumeSnapshotStatus{
+					Error: &snapshotv1.VolumeSnapshotError{Message: ptr.To("driver failure")},
+				}
+				Expect(p.Client.Update(context.Background(), snapshot)).To(Succeed())
+
+				_, err = p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(freezer.calls).To(Equal([]string{"freeze virt-launcher", "thaw virt-launcher"}))
+				snapshot = getSnapshot(p)
+				Expect(snapshot.Annotations).ToNot(HaveKey(annSourceFrozen))
+				Expect(snapshot.Annotations).To(HaveKeyWithValue(cc.AnnCloneApplicationConsistent, "false"))
+				event := <-p.Recorder.(*record.FakeRecorder).Events
+				Expect(event).To(ContainSubstring("snapshot failed: driver failure"))
+			})
 		})
 
 		Context("with snapshot", func() {
@@ -176,5 +334,81 @@ var _ = Describe("SnapshotPhase test", func() {
 				Expect(result).To(BeNil())
 			})
 		})
+
+		Context("clone batch", func() {
+			const memberLabel = cloneBatchMemberLabelPrefix + "uid"
+
+			It("should create a snapshot shared by the batch", func() {
+				p := createSnapshotPhase(sourceClaim())
+				p.BatchID = "batch"
+				_, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				snapshot := getSnapshot(p)
+				Expect(snapshot.Labels).To(HaveKeyWithValue(cloneBatchLabel, "batch"))
+				Expect(snapshot.Labels).To(HaveKey(memberLabel))
+				Expect(snapshot.Labels).ToNot(HaveKey("label"))
+			})
+
+			It("should join the snapshot of the batch", func() {
+				s := &snapshotv1.VolumeSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      targetName,
+						Labels: map[string]string{
+							cloneBatchLabel:                       "batch",
+							cloneBatchMemberLabelPrefix + "other": "",
+						},
+					},
+				}
+				p := createSnapshotPhase(s)
+				p.BatchID = "batch"
+				_, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				snapshot := getSnapshot(p)
+				Expect(getCloneBatchMembers(snapshot)).To(ConsistOf("other", "uid"))
+			})
+
+			It("should wait for the snapshot of an earlier batch to go away", func() {
+				t := metav1.Now()
+				s := &snapshotv1.VolumeSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:         namespace,
+						Name:              targetName,
+						DeletionTimestamp: &t,
+						Finalizers:        []string{"snapshot.storage.kubernetes.io/volumesnapshot-as-source-protection"},
+						Labels: map[string]string{
+							cloneBatchLabel: "batch",
+						},
+					},
+				}
+				p := createSnapshotPhase(s)
+				p.BatchID = "batch"
+				result, err := p.Reconcile(context.Background())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result).ToNot(BeNil())
+				Expect(result.RequeueAfter).ToNot(BeZero())
+				Expect(getSnapshot(p).Labels).ToNot(HaveKey(memberLabel))
+			})
+		})
 	})
 })
+
+type fakeFreezer struct {
+	frozen bool
+	err    error
+	calls  []string
+}
+
+func (f *fakeFreezer) Freeze(ctx context.Context, namespace, claimName string, pods []corev1.Pod) (bool, error) {
+	for _, pod := range pods {
+		f.calls = append(f.calls, "freeze "+pod.Name)
+	}
+	return f.frozen, f.err
+}
+
+func (f *fakeFreezer) Thaw(ctx context.Context, namespace, claimName string, pods []corev1.Pod) error {
+	for _, pod := range pods {
+		f.calls = append(f.calls, "thaw "+pod.Name)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-controller"
+)
+
+const (
+	// cloneStatisticsWindow is the number of recent clones the averages are computed over
+	cloneStatisticsWindow = 10
+
+	// minCloneStatisticsCount is the number of completed clones a strategy needs to be chosen by its measured throughput
+	minCloneStatisticsCount = 3
+
+	// maxCloneStatistics is the maximum number of clone statistics of a storage profile
+	maxCloneStatistics = 32
+
+	// cloneStatisticsMaxAge is how long the statistics of a strategy are used without a new clone, the storage
+	// may have changed since, the next clone of the strategy starts new statistics
+	cloneStatisticsMaxAge = 7 * 24 * time.Hour
+
+	// snapshotSourceStrategyLabel is the strategy label of the host assisted clones reading from a snapshot of the source
+	snapshotSourceStrategyLabel = "copy-from-snapshot"
+)
+
+// CompletionArgs are args for RecordCompletion function
+type CompletionArgs struct {
+	Log         logr.Logger
+	TargetClaim *corev1.PersistentVolumeClaim
+	DataSource  *cdiv1.VolumeCloneSource
+	Strategy    cdiv1.CDICloneStrategy
+	// SnapshotSource is set when a host assisted clone read from a snapshot of the source
+	SnapshotSource bool
+	Duration       time.Duration
+}
+
+// RecordCompletion records the duration of a completed clone of a PVC in the clone duration metric
+// and the clone statistics of the StorageProfile of the target
+func (p *Planner) RecordCompletion(ctx context.Context, args *CompletionArgs) error {
+	if !IsDataSourcePVC(args.DataSource.Spec.Source.Kind) || args.TargetClaim.Spec.StorageClassName == nil {
+		return nil
+	}
+
+	sourceClaim := &corev1.PersistentVolumeClaim{}
+	exists, err := getResource(ctx, p.Client, args.DataSource.Namespace, args.DataSource.Spec.Source.Name, sourceClaim)
+	if err != nil || !exists || sourceClaim.Spec.StorageClassName == nil {
+		return err
+	}
+
+	sourceStorageClass := *sourceClaim.Spec.StorageClassName
+	targetStorageClass := *args.TargetClaim.Spec.StorageClassName
+	if args.SnapshotSource {
+		// The duration includes the snapshot and the restore of the source, it does not measure a plain
+		// host assisted clone, which the measured strategy would pick
+		metrics.ObserveCloneDuration(sourceStorageClass, targetStorageClass, snapshotSourceStrategyLabel, args.Duration.Seconds())
+		return nil
+	}
+	metrics.ObserveCloneDuration(sourceStorageClass, targetStorageClass, string(args.Strategy), args.Duration.Seconds())
+
+	size, ok := args.TargetClaim.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok || args.Duration <= 0 {
+		return nil
+	}
+
+	args.Log.V(3).Info("Recording clone duration", "sourceStorageClass", sourceStorageClass,
+		"strategy", args.Strategy, "duration", args.Duration)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sp := &cdiv1.StorageProfile{}
+		exists, err := getResource(ctx, p.Client, metav1.NamespaceNone, targetStorageClass, sp)
+		if err != nil || !exists {
+			return err
+		}
+
+		sp.Status.CloneStatistics = addCloneStatistics(sp.Status.CloneStatistics, sourceStorageClass, args.Strategy, args.Duration, size.Value(), time.Now())
+		return p.Client.Status().Update(ctx, sp)
+	})
+}
+
+// addCloneStatistics adds a clone to the statistics of its source storage class and strategy, the averages
+// follow the recent clones so they adapt to the storage getting faster or slower. Expired statistics are restarted.
+func addCloneStatistics(stats []cdiv1.CloneStatistics, sourceStorageClass string, strategy cdiv1.CDICloneStrategy, duration time.Duration, size int64, now time.Time) []cdiv1.CloneStatistics {
+	throughput := float64(size) / duration.Seconds()
+
+	for i := range stats {
+		s := &stats[i]
+		if s.SourceStorageClass != sourceStorageClass || s.Strategy != strategy {
+			continue
+		}
+
+		if cloneStatisticsExpired(s, now) {
+			s.Count = 0
+		}
+		s.Count++
+		n := s.Count
+		if n > cloneStatisticsWindow {
+			n = cloneStatisticsWindow
+		}
+		avgDuration := s.AverageDuration.Duration + (duration-s.AverageDuration.Duration)/time.Duration(n)
+		avgThroughput := float64(s.AverageThroughput.Value()) + (throughput-float64(s.AverageThroughput.Value()))/float64(n)
+		s.AverageDuration = metav1.Duration{Duration: avgDuration.Round(time.Second)}
+		s.AverageThroughput = *resource.NewQuantity(int64(avgThroughput), resource.BinarySI)
+		s.LastCompletionTime = metav1.NewTime(now)
+		return stats
+	}
+
+	if len(stats) >= maxCloneStatistics {
+		oldest := 0
+		for i := range stats {
+			if stats[i].LastCompletionTime.Before(&stats[oldest].LastCompletionTime) {
+				oldest = i
+			}
+		}
+		stats = append(stats[:oldest], stats[oldest+1:]...)
+	}
+
+	return append(stats, cdiv1.CloneStatistics{
+		SourceStorageClass: sourceStorageClass,
+		Strategy:           strategy,
+		Count:              1,
+		AverageDuration:    metav1.Duration{Duration: duration.Round(time.Second)},
+		AverageThroughput:  *resource.NewQuantity(int64(throughput), resource.BinarySI),
+		LastCompletionTime: metav1.NewTime(now),
+	})
+}
+
+// getMeasuredCloneStrategy returns the strategy with the highest average throughput of the clones
+// from the source storage class, among the strategies with enough recent completed clones
+func getMeasuredCloneStrategy(stats []cdiv1.CloneStatistics, sourceStorageClass string, now time.Time) *cdiv1.CDICloneStrategy {
+	var fastest *cdiv1.CloneStatistics
+	for i := range stats {
+		s := &stats[i]
+		if s.SourceStorageClass != sourceStorageClass || s.Count < minCloneStatisticsCount || cloneStatisticsExpired(s, now) {
+			continue
+		}
+		if fastest == nil || s.AverageThroughput.Cmp(fastest.AverageThroughput) > 0 {
+			fastest = s
+		}
+	}
+
+	if fastest == nil {
+		return nil
+	}
+
+	strategy := fastest.Strategy
+	return &strategy
+}
+
+// cloneStatisticsExpired returns true if the strategy was not used for too long for its statistics to be trusted
+func cloneStatisticsExpired(s *cdiv1.CloneStatistics, now time.Time) bool {
+	return now.Sub(s.LastCompletionTime.Time) > cloneStatisticsMaxAge
+}
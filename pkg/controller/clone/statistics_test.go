@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+var _ = Describe("Clone statistics test", func() {
+	const (
+		namespace = "ns"
+		sourceSC  = "source-sc"
+		targetSC  = "target-sc"
+	)
+
+	log := logf.Log.WithName("statistics-test")
+	now := time.Now()
+
+	It("should average the recent clones of a strategy", func() {
+		stats := addCloneStatistics(nil, sourceSC, cdiv1.CloneStrategySnapshot, 10*time.Second, 10*1024*1024*1024, now)
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].Count).To(Equal(int64(1)))
+		Expect(stats[0].AverageThroughput.Value()).To(Equal(int64(1024 * 1024 * 1024)))
+
+		stats = addCloneStatistics(stats, sourceSC, cdiv1.CloneStrategySnapshot, 30*time.Second, 10*1024*1024*1024, now)
+		Expect(stats).To(HaveLen(1))
+		Expect(stats[0].Count).To(Equal(int64(2)))
+		Expect(stats[0].AverageDuration.Duration).To(Equal(20 * time.Second))
+
+		stats = addCloneStatistics(stats, sourceSC, cdiv1.CloneStrategyHostAssisted, time.Minute, 1024*1024*1024, now)
+		Expect(stats).To(HaveLen(2))
+		Expect(stats[1].Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+	})
+
+	It("should weigh the clones beyond the window like the recent ones", func() {
+		stats := []cdiv1.CloneStatistics{{
+			SourceStorageClass: sourceSC,
+			Strategy:           cdiv1.CloneStrategySnapshot,
+			Count:              100,
+			AverageDuration:    metav1.Duration{Duration: 100 * time.Second},
+			AverageThroughput:  resource.MustParse("100Mi"),
+			LastCompletionTime: metav1.NewTime(now),
+		}}
+		stats = addCloneStatistics(stats, sourceSC, cdiv1.CloneStrategySnapshot, 200*time.Second, 0, now)
+		Expect(stats[0].Count).To(Equal(int64(101)))
+		Expect(stats[0].AverageDuration.Duration).To(Equal(110 * time.Second))
+	})
+
+	It("should restart the expired statistics of a strategy", func() {
+		stats := []cdiv1.CloneStatistics{{
+			SourceStorageClass: sourceSC,
+			Strategy:           cdiv1.CloneStrategySnapshot,
+			Count:              100,
+			AverageDuration:    metav1.Duration{Duration: 100 * time.Second},
+			AverageThroughput:  resource.MustParse("100Mi"),
+			LastCompletionTime: metav1.NewTime(now.Add(-cloneStatisticsMaxAge - time.Hour)),
+		}}
+		stats = addCloneStatistics(stats, sourceSC, cdiv1.CloneStrategySnapshot, 200*time.Second, 0, now)
+		Expect(stats[0].Count).To(Equal(int64(1)))
+		Expect(stats[0].AverageDuration.Duration).To(Equal(200 * time.Second))
+	})
+
+	It("should drop the least recently used statistics", func() {
+		var stats []cdiv1.CloneStatistics
+		for i := 0; i < maxCloneStatistics; i++ {
+			stats = addCloneStatistics(stats, string(rune('a'+i)), cdiv1.CloneStrategySnapshot, time.Second, 1, now.Add(time.Duration(i)*time.Minute))
+		}
+		stats = addCloneStatistics(stats, "new", cdiv1.CloneStrategySnapshot, time.Second, 1, now.Add(time.Hour))
+		Expect(stats).To(HaveLen(maxCloneStatistics))
+		Expect(stats[0].SourceStorageClass).To(Equal("b"))
+		Expect(stats[maxCloneStatistics-1].SourceStorageClass).To(Equal("new"))
+	})
+
+	DescribeTable("should choose the measured strategy", func(stats []cdiv1.CloneStatistics, expected *cdiv1.CDICloneStrategy) {
+		for i := range stats {
+			if stats[i].LastCompletionTime.IsZero() {
+				stats[i].LastCompletionTime = metav1.NewTime(now)
+			}
+		}
+		Expect(getMeasuredCloneStrategy(stats, sourceSC, now)).To(Equal(expected))
+	},
+		Entry("without statistics", nil, nil),
+		Entry("with the highest throughput", []cdiv1.CloneStatistics{
+			{SourceStorageClass: sourceSC, Strategy: cdiv1.CloneStrategySnapshot, Count: 3, AverageThroughput: resource.MustParse("1Gi")},
+			{SourceStorageClass: sourceSC, Strategy: cdiv1.CloneStrategyCsiClone, Count: 3, AverageThroughput: resource.MustParse("2Gi")},
+		}, ptr.To(cdiv1.CloneStrategyCsiClone)),
+		Entry("with enough clones", []cdiv1.CloneStatistics{
+			{SourceStorageClass: sourceSC, Strategy: cdiv1.CloneStrategySnapshot, Count: 3, AverageThroughput: resource.MustParse("1Gi")},
+			{SourceStorageClass: sourceSC, Strategy: cdiv1.CloneStrategyCsiClone, Count: 2, AverageThroughput: resource.MustParse("2Gi")},
+		}, ptr.To(cdiv1.CloneStrategySnapshot)),
+		Entry("with recent clones", []cdiv1.CloneStatistics{
+			{SourceStorageClass: sourceSC, Strategy: cdiv1.CloneStrategySnapshot, Count: 3, AverageThroughput: resource.MustParse("1Gi")},
+			{SourceStorageClass: sourceSC, Strategy: cdiv1.CloneStrategyCsiClone, Count: 3, AverageThroughput: resource.MustParse("2Gi"),
+				LastCompletionTime: metav1.NewTime(now.Add(-cloneStatisticsMaxAge - time.Hour))},
+		}, ptr.To(cdiv1.CloneStrategySnapshot)),
+		Entry("from the source storage class", []cdiv1.CloneStatistics{
+			{SourceStorageClass: "other", Strategy: cdiv1.CloneStrategySnapshot, Count: 3, AverageThroughput: resource.MustParse("1Gi")},
+		}, nil),
+	)
+
+	recordCompletion := func(snapshotSource bool) *cdiv1.StorageProfile {
+		s := scheme.Scheme
+		_ = cdiv1.AddToScheme(s)
+
+		source := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "source"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To(sourceSC),
+			},
+		}
+		target := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "target"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To(targetSC),
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		}
+		sp := &cdiv1.StorageProfile{ObjectMeta: metav1.ObjectMeta{Name: targetSC}}
+		cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(source, sp).WithStatusSubresource(sp).Build()
+		planner := &Planner{Client: cl}
+
+		err := planner.RecordCompletion(context.Background(), &CompletionArgs{
+			Log:         log,
+			TargetClaim: target,
+			DataSource: &cdiv1.VolumeCloneSource{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+				Spec: cdiv1.VolumeCloneSourceSpec{
+					Source: corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "source"},
+				},
+			},
+			Strategy:       cdiv1.CloneStrategyHostAssisted,
+			SnapshotSource: snapshotSource,
+			Duration:       8 * time.Second,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cl.Get(context.Background(), client.ObjectKeyFromObject(sp), sp)).To(Succeed())
+		return sp
+	}
+
+	It("should record the completion of a clone in the storage profile", func() {
+		sp := recordCompletion(false)
+		Expect(sp.Status.CloneStatistics).To(HaveLen(1))
+		stats := sp.Status.CloneStatistics[0]
+		Expect(stats.SourceStorageClass).To(Equal(sourceSC))
+		Expect(stats.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+		Expect(stats.Count).To(Equal(int64(1)))
+		Expect(stats.AverageDuration.Duration).To(Equal(8 * time.Second))
+		Expect(stats.AverageThroughput.Value()).To(Equal(int64(128 * 1024 * 1024)))
+	})
+
+	It("should not record host assisted clones from a snapshot of the source in the storage profile", func() {
+		sp := recordCompletion(true)
+		Expect(sp.Status.CloneStatistics).To(BeEmpty())
+	})
+})
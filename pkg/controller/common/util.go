@@ -21,10 +21,12 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"reflect"
 	"regexp"
 	"sort"
@@ -63,6 +65,7 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/client/clientset/versioned/scheme"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/transfer"
 	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
@@ -115,11 +118,29 @@ const (
 
 	// AnnPopulatorProgress is a standard annotation that can be used progress reporting
 	AnnPopulatorProgress = AnnAPIGroup + "/storage.populator.progress"
+	// AnnPopulatorThroughput reports the data transfer rate of the population in bytes per second
+	AnnPopulatorThroughput = AnnAPIGroup + "/storage.populator.throughput"
+	// AnnPopulatorETA reports the estimated time remaining of the population
+	AnnPopulatorETA = AnnAPIGroup + "/storage.populator.eta"
 
 	// AnnPreallocationRequested provides a const to indicate whether preallocation should be performed on the PV
 	AnnPreallocationRequested = AnnAPIGroup + "/storage.preallocation.requested"
 	// AnnPreallocationApplied provides a const for PVC preallocation annotation
 	AnnPreallocationApplied = AnnAPIGroup + "/storage.preallocation"
+	// AnnExpandPartition provides a const to indicate whether the last partition of the disk and its filesystem should be grown along with the disk image
+	AnnExpandPartition = AnnAPIGroup + "/storage.expandPartition"
+	// AnnPartitionExpanded provides a const to indicate the last partition of the disk of a cloned PVC was grown to the size of the PVC
+	AnnPartitionExpanded = AnnAPIGroup + "/storage.partitionExpanded"
+	// AnnSparsify provides a const to indicate whether the blocks of zeroes of the disk should be deallocated once it is written
+	AnnSparsify = AnnAPIGroup + "/storage.sparsify"
+	// AnnReclaimedBytes reports the size of the blocks of zeroes deallocated by the last sparsify of the disk
+	AnnReclaimedBytes = AnnAPIGroup + "/storage.sparsify.reclaimedBytes"
+	// AnnSparsifyRequest requests the sparsify of the disk of an existing PVC, a new value requests a new sparsify
+	AnnSparsifyRequest = AnnAPIGroup + "/storage.sparsify.request"
+	// AnnSparsifyIdleConfirmed confirms that nothing outside of the cluster uses a ReadWriteMany block PVC, its value is the sparsify request it confirms
+	AnnSparsifyIdleConfirmed = AnnAPIGroup + "/storage.sparsify.idleConfirmed"
+	// AnnSparsifyCompleted is the value of the sparsify request of the PVC last completed
+	AnnSparsifyCompleted = AnnAPIGroup + "/storage.sparsify.completed"
 
 	// AnnRunningCondition provides a const for the running condition
 	AnnRunningCondition = AnnAPIGroup + "/storage.condition.running"
@@ -193,6 +214,40 @@ const (
 	AnnSecretExtraHeaders = AnnAPIGroup + "/storage.import.secretExtraHeaders"
 	// AnnRegistryImageArchitecture provides a const for our PVC registryImageArchitecture annotation
 	AnnRegistryImageArchitecture = AnnAPIGroup + "/storage.import.registryImageArchitecture"
+	// AnnAzureBlobAccount provides a const for our PVC Azure Blob storage account annotation
+	AnnAzureBlobAccount = AnnAPIGroup + "/storage.import.azureBlob.account"
+	// AnnS3Region provides a const for our PVC S3 region annotation
+	AnnS3Region = AnnAPIGroup + "/storage.import.s3.region"
+	// AnnS3AddressingStyle provides a const for our PVC S3 addressing style annotation
+	AnnS3AddressingStyle = AnnAPIGroup + "/storage.import.s3.addressingStyle"
+	// AnnS3RoleARN provides a const for our PVC S3 web identity role annotation
+	AnnS3RoleARN = AnnAPIGroup + "/storage.import.s3.roleARN"
+	// AnnS3WebIdentityAudience provides a const for our PVC S3 web identity token audience annotation
+	AnnS3WebIdentityAudience = AnnAPIGroup + "/storage.import.s3.webIdentityAudience"
+	// AnnS3STSEndpoint provides a const for our PVC S3 web identity STS endpoint annotation
+	AnnS3STSEndpoint = AnnAPIGroup + "/storage.import.s3.stsEndpoint"
+	// AnnGCSWorkloadIdentity provides a const for our PVC GCS workload identity annotation
+	AnnGCSWorkloadIdentity = AnnAPIGroup + "/storage.import.gcs.workloadIdentity"
+	// AnnGCSWorkloadIdentityProvider provides a const for our PVC GCS workload identity provider annotation
+	AnnGCSWorkloadIdentityProvider = AnnAPIGroup + "/storage.import.gcs.workloadIdentityProvider"
+	// AnnGCSServiceAccount provides a const for our PVC GCS impersonated service account annotation
+	AnnGCSServiceAccount = AnnAPIGroup + "/storage.import.gcs.serviceAccount"
+	// AnnGCSWorkloadIdentityAudience provides a const for our PVC GCS workload identity token audience annotation
+	AnnGCSWorkloadIdentityAudience = AnnAPIGroup + "/storage.import.gcs.workloadIdentityAudience"
+	// AnnDifferencingDisks provides a const for our PVC differencingDisks annotation, a JSON list of VHDX differencing disk URLs
+	AnnDifferencingDisks = AnnAPIGroup + "/storage.import.differencingDisks"
+	// AnnCDIBackupManifest provides a const for our PVC annotation indicating the S3 or GCS endpoint is the manifest of a chunked CDI backup
+	AnnCDIBackupManifest = AnnAPIGroup + "/storage.import.cdiBackupManifest"
+	// AnnPatchFor provides a const for our PVC annotation holding the UID of the DataVolume patching the PVC
+	AnnPatchFor = AnnAPIGroup + "/storage.import.patchFor"
+	// AnnPatchOffset provides a const for our PVC patch offset annotation
+	AnnPatchOffset = AnnAPIGroup + "/storage.import.patchOffset"
+	// AnnPatchPartitionLabel provides a const for our PVC patch partition label annotation
+	AnnPatchPartitionLabel = AnnAPIGroup + "/storage.import.patchPartitionLabel"
+	// AnnPatchOriginalAnnotations provides a const for our PVC annotation saving the annotations a patch replaced, restored once the patch is over
+	AnnPatchOriginalAnnotations = AnnAPIGroup + "/storage.patch.originalAnnotations"
+	// AnnCustomizeConfigMap references a ConfigMap that holds the customization recipe run against the imported disk image
+	AnnCustomizeConfigMap = AnnAPIGroup + "/storage.import.customizeConfigMap"
 
 	// AnnCloneToken is the annotation containing the clone token
 	AnnCloneToken = AnnAPIGroup + "/storage.clone.token"
@@ -204,6 +259,16 @@ const (
 	AnnOwnerUID = AnnAPIGroup + "/ownerUID"
 	// AnnCloneType is the comuuted/requested clone type
 	AnnCloneType = AnnAPIGroup + "/cloneType"
+	// AnnCloneStrategy forces the clone strategy of a DataVolume over the StorageProfile clone strategy
+	AnnCloneStrategy = AnnAPIGroup + "/cloneStrategy"
+	// AnnConsistentClone requests a clone freezing the workloads using the source around its snapshot
+	AnnConsistentClone = AnnAPIGroup + "/consistentClone"
+	// AnnCloneBatch groups the clones of a source that read it once for all, by a shared source pod or snapshot
+	AnnCloneBatch = AnnAPIGroup + "/cloneBatch"
+	// AnnCloneBatchTargets lists the target PVCs of a shared clone source pod
+	AnnCloneBatchTargets = AnnAPIGroup + "/cloneBatchTargets"
+	// AnnCloneApplicationConsistent reports whether the snapshot of a consistent clone is application consistent
+	AnnCloneApplicationConsistent = AnnAPIGroup + "/cloneApplicationConsistent"
 	// AnnCloneSourcePod name of the source clone pod
 	AnnCloneSourcePod = AnnAPIGroup + "/storage.sourceClonePodName"
 
@@ -301,6 +366,8 @@ const (
 	SourceS3 = "s3"
 	// SourceGCS is the source type GCS
 	SourceGCS = "gcs"
+	// SourceAzureBlob is the source type Azure Blob Storage
+	SourceAzureBlob = "azure-blob"
 	// SourceGlance is the source type of glance
 	SourceGlance = "glance"
 	// SourceNone means there is no source.
@@ -311,6 +378,8 @@ const (
 	SourceImageio = "imageio"
 	// SourceVDDK is the source type of VDDK
 	SourceVDDK = "vddk"
+	// SourceCDIBackup is the source type of a chunked CDI backup in an S3 compatible object store
+	SourceCDIBackup = "cdi-backup"
 
 	// VolumeSnapshotClassSelected reports that a VolumeSnapshotClass was selected
 	VolumeSnapshotClassSelected = "VolumeSnapshotClassSelected"
@@ -339,6 +408,13 @@ const (
 	//nolint:gosec // These are not credentials
 	LabelDynamicCredentialSupport = "kubevirt.io/dynamic-credentials-support"
 
+	// LabelOperatingSystem is the operating system of the image a DataSource provides, listed in the DataSource catalog
+	LabelOperatingSystem = "cdi.kubevirt.io/os"
+	// LabelArchitecture is the architecture of the image a DataSource provides, listed in the DataSource catalog
+	LabelArchitecture = "cdi.kubevirt.io/architecture"
+	// LabelOperatingSystemVersion is the operating system version of the image a DataSource provides, listed in the DataSource catalog
+	LabelOperatingSystemVersion = "cdi.kubevirt.io/version"
+
 	// LabelExcludeFromVeleroBackup provides a const to indicate whether an object should be excluded from velero backup
 	LabelExcludeFromVeleroBackup = "velero.io/exclude-from-backup"
 
@@ -836,13 +912,20 @@ func IsPopulated(pvc *corev1.PersistentVolumeClaim, c client.Client) (bool, erro
 	})
 }
 
-// GetPreallocation returns the preallocation setting for the specified object (DV or VolumeImportSource), falling back to StorageClass and global setting (in this order)
-func GetPreallocation(ctx context.Context, client client.Client, preallocation *bool) bool {
+// GetPreallocation returns the preallocation setting for the specified object (DV or VolumeImportSource), falling back to the StorageProfile of the storage class and global setting (in this order)
+func GetPreallocation(ctx context.Context, client client.Client, preallocation *bool, storageClassName *string) bool {
 	// First, the DV's preallocation
 	if preallocation != nil {
 		return *preallocation
 	}
 
+	// Then, the StorageProfile default
+	if tuning, err := GetImportTuning(ctx, client, storageClassName); err != nil {
+		klog.Errorf("Unable to get the import tuning of the storage profile, %v\n", err)
+	} else if tuning != nil && tuning.Preallocation != nil {
+		return *tuning.Preallocation
+	}
+
 	cdiconfig := &cdiv1.CDIConfig{}
 	if err := client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiconfig); err != nil {
 		klog.Errorf("Unable to find CDI configuration, %v\n", err)
@@ -852,6 +935,37 @@ func GetPreallocation(ctx context.Context, client client.Client, preallocation *
 	return cdiconfig.Status.Preallocation
 }
 
+// GetSparsify returns the sparsify setting for the specified object (DV or VolumeImportSource), falling back to the StorageProfile of the storage class
+func GetSparsify(ctx context.Context, client client.Client, sparsify *bool, storageClassName *string) bool {
+	if sparsify != nil {
+		return *sparsify
+	}
+
+	if tuning, err := GetImportTuning(ctx, client, storageClassName); err != nil {
+		klog.Errorf("Unable to get the import tuning of the storage profile, %v\n", err)
+	} else if tuning != nil && tuning.Sparsify != nil {
+		return *tuning.Sparsify
+	}
+
+	return false
+}
+
+// GetImportTuning returns the import tuning of the StorageProfile of the storage class, nil if there is none.
+// If storageClassName is nil, the default storage class is used.
+func GetImportTuning(ctx context.Context, client client.Client, storageClassName *string) (*cdiv1.ImportTuning, error) {
+	storageClass, err := GetStorageClassByNameWithK8sFallback(ctx, client, storageClassName)
+	if err != nil || storageClass == nil {
+		return nil, err
+	}
+
+	storageProfile := &cdiv1.StorageProfile{}
+	if err := client.Get(ctx, types.NamespacedName{Name: storageClass.Name}, storageProfile); err != nil {
+		return nil, IgnoreNotFound(err)
+	}
+
+	return storageProfile.Status.ImportTuning, nil
+}
+
 // ImmediateBindingRequested returns if an object has the ImmediateBinding annotation
 func ImmediateBindingRequested(obj metav1.Object) bool {
 	_, isImmediateBindingRequested := obj.GetAnnotations()[AnnImmediateBinding]
@@ -1043,7 +1157,7 @@ func HandleFailedPod(err error, podName string, pvc *corev1.PersistentVolumeClai
 
 	recorder.Event(pvc, corev1.EventTypeWarning, reason, msg)
 
-	if isCloneSourcePod := CreateCloneSourcePodName(pvc) == podName; isCloneSourcePod {
+	if isCloneSourcePod := CreateCloneSourcePodName(pvc) == podName || pvc.Annotations[AnnCloneSourcePod] == podName; isCloneSourcePod {
 		AddAnnotation(pvc, AnnSourceRunningCondition, "false")
 		AddAnnotation(pvc, AnnSourceRunningConditionReason, reason)
 		AddAnnotation(pvc, AnnSourceRunningConditionMessage, msg)
@@ -1072,11 +1186,13 @@ func GetSource(pvc *corev1.PersistentVolumeClaim) string {
 		SourceHTTP,
 		SourceS3,
 		SourceGCS,
+		SourceAzureBlob,
 		SourceGlance,
 		SourceNone,
 		SourceRegistry,
 		SourceImageio,
-		SourceVDDK:
+		SourceVDDK,
+		SourceCDIBackup:
 	default:
 		source = SourceHTTP
 	}
@@ -1658,8 +1774,32 @@ func GetMetricsURL(pod *corev1.Pod) (string, error) {
 	return url, nil
 }
 
+// ProgressReport is the data transfer progress reported by the metrics of a worker pod, empty fields were not reported
+type ProgressReport struct {
+	// Progress is the percentage of the data transferred
+	Progress string
+	// Throughput is the data transfer rate in bytes per second
+	Throughput string
+	// ETA is the estimated time remaining in seconds
+	ETA string
+	// TransferredBytes is the number of bytes transferred
+	TransferredBytes string
+	// TotalBytes is the number of bytes to transfer
+	TotalBytes string
+}
+
 // GetProgressReportFromURL fetches the progress report from the passed URL according to an specific metric expression and ownerUID
 func GetProgressReportFromURL(ctx context.Context, url string, httpClient *http.Client, metricExp, ownerUID string) (string, error) {
+	report, err := GetTransferReportFromURL(ctx, url, httpClient, metricExp, ownerUID)
+	if err != nil {
+		return "", err
+	}
+	return report.Progress, nil
+}
+
+// GetTransferReportFromURL fetches the progress, matching the metric expression, the throughput and the estimated time
+// remaining of the ownerUID from the passed URL
+func GetTransferReportFromURL(ctx context.Context, url string, httpClient *http.Client, metricExp, ownerUID string) (*ProgressReport, error) {
 	regExp := regexp.MustCompile(fmt.Sprintf("(%s)\\{ownerUID\\=%q\\} (\\d{1,3}\\.?\\d*)", metricExp, ownerUID))
 	// pod could be gone, don't block an entire thread for 30 seconds
 	// just to get back an i/o timeout
@@ -1667,28 +1807,110 @@ func GetProgressReportFromURL(ctx context.Context, url string, httpClient *http.
 	defer cancel()
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		if ErrConnectionRefused(err) {
-			return "", nil
+			return &ProgressReport{}, nil
 		}
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Parse the progress from the body
-	progressReport := ""
+	report := &ProgressReport{}
 	match := regExp.FindStringSubmatch(string(body))
 	if match != nil {
-		progressReport = match[len(match)-1]
+		report.Progress = match[len(match)-1]
+	}
+	report.Throughput = findMetricValue(body, transfer.ThroughputMetricName, ownerUID)
+	report.ETA = findMetricValue(body, transfer.ETAMetricName, ownerUID)
+	report.TransferredBytes = findMetricValue(body, transfer.TransferredBytesMetricName, ownerUID)
+	report.TotalBytes = findMetricValue(body, transfer.TotalBytesMetricName, ownerUID)
+	return report, nil
+}
+
+func findMetricValue(body []byte, metricName, ownerUID string) string {
+	regExp := regexp.MustCompile(fmt.Sprintf("%s\\{ownerUID\\=%q\\} ([0-9.eE+-]+)", metricName, ownerUID))
+	if match := regExp.FindSubmatch(body); match != nil {
+		return string(match[1])
+	}
+	return ""
+}
+
+// TransferStats returns the throughput and the estimated time remaining of the report, nil if not reported
+func (report *ProgressReport) TransferStats() (*resource.Quantity, *metav1.Duration) {
+	var throughput *resource.Quantity
+	var eta *metav1.Duration
+	if f, err := strconv.ParseFloat(report.Throughput, 64); err == nil && f >= 0 {
+		throughput = resource.NewQuantity(int64(f), resource.BinarySI)
+	}
+	if f, err := strconv.ParseFloat(report.ETA, 64); err == nil && f >= 0 {
+		eta = &metav1.Duration{Duration: time.Duration(f) * time.Second}
+	}
+	return throughput, eta
+}
+
+// ByteCounts returns the transferred and the total bytes of the report, nil if not reported
+func (report *ProgressReport) ByteCounts() (*resource.Quantity, *resource.Quantity) {
+	parse := func(value string) *resource.Quantity {
+		if f, err := strconv.ParseFloat(value, 64); err == nil && f >= 0 {
+			return resource.NewQuantity(int64(f), resource.BinarySI)
+		}
+		return nil
+	}
+	return parse(report.TransferredBytes), parse(report.TotalBytes)
+}
+
+// UpdateTransferAnnotations sets the throughput and estimated time remaining annotations of a population from the
+// progress report
+func UpdateTransferAnnotations(obj metav1.Object, report *ProgressReport) {
+	throughput, eta := report.TransferStats()
+	if throughput != nil {
+		AddAnnotation(obj, AnnPopulatorThroughput, throughput.String())
+	}
+	if eta != nil {
+		AddAnnotation(obj, AnnPopulatorETA, eta.Duration.String())
 	}
-	return progressReport, nil
+}
+
+// ClearTransferAnnotations removes the throughput and estimated time remaining annotations of a completed population
+func ClearTransferAnnotations(obj metav1.Object) {
+	annotations := obj.GetAnnotations()
+	delete(annotations, AnnPopulatorThroughput)
+	delete(annotations, AnnPopulatorETA)
+}
+
+// GetTransferStats returns the throughput and estimated time remaining of a population from its annotations
+func GetTransferStats(obj metav1.Object) (*resource.Quantity, *metav1.Duration) {
+	var throughput *resource.Quantity
+	var eta *metav1.Duration
+	if value, ok := obj.GetAnnotations()[AnnPopulatorThroughput]; ok {
+		if q, err := resource.ParseQuantity(value); err == nil {
+			throughput = &q
+		}
+	}
+	if value, ok := obj.GetAnnotations()[AnnPopulatorETA]; ok {
+		if d, err := time.ParseDuration(value); err == nil {
+			eta = &metav1.Duration{Duration: d}
+		}
+	}
+	return throughput, eta
+}
+
+// GetReclaimedSpace returns the size of the blocks of zeroes deallocated by the last sparsify of the disk of the object, nil if it was never sparsified
+func GetReclaimedSpace(obj metav1.Object) *resource.Quantity {
+	if value, ok := obj.GetAnnotations()[AnnReclaimedBytes]; ok {
+		if q, err := resource.ParseQuantity(value); err == nil {
+			return &q
+		}
+	}
+	return nil
 }
 
 // UpdateHTTPAnnotations updates the passed annotations for proper http import
@@ -1708,6 +1930,12 @@ func UpdateHTTPAnnotations(annotations map[string]string, http *cdiv1.DataVolume
 	for index, header := range http.SecretExtraHeaders {
 		annotations[fmt.Sprintf("%s.%d", AnnSecretExtraHeaders, index)] = header
 	}
+	if len(http.DifferencingDisks) > 0 {
+		// The order of the chain matters, so unlike the headers the disks are kept in a single annotation
+		if disks, err := json.Marshal(http.DifferencingDisks); err == nil {
+			annotations[AnnDifferencingDisks] = string(disks)
+		}
+	}
 }
 
 // UpdateS3Annotations updates the passed annotations for proper S3 import
@@ -1720,6 +1948,33 @@ func UpdateS3Annotations(annotations map[string]string, s3 *cdiv1.DataVolumeSour
 	if s3.CertConfigMap != "" {
 		annotations[AnnCertConfigMap] = s3.CertConfigMap
 	}
+	if s3.Region != "" {
+		annotations[AnnS3Region] = s3.Region
+	}
+	if s3.AddressingStyle != "" {
+		annotations[AnnS3AddressingStyle] = string(s3.AddressingStyle)
+	}
+	if identity := s3.WebIdentity; identity != nil {
+		annotations[AnnS3RoleARN] = identity.RoleARN
+		if identity.Audience != "" {
+			annotations[AnnS3WebIdentityAudience] = identity.Audience
+		}
+		if identity.STSEndpoint != "" {
+			annotations[AnnS3STSEndpoint] = identity.STSEndpoint
+		}
+	}
+}
+
+// UpdateCDIBackupAnnotations updates the passed annotations for proper chunked CDI backup import
+func UpdateCDIBackupAnnotations(annotations map[string]string, backup *cdiv1.DataVolumeSourceCDIBackup) {
+	annotations[AnnEndpoint] = backup.URL
+	annotations[AnnSource] = SourceCDIBackup
+	if backup.SecretRef != "" {
+		annotations[AnnSecret] = backup.SecretRef
+	}
+	if backup.CertConfigMap != "" {
+		annotations[AnnCertConfigMap] = backup.CertConfigMap
+	}
 }
 
 // UpdateGCSAnnotations updates the passed annotations for proper GCS import
@@ -1729,6 +1984,44 @@ func UpdateGCSAnnotations(annotations map[string]string, gcs *cdiv1.DataVolumeSo
 	if gcs.SecretRef != "" {
 		annotations[AnnSecret] = gcs.SecretRef
 	}
+	if identity := gcs.WorkloadIdentity; identity != nil {
+		annotations[AnnGCSWorkloadIdentity] = "true"
+		if identity.Provider != "" {
+			annotations[AnnGCSWorkloadIdentityProvider] = identity.Provider
+		}
+		if identity.ServiceAccount != "" {
+			annotations[AnnGCSServiceAccount] = identity.ServiceAccount
+		}
+		if identity.Audience != "" {
+			annotations[AnnGCSWorkloadIdentityAudience] = identity.Audience
+		}
+	}
+}
+
+// UpdateAzureBlobAnnotations updates the passed annotations for proper Azure Blob import
+func UpdateAzureBlobAnnotations(annotations map[string]string, blob *cdiv1.DataVolumeSourceAzureBlob) {
+	annotations[AnnEndpoint] = GetAzureBlobURL(blob)
+	annotations[AnnSource] = SourceAzureBlob
+	annotations[AnnAzureBlobAccount] = blob.Account
+	if blob.SecretRef != "" {
+		annotations[AnnSecret] = blob.SecretRef
+	}
+	if blob.CertConfigMap != "" {
+		annotations[AnnCertConfigMap] = blob.CertConfigMap
+	}
+}
+
+// GetAzureBlobURL returns the url of the blob of an Azure Blob source
+func GetAzureBlobURL(blob *cdiv1.DataVolumeSourceAzureBlob) string {
+	endpoint := blob.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", blob.Account)
+	}
+	segments := strings.Split(blob.Blob, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(endpoint, "/"), url.PathEscape(blob.Container), strings.Join(segments, "/"))
 }
 
 // UpdateRegistryAnnotations updates the passed annotations for proper registry import
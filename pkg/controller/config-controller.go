@@ -478,6 +478,7 @@ func (r *CDIConfigReconciler) reconcileDefaultPodResourceRequirements(config *cd
 func (r *CDIConfigReconciler) reconcileFilesystemOverhead(config *cdiv1.CDIConfig) error {
 	var globalOverhead cdiv1.Percent = common.DefaultGlobalOverhead
 	var perStorageConfig = make(map[string]cdiv1.Percent)
+	applyCalibration := false
 
 	log := r.log.WithName("CDIconfig").WithName("FilesystemOverhead")
 
@@ -498,6 +499,7 @@ func (r *CDIConfigReconciler) reconcileFilesystemOverhead(config *cdiv1.CDIConfi
 		if config.Spec.FilesystemOverhead.StorageClass != nil {
 			perStorageConfig = config.Spec.FilesystemOverhead.StorageClass
 		}
+		applyCalibration = config.Spec.FilesystemOverhead.ApplyCalibration
 	}
 
 	// Set status global overhead
@@ -509,9 +511,12 @@ func (r *CDIConfigReconciler) reconcileFilesystemOverhead(config *cdiv1.CDIConfi
 		return err
 	}
 	config.Status.FilesystemOverhead.StorageClass = make(map[string]cdiv1.Percent)
+	storageClasses := make(map[string]struct{})
 	for _, storageClass := range storageClassList.Items {
 		storageClassName := storageClass.GetName()
+		storageClasses[storageClassName] = struct{}{}
 		storageClassNameOverhead, found := perStorageConfig[storageClassName]
+		calibration, calibrated := config.Status.FilesystemOverheadCalibration[storageClassName]
 
 		if found {
 			valid, err := validOverhead(storageClassNameOverhead)
@@ -519,11 +524,20 @@ func (r *CDIConfigReconciler) reconcileFilesystemOverhead(config *cdiv1.CDIConfi
 				return err
 			}
 			config.Status.FilesystemOverhead.StorageClass[storageClassName] = storageClassNameOverhead
+		} else if valid, _ := validOverhead(calibration.Recommended); applyCalibration && calibrated && valid {
+			config.Status.FilesystemOverhead.StorageClass[storageClassName] = calibration.Recommended
 		} else {
 			config.Status.FilesystemOverhead.StorageClass[storageClassName] = globalOverhead
 		}
 	}
 
+	// Forget the measurements of deleted storage classes, a new one with the same name may be backed differently
+	for storageClassName := range config.Status.FilesystemOverheadCalibration {
+		if _, found := storageClasses[storageClassName]; !found {
+			delete(config.Status.FilesystemOverheadCalibration, storageClassName)
+		}
+	}
+
 	return nil
 }
 
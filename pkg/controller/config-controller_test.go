@@ -415,6 +415,47 @@ var _ = Describe("Controller storage class reconcile loop", func() {
 	})
 })
 
+var _ = Describe("Controller filesystem overhead reconcile loop", func() {
+	calibration := func(recommended cdiv1.Percent) cdiv1.FilesystemOverheadCalibration {
+		return cdiv1.FilesystemOverheadCalibration{Recommended: recommended, Samples: 1, LastMeasurementTime: metav1.Now()}
+	}
+
+	It("Should not apply the calibrated overhead unless asked to", func() {
+		reconciler, cdiConfig := createConfigReconciler(createStorageClassList(*CreateStorageClass("test-sc", nil)))
+		cdiConfig.Status.FilesystemOverheadCalibration = map[string]cdiv1.FilesystemOverheadCalibration{"test-sc": calibration("0.02")}
+		err := reconciler.reconcileFilesystemOverhead(cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cdiConfig.Status.FilesystemOverhead.StorageClass).To(HaveKeyWithValue("test-sc", cdiv1.Percent(common.DefaultGlobalOverhead)))
+		Expect(cdiConfig.Status.FilesystemOverheadCalibration).To(HaveKey("test-sc"))
+	})
+
+	It("Should apply the calibrated overhead to storage classes without a specific value", func() {
+		reconciler, cdiConfig := createConfigReconciler(createStorageClassList(
+			*CreateStorageClass("test-sc", nil),
+			*CreateStorageClass("test-sc2", nil),
+			*CreateStorageClass("test-sc3", nil),
+		))
+		cdiConfig.Spec.FilesystemOverhead = &cdiv1.FilesystemOverhead{
+			Global:           "0.1",
+			StorageClass:     map[string]cdiv1.Percent{"test-sc2": "0.2"},
+			ApplyCalibration: true,
+		}
+		cdiConfig.Status.FilesystemOverheadCalibration = map[string]cdiv1.FilesystemOverheadCalibration{
+			"test-sc":  calibration("0.02"),
+			"test-sc2": calibration("0.03"),
+			"deleted":  calibration("0.04"),
+		}
+		err := reconciler.reconcileFilesystemOverhead(cdiConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cdiConfig.Status.FilesystemOverhead.StorageClass).To(Equal(map[string]cdiv1.Percent{
+			"test-sc":  "0.02",
+			"test-sc2": "0.2",
+			"test-sc3": "0.1",
+		}))
+		Expect(cdiConfig.Status.FilesystemOverheadCalibration).ToNot(HaveKey("deleted"))
+	})
+})
+
 var _ = Describe("Controller ImportProxy reconcile loop", func() {
 	It("Should set ImportProxy to nil if no proxy configuration for import proxy exists", func() {
 		reconciler, cdiConfig := createConfigReconciler()
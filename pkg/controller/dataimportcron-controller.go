@@ -606,7 +606,7 @@ func (r *DataImportCronReconciler) deleteErroneousDataVolume(ctx context.Context
 	log := r.log.WithValues("name", dv.Name).WithValues("uid", dv.UID)
 	if cond := dvc.FindConditionByType(cdiv1.DataVolumeRunning, dv.Status.Conditions); cond != nil {
 		if cond.Status == corev1.ConditionFalse &&
-			(cond.Reason == common.GenericError || cond.Reason == ImagePullFailedReason) {
+			(cond.Reason == common.GenericError || cond.Reason == ImagePullFailedReason || common.IsFailureReason(cond.Reason)) {
 			log.Info("Delete DataVolume and reset DesiredDigest due to error", "message", cond.Message)
 			// Unlabel the DV before deleting it, to eliminate reconcile before DIC is updated
 			dv.Labels[common.DataImportCronLabel] = ""
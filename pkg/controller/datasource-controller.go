@@ -28,12 +28,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -42,7 +44,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
 )
 
 // DataSourceReconciler members
@@ -61,6 +66,9 @@ const (
 	maxReferenceDepthReached = "MaxReferenceDepthReached"
 	selfReference            = "SelfReference"
 	crossNamespaceReference  = "CrossNamespaceReference"
+	noCacheStorage           = "NoCacheStorage"
+	cacheOutdated            = "CacheOutdated"
+	dataSourceCacheSuffix    = "cache"
 
 	dataSourcePvcField        = "spec.source.pvc"
 	dataSourceSnapshotField   = "spec.source.snapshot"
@@ -108,6 +116,10 @@ func (r *DataSourceReconciler) update(ctx context.Context, dataSource *cdiv1.Dat
 		if err := r.handleSnapshotSource(ctx, resolved.Spec.Source.Snapshot, dataSource); err != nil {
 			return err
 		}
+	case cdiv1.IsDataSourceImportSource(&resolved.Spec.Source):
+		if err := r.handleImportSource(ctx, resolved, dataSource); err != nil {
+			return err
+		}
 	default:
 		updateDataSourceCondition(dataSource, cdiv1.DataSourceReady, corev1.ConditionFalse, "No source PVC set", noSource)
 	}
@@ -179,6 +191,85 @@ func (r *DataSourceReconciler) handleSnapshotSource(ctx context.Context, sourceS
 	return nil
 }
 
+// handleImportSource imports the external source of the resolved DataSource once into a cache DataVolume
+// in the resolved DataSource namespace, and serves the cache PVC as the DataSource source
+func (r *DataSourceReconciler) handleImportSource(ctx context.Context, resolved, dataSource *cdiv1.DataSource) error {
+	cachePvc := &cdiv1.DataVolumeSourcePVC{Namespace: resolved.Namespace, Name: getDataSourceCacheName(resolved)}
+	dataSource.Status.Source = cdiv1.DataSourceSource{PVC: cachePvc}
+
+	// Only the DataSource holding the external source owns the cache, referencing DataSources just follow it
+	if resolved.Namespace == dataSource.Namespace && resolved.Name == dataSource.Name {
+		if dataSource.Spec.Storage == nil {
+			updateDataSourceCondition(dataSource, cdiv1.DataSourceReady, corev1.ConditionFalse, "No storage set for the cache PVC", noCacheStorage)
+			return nil
+		}
+		upToDate, err := r.reconcileCacheDataVolume(ctx, dataSource)
+		if err != nil {
+			return err
+		}
+		if !upToDate {
+			updateDataSourceCondition(dataSource, cdiv1.DataSourceReady, corev1.ConditionFalse, "Cache DataVolume is outdated, reimporting", cacheOutdated)
+			return nil
+		}
+	}
+
+	return r.handlePvcSource(ctx, cachePvc, dataSource)
+}
+
+// reconcileCacheDataVolume creates the cache DataVolume of an import DataSource, and deletes it when the source was
+// changed so it is reimported. Returns false if the existing cache DataVolume is outdated.
+func (r *DataSourceReconciler) reconcileCacheDataVolume(ctx context.Context, dataSource *cdiv1.DataSource) (bool, error) {
+	desired := r.newCacheDataVolume(dataSource)
+	dv := &cdiv1.DataVolume{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), dv); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		if err := controllerutil.SetControllerReference(dataSource, desired, r.scheme); err != nil {
+			return false, err
+		}
+		r.log.Info("Creating cache DataVolume", "name", desired.Name, "namespace", desired.Namespace)
+		if err := r.client.Create(ctx, desired); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if !metav1.IsControlledBy(dv, dataSource) || reflect.DeepEqual(dv.Spec.Source, desired.Spec.Source) {
+		return true, nil
+	}
+	r.log.Info("Deleting outdated cache DataVolume", "name", dv.Name, "namespace", dv.Namespace)
+	if err := r.client.Delete(ctx, dv); err != nil && !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+	return false, nil
+}
+
+func (r *DataSourceReconciler) newCacheDataVolume(dataSource *cdiv1.DataSource) *cdiv1.DataVolume {
+	source := dataSource.Spec.Source.DeepCopy()
+	dv := &cdiv1.DataVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getDataSourceCacheName(dataSource),
+			Namespace: dataSource.Namespace,
+		},
+		Spec: cdiv1.DataVolumeSpec{
+			Source: &cdiv1.DataVolumeSource{
+				HTTP:     source.HTTP,
+				Registry: source.Registry,
+				S3:       source.S3,
+			},
+			Storage: dataSource.Spec.Storage.DeepCopy(),
+		},
+	}
+	util.SetRecommendedLabels(dv, r.installerLabels, common.CDIControllerName)
+	cc.AddAnnotation(dv, cc.AnnImmediateBinding, "true")
+	return dv
+}
+
+func getDataSourceCacheName(dataSource *cdiv1.DataSource) string {
+	return naming.GetResourceName(dataSource.Name, dataSourceCacheSuffix)
+}
+
 func handleDataSourceRefError(dataSource *cdiv1.DataSource, err error) error {
 	reason := ""
 	switch {
@@ -252,10 +343,14 @@ func addDataSourceControllerWatches(mgr manager.Manager, c controller.Controller
 
 func setupIndexers(mgr manager.Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &cdiv1.DataSource{}, dataSourcePvcField, func(obj client.Object) []string {
-		if pvc := obj.(*cdiv1.DataSource).Spec.Source.PVC; pvc != nil {
+		dataSource := obj.(*cdiv1.DataSource)
+		if pvc := dataSource.Spec.Source.PVC; pvc != nil {
 			ns := cc.GetNamespace(pvc.Namespace, obj.GetNamespace())
 			return []string{types.NamespacedName{Name: pvc.Name, Namespace: ns}.String()}
 		}
+		if cdiv1.IsDataSourceImportSource(&dataSource.Spec.Source) {
+			return []string{types.NamespacedName{Name: getDataSourceCacheName(dataSource), Namespace: obj.GetNamespace()}.String()}
+		}
 		return nil
 	}); err != nil {
 		return err
@@ -404,6 +499,9 @@ func sameSourceSpec(objOld, objNew client.Object) bool {
 	if dsOld.Spec.Source.DataSource != nil {
 		return reflect.DeepEqual(dsOld.Spec.Source.DataSource, dsNew.Spec.Source.DataSource)
 	}
+	if cdiv1.IsDataSourceImportSource(&dsOld.Spec.Source) {
+		return reflect.DeepEqual(dsOld.Spec, dsNew.Spec)
+	}
 
 	return false
 }
@@ -24,6 +24,9 @@ import (
 
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -191,6 +194,96 @@ var _ = Describe("All DataSource Tests", func() {
 			),
 		)
 
+		Describe("DataSource with external import source", func() {
+			const testURL = "http://example.com/disk.qcow2"
+
+			createImportDataSource := func() *cdiv1.DataSource {
+				ds := createDataSource(dsName)
+				ds.Spec.Source.HTTP = &cdiv1.DataVolumeSourceHTTP{URL: testURL}
+				ds.Spec.Storage = &cdiv1.StorageSpec{
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+					},
+				}
+				return ds
+			}
+
+			getCacheDataVolume := func(reconciler *DataSourceReconciler, ds *cdiv1.DataSource) (*cdiv1.DataVolume, error) {
+				dv := &cdiv1.DataVolume{}
+				err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: getDataSourceCacheName(ds), Namespace: ds.Namespace}, dv)
+				return dv, err
+			}
+
+			It("Should import the source into a cache DataVolume and become ready once it succeeds", func() {
+				ds := createImportDataSource()
+				reconciler := createDataSourceReconciler(ds)
+				verifyConditions("Cache DataVolume created", false, string(cdiv1.PhaseUnset), ds, reconciler)
+				Expect(ds.Status.Source.PVC).To(BeNil())
+
+				dv, err := getCacheDataVolume(reconciler, ds)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dv.Spec.Source.HTTP.URL).To(Equal(testURL))
+				Expect(dv.Spec.Storage).To(Equal(ds.Spec.Storage))
+				Expect(dv.Annotations[AnnImmediateBinding]).To(Equal("true"))
+				Expect(metav1.IsControlledBy(dv, ds)).To(BeTrue())
+
+				dv.Status.Phase = cdiv1.Succeeded
+				Expect(reconciler.client.Update(context.TODO(), dv)).To(Succeed())
+				verifyConditions("Cache DataVolume succeeded", true, ready, ds, reconciler)
+				Expect(ds.Status.Source).To(Equal(cdiv1.DataSourceSource{
+					PVC: &cdiv1.DataVolumeSourcePVC{Namespace: ds.Namespace, Name: getDataSourceCacheName(ds)},
+				}))
+			})
+
+			It("Should reimport when the external source changes", func() {
+				ds := createImportDataSource()
+				reconciler := createDataSourceReconciler(ds)
+				verifyConditions("Cache DataVolume created", false, string(cdiv1.PhaseUnset), ds, reconciler)
+				dv, err := getCacheDataVolume(reconciler, ds)
+				Expect(err).ToNot(HaveOccurred())
+				dv.Status.Phase = cdiv1.Succeeded
+				Expect(reconciler.client.Update(context.TODO(), dv)).To(Succeed())
+				verifyConditions("Cache DataVolume succeeded", true, ready, ds, reconciler)
+
+				ds.Spec.Source.HTTP.URL = testURL + ".new"
+				Expect(reconciler.client.Update(context.TODO(), ds)).To(Succeed())
+				verifyConditions("Outdated cache DataVolume deleted", false, cacheOutdated, ds, reconciler)
+				_, err = getCacheDataVolume(reconciler, ds)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+				verifyConditions("Cache DataVolume recreated", false, string(cdiv1.PhaseUnset), ds, reconciler)
+				dv, err = getCacheDataVolume(reconciler, ds)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dv.Spec.Source.HTTP.URL).To(Equal(testURL + ".new"))
+			})
+
+			It("Should not import when no cache storage is set", func() {
+				ds := createImportDataSource()
+				ds.Spec.Storage = nil
+				reconciler := createDataSourceReconciler(ds)
+				verifyConditions("No cache storage", false, noCacheStorage, ds, reconciler)
+				_, err := getCacheDataVolume(reconciler, ds)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("DataSource pointer should resolve to the cache PVC of the referenced DataSource", func() {
+				ds := createImportDataSource()
+				dsPointer := createDataSource(dsName + "-pointer")
+				dsPointer.Spec.Source = cdiv1.DataSourceSource{DataSource: &cdiv1.DataSourceRefSourceDataSource{Namespace: metav1.NamespaceDefault, Name: ds.Name}}
+				reconciler := createDataSourceReconciler(ds, dsPointer)
+				verifyConditions("Pointer does not create the cache", false, NotFound, dsPointer, reconciler)
+
+				verifyConditions("Cache DataVolume created", false, string(cdiv1.PhaseUnset), ds, reconciler)
+				dv, err := getCacheDataVolume(reconciler, ds)
+				Expect(err).ToNot(HaveOccurred())
+				dv.Status.Phase = cdiv1.Succeeded
+				Expect(reconciler.client.Update(context.TODO(), dv)).To(Succeed())
+
+				verifyConditions("DataSource is ready to be consumed", true, ready, dsPointer, reconciler)
+				Expect(dsPointer.Status.Source.PVC).To(Equal(&cdiv1.DataVolumeSourcePVC{Namespace: ds.Namespace, Name: getDataSourceCacheName(ds)}))
+			})
+		})
+
 		Describe("DataSource pointers", func() {
 			It("DataSource pointer should resolve reference DataSource and reach ready to be consumed state", func() {
 				ds := createDataSource(dsName)
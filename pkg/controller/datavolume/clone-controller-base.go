@@ -495,6 +495,7 @@ func (r *CloneReconcilerBase) updateStatusPhase(pvc *corev1.PersistentVolumeClai
 	case string(corev1.PodSucceeded):
 		dataVolumeCopy.Status.Phase = cdiv1.Succeeded
 		dataVolumeCopy.Status.Progress = cdiv1.DataVolumeProgress("100.0%")
+		dataVolumeCopy.Status.Throughput, dataVolumeCopy.Status.EstimatedTimeRemaining = nil, nil
 		event.eventType = corev1.EventTypeNormal
 		event.reason = CloneSucceeded
 		event.message = fmt.Sprintf(MessageCloneSucceeded, sourceNamespace, sourceName, pvc.Namespace, pvc.Name)
@@ -519,7 +520,7 @@ func (r *CloneReconcilerBase) populateSourceIfSourceRef(dv *cdiv1.DataVolume) er
 	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: dv.Spec.SourceRef.Name, Namespace: ns}, dataSource); err != nil {
 		return err
 	}
-	if dataSource.Spec.Source.DataSource != nil {
+	if dataSource.Spec.Source.DataSource != nil || cdiv1.IsDataSourceImportSource(&dataSource.Spec.Source) {
 		dataSource.Status.Source.DeepCopyInto(&dataSource.Spec.Source)
 	}
 	if dataSource.Spec.Source.PVC == nil && dataSource.Spec.Source.Snapshot == nil {
@@ -23,7 +23,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
 	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-controller"
 )
 
 var _ = Describe("findConditionByType", func() {
@@ -293,3 +295,34 @@ var _ = Describe("updateBoundCondition", func() {
 		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
 	})
 })
+
+var _ = Describe("countFailureConditions", func() {
+	createDataVolume := func(reason string) *cdiv1.DataVolume {
+		dv := NewImportDataVolume("test-dv")
+		dv.Status.Conditions = updateRunningCondition(nil, map[string]string{
+			AnnRunningCondition:        "false",
+			AnnRunningConditionReason:  reason,
+			AnnRunningConditionMessage: "Unable to process data",
+		})
+		return dv
+	}
+
+	It("should count a failure when the running condition gets a reason of the catalog", func() {
+		reason := string(common.FailureTLSError)
+		before := metrics.GetDataVolumeFailures(reason)
+		original := createDataVolume(common.GenericError)
+		dv := createDataVolume(reason)
+		countFailureConditions(dv, original.Status.Conditions)
+		Expect(metrics.GetDataVolumeFailures(reason)).To(Equal(before + 1))
+
+		By("not counting the same failure again")
+		countFailureConditions(dv, dv.Status.Conditions)
+		Expect(metrics.GetDataVolumeFailures(reason)).To(Equal(before + 1))
+	})
+
+	It("should not count a reason outside of the catalog", func() {
+		before := metrics.GetDataVolumeFailures(common.GenericError)
+		countFailureConditions(createDataVolume(common.GenericError), nil)
+		Expect(metrics.GetDataVolumeFailures(common.GenericError)).To(Equal(before))
+	})
+})
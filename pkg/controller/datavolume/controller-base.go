@@ -141,6 +141,11 @@ func dvIsPrePopulated(dv *cdiv1.DataVolume) bool {
 	return ok
 }
 
+// dvIsPatch returns true if the DataVolume writes into an existing PVC it does not own
+func dvIsPatch(dv *cdiv1.DataVolume) bool {
+	return dv != nil && dv.Spec.Patch != nil
+}
+
 func checkStaticProvisionPending(pvc *corev1.PersistentVolumeClaim, dv *cdiv1.DataVolume) bool {
 	if pvc == nil || dv == nil {
 		return false
@@ -277,6 +282,10 @@ func addDataVolumeControllerCommonWatches(mgr manager.Manager, dataVolumeControl
 			if populatedFor != "" {
 				result = appendMatchingDataVolumeRequest(ctx, result, mgr, obj.GetNamespace(), populatedFor)
 			}
+			// A DataVolume patching a PVC has its name but does not own it
+			if _, ok := obj.GetAnnotations()[cc.AnnPatchFor]; ok {
+				result = appendMatchingDataVolumeRequest(ctx, result, mgr, obj.GetNamespace(), obj.GetName())
+			}
 			// it is okay if result contains the same entry twice, will be deduplicated by caller
 			return result
 		}),
@@ -385,7 +394,7 @@ func getDataVolumeOp(ctx context.Context, log logr.Logger, dv *cdiv1.DataVolume,
 	if src.Upload != nil {
 		return dataVolumeUpload
 	}
-	if src.HTTP != nil || src.S3 != nil || src.GCS != nil || src.Registry != nil || src.Blank != nil || src.Imageio != nil || src.VDDK != nil {
+	if src.HTTP != nil || src.S3 != nil || src.GCS != nil || src.AzureBlob != nil || src.Registry != nil || src.Blank != nil || src.Imageio != nil || src.VDDK != nil || src.Backup != nil {
 		return dataVolumeImport
 	}
 
@@ -414,6 +423,8 @@ func getSourceRefOp(ctx context.Context, log logr.Logger, dv *cdiv1.DataVolume,
 		return dataVolumePvcClone
 	case resolved.Spec.Source.Snapshot != nil:
 		return dataVolumeSnapshotClone
+	case cdiv1.IsDataSourceImportSource(&resolved.Spec.Source):
+		return dataVolumePvcClone
 	default:
 		return dataVolumeNop
 	}
@@ -510,7 +521,7 @@ func (r *ReconcilerBase) syncDvPvcState(log logr.Logger, req reconcile.Request,
 	}
 
 	if prepare != nil {
-		if err := prepare(&syncState); err != nil {
+		if err := prepare(&syncState); err != nil || syncState.result != nil {
 			return syncState, err
 		}
 	}
@@ -710,6 +721,9 @@ func (r *ReconcilerBase) getAvailableVolumesForDV(syncState *dvSyncState, log lo
 }
 
 func (r *ReconcilerBase) handlePrePopulation(dv *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) {
+	if dvIsPatch(dv) {
+		return
+	}
 	if pvc.Status.Phase == corev1.ClaimBound && pvcIsPopulatedForDataVolume(pvc, dv) {
 		cc.AddAnnotation(dv, cc.AnnPrePopulated, pvc.Name)
 	}
@@ -723,6 +737,10 @@ func (r *ReconcilerBase) validatePVC(dv *cdiv1.DataVolume, pvc *corev1.Persisten
 		r.recorder.Event(dv, corev1.EventTypeWarning, ErrResourceMarkedForDeletion, msg)
 		return errors.New(msg)
 	}
+	// A patch writes into a PVC the DataVolume does not own
+	if dvIsPatch(dv) {
+		return nil
+	}
 	// If the PVC is not controlled by this DataVolume resource, we should log
 	// a warning to the event recorder and return
 	if !metav1.IsControlledBy(pvc, dv) {
@@ -812,6 +830,7 @@ func (r *ReconcilerBase) reconcileProgressUpdate(datavolume *cdiv1.DataVolume, p
 		} else {
 			datavolume.Status.Progress = "N/A"
 		}
+		datavolume.Status.Throughput, datavolume.Status.EstimatedTimeRemaining = cc.GetTransferStats(pvc)
 		return nil
 	}
 
@@ -918,6 +937,11 @@ func (r *ReconcilerBase) updateStatus(req reconcile.Request, phaseSync *statusPh
 		dataVolumeCopy.Status.Phase = cdiv1.Pending
 	} else if pvc != nil && pvc.DeletionTimestamp == nil {
 		dataVolumeCopy.Status.ClaimName = pvc.Name
+		// Before and after a patch, the PVC holds the state of its previous import
+		otherImport := dvIsPatch(dataVolumeCopy) && pvc.Annotations[cc.AnnPatchFor] != string(dataVolumeCopy.UID)
+		if !otherImport {
+			dataVolumeCopy.Status.ReclaimedSpace = cc.GetReclaimedSpace(pvc)
+		}
 
 		phase := pvc.Annotations[cc.AnnPodPhase]
 		requiresWork, err := r.pvcRequiresWork(pvc, dataVolumeCopy)
@@ -968,7 +992,7 @@ func (r *ReconcilerBase) updateStatus(req reconcile.Request, phaseSync *statusPh
 			}
 		}
 
-		if i, err := strconv.ParseInt(pvc.Annotations[cc.AnnPodRestarts], 10, 32); err == nil && i >= 0 {
+		if i, err := strconv.ParseInt(pvc.Annotations[cc.AnnPodRestarts], 10, 32); err == nil && i >= 0 && !otherImport {
 			dataVolumeCopy.Status.RestartCount = int32(i)
 		}
 		if err := r.reconcileProgressUpdate(dataVolumeCopy, pvc, &result); err != nil {
@@ -1045,6 +1069,18 @@ func (r *ReconcilerBase) updateConditions(dataVolume *cdiv1.DataVolume, pvc *cor
 func (r *ReconcilerBase) emitConditionEvent(dataVolume *cdiv1.DataVolume, originalCond []cdiv1.DataVolumeCondition) {
 	r.emitBoundConditionEvent(dataVolume, FindConditionByType(cdiv1.DataVolumeBound, dataVolume.Status.Conditions), FindConditionByType(cdiv1.DataVolumeBound, originalCond))
 	r.emitFailureConditionEvent(dataVolume, originalCond)
+	countFailureConditions(dataVolume, originalCond)
+}
+
+// countFailureConditions counts a failure when a condition of the DataVolume gets a reason of the failure catalog
+func countFailureConditions(dataVolume *cdiv1.DataVolume, originalCond []cdiv1.DataVolumeCondition) {
+	for _, conditionType := range []cdiv1.DataVolumeConditionType{cdiv1.DataVolumeBound, cdiv1.DataVolumeRunning} {
+		current := FindConditionByType(conditionType, dataVolume.Status.Conditions)
+		original := FindConditionByType(conditionType, originalCond)
+		if current != nil && common.IsFailureReason(current.Reason) && (original == nil || original.Reason != current.Reason) {
+			metrics.IncDataVolumeFailures(current.Reason)
+		}
+	}
 }
 
 func (r *ReconcilerBase) emitBoundConditionEvent(dataVolume *cdiv1.DataVolume, current, original *cdiv1.DataVolumeCondition) {
@@ -1114,17 +1150,20 @@ func updateProgressUsingPod(dataVolumeCopy *cdiv1.DataVolume, pod *corev1.Pod) e
 	}
 
 	// Used for both import and clone, so it should match both metric names
-	progressReport, err := cc.GetProgressReportFromURL(context.TODO(), url, httpClient,
+	report, err := cc.GetTransferReportFromURL(context.TODO(), url, httpClient,
 		fmt.Sprintf("%s|%s", importMetrics.ImportProgressMetricName, cloneMetrics.CloneProgressMetricName),
 		string(dataVolumeCopy.UID))
 	if err != nil {
 		return err
 	}
-	if progressReport != "" {
-		if f, err := strconv.ParseFloat(progressReport, 64); err == nil {
+	if report.Progress != "" {
+		if f, err := strconv.ParseFloat(report.Progress, 64); err == nil {
 			dataVolumeCopy.Status.Progress = cdiv1.DataVolumeProgress(fmt.Sprintf("%.2f%%", f))
 		}
 	}
+	if throughput, eta := report.TransferStats(); throughput != nil || eta != nil {
+		dataVolumeCopy.Status.Throughput, dataVolumeCopy.Status.EstimatedTimeRemaining = throughput, eta
+	}
 	return nil
 }
 
@@ -1152,7 +1191,17 @@ func (r *ReconcilerBase) newPersistentVolumeClaim(dataVolume *cdiv1.DataVolume,
 	if dataVolume.Spec.PriorityClassName != "" {
 		annotations[cc.AnnPriorityClassName] = dataVolume.Spec.PriorityClassName
 	}
-	annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, dataVolume.Spec.Preallocation))
+	annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, dataVolume.Spec.Preallocation, targetPvcSpec.StorageClassName))
+	if dataVolume.Spec.ExpandPartition != nil && *dataVolume.Spec.ExpandPartition {
+		annotations[cc.AnnExpandPartition] = "true"
+	}
+	if cc.GetSparsify(context.TODO(), r.client, dataVolume.Spec.Sparsify, targetPvcSpec.StorageClassName) {
+		annotations[cc.AnnSparsify] = "true"
+	}
+	if dataVolume.Spec.Source != nil && dataVolume.Spec.Source.Backup != nil {
+		// DataVolumeBackups are written as a manifest and chunk objects
+		annotations[cc.AnnCDIBackupManifest] = "true"
+	}
 	annotations[cc.AnnCreatedForDataVolume] = string(dataVolume.UID)
 
 	if dataVolume.Spec.Storage != nil && labels[common.PvcApplyStorageProfileLabel] == "true" {
@@ -1310,6 +1359,10 @@ func (r *ReconcilerBase) handlePvcCreation(log logr.Logger, syncState *dvSyncSta
 // * annotation cdi.kubevirt.io/storage.usePopulator is not set by user to "false"
 func (r *ReconcilerBase) shouldUseCDIPopulator(syncState *dvSyncState) (bool, error) {
 	dv := syncState.dvMutated
+	if dvIsPatch(dv) {
+		// Populators only fill new PVCs
+		return false, nil
+	}
 	if usePopulator, ok := dv.Annotations[cc.AnnUsePopulator]; ok {
 		boolUsePopulator, err := strconv.ParseBool(usePopulator)
 		if err != nil {
@@ -1335,6 +1388,10 @@ func (r *ReconcilerBase) pvcRequiresWork(pvc *corev1.PersistentVolumeClaim, dv *
 	if pvc == nil || dv == nil {
 		return true, nil
 	}
+	if dvIsPatch(dv) {
+		// The PVC is populated already, the patch is the work
+		return dv.Status.Phase != cdiv1.Succeeded, nil
+	}
 	if pvcIsPopulatedForDataVolume(pvc, dv) {
 		return false, nil
 	}
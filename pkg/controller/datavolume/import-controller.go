@@ -18,9 +18,11 @@ package datavolume
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -29,11 +31,15 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -52,6 +58,10 @@ const (
 	ImportFailed = "ImportFailed"
 	// ImportSucceeded provides a const to indicate import has succeeded
 	ImportSucceeded = "ImportSucceeded"
+	// PatchTargetNotFound provides a const to indicate the PVC to patch does not exist
+	PatchTargetNotFound = "PatchTargetNotFound"
+	// BackupNotReady provides a const to indicate the DataVolumeBackup to restore has not succeeded
+	BackupNotReady = "BackupNotReady"
 
 	// MessageImportScheduled provides a const to form import is scheduled message
 	MessageImportScheduled = "Import into %s scheduled"
@@ -61,6 +71,10 @@ const (
 	MessageImportFailed = "Failed to import into PVC %s"
 	// MessageImportSucceeded provides a const to form import has succeeded message
 	MessageImportSucceeded = "Successfully imported into PVC %s"
+	// MessagePatchTargetNotFound provides a const to form the PVC to patch does not exist message
+	MessagePatchTargetNotFound = "Waiting for PVC %s to patch"
+	// MessageBackupNotReady provides a const to form the DataVolumeBackup to restore has not succeeded message
+	MessageBackupNotReady = "Waiting for DataVolumeBackup %s to succeed"
 
 	importControllerName = "datavolume-import-controller"
 
@@ -114,17 +128,58 @@ func addDataVolumeImportControllerWatches(mgr manager.Manager, datavolumeControl
 		mgr.GetScheme(), mgr.GetClient().RESTMapper(), &cdiv1.DataVolume{}, handler.OnlyControllerOwner()))); err != nil {
 		return err
 	}
+	if err := addBackupSourceWatch(mgr, datavolumeController); err != nil {
+		return err
+	}
 	return nil
 }
 
+// addBackupSourceWatch reconciles DataVolumes waiting for the DataVolumeBackup they restore
+func addBackupSourceWatch(mgr manager.Manager, datavolumeController controller.Controller) error {
+	const indexingKey = "spec.source.backup"
+
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &cdiv1.DataVolume{}, indexingKey, func(obj client.Object) []string {
+		dv := obj.(*cdiv1.DataVolume)
+		if source := dv.Spec.Source; source != nil && source.Backup != nil && source.Backup.Name != "" {
+			return []string{types.NamespacedName{Namespace: dv.Namespace, Name: source.Backup.Name}.String()}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	dataVolumeMapper := func(ctx context.Context, obj client.Object) []reconcile.Request {
+		dvList := &cdiv1.DataVolumeList{}
+		namespacedName := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		if err := mgr.GetClient().List(ctx, dvList, client.InNamespace(obj.GetNamespace()), client.MatchingFields{indexingKey: namespacedName.String()}); err != nil {
+			return nil
+		}
+		var reqs []reconcile.Request
+		for _, dv := range dvList.Items {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: dv.Namespace, Name: dv.Name}})
+		}
+		return reqs
+	}
+
+	return datavolumeController.Watch(source.Kind(mgr.GetCache(), client.Object(&cdiv1.DataVolumeBackup{}),
+		handler.EnqueueRequestsFromMapFunc(dataVolumeMapper),
+		predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool { return true },
+			DeleteFunc: func(e event.DeleteEvent) bool { return false },
+			UpdateFunc: func(e event.UpdateEvent) bool { return true },
+		}))
+}
+
 func (r *ImportReconciler) updatePVCForPopulation(dataVolume *cdiv1.DataVolume, pvc *corev1.PersistentVolumeClaim) error {
 	if dataVolume.Spec.Source.HTTP == nil &&
 		dataVolume.Spec.Source.S3 == nil &&
 		dataVolume.Spec.Source.GCS == nil &&
+		dataVolume.Spec.Source.AzureBlob == nil &&
 		dataVolume.Spec.Source.Registry == nil &&
 		dataVolume.Spec.Source.Imageio == nil &&
 		dataVolume.Spec.Source.VDDK == nil &&
-		dataVolume.Spec.Source.Blank == nil {
+		dataVolume.Spec.Source.Blank == nil &&
+		dataVolume.Spec.Source.Backup == nil {
 		return errors.Errorf("no source set for import datavolume")
 	}
 	if err := cc.AddImmediateBindingAnnotationIfWFFCDisabled(pvc, r.featureGates); err != nil {
@@ -147,11 +202,26 @@ func (r *ImportReconciler) updateAnnotations(dataVolume *cdiv1.DataVolume, pvc *
 		annotations[cc.AnnPreviousCheckpoint] = checkpoint.Previous
 		annotations[cc.AnnFinalCheckpoint] = strconv.FormatBool(checkpoint.IsFinal)
 	}
+	if customization := dataVolume.Spec.Customization; customization != nil {
+		annotations[cc.AnnCustomizeConfigMap] = customization.ConfigMap
+	}
 
 	if http := dataVolume.Spec.Source.HTTP; http != nil {
 		cc.UpdateHTTPAnnotations(annotations, http)
 		return nil
 	}
+	if backup := dataVolume.Spec.Source.Backup; backup != nil {
+		destination, err := r.getBackupDestination(dataVolume.Namespace, backup)
+		if err != nil {
+			return err
+		}
+		if destination.S3 != nil {
+			cc.UpdateS3Annotations(annotations, destination.S3)
+		} else {
+			cc.UpdateGCSAnnotations(annotations, destination.GCS)
+		}
+		return nil
+	}
 	if s3 := dataVolume.Spec.Source.S3; s3 != nil {
 		cc.UpdateS3Annotations(annotations, s3)
 		return nil
@@ -160,6 +230,10 @@ func (r *ImportReconciler) updateAnnotations(dataVolume *cdiv1.DataVolume, pvc *
 		cc.UpdateGCSAnnotations(annotations, gcs)
 		return nil
 	}
+	if blob := dataVolume.Spec.Source.AzureBlob; blob != nil {
+		cc.UpdateAzureBlobAnnotations(annotations, blob)
+		return nil
+	}
 	if registry := dataVolume.Spec.Source.Registry; registry != nil {
 		cc.UpdateRegistryAnnotations(annotations, registry)
 		return nil
@@ -193,11 +267,22 @@ func (r *ImportReconciler) sync(log logr.Logger, req reconcile.Request) (dvSyncR
 }
 
 func (r *ImportReconciler) syncImport(log logr.Logger, req reconcile.Request) (dvSyncState, error) {
-	syncState, syncErr := r.syncCommon(log, req, r.cleanup, nil)
+	syncState, syncErr := r.syncCommon(log, req, r.cleanup, r.prepare)
+	if syncErr == nil && syncState.dv == nil {
+		// A deleted DataVolume may have been patching the PVC with its name
+		var pvc *corev1.PersistentVolumeClaim
+		if pvc, syncErr = r.getPVC(req.NamespacedName); syncErr == nil && pvc != nil {
+			_, syncErr = r.restorePatchedPVC(pvc)
+		}
+	}
 	if syncErr != nil || syncState.result != nil {
 		return syncState, syncErr
 	}
 
+	if dvIsPatch(syncState.dvMutated) {
+		return syncState, r.updatePVCForPatch(&syncState)
+	}
+
 	pvcModifier := r.updateAnnotations
 	if syncState.usePopulator {
 		if r.shouldReconcileVolumeSourceCR(&syncState) {
@@ -220,7 +305,151 @@ func (r *ImportReconciler) syncImport(log logr.Logger, req reconcile.Request) (d
 	return syncState, syncErr
 }
 
+// prepare waits for the PVC a DataVolume patches, it is never created by the DataVolume,
+// and for the DataVolumeBackup a DataVolume restores to succeed
+func (r *ImportReconciler) prepare(syncState *dvSyncState) error {
+	dv := syncState.dvMutated
+	if syncState.pvc != nil {
+		return nil
+	}
+	if dvIsPatch(dv) {
+		syncState.result = &reconcile.Result{}
+		return r.syncDataVolumeStatusPhaseWithEvent(syncState, cdiv1.Pending, nil,
+			Event{corev1.EventTypeWarning, PatchTargetNotFound, fmt.Sprintf(MessagePatchTargetNotFound, dv.Name)})
+	}
+	if backup := dv.Spec.Source.Backup; backup != nil {
+		ready, err := r.isBackupReady(dv.Namespace, backup)
+		if err != nil || ready {
+			return err
+		}
+		syncState.result = &reconcile.Result{}
+		return r.syncDataVolumeStatusPhaseWithEvent(syncState, cdiv1.Pending, nil,
+			Event{corev1.EventTypeNormal, BackupNotReady, fmt.Sprintf(MessageBackupNotReady, backup.Name)})
+	}
+	return nil
+}
+
+// updatePVCForPatch makes the import controller write the source of the DataVolume into its existing PVC,
+// once per DataVolume. The state of the previous import of the PVC is saved, and restored once the patch is over.
+func (r *ImportReconciler) updatePVCForPatch(syncState *dvSyncState) error {
+	dv := syncState.dvMutated
+	if syncState.pvc.Annotations[cc.AnnPatchFor] == string(dv.UID) || dv.Status.Phase == cdiv1.Succeeded {
+		return nil
+	}
+
+	pvc := syncState.pvc.DeepCopy()
+	for ann := range pvc.Annotations {
+		if isImportStateAnnotation(ann) {
+			delete(pvc.Annotations, ann)
+		}
+	}
+	delete(pvc.Annotations, cc.AnnPodPhase)
+	cc.AddAnnotation(pvc, cc.AnnPodRestarts, "0")
+	cc.AddAnnotation(pvc, cc.AnnContentType, string(cc.GetContentType(dv.Spec.ContentType)))
+	if dv.Spec.PriorityClassName != "" {
+		cc.AddAnnotation(pvc, cc.AnnPriorityClassName, dv.Spec.PriorityClassName)
+	}
+
+	cc.AddAnnotation(pvc, cc.AnnPatchFor, string(dv.UID))
+	if offset := dv.Spec.Patch.Offset; offset != nil {
+		cc.AddAnnotation(pvc, cc.AnnPatchOffset, strconv.FormatInt(*offset, 10))
+	}
+	if label := dv.Spec.Patch.PartitionLabel; label != "" {
+		cc.AddAnnotation(pvc, cc.AnnPatchPartitionLabel, label)
+	}
+	if err := r.updateAnnotations(dv, pvc); err != nil {
+		return err
+	}
+	// A PVC still holding the annotations saved by a previous patch was not restored yet, keep them
+	if _, ok := syncState.pvc.Annotations[cc.AnnPatchOriginalAnnotations]; !ok {
+		original, err := replacedAnnotations(syncState.pvc, pvc)
+		if err != nil {
+			return err
+		}
+		cc.AddAnnotation(pvc, cc.AnnPatchOriginalAnnotations, original)
+	}
+
+	if err := r.updatePVC(pvc); err != nil {
+		return err
+	}
+	syncState.pvc = pvc
+	return nil
+}
+
+// restorePatchedPVC gives a PVC back the annotations it had before it was patched, and drops the patch annotations
+func (r *ImportReconciler) restorePatchedPVC(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	if _, ok := pvc.Annotations[cc.AnnPatchFor]; !ok {
+		return pvc, nil
+	}
+
+	pvc = pvc.DeepCopy()
+	if saved, ok := pvc.Annotations[cc.AnnPatchOriginalAnnotations]; ok {
+		original := map[string]*string{}
+		if err := json.Unmarshal([]byte(saved), &original); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse annotation %s", cc.AnnPatchOriginalAnnotations)
+		}
+		for ann := range pvc.Annotations {
+			if isImportStateAnnotation(ann) {
+				delete(pvc.Annotations, ann)
+			}
+		}
+		for ann, value := range original {
+			if value == nil {
+				delete(pvc.Annotations, ann)
+			} else {
+				pvc.Annotations[ann] = *value
+			}
+		}
+	}
+	delete(pvc.Annotations, cc.AnnPatchOriginalAnnotations)
+	delete(pvc.Annotations, cc.AnnPatchFor)
+	delete(pvc.Annotations, cc.AnnPatchOffset)
+	delete(pvc.Annotations, cc.AnnPatchPartitionLabel)
+	if err := r.updatePVC(pvc); err != nil {
+		return nil, err
+	}
+	return pvc, nil
+}
+
+// isImportStateAnnotation returns true if the annotation describes an import of the PVC, or one of its checkpoints
+func isImportStateAnnotation(ann string) bool {
+	return strings.HasPrefix(ann, cc.AnnAPIGroup+"/storage.import.") || strings.HasPrefix(ann, cc.AnnAPIGroup+"/storage.checkpoint.")
+}
+
+// replacedAnnotations returns the annotations of the original PVC that differ in the patched one, as a JSON object
+// where a null value stands for an annotation the original PVC did not have
+func replacedAnnotations(original, patched *corev1.PersistentVolumeClaim) (string, error) {
+	replaced := map[string]*string{}
+	for ann, value := range original.Annotations {
+		if patchedValue, ok := patched.Annotations[ann]; !ok || patchedValue != value {
+			replaced[ann] = ptr.To(value)
+		}
+	}
+	for ann := range patched.Annotations {
+		if _, ok := original.Annotations[ann]; !ok {
+			replaced[ann] = nil
+		}
+	}
+	b, err := json.Marshal(replaced)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
 func (r *ImportReconciler) cleanup(syncState *dvSyncState) error {
+	dv := syncState.dv
+	if dvIsPatch(dv) {
+		// The PVC is left as it was before the patch once the patch succeeded
+		if syncState.pvc != nil && dv.Status.Phase == cdiv1.Succeeded && syncState.pvc.Annotations[cc.AnnPatchFor] == string(dv.UID) {
+			pvc, err := r.restorePatchedPVC(syncState.pvc)
+			if err != nil {
+				return err
+			}
+			syncState.pvc = pvc
+		}
+		return nil
+	}
 	// The cleanup is to delete the volumeImportSourceCR which is used only with populators,
 	// it is owner by the DV so will be deleted when dv is deleted
 	// also we can already delete once dv is succeeded
@@ -262,6 +491,11 @@ func (r *ImportReconciler) shouldUpdateStatusPhase(pvc *corev1.PersistentVolumeC
 }
 
 func (r *ImportReconciler) updateStatusPhase(pvc *corev1.PersistentVolumeClaim, dataVolumeCopy *cdiv1.DataVolume, event *Event) error {
+	if dvIsPatch(dataVolumeCopy) && pvc.Annotations[cc.AnnPatchFor] != string(dataVolumeCopy.UID) {
+		// The phase is still the one of the previous import of the PVC
+		dataVolumeCopy.Status.Phase = cdiv1.ImportScheduled
+		return nil
+	}
 	phase, ok := pvc.Annotations[cc.AnnPodPhase]
 	if phase != string(corev1.PodSucceeded) {
 		update, err := r.shouldUpdateStatusPhase(pvc, dataVolumeCopy)
@@ -308,6 +542,7 @@ func (r *ImportReconciler) updateStatusPhase(pvc *corev1.PersistentVolumeClaim,
 		}
 		dataVolumeCopy.Status.Phase = cdiv1.Succeeded
 		dataVolumeCopy.Status.Progress = cdiv1.DataVolumeProgress("100.0%")
+		dataVolumeCopy.Status.Throughput, dataVolumeCopy.Status.EstimatedTimeRemaining = nil, nil
 		event.eventType = corev1.EventTypeNormal
 		event.reason = ImportSucceeded
 		event.message = fmt.Sprintf(MessageImportSucceeded, pvc.Name)
@@ -336,6 +571,33 @@ func (r *ImportReconciler) getCheckpointArgs(dv *cdiv1.DataVolume) *cc.Checkpoin
 	}
 }
 
+// isBackupReady returns whether the DataVolumeBackup a DataVolume restores exists and has succeeded
+func (r *ImportReconciler) isBackupReady(namespace string, source *cdiv1.DataVolumeSourceBackup) (bool, error) {
+	backup := &cdiv1.DataVolumeBackup{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: source.Name}, backup); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return backup.Status.Phase == cdiv1.DataVolumeBackupSucceeded, nil
+}
+
+// getBackupDestination returns the object storage a succeeded DataVolumeBackup was written to
+func (r *ImportReconciler) getBackupDestination(namespace string, source *cdiv1.DataVolumeSourceBackup) (*cdiv1.DataVolumeBackupDestination, error) {
+	backup := &cdiv1.DataVolumeBackup{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: source.Name}, backup); err != nil {
+		return nil, err
+	}
+	if backup.Status.Phase != cdiv1.DataVolumeBackupSucceeded {
+		return nil, errors.Errorf("DataVolumeBackup %s/%s has not succeeded", namespace, source.Name)
+	}
+	if backup.Spec.Destination.S3 == nil && backup.Spec.Destination.GCS == nil {
+		return nil, errors.Errorf("DataVolumeBackup %s/%s has no destination", namespace, source.Name)
+	}
+	return &backup.Spec.Destination, nil
+}
+
 func volumeImportSourceName(dv *cdiv1.DataVolume) string {
 	return fmt.Sprintf("%s-%s", volumeImportSourcePrefix, dv.UID)
 }
@@ -357,10 +619,19 @@ func (r *ImportReconciler) reconcileVolumeImportSourceCR(syncState *dvSyncState)
 	source := &cdiv1.ImportSourceType{}
 	if http := dv.Spec.Source.HTTP; http != nil {
 		source.HTTP = http
+	} else if backup := dv.Spec.Source.Backup; backup != nil {
+		destination, err := r.getBackupDestination(dv.Namespace, backup)
+		if err != nil {
+			return err
+		}
+		source.S3 = destination.S3
+		source.GCS = destination.GCS
 	} else if s3 := dv.Spec.Source.S3; s3 != nil {
 		source.S3 = s3
 	} else if gcs := dv.Spec.Source.GCS; gcs != nil {
 		source.GCS = gcs
+	} else if blob := dv.Spec.Source.AzureBlob; blob != nil {
+		source.AzureBlob = blob
 	} else if registry := dv.Spec.Source.Registry; registry != nil {
 		source.Registry = registry
 	} else if imageio := dv.Spec.Source.Imageio; imageio != nil {
@@ -382,6 +653,7 @@ func (r *ImportReconciler) reconcileVolumeImportSourceCR(syncState *dvSyncState)
 			Source:        source,
 			ContentType:   dv.Spec.ContentType,
 			Preallocation: dv.Spec.Preallocation,
+			Customization: dv.Spec.Customization,
 		},
 	}
 
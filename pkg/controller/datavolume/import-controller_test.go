@@ -24,6 +24,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -140,6 +141,7 @@ var _ = Describe("All DataVolume Tests", func() {
 			dv.Spec.ContentType = cdiv1.DataVolumeArchive
 			preallocation := true
 			dv.Spec.Preallocation = &preallocation
+			dv.Spec.Customization = &cdiv1.DataVolumeCustomization{ConfigMap: "golden-recipe"}
 			reconciler = createImportReconciler(dv, sc, csiDriver)
 			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
 			Expect(err).ToNot(HaveOccurred())
@@ -154,6 +156,7 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(importSource.Spec.Source).ToNot(BeNil())
 			Expect(importSource.Spec.ContentType).To(Equal(dv.Spec.ContentType))
 			Expect(importSource.Spec.Preallocation).To(Equal(dv.Spec.Preallocation))
+			Expect(importSource.Spec.Customization).To(Equal(dv.Spec.Customization))
 			Expect(importSource.OwnerReferences).To(HaveLen(1))
 			or := importSource.OwnerReferences[0]
 			Expect(or.UID).To(Equal(dv.UID))
@@ -739,6 +742,172 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(pvc.GetAnnotations()[AnnPriorityClassName]).To(Equal("p0-s3"))
 		})
 
+		It("Should import a DataVolume with backup source from the backup destination", func() {
+			dv := newBackupImportDataVolume("test-dv")
+			reconciler = createImportReconciler(dv, newSucceededDataVolumeBackup())
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnSource]).To(Equal(SourceS3))
+			Expect(pvc.GetAnnotations()[AnnEndpoint]).To(Equal("http://example.com/bucket/backup.img.gz"))
+			Expect(pvc.GetAnnotations()[AnnSecret]).To(Equal("s3-secret"))
+			Expect(pvc.GetAnnotations()[AnnCDIBackupManifest]).To(Equal("true"))
+		})
+
+		It("Should create volumeImportSource with the backup destination if should use cdi populator", func() {
+			sc := CreateStorageClassWithProvisioner("testSC", map[string]string{AnnDefaultStorageClass: "true"}, map[string]string{}, "csi-plugin")
+			csiDriver := &storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "csi-plugin"}}
+			dv := newBackupImportDataVolume("test-dv")
+			reconciler = createImportReconciler(dv, newSucceededDataVolumeBackup(), sc, csiDriver)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			importSource := &cdiv1.VolumeImportSource{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: volumeImportSourceName(dv), Namespace: metav1.NamespaceDefault}, importSource)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(importSource.Spec.Source.S3).ToNot(BeNil())
+			Expect(importSource.Spec.Source.S3.URL).To(Equal("http://example.com/bucket/backup.img.gz"))
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnCDIBackupManifest]).To(Equal("true"))
+		})
+
+		It("Should not import a DataVolume with backup source until the backup succeeded", func() {
+			backup := newSucceededDataVolumeBackup()
+			backup.Status.Phase = cdiv1.DataVolumeBackupInProgress
+			dv := newBackupImportDataVolume("test-dv")
+			reconciler = createImportReconciler(dv, backup)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.Pending))
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			Expect(event).To(ContainSubstring(fmt.Sprintf(MessageBackupNotReady, "test-backup")))
+		})
+
+		It("Should wait for a DataVolumeBackup that does not exist yet", func() {
+			dv := newBackupImportDataVolume("test-dv")
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.Pending))
+		})
+
+		It("Should wait for the PVC a patch DataVolume writes into", func() {
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.Patch = &cdiv1.DataVolumePatch{PartitionLabel: "EFI"}
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.Pending))
+			event := <-reconciler.recorder.(*record.FakeRecorder).Events
+			Expect(event).To(ContainSubstring(fmt.Sprintf(MessagePatchTargetNotFound, "test-dv")))
+		})
+
+		It("Should patch the existing PVC once", func() {
+			pvc := CreatePvc("test-dv", metav1.NamespaceDefault, map[string]string{
+				AnnEndpoint:          "http://example.com/old.img",
+				AnnSource:            SourceHTTP,
+				AnnPodPhase:          string(corev1.PodSucceeded),
+				AnnImportPod:         "importer-test-dv",
+				AnnCurrentCheckpoint: "old",
+			}, nil)
+			pvc.Status.Phase = corev1.ClaimBound
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.Patch = &cdiv1.DataVolumePatch{Offset: ptr.To[int64](1024)}
+			reconciler = createImportReconciler(pvc, dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.OwnerReferences).To(BeEmpty())
+			Expect(pvc.Annotations[AnnPatchFor]).To(Equal(string(dv.UID)))
+			Expect(pvc.Annotations[AnnPatchOffset]).To(Equal("1024"))
+			Expect(pvc.Annotations[AnnEndpoint]).To(Equal(dv.Spec.Source.HTTP.URL))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPodPhase))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnImportPod))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnCurrentCheckpoint))
+
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).ToNot(Equal(cdiv1.Succeeded))
+
+			By("Following the phase of the patch")
+			pvc.Annotations[AnnImportPod] = "importer-test-dv-patch"
+			pvc.Annotations[AnnPodPhase] = string(corev1.PodSucceeded)
+			Expect(reconciler.client.Update(context.TODO(), pvc)).To(Succeed())
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations[AnnPodPhase]).To(Equal(string(corev1.PodSucceeded)))
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.Succeeded))
+
+			By("Restoring the annotations of the previous import once the patch succeeded")
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations[AnnEndpoint]).To(Equal("http://example.com/old.img"))
+			Expect(pvc.Annotations[AnnImportPod]).To(Equal("importer-test-dv"))
+			Expect(pvc.Annotations[AnnCurrentCheckpoint]).To(Equal("old"))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPatchFor))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPatchOffset))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPatchOriginalAnnotations))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPodRestarts))
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Phase).To(Equal(cdiv1.Succeeded))
+		})
+
+		It("Should restore the annotations of the patched PVC once the DataVolume is deleted", func() {
+			pvc := CreatePvc("test-dv", metav1.NamespaceDefault, map[string]string{
+				AnnEndpoint: "http://example.com/old.img",
+				AnnSource:   SourceHTTP,
+				AnnPodPhase: string(corev1.PodSucceeded),
+			}, nil)
+			pvc.Status.Phase = corev1.ClaimBound
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.Patch = &cdiv1.DataVolumePatch{PartitionLabel: "EFI"}
+			reconciler = createImportReconciler(pvc, dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations[AnnPatchPartitionLabel]).To(Equal("EFI"))
+
+			Expect(reconciler.client.Delete(context.TODO(), dv)).To(Succeed())
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.Annotations[AnnEndpoint]).To(Equal("http://example.com/old.img"))
+			Expect(pvc.Annotations[AnnPodPhase]).To(Equal(string(corev1.PodSucceeded)))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPatchFor))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPatchPartitionLabel))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnPatchOriginalAnnotations))
+			Expect(pvc.Annotations).ToNot(HaveKey(AnnContentType))
+		})
+
 		It("Should follow the phase of the created PVC", func() {
 			reconciler = createImportReconciler(NewImportDataVolume("test-dv"))
 			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
@@ -766,6 +935,26 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(dv.Status.Phase).To(Equal(cdiv1.Pending))
 		})
 
+		It("Should report the space reclaimed by the sparsify of the PVC", func() {
+			reconciler = createImportReconciler(NewImportDataVolume("test-dv"))
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+
+			pvc.Annotations[AnnReclaimedBytes] = "1048576"
+			Expect(reconciler.client.Update(context.TODO(), pvc)).To(Succeed())
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+
+			dv := &cdiv1.DataVolume{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, dv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.ReclaimedSpace).ToNot(BeNil())
+			Expect(dv.Status.ReclaimedSpace.Value()).To(Equal(int64(1048576)))
+		})
+
 		It("Should follow the restarts of the PVC", func() {
 			reconciler = createImportReconciler(NewImportDataVolume("test-dv"))
 			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
@@ -1121,6 +1310,42 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(pvc).ToNot(BeNil())
 			Expect(pvc.GetAnnotations()[AnnVddkExtraArgs]).To(Equal("vddk-extra-args"))
 		})
+
+		It("Should copy the customization recipe ConfigMap to PVC", func() {
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.Customization = &cdiv1.DataVolumeCustomization{ConfigMap: "golden-recipe"}
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnCustomizeConfigMap]).To(Equal("golden-recipe"))
+		})
+
+		It("Should request partition expansion on PVC", func() {
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.ExpandPartition = ptr.To(true)
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnExpandPartition]).To(Equal("true"))
+		})
+
+		It("Should request sparsify on PVC", func() {
+			dv := NewImportDataVolume("test-dv")
+			dv.Spec.Sparsify = ptr.To(true)
+			reconciler = createImportReconciler(dv)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			pvc := &corev1.PersistentVolumeClaim{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "test-dv", Namespace: metav1.NamespaceDefault}, pvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvc.GetAnnotations()[AnnSparsify]).To(Equal("true"))
+		})
 	})
 
 	var _ = Describe("Reconcile Datavolume status", func() {
@@ -1745,6 +1970,28 @@ var _ = Describe("All DataVolume Tests", func() {
 			Expect(dv.Status.Progress).To(BeEquivalentTo("13.45%"))
 		})
 
+		It("Should update throughput and ETA if http endpoint reports them", func() {
+			dv.SetUID("b856691e-1038-11e9-a5ab-525500d15501")
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprintf(w, "kubevirt_cdi_import_progress_total{ownerUID=\"%v\"} 40\n", dv.GetUID())
+				_, _ = fmt.Fprintf(w, "kubevirt_cdi_transfer_throughput_bytes{ownerUID=\"%v\"} 2.097152e+06\n", dv.GetUID())
+				_, _ = fmt.Fprintf(w, "kubevirt_cdi_transfer_eta_seconds{ownerUID=\"%v\"} 42\n", dv.GetUID())
+			}))
+			defer ts.Close()
+			ep, err := url.Parse(ts.URL)
+			Expect(err).ToNot(HaveOccurred())
+			port, err := strconv.ParseInt(ep.Port(), 10, 32)
+			Expect(err).ToNot(HaveOccurred())
+			pod.Spec.Containers[0].Ports[0].ContainerPort = int32(port)
+			pod.Status.PodIP = ep.Hostname()
+			err = updateProgressUsingPod(dv, pod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Status.Progress).To(BeEquivalentTo("40.00%"))
+			Expect(dv.Status.Throughput).ToNot(BeNil())
+			Expect(dv.Status.Throughput.String()).To(Equal("2Mi"))
+			Expect(dv.Status.EstimatedTimeRemaining).To(HaveValue(Equal(metav1.Duration{Duration: 42 * time.Second})))
+		})
+
 		It("Should not change update progress if http endpoint returns no matching data", func() {
 			dv.SetUID("b856691e-1038-11e9-a5ab-525500d15501")
 			dv.Status.Progress = cdiv1.DataVolumeProgress("2.3%")
@@ -2104,6 +2351,32 @@ func newS3ImportDataVolume(name string) *cdiv1.DataVolume {
 	}
 }
 
+func newBackupImportDataVolume(name string) *cdiv1.DataVolume {
+	dv := newS3ImportDataVolume(name)
+	dv.Spec.Source = &cdiv1.DataVolumeSource{
+		Backup: &cdiv1.DataVolumeSourceBackup{Name: "test-backup"},
+	}
+	return dv
+}
+
+func newSucceededDataVolumeBackup() *cdiv1.DataVolumeBackup {
+	return &cdiv1.DataVolumeBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-backup",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: cdiv1.DataVolumeBackupSpec{
+			Source: cdiv1.DataVolumeBackupSource{Name: "source-pvc"},
+			Destination: cdiv1.DataVolumeBackupDestination{
+				S3: &cdiv1.DataVolumeSourceS3{URL: "http://example.com/bucket/backup.img.gz", SecretRef: "s3-secret"},
+			},
+		},
+		Status: cdiv1.DataVolumeBackupStatus{
+			Phase: cdiv1.DataVolumeBackupSucceeded,
+		},
+	}
+}
+
 func newUploadDataVolume(name string) *cdiv1.DataVolume {
 	return &cdiv1.DataVolume{
 		TypeMeta: metav1.TypeMeta{APIVersion: cdiv1.SchemeGroupVersion.String()},
@@ -363,6 +363,9 @@ func (r *PvcCloneReconciler) syncClone(log logr.Logger, req reconcile.Request) (
 		if ok {
 			cc.AddAnnotation(datavolume, cc.AnnCloneType, ct)
 		}
+		if consistent, ok := pvc.Annotations[cc.AnnCloneApplicationConsistent]; ok {
+			cc.AddAnnotation(datavolume, cc.AnnCloneApplicationConsistent, consistent)
+		}
 	} else {
 		cc.AddAnnotation(datavolume, cc.AnnCloneType, string(cdiv1.CloneStrategyHostAssisted))
 		if err := r.fallbackToHostAssisted(pvc); err != nil {
@@ -0,0 +1,540 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/controller/clone"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	dataVolumeBackupControllerName = "datavolumebackup-controller"
+	dataVolumeBackupFinalizer      = "cdi.kubevirt.io/dataVolumeBackup"
+	dataVolumeBackupSourceField    = "spec.source.name"
+	// labelDataVolumeBackupUID is set on the temporary snapshot and PVC of a DataVolumeBackup
+	labelDataVolumeBackupUID = "cdi.kubevirt.io/dataVolumeBackupUID"
+	dataVolumeBackupPrefix   = "cdi-backup-"
+
+	// BackupSourceNotFound provides a const to indicate the backup source PVC does not exist
+	BackupSourceNotFound = "BackupSourceNotFound"
+	// BackupNoSnapshotClass provides a const to indicate no VolumeSnapshotClass matches the backup source
+	BackupNoSnapshotClass = "BackupNoSnapshotClass"
+	// BackupInvalidDestination provides a const to indicate the backup destination is invalid
+	BackupInvalidDestination = "BackupInvalidDestination"
+	// BackupSucceeded provides a const to indicate the backup completed
+	BackupSucceeded = "BackupSucceeded"
+	// BackupFailed provides a const to indicate the backup pod failed
+	BackupFailed = "BackupFailed"
+)
+
+// DataVolumeBackupReconciler members
+type DataVolumeBackupReconciler struct {
+	client          client.Client
+	recorder        record.EventRecorder
+	scheme          *runtime.Scheme
+	log             logr.Logger
+	image           string
+	pullPolicy      string
+	verbose         string
+	installerLabels map[string]string
+}
+
+// Reconcile loop for DataVolumeBackupReconciler
+func (r *DataVolumeBackupReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	backup := &cdiv1.DataVolumeBackup{}
+	if err := r.client.Get(ctx, req.NamespacedName, backup); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log := r.log.WithValues("DataVolumeBackup", req.NamespacedName)
+
+	if backup.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.cleanup(ctx, backup, false)
+	}
+	if backup.Status.Phase == cdiv1.DataVolumeBackupSucceeded || backup.Status.Phase == cdiv1.DataVolumeBackupFailed {
+		return reconcile.Result{}, r.cleanup(ctx, backup, backup.Status.Phase == cdiv1.DataVolumeBackupSucceeded)
+	}
+	if !cc.HasFinalizer(backup, dataVolumeBackupFinalizer) {
+		cc.AddFinalizer(backup, dataVolumeBackupFinalizer)
+		return reconcile.Result{}, r.client.Update(ctx, backup)
+	}
+
+	backupCopy := backup.DeepCopy()
+	res, err := r.reconcileBackup(ctx, backup, log)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !reflect.DeepEqual(backup.Status, backupCopy.Status) {
+		if err := r.client.Status().Update(ctx, backup); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return res, nil
+}
+
+func (r *DataVolumeBackupReconciler) reconcileBackup(ctx context.Context, backup *cdiv1.DataVolumeBackup, log logr.Logger) (reconcile.Result, error) {
+	if err := validateBackupDestination(&backup.Spec.Destination); err != nil {
+		r.failBackup(backup, BackupInvalidDestination, err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	sourcePvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: backup.Namespace, Name: backup.Spec.Source.Name}, sourcePvc); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		updateBackupPhase(backup, cdiv1.DataVolumeBackupPending, fmt.Sprintf("Source PVC %s not found", backup.Spec.Source.Name))
+		r.recorder.Event(backup, corev1.EventTypeWarning, BackupSourceNotFound, backup.Status.Message)
+		return reconcile.Result{}, nil
+	}
+
+	if backup.Status.StartTime == nil {
+		backup.Status.StartTime = ptr.To(metav1.Now())
+	}
+	if backup.Status.Size == nil {
+		size, ok := sourcePvc.Status.Capacity[corev1.ResourceStorage]
+		if !ok {
+			size = sourcePvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		}
+		backup.Status.Size = &size
+	}
+	backup.Status.VolumeMode = ptr.To(util.ResolveVolumeMode(sourcePvc.Spec.VolumeMode))
+
+	snapshotClass, err := cc.GetSnapshotClassForSmartClone(nil, sourcePvc.Spec.StorageClassName, backup.Spec.VolumeSnapshotClassName, log, r.client, r.recorder)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if snapshotClass == "" {
+		r.failBackup(backup, BackupNoSnapshotClass, "No VolumeSnapshotClass found for the source PVC storage class")
+		return reconcile.Result{}, nil
+	}
+
+	tempName := getDataVolumeBackupTempName(backup)
+	snapshotPhase := &clone.SnapshotPhase{
+		Owner:               backup,
+		SourceNamespace:     backup.Namespace,
+		SourceName:          sourcePvc.Name,
+		TargetName:          tempName,
+		VolumeSnapshotClass: snapshotClass,
+		OwnershipLabel:      labelDataVolumeBackupUID,
+		Client:              r.client,
+		Log:                 log,
+		Recorder:            r.recorder,
+	}
+	if res, err := snapshotPhase.Reconcile(ctx); res != nil || err != nil {
+		updateBackupPhase(backup, cdiv1.DataVolumeBackupSnapshotInProgress, "")
+		return ptr.Deref(res, reconcile.Result{}), err
+	}
+
+	claimPhase := &clone.SnapshotClonePhase{
+		Owner:          backup,
+		Namespace:      backup.Namespace,
+		SourceName:     tempName,
+		DesiredClaim:   newDataVolumeBackupClaim(sourcePvc, tempName),
+		OwnershipLabel: labelDataVolumeBackupUID,
+		Client:         r.client,
+		Log:            log,
+		Recorder:       r.recorder,
+	}
+	if res, err := claimPhase.Reconcile(ctx); res != nil || err != nil {
+		updateBackupPhase(backup, cdiv1.DataVolumeBackupSnapshotInProgress, "")
+		return ptr.Deref(res, reconcile.Result{}), err
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: backup.Namespace, Name: tempName}, pod); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		if pod, err = r.createBackupPod(ctx, backup, sourcePvc); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		updateBackupPhase(backup, cdiv1.DataVolumeBackupSucceeded, "Backup completed")
+		backup.Status.CompletionTime = ptr.To(metav1.Now())
+		r.recorder.Event(backup, corev1.EventTypeNormal, BackupSucceeded, backup.Status.Message)
+	case corev1.PodFailed:
+		message := "Backup pod failed"
+		if termMsg := getBackupPodTerminationMessage(pod); termMsg != "" {
+			message = fmt.Sprintf("%s: %s", message, termMsg)
+		}
+		r.failBackup(backup, BackupFailed, message)
+	default:
+		updateBackupPhase(backup, cdiv1.DataVolumeBackupInProgress, "")
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *DataVolumeBackupReconciler) failBackup(backup *cdiv1.DataVolumeBackup, reason, message string) {
+	updateBackupPhase(backup, cdiv1.DataVolumeBackupFailed, message)
+	backup.Status.CompletionTime = ptr.To(metav1.Now())
+	r.recorder.Event(backup, corev1.EventTypeWarning, reason, message)
+}
+
+// cleanup deletes the temporary snapshot and PVC of the backup, and the backup pod if requested, then removes the finalizer
+func (r *DataVolumeBackupReconciler) cleanup(ctx context.Context, backup *cdiv1.DataVolumeBackup, deletePod bool) error {
+	if !cc.HasFinalizer(backup, dataVolumeBackupFinalizer) {
+		return nil
+	}
+	tempName := getDataVolumeBackupTempName(backup)
+	objs := []client.Object{
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: backup.Namespace, Name: tempName}},
+		&snapshotv1.VolumeSnapshot{ObjectMeta: metav1.ObjectMeta{Namespace: backup.Namespace, Name: tempName}},
+	}
+	if deletePod {
+		objs = append(objs, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: backup.Namespace, Name: tempName}})
+	}
+	for _, obj := range objs {
+		if err := r.client.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return err
+		}
+	}
+	cc.RemoveFinalizer(backup, dataVolumeBackupFinalizer)
+	return r.client.Update(ctx, backup)
+}
+
+func (r *DataVolumeBackupReconciler) createBackupPod(ctx context.Context, backup *cdiv1.DataVolumeBackup, sourcePvc *corev1.PersistentVolumeClaim) (*corev1.Pod, error) {
+	resourceRequirements, err := cc.GetDefaultPodResourceRequirements(r.client)
+	if err != nil {
+		return nil, err
+	}
+	imagePullSecrets, err := cc.GetImagePullSecrets(r.client)
+	if err != nil {
+		return nil, err
+	}
+	workloadNodePlacement, err := cc.GetWorkloadNodePlacement(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	pod := r.makeBackupPodSpec(backup, util.ResolveVolumeMode(sourcePvc.Spec.VolumeMode))
+	if resourceRequirements != nil {
+		pod.Spec.Containers[0].Resources = *resourceRequirements
+	}
+	pod.Spec.ImagePullSecrets = imagePullSecrets
+	pod.Spec.NodeSelector = workloadNodePlacement.NodeSelector
+	pod.Spec.Tolerations = workloadNodePlacement.Tolerations
+	pod.Spec.Affinity = workloadNodePlacement.Affinity
+	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
+
+	if err := r.client.Create(ctx, pod); err != nil {
+		return nil, err
+	}
+	r.log.V(3).Info("backup pod created", "pod.Name", pod.Name, "pod.Namespace", pod.Namespace)
+	return pod, nil
+}
+
+func (r *DataVolumeBackupReconciler) makeBackupPodSpec(backup *cdiv1.DataVolumeBackup, volumeMode corev1.PersistentVolumeMode) *corev1.Pod {
+	tempName := getDataVolumeBackupTempName(backup)
+	podEnvVar := &importPodEnvVar{}
+	if s3 := backup.Spec.Destination.S3; s3 != nil {
+		podEnvVar.source = cc.SourceS3
+		podEnvVar.ep = s3.URL
+		podEnvVar.secretName = s3.SecretRef
+		podEnvVar.certConfigMap = s3.CertConfigMap
+		podEnvVar.s3Region = s3.Region
+		podEnvVar.s3AddressingStyle = string(s3.AddressingStyle)
+		if identity := s3.WebIdentity; identity != nil {
+			podEnvVar.s3RoleARN = identity.RoleARN
+			podEnvVar.s3WebIdentityAudience = identity.Audience
+			podEnvVar.s3STSEndpoint = identity.STSEndpoint
+		}
+	} else if gcs := backup.Spec.Destination.GCS; gcs != nil {
+		podEnvVar.source = cc.SourceGCS
+		podEnvVar.ep = gcs.URL
+		podEnvVar.secretName = gcs.SecretRef
+		if identity := gcs.WorkloadIdentity; identity != nil {
+			podEnvVar.gcsWorkloadIdentity = true
+			podEnvVar.gcsWorkloadIdentityProvider = identity.Provider
+			podEnvVar.gcsServiceAccount = identity.ServiceAccount
+			podEnvVar.gcsWorkloadIdentityAudience = identity.Audience
+		}
+	}
+	env := append(makeImportEnv(podEnvVar, backup.UID), corev1.EnvVar{
+		Name:  common.ImporterBackup,
+		Value: "true",
+	})
+
+	container := corev1.Container{
+		Name:                     common.ImporterPodName,
+		Image:                    r.image,
+		ImagePullPolicy:          corev1.PullPolicy(r.pullPolicy),
+		Args:                     []string{"-v=" + r.verbose},
+		Env:                      env,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	if volumeMode == corev1.PersistentVolumeBlock {
+		container.VolumeDevices = cc.AddVolumeDevices()
+	} else {
+		container.VolumeMounts = cc.AddImportVolumeMounts()
+	}
+	volumes := []corev1.Volume{
+		{
+			Name: cc.DataVolName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: tempName,
+					ReadOnly:  false,
+				},
+			},
+		},
+	}
+	if podEnvVar.certConfigMap != "" {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      CertVolName,
+			MountPath: common.ImporterCertDir,
+		})
+		volumes = append(volumes, createConfigMapVolume(CertVolName, podEnvVar.certConfigMap))
+	}
+	if hasS3WebIdentity(podEnvVar) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      s3WebIdentityVolName,
+			MountPath: common.ImporterS3WebIdentityTokenDir,
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, createS3WebIdentityVolume(podEnvVar.s3WebIdentityAudience))
+	}
+	if hasGCSWorkloadIdentityToken(podEnvVar) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      gcsWorkloadIdentityVolName,
+			MountPath: common.ImporterGCSWorkloadIdentityTokenDir,
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, createGCSWorkloadIdentityVolume(podEnvVar.gcsWorkloadIdentityProvider, podEnvVar.gcsWorkloadIdentityAudience))
+	}
+	if podEnvVar.source == cc.SourceGCS && podEnvVar.secretName != "" {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterGoogleCredentialDir,
+		})
+		volumes = append(volumes, createSecretVolume(SecretVolName, podEnvVar.secretName))
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tempName,
+			Namespace: backup.Namespace,
+			Annotations: map[string]string{
+				cc.AnnCreatedBy: "yes",
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ImporterPodName,
+				labelDataVolumeBackupUID: string(backup.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         cdiv1.SchemeGroupVersion.String(),
+					Kind:               "DataVolumeBackup",
+					Name:               backup.Name,
+					UID:                backup.UID,
+					BlockOwnerDeletion: ptr.To[bool](true),
+					Controller:         ptr.To[bool](true),
+				},
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers:    []corev1.Container{container},
+			Volumes:       volumes,
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+	cc.SetRestrictedSecurityContext(&pod.Spec)
+	return pod
+}
+
+// newDataVolumeBackupClaim returns the temporary PVC the source snapshot is restored to
+func newDataVolumeBackupClaim(sourcePvc *corev1.PersistentVolumeClaim, name string) *corev1.PersistentVolumeClaim {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: sourcePvc.Namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      sourcePvc.Spec.AccessModes,
+			StorageClassName: sourcePvc.Spec.StorageClassName,
+			VolumeMode:       sourcePvc.Spec.VolumeMode,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: sourcePvc.Spec.Resources.Requests[corev1.ResourceStorage],
+				},
+			},
+		},
+	}
+	return claim
+}
+
+func validateBackupDestination(destination *cdiv1.DataVolumeBackupDestination) error {
+	switch {
+	case destination.S3 != nil && destination.GCS != nil:
+		return fmt.Errorf("only one of s3 and gcs destinations may be set")
+	case destination.S3 != nil && destination.S3.URL == "":
+		return fmt.Errorf("s3 destination url is missing")
+	case destination.GCS != nil && destination.GCS.URL == "":
+		return fmt.Errorf("gcs destination url is missing")
+	case destination.S3 == nil && destination.GCS == nil:
+		return fmt.Errorf("no destination set")
+	}
+	return nil
+}
+
+func updateBackupPhase(backup *cdiv1.DataVolumeBackup, phase cdiv1.DataVolumeBackupPhase, message string) {
+	backup.Status.Phase = phase
+	backup.Status.Message = message
+}
+
+func getBackupPodTerminationMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			return status.State.Terminated.Message
+		}
+	}
+	return ""
+}
+
+func getDataVolumeBackupTempName(backup *cdiv1.DataVolumeBackup) string {
+	return dataVolumeBackupPrefix + string(backup.UID)
+}
+
+// NewDataVolumeBackupController creates a new instance of the DataVolumeBackup controller
+func NewDataVolumeBackupController(mgr manager.Manager, log logr.Logger, importerImage, pullPolicy, verbose string, installerLabels map[string]string) (controller.Controller, error) {
+	reconciler := &DataVolumeBackupReconciler{
+		client:          mgr.GetClient(),
+		recorder:        mgr.GetEventRecorderFor(dataVolumeBackupControllerName),
+		scheme:          mgr.GetScheme(),
+		log:             log.WithName(dataVolumeBackupControllerName),
+		image:           importerImage,
+		pullPolicy:      pullPolicy,
+		verbose:         verbose,
+		installerLabels: installerLabels,
+	}
+	dataVolumeBackupController, err := controller.New(dataVolumeBackupControllerName, mgr, controller.Options{
+		MaxConcurrentReconciles: 3,
+		Reconciler:              reconciler,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addDataVolumeBackupControllerWatches(mgr, dataVolumeBackupController, log); err != nil {
+		return nil, err
+	}
+	log.Info("Initialized DataVolumeBackup controller")
+	return dataVolumeBackupController, nil
+}
+
+func addDataVolumeBackupControllerWatches(mgr manager.Manager, c controller.Controller, log logr.Logger) error {
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &cdiv1.DataVolumeBackup{}, dataVolumeBackupSourceField, func(obj client.Object) []string {
+		return []string{obj.(*cdiv1.DataVolumeBackup).Spec.Source.Name}
+	}); err != nil {
+		return err
+	}
+
+	if err := c.Watch(source.Kind(mgr.GetCache(), &cdiv1.DataVolumeBackup{}, &handler.TypedEnqueueRequestForObject[*cdiv1.DataVolumeBackup]{})); err != nil {
+		return err
+	}
+
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{}, handler.TypedEnqueueRequestForOwner[*corev1.Pod](
+		mgr.GetScheme(), mgr.GetClient().RESTMapper(), &cdiv1.DataVolumeBackup{}, handler.OnlyControllerOwner()))); err != nil {
+		return err
+	}
+
+	// Temporary PVCs are mapped by ownership label, source PVCs by the backups referring to them
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.PersistentVolumeClaim{},
+		handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj *corev1.PersistentVolumeClaim) []reconcile.Request {
+			reqs := mapToDataVolumeBackupByLabel(ctx, mgr.GetClient(), obj, log)
+			var backups cdiv1.DataVolumeBackupList
+			if err := mgr.GetClient().List(ctx, &backups, client.InNamespace(obj.Namespace), client.MatchingFields{dataVolumeBackupSourceField: obj.Name}); err != nil {
+				log.Error(err, "Unable to list DataVolumeBackups")
+				return reqs
+			}
+			for _, backup := range backups.Items {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name}})
+			}
+			return reqs
+		}),
+	)); err != nil {
+		return err
+	}
+
+	if err := mgr.GetClient().List(context.TODO(), &snapshotv1.VolumeSnapshotList{}); err != nil {
+		if meta.IsNoMatchError(err) {
+			// Back out if there's no point to attempt watch
+			return nil
+		}
+		if !cc.IsErrCacheNotStarted(err) {
+			return err
+		}
+	}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &snapshotv1.VolumeSnapshot{},
+		handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, obj *snapshotv1.VolumeSnapshot) []reconcile.Request {
+			return mapToDataVolumeBackupByLabel(ctx, mgr.GetClient(), obj, log)
+		}),
+	)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mapToDataVolumeBackupByLabel(ctx context.Context, c client.Client, obj client.Object, log logr.Logger) []reconcile.Request {
+	uid, ok := obj.GetLabels()[labelDataVolumeBackupUID]
+	if !ok {
+		return nil
+	}
+	var backups cdiv1.DataVolumeBackupList
+	if err := c.List(ctx, &backups, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.Error(err, "Unable to list DataVolumeBackups")
+		return nil
+	}
+	for _, backup := range backups.Items {
+		if string(backup.UID) == uid {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: backup.Namespace, Name: backup.Name}}}
+		}
+	}
+	return nil
+}
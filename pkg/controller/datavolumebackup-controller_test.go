@@ -0,0 +1,264 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const (
+	backupName              = "test-backup"
+	backupSourceName        = "test-backup-source"
+	backupStorageClassName  = "test-backup-sc"
+	backupSnapshotClassName = "test-backup-vsc"
+	backupProvisioner       = "test.backup.csi"
+)
+
+var dvBackupLog = logf.Log.WithName("datavolumebackup-controller-test")
+
+var _ = Describe("DataVolumeBackup controller reconcile loop", func() {
+	var reconciler *DataVolumeBackupReconciler
+
+	reconcileBackup := func() *cdiv1.DataVolumeBackup {
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: backupName, Namespace: metav1.NamespaceDefault}})
+		Expect(err).ToNot(HaveOccurred())
+		backup := &cdiv1.DataVolumeBackup{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: backupName, Namespace: metav1.NamespaceDefault}, backup)
+		Expect(err).ToNot(HaveOccurred())
+		return backup
+	}
+
+	getTempObject := func(backup *cdiv1.DataVolumeBackup, obj client.Object) error {
+		return reconciler.client.Get(context.TODO(), types.NamespacedName{Name: getDataVolumeBackupTempName(backup), Namespace: metav1.NamespaceDefault}, obj)
+	}
+
+	It("Should do nothing when no DataVolumeBackup exists", func() {
+		reconciler = createDataVolumeBackupReconciler()
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: backupName, Namespace: metav1.NamespaceDefault}})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Should add the finalizer first", func() {
+		reconciler = createDataVolumeBackupReconciler(createDataVolumeBackup())
+		backup := reconcileBackup()
+		Expect(backup.Finalizers).To(ContainElement(dataVolumeBackupFinalizer))
+		Expect(backup.Status.Phase).To(BeEmpty())
+	})
+
+	DescribeTable("Should fail when the destination is invalid", func(destination cdiv1.DataVolumeBackupDestination, message string) {
+		backup := createDataVolumeBackup()
+		backup.Spec.Destination = destination
+		reconciler = createDataVolumeBackupReconciler(backup)
+		reconcileBackup()
+		backup = reconcileBackup()
+		Expect(backup.Status.Phase).To(Equal(cdiv1.DataVolumeBackupFailed))
+		Expect(backup.Status.Message).To(Equal(message))
+		Expect(backup.Status.CompletionTime).ToNot(BeNil())
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(BackupInvalidDestination)))
+	},
+		Entry("no destination", cdiv1.DataVolumeBackupDestination{}, "no destination set"),
+		Entry("both destinations", cdiv1.DataVolumeBackupDestination{
+			S3:  &cdiv1.DataVolumeSourceS3{URL: "http://s3/bucket/backup"},
+			GCS: &cdiv1.DataVolumeSourceGCS{URL: "gs://bucket/backup"},
+		}, "only one of s3 and gcs destinations may be set"),
+		Entry("s3 without url", cdiv1.DataVolumeBackupDestination{S3: &cdiv1.DataVolumeSourceS3{}}, "s3 destination url is missing"),
+	)
+
+	It("Should stay pending while the source PVC does not exist", func() {
+		reconciler = createDataVolumeBackupReconciler(createDataVolumeBackup())
+		reconcileBackup()
+		backup := reconcileBackup()
+		Expect(backup.Status.Phase).To(Equal(cdiv1.DataVolumeBackupPending))
+		Expect(backup.Status.Message).To(ContainSubstring("not found"))
+	})
+
+	It("Should fail when no VolumeSnapshotClass matches the source", func() {
+		reconciler = createDataVolumeBackupReconciler(createDataVolumeBackup(), createBackupSourcePvc(),
+			CreateStorageClassWithProvisioner(backupStorageClassName, nil, nil, backupProvisioner))
+		reconcileBackup()
+		backup := reconcileBackup()
+		Expect(backup.Status.Phase).To(Equal(cdiv1.DataVolumeBackupFailed))
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(BackupNoSnapshotClass)))
+	})
+
+	Context("with a snapshot capable source", func() {
+		BeforeEach(func() {
+			reconciler = createDataVolumeBackupReconciler(createDataVolumeBackup(), createBackupSourcePvc(),
+				CreateStorageClassWithProvisioner(backupStorageClassName, nil, nil, backupProvisioner),
+				createSnapshotClass(backupSnapshotClassName, nil, backupProvisioner),
+				createVolumeSnapshotContentCrd(), createVolumeSnapshotClassCrd(), createVolumeSnapshotCrd())
+		})
+
+		// reconcileToPod reconciles the backup through the snapshot and temporary PVC to the backup pod
+		reconcileToPod := func() (*cdiv1.DataVolumeBackup, *corev1.Pod) {
+			reconcileBackup()
+			backup := reconcileBackup()
+			Expect(backup.Status.Phase).To(Equal(cdiv1.DataVolumeBackupSnapshotInProgress))
+			Expect(backup.Status.Size.Cmp(resource.MustParse("1Gi"))).To(Equal(0))
+			Expect(*backup.Status.VolumeMode).To(Equal(corev1.PersistentVolumeFilesystem))
+			Expect(backup.Status.StartTime).ToNot(BeNil())
+
+			snapshot := &snapshotv1.VolumeSnapshot{}
+			Expect(getTempObject(backup, snapshot)).To(Succeed())
+			Expect(*snapshot.Spec.Source.PersistentVolumeClaimName).To(Equal(backupSourceName))
+			Expect(*snapshot.Spec.VolumeSnapshotClassName).To(Equal(backupSnapshotClassName))
+			Expect(snapshot.Labels[labelDataVolumeBackupUID]).To(Equal(string(backup.UID)))
+			snapshot.Status = &snapshotv1.VolumeSnapshotStatus{
+				CreationTime: ptr.To(metav1.Now()),
+				ReadyToUse:   ptr.To(true),
+				RestoreSize:  ptr.To(resource.MustParse("1Gi")),
+			}
+			Expect(reconciler.client.Update(context.TODO(), snapshot)).To(Succeed())
+
+			backup = reconcileBackup()
+			Expect(backup.Status.Phase).To(Equal(cdiv1.DataVolumeBackupSnapshotInProgress))
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(getTempObject(backup, pvc)).To(Succeed())
+			Expect(pvc.Spec.DataSourceRef.Name).To(Equal(snapshot.Name))
+			Expect(*pvc.Spec.StorageClassName).To(Equal(backupStorageClassName))
+			pvc.Status.Phase = corev1.ClaimBound
+			Expect(reconciler.client.Status().Update(context.TODO(), pvc)).To(Succeed())
+
+			backup = reconcileBackup()
+			Expect(backup.Status.Phase).To(Equal(cdiv1.DataVolumeBackupInProgress))
+			pod := &corev1.Pod{}
+			Expect(getTempObject(backup, pod)).To(Succeed())
+			return backup, pod
+		}
+
+		It("Should back up the snapshot of the source and clean up", func() {
+			backup, pod := reconcileToPod()
+			Expect(pod.OwnerReferences[0].UID).To(Equal(backup.UID))
+			Expect(pod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(getDataVolumeBackupTempName(backup)))
+			Expect(pod.Spec.Containers[0].VolumeMounts).ToNot(BeEmpty())
+			Expect(pod.Spec.Containers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: common.ImporterBackup, Value: "true"},
+				corev1.EnvVar{Name: common.ImporterSource, Value: SourceS3},
+				corev1.EnvVar{Name: common.ImporterEndpoint, Value: "http://s3.example.com/bucket/backup.img.gz"},
+			))
+
+			pod.Status.Phase = corev1.PodSucceeded
+			Expect(reconciler.client.Status().Update(context.TODO(), pod)).To(Succeed())
+			backup = reconcileBackup()
+			Expect(backup.Status.Phase).To(Equal(cdiv1.DataVolumeBackupSucceeded))
+			Expect(backup.Status.CompletionTime).ToNot(BeNil())
+
+			backup = reconcileBackup()
+			Expect(backup.Finalizers).ToNot(ContainElement(dataVolumeBackupFinalizer))
+			Expect(k8serrors.IsNotFound(getTempObject(backup, &snapshotv1.VolumeSnapshot{}))).To(BeTrue())
+			Expect(k8serrors.IsNotFound(getTempObject(backup, &corev1.PersistentVolumeClaim{}))).To(BeTrue())
+			Expect(k8serrors.IsNotFound(getTempObject(backup, &corev1.Pod{}))).To(BeTrue())
+		})
+
+		It("Should fail with the termination message of the backup pod and keep the pod", func() {
+			backup, pod := reconcileToPod()
+			pod.Status.Phase = corev1.PodFailed
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "access denied"}},
+			}}
+			Expect(reconciler.client.Status().Update(context.TODO(), pod)).To(Succeed())
+			backup = reconcileBackup()
+			Expect(backup.Status.Phase).To(Equal(cdiv1.DataVolumeBackupFailed))
+			Expect(backup.Status.Message).To(Equal("Backup pod failed: access denied"))
+
+			backup = reconcileBackup()
+			Expect(backup.Finalizers).ToNot(ContainElement(dataVolumeBackupFinalizer))
+			Expect(k8serrors.IsNotFound(getTempObject(backup, &corev1.PersistentVolumeClaim{}))).To(BeTrue())
+			Expect(getTempObject(backup, &corev1.Pod{})).To(Succeed())
+		})
+
+		It("Should clean up the snapshot when the backup is deleted", func() {
+			reconcileBackup()
+			backup := reconcileBackup()
+			Expect(getTempObject(backup, &snapshotv1.VolumeSnapshot{})).To(Succeed())
+
+			Expect(reconciler.client.Delete(context.TODO(), backup)).To(Succeed())
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: backupName, Namespace: metav1.NamespaceDefault}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(k8serrors.IsNotFound(getTempObject(backup, &snapshotv1.VolumeSnapshot{}))).To(BeTrue())
+			Expect(k8serrors.IsNotFound(reconciler.client.Get(context.TODO(), client.ObjectKeyFromObject(backup), backup))).To(BeTrue())
+		})
+	})
+})
+
+func createDataVolumeBackupReconciler(objects ...runtime.Object) *DataVolumeBackupReconciler {
+	objs := append([]runtime.Object{MakeEmptyCDICR(), MakeEmptyCDIConfigSpec(common.ConfigName)}, objects...)
+	s := scheme.Scheme
+	_ = cdiv1.AddToScheme(s)
+	_ = snapshotv1.AddToScheme(s)
+	_ = extv1.AddToScheme(s)
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).
+		WithStatusSubresource(&cdiv1.DataVolumeBackup{}).Build()
+	return &DataVolumeBackupReconciler{
+		client:     cl,
+		recorder:   record.NewFakeRecorder(10),
+		scheme:     s,
+		log:        dvBackupLog,
+		image:      "test/myimage",
+		pullPolicy: "Always",
+		verbose:    "5",
+	}
+}
+
+func createDataVolumeBackup() *cdiv1.DataVolumeBackup {
+	return &cdiv1.DataVolumeBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backupName,
+			Namespace: metav1.NamespaceDefault,
+			UID:       types.UID("backup-uid"),
+		},
+		Spec: cdiv1.DataVolumeBackupSpec{
+			Source: cdiv1.DataVolumeBackupSource{Name: backupSourceName},
+			Destination: cdiv1.DataVolumeBackupDestination{
+				S3: &cdiv1.DataVolumeSourceS3{URL: "http://s3.example.com/bucket/backup.img.gz", SecretRef: "s3-secret"},
+			},
+		},
+	}
+}
+
+func createBackupSourcePvc() *corev1.PersistentVolumeClaim {
+	pvc := CreatePvcInStorageClass(backupSourceName, metav1.NamespaceDefault, ptr.To(backupStorageClassName), nil, nil, corev1.ClaimBound)
+	pvc.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeFilesystem)
+	pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")}
+	return pvc
+}
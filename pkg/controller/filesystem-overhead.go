@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+// measureFilesystemOverhead returns the overhead of a Filesystem volume of the given capacity, which had
+// the given space available before anything was written to it, rounded up to a valid Percent
+func measureFilesystemOverhead(capacity, available int64) (cdiv1.Percent, bool) {
+	if capacity <= 0 || available <= 0 {
+		return "", false
+	}
+	// Same definition as util.GetRequiredSpace, capacity = available * (1 + overhead)
+	overhead := math.Ceil((float64(capacity)/float64(available)-1)*1000) / 1000
+	if overhead < 0 {
+		overhead = 0
+	}
+	if overhead >= 1 {
+		return "", false
+	}
+	return cdiv1.Percent(strconv.FormatFloat(overhead, 'f', -1, 64)), true
+}
+
+// calibrateFilesystemOverhead records the filesystem overhead measured by a completed import in the CDIConfig status
+func (r *ImportReconciler) calibrateFilesystemOverhead(pvc *corev1.PersistentVolumeClaim, termMsg *common.TerminationMessage, log logr.Logger) error {
+	if termMsg == nil || termMsg.AvailableSpace == nil ||
+		util.ResolveVolumeMode(pvc.Spec.VolumeMode) != corev1.PersistentVolumeFilesystem ||
+		pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" ||
+		metav1.HasAnnotation(pvc.ObjectMeta, cc.AnnCurrentCheckpoint) {
+		return nil
+	}
+	// A restarted importer measures the space available after a partial write
+	if restarts, _ := strconv.Atoi(pvc.Annotations[cc.AnnPodRestarts]); restarts > 0 {
+		log.V(1).Info("Ignoring filesystem overhead measurement of a restarted import", "restarts", restarts)
+		return nil
+	}
+	capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+	overhead, ok := measureFilesystemOverhead(capacity.Value(), *termMsg.AvailableSpace)
+	if !ok {
+		log.V(1).Info("Ignoring filesystem overhead measurement", "capacity", capacity.Value(), "available", *termMsg.AvailableSpace)
+		return nil
+	}
+
+	storageClassName := *pvc.Spec.StorageClassName
+	// The import is measured once, a conflicting update must not drop its sample
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		config := &cdiv1.CDIConfig{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
+			return err
+		}
+		calibration := config.Status.FilesystemOverheadCalibration[storageClassName]
+		if calibration.Samples == 0 || percentValue(overhead) > percentValue(calibration.Recommended) {
+			calibration.Recommended = overhead
+		}
+		calibration.Samples++
+		calibration.LastMeasurementTime = metav1.Now()
+		if config.Status.FilesystemOverheadCalibration == nil {
+			config.Status.FilesystemOverheadCalibration = make(map[string]cdiv1.FilesystemOverheadCalibration)
+		}
+		config.Status.FilesystemOverheadCalibration[storageClassName] = calibration
+
+		log.V(1).Info("Measured filesystem overhead", "storageClass", storageClassName, "overhead", overhead, "recommended", calibration.Recommended)
+		return r.client.Update(context.TODO(), config)
+	})
+}
+
+func percentValue(percent cdiv1.Percent) float64 {
+	value, _ := strconv.ParseFloat(string(percent), 64)
+	return value
+}
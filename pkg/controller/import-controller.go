@@ -57,6 +57,18 @@ const (
 
 	// secretExtraHeadersVolumeName is the format string that specifies where extra HTTP header secrets will be mounted
 	secretExtraHeadersVolumeName = "cdi-secret-extra-headers-vol-%d"
+
+	// chunkCacheVolName is the name of the volume containing the import chunk cache
+	chunkCacheVolName = "cdi-chunk-cache-vol"
+
+	// s3WebIdentityVolName is the name of the volume containing the projected service account token of S3 web identities
+	s3WebIdentityVolName = "cdi-s3-web-identity-vol"
+	// s3WebIdentityDefaultAudience is the audience of the projected service account token when not set in the source
+	s3WebIdentityDefaultAudience = "sts.amazonaws.com"
+	// gcsWorkloadIdentityVolName is the name of the volume containing the projected service account token of GCS workload identities
+	gcsWorkloadIdentityVolName = "cdi-gcs-workload-identity-vol"
+	// serviceAccountTokenExpirationSeconds is the lifetime of the projected service account tokens, kubelet renews them before
+	serviceAccountTokenExpirationSeconds int64 = 3600
 )
 
 // ImportReconciler members
@@ -76,33 +88,55 @@ type ImportReconciler struct {
 }
 
 type importPodEnvVar struct {
-	ep                        string
-	secretName                string
-	source                    string
-	contentType               string
-	imageSize                 string
-	certConfigMap             string
-	diskID                    string
-	uuid                      string
-	pullMethod                string
-	readyFile                 string
-	doneFile                  string
-	backingFile               string
-	thumbprint                string
-	filesystemOverhead        string
-	insecureTLS               bool
-	currentCheckpoint         string
-	previousCheckpoint        string
-	finalCheckpoint           string
-	preallocation             bool
-	httpProxy                 string
-	httpsProxy                string
-	noProxy                   string
-	certConfigMapProxy        string
-	extraHeaders              []string
-	secretExtraHeaders        []string
-	cacheMode                 string
-	registryImageArchitecture string
+	ep                          string
+	secretName                  string
+	source                      string
+	contentType                 string
+	imageSize                   string
+	certConfigMap               string
+	diskID                      string
+	uuid                        string
+	pullMethod                  string
+	readyFile                   string
+	doneFile                    string
+	backingFile                 string
+	thumbprint                  string
+	filesystemOverhead          string
+	insecureTLS                 bool
+	currentCheckpoint           string
+	previousCheckpoint          string
+	finalCheckpoint             string
+	preallocation               bool
+	expandPartition             bool
+	sparsify                    bool
+	httpProxy                   string
+	httpsProxy                  string
+	noProxy                     string
+	certConfigMapProxy          string
+	extraHeaders                []string
+	secretExtraHeaders          []string
+	cacheMode                   string
+	writeBlockSize              string
+	zeroDetection               string
+	registryImageArchitecture   string
+	differencingDisks           string
+	cdiBackupManifest           bool
+	patchOffset                 string
+	patchPartitionLabel         string
+	azureBlobAccount            string
+	s3Region                    string
+	s3AddressingStyle           string
+	s3RoleARN                   string
+	s3WebIdentityAudience       string
+	s3STSEndpoint               string
+	gcsWorkloadIdentity         bool
+	gcsWorkloadIdentityProvider string
+	gcsServiceAccount           string
+	gcsWorkloadIdentityAudience string
+	chunkCacheHostPath          string
+	chunkCacheClaim             string
+	chunkCacheScope             string
+	chunkCacheLimit             string
 }
 
 type importerPodArgs struct {
@@ -118,6 +152,7 @@ type importerPodArgs struct {
 	workloadNodePlacement   *sdkapi.NodePlacement
 	vddkImageName           *string
 	vddkExtraArgs           *string
+	customizeConfigMap      string
 	priorityClassName       string
 }
 
@@ -377,6 +412,7 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 
 	log.V(1).Info("Updating PVC from pod")
 	anno := pvc.GetAnnotations()
+	wasComplete := cc.IsPVCComplete(pvc)
 
 	termMsg, err := parseTerminationMessage(pod)
 	if err != nil {
@@ -398,7 +434,11 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 		}
 		if terminated := statuses[0].State.Terminated; terminated != nil && terminated.ExitCode > 0 {
 			log.Info("Pod termination code", "pod.Name", pod.Name, "ExitCode", terminated.ExitCode)
-			r.recorder.Event(pvc, corev1.EventTypeWarning, ErrImportFailedPVC, terminated.Message)
+			message := terminated.Message
+			if termMsg != nil && termMsg.Message != nil {
+				message = *termMsg.Message
+			}
+			r.recorder.Event(pvc, corev1.EventTypeWarning, ErrImportFailedPVC, message)
 		}
 	}
 
@@ -460,6 +500,13 @@ func (r *ImportReconciler) updatePvcFromPod(pvc *corev1.PersistentVolumeClaim, p
 		log.V(1).Info("Updated PVC", "pvc.anno.Phase", anno[cc.AnnPodPhase], "pvc.anno.Restarts", anno[cc.AnnPodRestarts])
 	}
 
+	if !wasComplete && cc.IsPVCComplete(pvc) {
+		// The calibration is best effort, it must not hold back the import
+		if err := r.calibrateFilesystemOverhead(pvc, termMsg, log); err != nil {
+			log.Error(err, "Unable to record the filesystem overhead")
+		}
+	}
+
 	if cc.IsPVCComplete(pvc) || podModificationsNeeded {
 		if !podModificationsNeeded {
 			r.recorder.Event(pvc, corev1.EventTypeNormal, ImportSucceededPVC, "Import Successful")
@@ -541,15 +588,16 @@ func (r *ImportReconciler) createImporterPod(pvc *corev1.PersistentVolumeClaim)
 	}
 	// all checks passed, let's create the importer pod!
 	podArgs := &importerPodArgs{
-		image:             r.image,
-		verbose:           r.verbose,
-		pullPolicy:        r.pullPolicy,
-		podEnvVar:         podEnvVar,
-		pvc:               pvc,
-		scratchPvcName:    scratchPvcName,
-		vddkImageName:     vddkImageName,
-		vddkExtraArgs:     vddkExtraArgs,
-		priorityClassName: cc.GetPriorityClass(pvc),
+		image:              r.image,
+		verbose:            r.verbose,
+		pullPolicy:         r.pullPolicy,
+		podEnvVar:          podEnvVar,
+		pvc:                pvc,
+		scratchPvcName:     scratchPvcName,
+		vddkImageName:      vddkImageName,
+		vddkExtraArgs:      vddkExtraArgs,
+		customizeConfigMap: pvc.Annotations[cc.AnnCustomizeConfigMap],
+		priorityClassName:  cc.GetPriorityClass(pvc),
 	}
 
 	pod, err := createImporterPod(context.TODO(), r.log, r.client, podArgs, r.installerLabels)
@@ -618,6 +666,20 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 		podEnvVar.currentCheckpoint = getValueFromAnnotation(pvc, cc.AnnCurrentCheckpoint)
 		podEnvVar.finalCheckpoint = getValueFromAnnotation(pvc, cc.AnnFinalCheckpoint)
 		podEnvVar.registryImageArchitecture = getValueFromAnnotation(pvc, cc.AnnRegistryImageArchitecture)
+		podEnvVar.differencingDisks = getValueFromAnnotation(pvc, cc.AnnDifferencingDisks)
+		podEnvVar.cdiBackupManifest = getValueFromAnnotation(pvc, cc.AnnCDIBackupManifest) == "true"
+		podEnvVar.patchOffset = getValueFromAnnotation(pvc, cc.AnnPatchOffset)
+		podEnvVar.patchPartitionLabel = getValueFromAnnotation(pvc, cc.AnnPatchPartitionLabel)
+		podEnvVar.azureBlobAccount = getValueFromAnnotation(pvc, cc.AnnAzureBlobAccount)
+		podEnvVar.s3Region = getValueFromAnnotation(pvc, cc.AnnS3Region)
+		podEnvVar.s3AddressingStyle = getValueFromAnnotation(pvc, cc.AnnS3AddressingStyle)
+		podEnvVar.s3RoleARN = getValueFromAnnotation(pvc, cc.AnnS3RoleARN)
+		podEnvVar.s3WebIdentityAudience = getValueFromAnnotation(pvc, cc.AnnS3WebIdentityAudience)
+		podEnvVar.s3STSEndpoint = getValueFromAnnotation(pvc, cc.AnnS3STSEndpoint)
+		podEnvVar.gcsWorkloadIdentity = getValueFromAnnotation(pvc, cc.AnnGCSWorkloadIdentity) == "true"
+		podEnvVar.gcsWorkloadIdentityProvider = getValueFromAnnotation(pvc, cc.AnnGCSWorkloadIdentityProvider)
+		podEnvVar.gcsServiceAccount = getValueFromAnnotation(pvc, cc.AnnGCSServiceAccount)
+		podEnvVar.gcsWorkloadIdentityAudience = getValueFromAnnotation(pvc, cc.AnnGCSWorkloadIdentityAudience)
 
 		for annotation, value := range pvc.Annotations {
 			if strings.HasPrefix(annotation, cc.AnnExtraHeaders) {
@@ -645,6 +707,10 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 			r.log.V(3).Info("no proxy CA certiticate will be supplied:", "error", err.Error())
 		}
 		podEnvVar.certConfigMapProxy = field
+
+		if err := r.setChunkCache(pvc, podEnvVar, cdiConfig); err != nil {
+			return nil, err
+		}
 	}
 
 	fsOverhead, err := GetFilesystemOverhead(context.TODO(), r.client, pvc)
@@ -656,6 +722,8 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 	if preallocation, err := strconv.ParseBool(getValueFromAnnotation(pvc, cc.AnnPreallocationRequested)); err == nil {
 		podEnvVar.preallocation = preallocation
 	} // else use the default "false"
+	podEnvVar.expandPartition = getValueFromAnnotation(pvc, cc.AnnExpandPartition) == "true"
+	podEnvVar.sparsify = getValueFromAnnotation(pvc, cc.AnnSparsify) == "true"
 
 	//get the requested image size.
 	podEnvVar.imageSize, err = cc.GetRequestedImageSize(pvc)
@@ -663,6 +731,22 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 		return nil, err
 	}
 
+	tuning, err := cc.GetImportTuning(context.TODO(), r.client, pvc.Spec.StorageClassName)
+	if err != nil {
+		return nil, err
+	}
+	if tuning != nil {
+		if tuning.CacheMode != nil {
+			podEnvVar.cacheMode = string(*tuning.CacheMode)
+		}
+		if tuning.WriteBlockSize != nil {
+			podEnvVar.writeBlockSize = strconv.FormatInt(tuning.WriteBlockSize.Value(), 10)
+		}
+		if tuning.ZeroDetection != nil {
+			podEnvVar.zeroDetection = string(*tuning.ZeroDetection)
+		}
+	}
+
 	if v, ok := pvc.Annotations[cc.AnnRequiresDirectIO]; ok && v == "true" {
 		podEnvVar.cacheMode = common.CacheModeTryNone
 	}
@@ -670,6 +754,58 @@ func (r *ImportReconciler) createImportEnvVar(pvc *corev1.PersistentVolumeClaim)
 	return podEnvVar, nil
 }
 
+// setChunkCache enables the import chunk cache for the sources reading through it
+func (r *ImportReconciler) setChunkCache(pvc *corev1.PersistentVolumeClaim, podEnvVar *importPodEnvVar, cdiConfig *cdiv1.CDIConfig) error {
+	chunkCache := cdiConfig.Spec.ImportChunkCache
+	if chunkCache == nil {
+		return nil
+	}
+	switch podEnvVar.source {
+	case cc.SourceHTTP, cc.SourceS3, cc.SourceAzureBlob, cc.SourceCDIBackup:
+	case cc.SourceRegistry:
+		// Node pull imports are served by the node, there is nothing to download
+		if pvc.Annotations[cc.AnnRegistryImportMethod] == string(cdiv1.RegistryPullNode) {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	switch {
+	case chunkCache.HostPath != "":
+		// hostPath volumes are rejected by the baseline and restricted pod security levels and share the cache
+		// with every namespace of the node, so the admin has to opt in
+		enabled, err := r.featureGates.ImportChunkCacheHostPathEnabled()
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			r.log.V(1).Info("the import chunk cache hostPath requires the feature gate, the import will not be cached", "featureGate", featuregates.ImportChunkCacheHostPath)
+			return nil
+		}
+		podEnvVar.chunkCacheHostPath = chunkCache.HostPath
+		// The namespaces of the node share the cache, the content of sources that can't be verified is only
+		// served to the imports of the namespace that downloaded it
+		podEnvVar.chunkCacheScope = pvc.Namespace
+	case chunkCache.ClaimName != "":
+		claim := &corev1.PersistentVolumeClaim{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: pvc.Namespace, Name: chunkCache.ClaimName}, claim); err != nil {
+			if k8serrors.IsNotFound(err) {
+				r.log.V(3).Info("no chunk cache claim in the namespace, the import will not be cached", "namespace", pvc.Namespace)
+				return nil
+			}
+			return err
+		}
+		podEnvVar.chunkCacheClaim = chunkCache.ClaimName
+	default:
+		return nil
+	}
+	if chunkCache.Limit != nil {
+		podEnvVar.chunkCacheLimit = strconv.FormatInt(chunkCache.Limit.Value(), 10)
+	}
+	return nil
+}
+
 func (r *ImportReconciler) isInsecureTLS(pvc *corev1.PersistentVolumeClaim, cdiConfig *cdiv1.CDIConfig) (bool, error) {
 	// Check if insecureSkipVerify annotation is set (only applicable for ImageIO sources)
 	source, sourceOk := pvc.Annotations[cc.AnnSource]
@@ -750,6 +886,9 @@ func (r *ImportReconciler) requiresScratchSpace(pvc *corev1.PersistentVolumeClai
 	// All archive requires scratch space.
 	if contentType == cdiv1.DataVolumeArchive {
 		scratchRequired = true
+	} else if isPatch(pvc) {
+		// A patch is converted in scratch space before being written into the existing disk
+		scratchRequired = true
 	} else {
 		switch cc.GetSource(pvc) {
 		case cc.SourceGlance:
@@ -788,6 +927,10 @@ func (r *ImportReconciler) createScratchPvcForPod(pvc *corev1.PersistentVolumeCl
 		// Scratch PVC doesn't exist yet, create it. Determine which storage class to use.
 		_, err = createScratchPersistentVolumeClaim(r.client, pvc, pod, scratchPVCName, storageClassName, r.installerLabels, r.recorder)
 		if err != nil {
+			setScratchFailureCondition(anno, err)
+			if updateErr := r.updatePVC(pvc, r.log); updateErr != nil {
+				r.log.Error(updateErr, "Unable to record the scratch space failure", "pvc.Name", pvc.Name)
+			}
 			return err
 		}
 		anno[cc.AnnBoundCondition] = "false"
@@ -856,9 +999,18 @@ func podNameWithCheckpoint(pvc *corev1.PersistentVolumeClaim) string {
 	if checkpoint := pvc.Annotations[cc.AnnCurrentCheckpoint]; checkpoint != "" {
 		return pvc.Name + "-checkpoint-" + checkpoint
 	}
+	if patchFor := pvc.Annotations[cc.AnnPatchFor]; patchFor != "" {
+		// Distinct from the pod of the import that populated the PVC, which may be retained
+		return pvc.Name + "-patch-" + patchFor[:min(len(patchFor), 8)]
+	}
 	return pvc.Name
 }
 
+// isPatch returns true if the import writes into the existing disk of the PVC
+func isPatch(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Annotations[cc.AnnPatchFor] != ""
+}
+
 func getImportPodNameFromPvc(pvc *corev1.PersistentVolumeClaim) string {
 	podName, ok := pvc.Annotations[cc.AnnImportPod]
 	if ok {
@@ -1047,6 +1199,12 @@ func makeImporterContainerSpec(args *importerPodArgs) []corev1.Container {
 			MountPath: common.VddkArgsDir,
 		})
 	}
+	if args.customizeConfigMap != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      common.CustomizeRecipeVolName,
+			MountPath: common.CustomizeRecipeDir,
+		})
+	}
 	if args.podEnvVar.certConfigMap != "" {
 		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
 			Name:      CertVolName,
@@ -1065,6 +1223,32 @@ func makeImporterContainerSpec(args *importerPodArgs) []corev1.Container {
 			MountPath: common.ImporterGoogleCredentialDir,
 		})
 	}
+	if args.podEnvVar.source == cc.SourceAzureBlob && args.podEnvVar.secretName != "" {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      SecretVolName,
+			MountPath: common.ImporterAzureBlobCredentialDir,
+		})
+	}
+	if hasS3WebIdentity(args.podEnvVar) {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      s3WebIdentityVolName,
+			MountPath: common.ImporterS3WebIdentityTokenDir,
+			ReadOnly:  true,
+		})
+	}
+	if hasGCSWorkloadIdentityToken(args.podEnvVar) {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      gcsWorkloadIdentityVolName,
+			MountPath: common.ImporterGCSWorkloadIdentityTokenDir,
+			ReadOnly:  true,
+		})
+	}
+	if hasChunkCache(args.podEnvVar) {
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      chunkCacheVolName,
+			MountPath: common.ImporterChunkCacheDir,
+		})
+	}
 	for index := range args.podEnvVar.secretExtraHeaders {
 		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
 			Name:      fmt.Sprintf(secretExtraHeadersVolumeName, index),
@@ -1130,15 +1314,44 @@ func makeImporterVolumeSpec(args *importerPodArgs) []corev1.Volume {
 			},
 		})
 	}
+	if args.customizeConfigMap != "" {
+		volumes = append(volumes, createConfigMapVolume(common.CustomizeRecipeVolName, args.customizeConfigMap))
+	}
 	if args.podEnvVar.certConfigMap != "" {
 		volumes = append(volumes, createConfigMapVolume(CertVolName, args.podEnvVar.certConfigMap))
 	}
 	if args.podEnvVar.certConfigMapProxy != "" {
 		volumes = append(volumes, createConfigMapVolume(ProxyCertVolName, GetImportProxyConfigMapName(args.pvc.Name)))
 	}
-	if args.podEnvVar.source == cc.SourceGCS && args.podEnvVar.secretName != "" {
+	if (args.podEnvVar.source == cc.SourceGCS || args.podEnvVar.source == cc.SourceAzureBlob) && args.podEnvVar.secretName != "" {
 		volumes = append(volumes, createSecretVolume(SecretVolName, args.podEnvVar.secretName))
 	}
+	if hasS3WebIdentity(args.podEnvVar) {
+		volumes = append(volumes, createS3WebIdentityVolume(args.podEnvVar.s3WebIdentityAudience))
+	}
+	if hasGCSWorkloadIdentityToken(args.podEnvVar) {
+		volumes = append(volumes, createGCSWorkloadIdentityVolume(args.podEnvVar.gcsWorkloadIdentityProvider, args.podEnvVar.gcsWorkloadIdentityAudience))
+	}
+	if args.podEnvVar.chunkCacheHostPath != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: chunkCacheVolName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: args.podEnvVar.chunkCacheHostPath,
+					Type: ptr.To(corev1.HostPathDirectoryOrCreate),
+				},
+			},
+		})
+	} else if args.podEnvVar.chunkCacheClaim != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: chunkCacheVolName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: args.podEnvVar.chunkCacheClaim,
+				},
+			},
+		})
+	}
 	for index, header := range args.podEnvVar.secretExtraHeaders {
 		volumes = append(volumes, corev1.Volume{
 			Name: fmt.Sprintf(secretExtraHeadersVolumeName, index),
@@ -1241,6 +1454,40 @@ func createSecretVolume(thisVolName, objRef string) corev1.Volume {
 	}
 }
 
+// createS3WebIdentityVolume returns a volume with a projected service account token of the given audience
+func createS3WebIdentityVolume(audience string) corev1.Volume {
+	if audience == "" {
+		audience = s3WebIdentityDefaultAudience
+	}
+	return createServiceAccountTokenVolume(s3WebIdentityVolName, audience, path.Base(common.ImporterS3WebIdentityTokenFile))
+}
+
+// createGCSWorkloadIdentityVolume returns a volume with a projected service account token for the workload identity provider,
+// whose default allowed audience is its https: url
+func createGCSWorkloadIdentityVolume(provider, audience string) corev1.Volume {
+	if audience == "" {
+		audience = "https:" + provider
+	}
+	return createServiceAccountTokenVolume(gcsWorkloadIdentityVolName, audience, path.Base(common.ImporterGCSWorkloadIdentityTokenFile))
+}
+
+func createServiceAccountTokenVolume(name, audience, tokenPath string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          audience,
+						ExpirationSeconds: ptr.To(serviceAccountTokenExpirationSeconds),
+						Path:              tokenPath,
+					},
+				}},
+			},
+		},
+	}
+}
+
 // return the Env portion for the importer container.
 func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 	env := []corev1.EnvVar{
@@ -1337,7 +1584,7 @@ func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 			Value: podEnvVar.registryImageArchitecture,
 		},
 	}
-	if podEnvVar.secretName != "" && podEnvVar.source != cc.SourceGCS {
+	if podEnvVar.secretName != "" && podEnvVar.source != cc.SourceGCS && podEnvVar.source != cc.SourceAzureBlob {
 		env = append(env, corev1.EnvVar{
 			Name: common.ImporterAccessKeyID,
 			ValueFrom: &corev1.EnvVarSource{
@@ -1366,6 +1613,57 @@ func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 			Value: common.ImporterGoogleCredentialFile,
 		})
 	}
+	if podEnvVar.source == cc.SourceGCS && podEnvVar.gcsWorkloadIdentity {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterGCSWorkloadIdentity,
+			Value: "true",
+		})
+		if hasGCSWorkloadIdentityToken(podEnvVar) {
+			env = append(env, corev1.EnvVar{
+				Name:  common.ImporterGCSWorkloadIdentityProvider,
+				Value: podEnvVar.gcsWorkloadIdentityProvider,
+			}, corev1.EnvVar{
+				Name:  common.ImporterGCSServiceAccount,
+				Value: podEnvVar.gcsServiceAccount,
+			}, corev1.EnvVar{
+				Name:  common.ImporterGCSWorkloadIdentityTokenFileVar,
+				Value: common.ImporterGCSWorkloadIdentityTokenFile,
+			})
+		}
+	}
+	if podEnvVar.source == cc.SourceAzureBlob {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterAzureBlobAccount,
+			Value: podEnvVar.azureBlobAccount,
+		})
+		if podEnvVar.secretName != "" {
+			env = append(env, corev1.EnvVar{
+				Name:  common.ImporterAzureBlobCredentialDirVar,
+				Value: common.ImporterAzureBlobCredentialDir,
+			})
+		}
+	}
+	if podEnvVar.source == cc.SourceS3 {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterS3Region,
+			Value: podEnvVar.s3Region,
+		}, corev1.EnvVar{
+			Name:  common.ImporterS3AddressingStyle,
+			Value: podEnvVar.s3AddressingStyle,
+		})
+		if hasS3WebIdentity(podEnvVar) {
+			env = append(env, corev1.EnvVar{
+				Name:  common.ImporterS3RoleARN,
+				Value: podEnvVar.s3RoleARN,
+			}, corev1.EnvVar{
+				Name:  common.ImporterS3STSEndpoint,
+				Value: podEnvVar.s3STSEndpoint,
+			}, corev1.EnvVar{
+				Name:  common.ImporterS3WebIdentityTokenFileVar,
+				Value: common.ImporterS3WebIdentityTokenFile,
+			})
+		}
+	}
 	if podEnvVar.certConfigMap != "" {
 		env = append(env, corev1.EnvVar{
 			Name:  common.ImporterCertDirVar,
@@ -1384,9 +1682,87 @@ func makeImportEnv(podEnvVar *importPodEnvVar, uid types.UID) []corev1.EnvVar {
 			Value: header,
 		})
 	}
+	if podEnvVar.differencingDisks != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterDifferencingDisks,
+			Value: podEnvVar.differencingDisks,
+		})
+	}
+	if podEnvVar.cdiBackupManifest {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterCDIBackupManifest,
+			Value: "true",
+		})
+	}
+	if podEnvVar.patchOffset != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterPatchOffset,
+			Value: podEnvVar.patchOffset,
+		})
+	}
+	if podEnvVar.patchPartitionLabel != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterPatchPartitionLabel,
+			Value: podEnvVar.patchPartitionLabel,
+		})
+	}
+	if podEnvVar.expandPartition {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ExpandPartition,
+			Value: "true",
+		})
+	}
+	if podEnvVar.sparsify {
+		env = append(env, corev1.EnvVar{
+			Name:  common.Sparsify,
+			Value: "true",
+		})
+	}
+	if podEnvVar.writeBlockSize != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.WriteBlockSize,
+			Value: podEnvVar.writeBlockSize,
+		})
+	}
+	if podEnvVar.zeroDetection != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ZeroDetection,
+			Value: podEnvVar.zeroDetection,
+		})
+	}
+	if hasChunkCache(podEnvVar) {
+		env = append(env, corev1.EnvVar{
+			Name:  common.ImporterChunkCacheDirVar,
+			Value: common.ImporterChunkCacheDir,
+		})
+		if podEnvVar.chunkCacheLimit != "" {
+			env = append(env, corev1.EnvVar{
+				Name:  common.ImporterChunkCacheLimit,
+				Value: podEnvVar.chunkCacheLimit,
+			})
+		}
+		if podEnvVar.chunkCacheScope != "" {
+			env = append(env, corev1.EnvVar{
+				Name:  common.ImporterChunkCacheScope,
+				Value: podEnvVar.chunkCacheScope,
+			})
+		}
+	}
 	return env
 }
 
+func hasS3WebIdentity(podEnvVar *importPodEnvVar) bool {
+	return podEnvVar.source == cc.SourceS3 && podEnvVar.s3RoleARN != ""
+}
+
+func hasGCSWorkloadIdentityToken(podEnvVar *importPodEnvVar) bool {
+	return podEnvVar.source == cc.SourceGCS && podEnvVar.gcsWorkloadIdentity && podEnvVar.gcsWorkloadIdentityProvider != ""
+}
+
+func hasChunkCache(podEnvVar *importPodEnvVar) bool {
+	return podEnvVar.chunkCacheHostPath != "" || podEnvVar.chunkCacheClaim != ""
+}
+
 func isOOMKilled(status v1.ContainerStatus) bool {
 	if terminated := status.State.Terminated; terminated != nil {
 		if terminated.Reason == cc.OOMKilledReason {
@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -443,6 +445,93 @@ var _ = Describe("Update PVC from POD", func() {
 		Entry("Message which cannot be unmarshalled", "somemessage", "somemessage"),
 	)
 
+	DescribeTable("Should measure the filesystem overhead", func(capacity string, available int64, expected cdiv1.Percent, ok bool) {
+		capacityQuantity := resource.MustParse(capacity)
+		overhead, measured := measureFilesystemOverhead(capacityQuantity.Value(), available)
+		Expect(measured).To(Equal(ok))
+		Expect(overhead).To(Equal(expected))
+	},
+		Entry("with some overhead", "1Gi", int64(1000*1024*1024), cdiv1.Percent("0.025"), true),
+		Entry("without overhead", "1Gi", int64(1024*1024*1024), cdiv1.Percent("0"), true),
+		Entry("with more space than the capacity", "1Gi", int64(2*1024*1024*1024), cdiv1.Percent("0"), true),
+		Entry("with a bogus measurement", "1Gi", int64(1024*1024), cdiv1.Percent(""), false),
+	)
+
+	It("Should record the filesystem overhead of a completed import", func() {
+		termMsgBytes, err := json.Marshal(common.TerminationMessage{AvailableSpace: ptr.To[int64](900 * 1000 * 1000)})
+		Expect(err).ToNot(HaveOccurred())
+		newPod := func(pvc *corev1.PersistentVolumeClaim) *corev1.Pod {
+			pod := cc.CreateImporterTestPod(pvc, pvc.Name, nil)
+			pod.Status = corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						State: v1.ContainerState{
+							Terminated: &v1.ContainerStateTerminated{
+								Message: string(termMsgBytes),
+							},
+						},
+					},
+				},
+			}
+			return pod
+		}
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint}, nil, corev1.ClaimBound)
+		pvc2 := cc.CreatePvcInStorageClass("testPvc2", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint}, nil, corev1.ClaimBound)
+		pvc2.Status.Capacity[corev1.ResourceStorage] = resource.MustParse("1100M")
+		pod, pod2 := newPod(pvc), newPod(pvc2)
+		reconciler = createImportReconciler(pvc, pod, pvc2, pod2)
+		reconciler.recorder = record.NewFakeRecorder(10)
+
+		Expect(reconciler.updatePvcFromPod(pvc, pod, reconciler.log)).To(Succeed())
+		config := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		calibration := config.Status.FilesystemOverheadCalibration[testStorageClass]
+		Expect(calibration.Recommended).To(Equal(cdiv1.Percent("0.112")))
+		Expect(calibration.Samples).To(Equal(int32(1)))
+
+		By("Keeping the highest overhead")
+		Expect(reconciler.updatePvcFromPod(pvc2, pod2, reconciler.log)).To(Succeed())
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		calibration = config.Status.FilesystemOverheadCalibration[testStorageClass]
+		Expect(calibration.Recommended).To(Equal(cdiv1.Percent("0.223")))
+		Expect(calibration.Samples).To(Equal(int32(2)))
+
+		By("Measuring an import only once")
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, pvc)).To(Succeed())
+		Expect(reconciler.updatePvcFromPod(pvc, pod, reconciler.log)).To(Succeed())
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		Expect(config.Status.FilesystemOverheadCalibration[testStorageClass].Samples).To(Equal(int32(2)))
+	})
+
+	It("Should not record the filesystem overhead of a restarted import", func() {
+		termMsgBytes, err := json.Marshal(common.TerminationMessage{AvailableSpace: ptr.To[int64](100 * 1000 * 1000)})
+		Expect(err).ToNot(HaveOccurred())
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint}, nil, corev1.ClaimBound)
+		pod := cc.CreateImporterTestPod(pvc, pvc.Name, nil)
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					RestartCount: 1,
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							Message: string(termMsgBytes),
+						},
+					},
+				},
+			},
+		}
+		reconciler = createImportReconciler(pvc, pod)
+		reconciler.recorder = record.NewFakeRecorder(10)
+
+		Expect(reconciler.updatePvcFromPod(pvc, pod, reconciler.log)).To(Succeed())
+		Expect(pvc.Annotations).To(HaveKeyWithValue(cc.AnnPodRestarts, "1"))
+		config := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config)).To(Succeed())
+		Expect(config.Status.FilesystemOverheadCalibration).ToNot(HaveKey(testStorageClass))
+	})
+
 	DescribeTable("Update the PVC labels from termination message if pod is succeeded", func(phase v1.PodPhase, updated bool) {
 		const testKeyExisting = "test"
 		const testValueExisting = "existing"
@@ -840,6 +929,258 @@ var _ = Describe("Update PVC from POD", func() {
 	})
 })
 
+var _ = Describe("Import tuning", func() {
+	It("Should pass the import tuning of the storage profile to the importer pod", func() {
+		pvc := cc.CreatePvcInStorageClass("testPvc1", "default", &testStorageClass, map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnSource: cc.SourceHTTP}, nil, corev1.ClaimBound)
+		writeBlockSize := resource.MustParse("64Ki")
+		storageProfile := &cdiv1.StorageProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: testStorageClass},
+			Status: cdiv1.StorageProfileStatus{
+				ImportTuning: &cdiv1.ImportTuning{
+					CacheMode:      ptr.To(cdiv1.ImportCacheModeNone),
+					WriteBlockSize: &writeBlockSize,
+					ZeroDetection:  ptr.To(cdiv1.ImportZeroDetectionDiscard),
+				},
+			},
+		}
+		reconciler := createImportReconciler(pvc, cc.CreateStorageClass(testStorageClass, nil), storageProfile)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podEnvVar.cacheMode).To(Equal("none"))
+		env := makeImportEnv(podEnvVar, pvc.UID)
+		Expect(env).To(ContainElements(
+			corev1.EnvVar{Name: common.CacheMode, Value: "none"},
+			corev1.EnvVar{Name: common.WriteBlockSize, Value: "65536"},
+			corev1.EnvVar{Name: common.ZeroDetection, Value: "Discard"},
+		))
+
+		By("Preferring direct IO after the importer ran out of memory")
+		pvc.Annotations[cc.AnnRequiresDirectIO] = "true"
+		podEnvVar, err = reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podEnvVar.cacheMode).To(Equal(common.CacheModeTryNone))
+	})
+})
+
+var _ = Describe("S3 web identity", func() {
+	It("Should project a service account token in the importer pod", func() {
+		pvc := cc.CreatePvc("testPvc1", "default", map[string]string{
+			cc.AnnEndpoint:              "http://minio:9000/bucket/disk.img",
+			cc.AnnSource:                cc.SourceS3,
+			cc.AnnS3Region:              "us-east-1",
+			cc.AnnS3RoleARN:             "arn:minio:iam:::role/importer",
+			cc.AnnS3WebIdentityAudience: "minio",
+			cc.AnnS3STSEndpoint:         "http://minio:9000",
+		}, nil)
+		reconciler := createImportReconciler(pvc)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		args := &importerPodArgs{podEnvVar: podEnvVar, pvc: pvc}
+		Expect(makeImportEnv(podEnvVar, pvc.UID)).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterS3Region, Value: "us-east-1"},
+			corev1.EnvVar{Name: common.ImporterS3RoleARN, Value: "arn:minio:iam:::role/importer"},
+			corev1.EnvVar{Name: common.ImporterS3STSEndpoint, Value: "http://minio:9000"},
+			corev1.EnvVar{Name: common.ImporterS3WebIdentityTokenFileVar, Value: common.ImporterS3WebIdentityTokenFile},
+		))
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(corev1.Volume{
+			Name: s3WebIdentityVolName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          "minio",
+							ExpirationSeconds: ptr.To[int64](3600),
+							Path:              "token",
+						},
+					}},
+				},
+			},
+		}))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      s3WebIdentityVolName,
+			MountPath: common.ImporterS3WebIdentityTokenDir,
+			ReadOnly:  true,
+		}))
+	})
+
+	It("Should not project a service account token without role", func() {
+		pvc := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnEndpoint: "http://minio:9000/bucket/disk.img", cc.AnnSource: cc.SourceS3}, nil)
+		reconciler := createImportReconciler(pvc)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		for _, volume := range makeImporterVolumeSpec(&importerPodArgs{podEnvVar: podEnvVar, pvc: pvc}) {
+			Expect(volume.Name).ToNot(Equal(s3WebIdentityVolName))
+		}
+		for _, envVar := range makeImportEnv(podEnvVar, pvc.UID) {
+			Expect(envVar.Name).ToNot(Equal(common.ImporterS3WebIdentityTokenFileVar))
+		}
+	})
+})
+
+var _ = Describe("GCS workload identity", func() {
+	const provider = "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/providers/cluster"
+
+	It("Should project a service account token for the workload identity provider", func() {
+		pvc := cc.CreatePvc("testPvc1", "default", map[string]string{
+			cc.AnnEndpoint:                    "gs://bucket/disk.img",
+			cc.AnnSource:                      cc.SourceGCS,
+			cc.AnnGCSWorkloadIdentity:         "true",
+			cc.AnnGCSWorkloadIdentityProvider: provider,
+			cc.AnnGCSServiceAccount:           "importer@project.iam.gserviceaccount.com",
+		}, nil)
+		reconciler := createImportReconciler(pvc)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		args := &importerPodArgs{podEnvVar: podEnvVar, pvc: pvc}
+		Expect(makeImportEnv(podEnvVar, pvc.UID)).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterGCSWorkloadIdentity, Value: "true"},
+			corev1.EnvVar{Name: common.ImporterGCSWorkloadIdentityProvider, Value: provider},
+			corev1.EnvVar{Name: common.ImporterGCSServiceAccount, Value: "importer@project.iam.gserviceaccount.com"},
+			corev1.EnvVar{Name: common.ImporterGCSWorkloadIdentityTokenFileVar, Value: common.ImporterGCSWorkloadIdentityTokenFile},
+		))
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(corev1.Volume{
+			Name: gcsWorkloadIdentityVolName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          "https:" + provider,
+							ExpirationSeconds: ptr.To[int64](3600),
+							Path:              "token",
+						},
+					}},
+				},
+			},
+		}))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      gcsWorkloadIdentityVolName,
+			MountPath: common.ImporterGCSWorkloadIdentityTokenDir,
+			ReadOnly:  true,
+		}))
+	})
+
+	It("Should use the application default credentials without provider", func() {
+		pvc := cc.CreatePvc("testPvc1", "default", map[string]string{
+			cc.AnnEndpoint:            "gs://bucket/disk.img",
+			cc.AnnSource:              cc.SourceGCS,
+			cc.AnnGCSWorkloadIdentity: "true",
+		}, nil)
+		reconciler := createImportReconciler(pvc)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		env := makeImportEnv(podEnvVar, pvc.UID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterGCSWorkloadIdentity, Value: "true"}))
+		for _, envVar := range env {
+			Expect(envVar.Name).ToNot(Equal(common.ImporterGCSWorkloadIdentityTokenFileVar))
+			Expect(envVar.Name).ToNot(Equal(common.ImporterGoogleCredentialFileVar))
+		}
+		for _, volume := range makeImporterVolumeSpec(&importerPodArgs{podEnvVar: podEnvVar, pvc: pvc}) {
+			Expect(volume.Name).ToNot(Equal(gcsWorkloadIdentityVolName))
+		}
+	})
+})
+
+var _ = Describe("Import chunk cache", func() {
+	const cacheClaimName = "chunk-cache"
+
+	createChunkCacheReconciler := func(chunkCache *cdiv1.ImportChunkCache, objects ...runtime.Object) *ImportReconciler {
+		reconciler := createImportReconciler(objects...)
+		cdiConfig := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+		cdiConfig.Spec.ImportChunkCache = chunkCache
+		cdiConfig.Spec.FeatureGates = []string{featuregates.ImportChunkCacheHostPath}
+		Expect(reconciler.client.Update(context.TODO(), cdiConfig)).To(Succeed())
+		return reconciler
+	}
+
+	It("Should mount the host path cache in the importer pod", func() {
+		pvc := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnSource: cc.SourceHTTP}, nil)
+		limit := resource.MustParse("10Gi")
+		reconciler := createChunkCacheReconciler(&cdiv1.ImportChunkCache{HostPath: "/var/lib/cdi-cache", Limit: &limit}, pvc)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		args := &importerPodArgs{podEnvVar: podEnvVar, pvc: pvc}
+		Expect(makeImportEnv(podEnvVar, pvc.UID)).To(ContainElements(
+			corev1.EnvVar{Name: common.ImporterChunkCacheDirVar, Value: common.ImporterChunkCacheDir},
+			corev1.EnvVar{Name: common.ImporterChunkCacheLimit, Value: strconv.FormatInt(limit.Value(), 10)},
+			corev1.EnvVar{Name: common.ImporterChunkCacheScope, Value: "default"},
+		))
+		Expect(makeImporterVolumeSpec(args)).To(ContainElement(corev1.Volume{
+			Name: chunkCacheVolName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: "/var/lib/cdi-cache",
+					Type: ptr.To(corev1.HostPathDirectoryOrCreate),
+				},
+			},
+		}))
+		Expect(makeImporterContainerSpec(args)[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      chunkCacheVolName,
+			MountPath: common.ImporterChunkCacheDir,
+		}))
+	})
+
+	It("Should mount the cache claim of the namespace in the importer pod", func() {
+		pvc := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnSource: cc.SourceRegistry}, nil)
+		cacheClaim := cc.CreatePvc(cacheClaimName, "default", nil, nil)
+		reconciler := createChunkCacheReconciler(&cdiv1.ImportChunkCache{ClaimName: cacheClaimName}, pvc, cacheClaim)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(podEnvVar.chunkCacheClaim).To(Equal(cacheClaimName))
+		env := makeImportEnv(podEnvVar, pvc.UID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterChunkCacheDirVar, Value: common.ImporterChunkCacheDir}))
+		for _, envVar := range env {
+			Expect(envVar.Name).ToNot(Equal(common.ImporterChunkCacheLimit))
+			// The claim is only shared by the imports of its namespace
+			Expect(envVar.Name).ToNot(Equal(common.ImporterChunkCacheScope))
+		}
+		Expect(makeImporterVolumeSpec(&importerPodArgs{podEnvVar: podEnvVar, pvc: pvc})).To(ContainElement(corev1.Volume{
+			Name: chunkCacheVolName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: cacheClaimName},
+			},
+		}))
+	})
+
+	DescribeTable("Should not cache", func(annotations map[string]string, chunkCache *cdiv1.ImportChunkCache) {
+		annotations[cc.AnnEndpoint] = testEndPoint
+		pvc := cc.CreatePvc("testPvc1", "default", annotations, nil)
+		reconciler := createChunkCacheReconciler(chunkCache, pvc)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hasChunkCache(podEnvVar)).To(BeFalse())
+		for _, volume := range makeImporterVolumeSpec(&importerPodArgs{podEnvVar: podEnvVar, pvc: pvc}) {
+			Expect(volume.Name).ToNot(Equal(chunkCacheVolName))
+		}
+	},
+		Entry("when the cache is not configured", map[string]string{cc.AnnSource: cc.SourceHTTP}, nil),
+		Entry("when the namespace has no cache claim", map[string]string{cc.AnnSource: cc.SourceHTTP}, &cdiv1.ImportChunkCache{ClaimName: cacheClaimName}),
+		Entry("sources that do not read through the cache", map[string]string{cc.AnnSource: cc.SourceGCS}, &cdiv1.ImportChunkCache{HostPath: "/var/lib/cdi-cache"}),
+		Entry("node pull registry imports", map[string]string{cc.AnnSource: cc.SourceRegistry, cc.AnnRegistryImportMethod: string(cdiv1.RegistryPullNode)}, &cdiv1.ImportChunkCache{HostPath: "/var/lib/cdi-cache"}),
+	)
+
+	It("Should not mount the host path cache without the feature gate", func() {
+		pvc := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnEndpoint: testEndPoint, cc.AnnSource: cc.SourceHTTP}, nil)
+		reconciler := createChunkCacheReconciler(&cdiv1.ImportChunkCache{HostPath: "/var/lib/cdi-cache"}, pvc)
+		cdiConfig := &cdiv1.CDIConfig{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, cdiConfig)).To(Succeed())
+		cdiConfig.Spec.FeatureGates = nil
+		Expect(reconciler.client.Update(context.TODO(), cdiConfig)).To(Succeed())
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hasChunkCache(podEnvVar)).To(BeFalse())
+	})
+})
+
 var _ = Describe("Create Importer Pod", func() {
 	var scratchPvcName = "scratchPvc"
 
@@ -939,6 +1280,86 @@ var _ = Describe("Create Importer Pod", func() {
 		Entry("with long PVC and checkpoint names", strings.Repeat("test-pvc-", 20), strings.Repeat("repeating-checkpoint-id-", 10)),
 	)
 
+	It("should patch the disk of the PVC with its own importer pod and scratch space", func() {
+		pvc := cc.CreatePvc("testPvc1", "default", map[string]string{
+			cc.AnnEndpoint:            testEndPoint,
+			cc.AnnPatchFor:            "0123456789abcdef",
+			cc.AnnPatchPartitionLabel: "EFI",
+		}, nil)
+		pvc.Status.Phase = v1.ClaimBound
+
+		reconciler := createImportReconciler(pvc)
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+
+		resPvc := &corev1.PersistentVolumeClaim{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resPvc.Annotations[cc.AnnImportPod]).To(Equal("importer-testPvc1-patch-01234567"))
+		Expect(resPvc.Annotations[cc.AnnRequiresScratch]).To(Equal("true"))
+
+		podEnvVar, err := reconciler.createImportEnvVar(resPvc)
+		Expect(err).ToNot(HaveOccurred())
+		env := makeImportEnv(podEnvVar, resPvc.UID)
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterPatchPartitionLabel, Value: "EFI"}))
+		Expect(env).ToNot(ContainElement(HaveField("Name", common.ImporterPatchOffset)))
+	})
+
+	DescribeTable("should request partition expansion in the importer environment", func(annotations map[string]string, expected bool) {
+		annotations[cc.AnnEndpoint] = testEndPoint
+		pvc := cc.CreatePvc("testPvc1", "default", annotations, nil)
+		reconciler := createImportReconciler(pvc)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		env := makeImportEnv(podEnvVar, pvc.UID)
+		if expected {
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ExpandPartition, Value: "true"}))
+		} else {
+			Expect(env).ToNot(ContainElement(HaveField("Name", common.ExpandPartition)))
+		}
+	},
+		Entry("when annotated", map[string]string{cc.AnnExpandPartition: "true"}, true),
+		Entry("when not annotated", map[string]string{}, false),
+	)
+
+	DescribeTable("should request sparsify in the importer environment", func(annotations map[string]string, expected bool) {
+		annotations[cc.AnnEndpoint] = testEndPoint
+		pvc := cc.CreatePvc("testPvc1", "default", annotations, nil)
+		reconciler := createImportReconciler(pvc)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		env := makeImportEnv(podEnvVar, pvc.UID)
+		if expected {
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: common.Sparsify, Value: "true"}))
+		} else {
+			Expect(env).ToNot(ContainElement(HaveField("Name", common.Sparsify)))
+		}
+	},
+		Entry("when annotated", map[string]string{cc.AnnSparsify: "true"}, true),
+		Entry("when not annotated", map[string]string{}, false),
+	)
+
+	DescribeTable("should import a CDI backup manifest", func(annotations map[string]string, expected bool) {
+		annotations[cc.AnnEndpoint] = testEndPoint
+		annotations[cc.AnnSource] = cc.SourceS3
+		pvc := cc.CreatePvc("testPvc1", "default", annotations, nil)
+		reconciler := createImportReconciler(pvc)
+
+		podEnvVar, err := reconciler.createImportEnvVar(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		env := makeImportEnv(podEnvVar, pvc.UID)
+		if expected {
+			Expect(env).To(ContainElement(corev1.EnvVar{Name: common.ImporterCDIBackupManifest, Value: "true"}))
+		} else {
+			Expect(env).ToNot(ContainElement(HaveField("Name", common.ImporterCDIBackupManifest)))
+		}
+	},
+		Entry("when annotated", map[string]string{cc.AnnCDIBackupManifest: "true"}, true),
+		Entry("when not annotated", map[string]string{}, false),
+	)
+
 	It("should mount extra VDDK arguments ConfigMap when annotation is set", func() {
 		pvcName := "testPvc1"
 		podName := "testpod"
@@ -970,6 +1391,34 @@ var _ = Describe("Create Importer Pod", func() {
 		Expect(found).To(BeTrue())
 	})
 
+	It("should mount the customization recipe ConfigMap when annotation is set", func() {
+		pvcName := "testPvc1"
+		podName := "testpod"
+		annotations := map[string]string{
+			cc.AnnEndpoint:           testEndPoint,
+			cc.AnnImportPod:          podName,
+			cc.AnnSource:             cc.SourceHTTP,
+			cc.AnnCustomizeConfigMap: "golden-recipe",
+		}
+		pvc := cc.CreatePvcInStorageClass(pvcName, "default", &testStorageClass, annotations, nil, corev1.ClaimBound)
+		reconciler := createImportReconciler(pvc)
+
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: pvcName, Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+
+		pod := &corev1.Pod{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: podName, Namespace: "default"}, pod)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.Volumes).To(ContainElement(And(
+			HaveField("Name", common.CustomizeRecipeVolName),
+			HaveField("ConfigMap.Name", "golden-recipe"),
+		)))
+		Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      common.CustomizeRecipeVolName,
+			MountPath: common.CustomizeRecipeDir,
+		}))
+	})
+
 	It("Should create relevant containers and init containers when source is registry and pull method is node", func() {
 		pvcName := "testPvc1"
 		podName := "testpod"
@@ -1340,6 +1789,8 @@ type FakeFeatureGates struct {
 	honorWaitForFirstConsumerEnabled bool
 	claimAdoptionEnabled             bool
 	webhookPvcRenderingEnabled       bool
+	storageProfileProbeEnabled       bool
+	importChunkCacheHostPathEnabled  bool
 }
 
 func (f *FakeFeatureGates) HonorWaitForFirstConsumerEnabled() (bool, error) {
@@ -1354,6 +1805,14 @@ func (f *FakeFeatureGates) WebhookPvcRenderingEnabled() (bool, error) {
 	return f.webhookPvcRenderingEnabled, nil
 }
 
+func (f *FakeFeatureGates) StorageProfileProbeEnabled() (bool, error) {
+	return f.storageProfileProbeEnabled, nil
+}
+
+func (f *FakeFeatureGates) ImportChunkCacheHostPathEnabled() (bool, error) {
+	return f.importChunkCacheHostPathEnabled, nil
+}
+
 func createPendingPvc(name, ns string, annotations, labels map[string]string) *v1.PersistentVolumeClaim {
 	return cc.CreatePvcInStorageClass(name, ns, nil, annotations, labels, v1.ClaimPending)
 }
@@ -60,6 +60,12 @@ const (
 	// AnnCloneFallbackReason has the host-assisted clone fallback reason
 	AnnCloneFallbackReason = "cdi.kubevirt.io/cloneFallbackReason"
 
+	// AnnCloneSnapshotSource marks a host-assisted clone reading from a snapshot of the source
+	AnnCloneSnapshotSource = "cdi.kubevirt.io/cloneSnapshotSource"
+
+	// AnnCloneStartTime is the time the clone strategy was chosen, to measure the clone duration
+	AnnCloneStartTime = "cdi.kubevirt.io/cloneStartTime"
+
 	// AnnDataSourceNamespace has the namespace of the DataSource
 	// this will be deprecated when cross namespace datasource goes beta
 	AnnDataSourceNamespace = "cdi.kubevirt.io/dataSourceNamespace"
@@ -70,8 +76,10 @@ const (
 )
 
 var desiredCloneAnnotations = map[string]struct{}{
-	cc.AnnPreallocationApplied: {},
-	cc.AnnCloneOf:              {},
+	cc.AnnPreallocationApplied:       {},
+	cc.AnnReclaimedBytes:             {},
+	cc.AnnCloneOf:                    {},
+	cc.AnnCloneApplicationConsistent: {},
 }
 
 // Planner is an interface to mock out planner implementation for testing
@@ -79,6 +87,7 @@ type Planner interface {
 	ChooseStrategy(context.Context, *clone.ChooseStrategyArgs) (*clone.ChooseStrategyResult, error)
 	Plan(context.Context, *clone.PlanArgs) ([]clone.Phase, error)
 	Cleanup(context.Context, logr.Logger, client.Object) error
+	RecordCompletion(context.Context, *clone.CompletionArgs) error
 }
 
 // ClonePopulatorReconciler reconciles PVCs with VolumeCloneSources
@@ -191,6 +200,7 @@ func NewClonePopulator(
 	mgr manager.Manager,
 	log logr.Logger,
 	clonerImage string,
+	importerImage string,
 	pullPolicy string,
 	installerLabels map[string]string,
 	publicKey *rsa.PublicKey,
@@ -222,12 +232,14 @@ func NewClonePopulator(
 		OwnershipLabel:  LabelOwnedByUID,
 		UIDField:        uidField,
 		Image:           clonerImage,
+		ImporterImage:   importerImage,
 		PullPolicy:      corev1.PullPolicy(pullPolicy),
 		InstallerLabels: installerLabels,
 		Client:          reconciler.client,
 		Recorder:        reconciler.recorder,
 		Controller:      clonePopulator,
 		GetCache:        mgr.GetCache,
+		RESTConfig:      mgr.GetConfig(),
 	}
 	reconciler.planner = planner
 
@@ -335,10 +347,11 @@ func (r *ClonePopulatorReconciler) reconcilePending(ctx context.Context, log log
 	}
 
 	args := &clone.PlanArgs{
-		Log:         log,
-		TargetClaim: pvc,
-		DataSource:  vcs,
-		Strategy:    csr.Strategy,
+		Log:            log,
+		TargetClaim:    pvc,
+		DataSource:     vcs,
+		Strategy:       csr.Strategy,
+		SnapshotSource: csr.SnapshotSource,
 	}
 
 	return r.planAndExecute(ctx, log, pvc, statusOnly, args)
@@ -346,7 +359,10 @@ func (r *ClonePopulatorReconciler) reconcilePending(ctx context.Context, log log
 
 func (r *ClonePopulatorReconciler) getCloneStrategy(ctx context.Context, log logr.Logger, pvc *corev1.PersistentVolumeClaim, vcs *cdiv1.VolumeCloneSource) (*clone.ChooseStrategyResult, error) {
 	if cs := getSavedCloneStrategy(pvc); cs != nil {
-		return &clone.ChooseStrategyResult{Strategy: *cs}, nil
+		return &clone.ChooseStrategyResult{
+			Strategy:       *cs,
+			SnapshotSource: pvc.Annotations[AnnCloneSnapshotSource] == "true",
+		}, nil
 	}
 
 	args := &clone.ChooseStrategyArgs{
@@ -395,7 +411,33 @@ func (r *ClonePopulatorReconciler) planAndExecute(ctx context.Context, log logr.
 
 	log.V(3).Info("executed all phases, setting phase to Succeeded")
 
-	return reconcile.Result{}, r.updateClonePhaseSucceeded(ctx, log, pvc, statusResults)
+	if err := r.updateClonePhaseSucceeded(ctx, log, pvc, statusResults); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	r.recordCompletion(ctx, log, pvc, args)
+
+	return reconcile.Result{}, nil
+}
+
+// recordCompletion records the duration of the clone, a failure only costs a sample so it does not fail the clone
+func (r *ClonePopulatorReconciler) recordCompletion(ctx context.Context, log logr.Logger, pvc *corev1.PersistentVolumeClaim, args *clone.PlanArgs) {
+	startTime, err := time.Parse(time.RFC3339, pvc.Annotations[AnnCloneStartTime])
+	if err != nil {
+		log.V(3).Info("clone start time unknown, not recording the clone duration")
+		return
+	}
+
+	if err := r.planner.RecordCompletion(ctx, &clone.CompletionArgs{
+		Log:            log,
+		TargetClaim:    pvc,
+		DataSource:     args.DataSource,
+		Strategy:       args.Strategy,
+		SnapshotSource: args.SnapshotSource,
+		Duration:       time.Since(startTime),
+	}); err != nil {
+		log.Error(err, "error recording the clone duration")
+	}
 }
 
 func (r *ClonePopulatorReconciler) validateCrossNamespace(pvc *corev1.PersistentVolumeClaim, vcs *cdiv1.VolumeCloneSource) error {
@@ -436,6 +478,9 @@ func (r *ClonePopulatorReconciler) reconcileDone(ctx context.Context, log logr.L
 func (r *ClonePopulatorReconciler) initTargetClaim(ctx context.Context, log logr.Logger, pvc *corev1.PersistentVolumeClaim, vcs *cdiv1.VolumeCloneSource, csr *clone.ChooseStrategyResult) (bool, error) {
 	claimCpy := pvc.DeepCopy()
 	clone.AddCommonClaimLabels(claimCpy)
+	if getSavedCloneStrategy(pvc) == nil {
+		cc.AddAnnotation(claimCpy, AnnCloneStartTime, time.Now().Format(time.RFC3339))
+	}
 	setSavedCloneStrategy(claimCpy, csr.Strategy)
 	if claimCpy.Annotations[AnnClonePhase] == "" {
 		cc.AddAnnotation(claimCpy, AnnClonePhase, clone.PendingPhaseName)
@@ -443,6 +488,9 @@ func (r *ClonePopulatorReconciler) initTargetClaim(ctx context.Context, log logr
 	if claimCpy.Annotations[AnnCloneFallbackReason] == "" && csr.FallbackReason != nil {
 		cc.AddAnnotation(claimCpy, AnnCloneFallbackReason, *csr.FallbackReason)
 	}
+	if csr.SnapshotSource {
+		cc.AddAnnotation(claimCpy, AnnCloneSnapshotSource, "true")
+	}
 	cc.AddFinalizer(claimCpy, cloneFinalizer)
 
 	if !apiequality.Semantic.DeepEqual(pvc, claimCpy) {
@@ -19,6 +19,7 @@ package populators
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -256,6 +257,32 @@ var _ = Describe("Clone populator tests", func() {
 		Expect(pvc.Annotations[AnnClonePhase]).To(Equal(string(clone.PendingPhaseName)))
 		Expect(pvc.Annotations[cc.AnnCloneType]).To(Equal(string(csr.Strategy)))
 		Expect(pvc.Finalizers).To(ContainElement(cloneFinalizer))
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnCloneSnapshotSource))
+		Expect(pvc.Annotations).To(HaveKey(AnnCloneStartTime))
+	})
+
+	It("should save and plan host assisted clone from a snapshot of the source", func() {
+		target, source := targetAndDataSource()
+		reconciler := createClonePopulatorReconciler(target, storageClass(), source)
+		fp := &fakePlanner{
+			chooseStrategyResult: &clone.ChooseStrategyResult{
+				Strategy:       cdiv1.CloneStrategyHostAssisted,
+				SnapshotSource: true,
+			},
+		}
+		reconciler.planner = fp
+		result, err := reconciler.Reconcile(context.Background(), nn)
+		isDefaultResult(result, err)
+		pvc := getTarget(reconciler.client)
+		Expect(pvc.Annotations[cc.AnnCloneType]).To(Equal(string(cdiv1.CloneStrategyHostAssisted)))
+		Expect(pvc.Annotations[AnnCloneSnapshotSource]).To(Equal("true"))
+
+		fp.chooseStrategyResult = nil
+		_, err = reconciler.Reconcile(context.Background(), nn)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fp.planArgs).ToNot(BeNil())
+		Expect(fp.planArgs.Strategy).To(Equal(cdiv1.CloneStrategyHostAssisted))
+		Expect(fp.planArgs.SnapshotSource).To(BeTrue())
 	})
 
 	It("should be in error phase if plan returns an error", func() {
@@ -313,10 +340,11 @@ var _ = Describe("Clone populator tests", func() {
 					status: &clone.PhaseStatus{
 						Progress: "50.0%",
 						Annotations: map[string]string{
-							"foo":                         "bar",
-							cc.AnnRunningCondition:        "true",
-							cc.AnnRunningConditionMessage: "message",
-							cc.AnnRunningConditionReason:  "reason",
+							"foo":                            "bar",
+							cc.AnnRunningCondition:           "true",
+							cc.AnnRunningConditionMessage:    "message",
+							cc.AnnRunningConditionReason:     "reason",
+							cc.AnnCloneApplicationConsistent: "true",
 						},
 					},
 				},
@@ -328,6 +356,7 @@ var _ = Describe("Clone populator tests", func() {
 		Expect(pvc.Annotations[AnnClonePhase]).To(Equal("phase2"))
 		Expect(pvc.Annotations[cc.AnnPopulatorProgress]).To(Equal("50.0%"))
 		Expect(pvc.Annotations).ToNot(HaveKey("foo"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(cc.AnnCloneApplicationConsistent, "true"))
 		if ownedByDataVolume {
 			Expect(pvc.Annotations).To(HaveKey(cc.AnnRunningCondition))
 			Expect(pvc.Annotations).To(HaveKey(cc.AnnRunningConditionMessage))
@@ -382,6 +411,25 @@ var _ = Describe("Clone populator tests", func() {
 		Expect(pvc.Annotations[AnnClonePhase]).To(Equal("Succeeded"))
 	})
 
+	It("should record the duration of the clone when all phases are done", func() {
+		target, source := initializedTargetAndDataSource()
+		target.Annotations[AnnCloneStartTime] = time.Now().Add(-time.Hour).Format(time.RFC3339)
+		reconciler := createClonePopulatorReconciler(target, storageClass(), source)
+		fp := &fakePlanner{
+			planResult: []clone.Phase{
+				&fakePhase{
+					name: "phase1",
+				},
+			},
+		}
+		reconciler.planner = fp
+		result, err := reconciler.Reconcile(context.Background(), nn)
+		isDefaultResult(result, err)
+		Expect(fp.completionArgs).ToNot(BeNil())
+		Expect(fp.completionArgs.Strategy).To(Equal(cdiv1.CloneStrategySnapshot))
+		Expect(fp.completionArgs.Duration).To(BeNumerically("~", time.Hour, time.Minute))
+	})
+
 	It("should remove finalizer and call cleanup when succeeded", func() {
 		target := succeededTarget()
 		reconciler := createClonePopulatorReconciler(target)
@@ -401,7 +449,9 @@ type fakePlanner struct {
 	chooseStrategyError  error
 	planResult           []clone.Phase
 	planError            error
+	planArgs             *clone.PlanArgs
 	cleanupCalled        bool
+	completionArgs       *clone.CompletionArgs
 }
 
 func (p *fakePlanner) ChooseStrategy(ctx context.Context, args *clone.ChooseStrategyArgs) (*clone.ChooseStrategyResult, error) {
@@ -409,6 +459,7 @@ func (p *fakePlanner) ChooseStrategy(ctx context.Context, args *clone.ChooseStra
 }
 
 func (p *fakePlanner) Plan(ctx context.Context, args *clone.PlanArgs) ([]clone.Phase, error) {
+	p.planArgs = args
 	return p.planResult, p.planError
 }
 
@@ -417,6 +468,11 @@ func (p *fakePlanner) Cleanup(ctx context.Context, log logr.Logger, owner client
 	return nil
 }
 
+func (p *fakePlanner) RecordCompletion(ctx context.Context, args *clone.CompletionArgs) error {
+	p.completionArgs = args
+	return nil
+}
+
 type fakePhase struct {
 	name   string
 	result *reconcile.Result
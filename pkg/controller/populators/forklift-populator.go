@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -22,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer-api/pkg/apis/forklift/v1beta1"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
@@ -49,6 +51,14 @@ var (
 
 var errCrNotFound = errors.New("populator CR not found")
 
+// errInvalidPopulatorSpec is returned for populator CRs created before the CRD validation rules
+var errInvalidPopulatorSpec = errors.New("invalid populator spec")
+
+const (
+	// invalidPopulatorSpec is the reason of the Running condition of a PVC whose populator CR is invalid
+	invalidPopulatorSpec = "InvalidPopulatorSpec"
+)
+
 // ForkliftPopulatorReconciler members
 type ForkliftPopulatorReconciler struct {
 	ReconcilerBase
@@ -202,12 +212,12 @@ func (r *ForkliftPopulatorReconciler) reconcile(ctx context.Context, req reconci
 		return res, err
 	}
 
-	if cc.IsPVCComplete(pvc) {
+	if cc.IsPVCComplete(pvc) && !cc.IsMultiStageImportInProgress(pvc) {
 		res, err = r.reconcileCleanup(pvcPrime)
 	}
 
 	if pvcPrime.DeletionTimestamp != nil {
-		res, err = r.deletePopulatorPod(fmt.Sprintf("%s-%s", populatorPodPrefix, pvc.UID), pvc, pvcPrime)
+		res, err = r.deletePopulatorPod(populatorPodName(pvc, pvcPrime), pvc, pvcPrime)
 	}
 
 	return res, err
@@ -302,7 +312,7 @@ func (r *ForkliftPopulatorReconciler) reconcileTargetPVC(pvc, pvcPrime *corev1.P
 	pvcPrimeCopy := pvcPrime.DeepCopy()
 
 	// Look for the populator pod
-	podName := fmt.Sprintf("%s-%s", populatorPodPrefix, pvc.UID)
+	podName := populatorPodName(pvc, pvcPrime)
 	pod, err := r.getImportPod(pvcPrime, podName)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -314,10 +324,22 @@ func (r *ForkliftPopulatorReconciler) reconcileTargetPVC(pvc, pvcPrime *corev1.P
 			if errors.Is(err, errCrNotFound) || k8serrors.IsAlreadyExists(err) {
 				return reconcile.Result{}, nil
 			}
+			if errors.Is(err, errInvalidPopulatorSpec) {
+				// Wait for the populator CR to be fixed, its updates trigger a new reconcile
+				r.recorder.Eventf(pvc, corev1.EventTypeWarning, invalidPopulatorSpec, err.Error())
+				cc.AddAnnotation(pvcPrimeCopy, cc.AnnRunningCondition, "false")
+				cc.AddAnnotation(pvcPrimeCopy, cc.AnnRunningConditionReason, invalidPopulatorSpec)
+				cc.AddAnnotation(pvcPrimeCopy, cc.AnnRunningConditionMessage, err.Error())
+				return reconcile.Result{}, r.updatePVCPrime(pvc, pvcPrimeCopy)
+			}
 
 			return reconcile.Result{}, err
 		}
 
+		if pvc.Annotations[cc.AnnRunningConditionReason] == invalidPopulatorSpec {
+			// Clear the condition of the previous invalid spec
+			return reconcile.Result{}, r.updatePVCPrime(pvc, pvcPrimeCopy)
+		}
 		return reconcile.Result{}, nil
 	}
 
@@ -329,6 +351,9 @@ func (r *ForkliftPopulatorReconciler) reconcileTargetPVC(pvc, pvcPrime *corev1.P
 	anno[cc.AnnPodPhase] = string(pod.Status.Phase)
 	anno[cc.AnnImportPod] = pod.Name
 	anno[cc.AnnPodRestarts] = strconv.Itoa(int(pod.Status.ContainerStatuses[0].RestartCount))
+	if anno[cc.AnnCurrentCheckpoint] != "" {
+		anno[cc.AnnCurrentPodID] = string(pod.UID)
+	}
 
 	phase := pvcPrimeCopy.Annotations[cc.AnnPodPhase]
 	switch phase {
@@ -342,6 +367,22 @@ func (r *ForkliftPopulatorReconciler) reconcileTargetPVC(pvc, pvcPrime *corev1.P
 	case string(corev1.PodPending):
 		return reconcile.Result{RequeueAfter: 2 * time.Second}, nil
 	case string(corev1.PodSucceeded):
+		if cc.IsMultiStageImportInProgress(pvcPrime) {
+			// The checkpoint is copied, advance to the next one or wait for it to be added to the populator CR
+			args, err := r.getCheckpointArgs(pvc)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if err := cc.UpdatesMultistageImportSucceeded(pvcPrimeCopy, args); err != nil {
+				return reconcile.Result{}, err
+			}
+			r.recorder.Eventf(pvc, corev1.EventTypeNormal, cc.ImportPaused, cc.MessageImportPaused, pvc.Name)
+			if err := r.updatePVCPrime(pvc, pvcPrimeCopy); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
+		}
+
 		if cc.IsPVCComplete(pvcPrime) && cc.IsUnbound(pvc) {
 			// TODO(benny) use a different const?
 			r.recorder.Eventf(pvc, corev1.EventTypeNormal, importSucceeded, messageImportSucceeded, pvc.Name)
@@ -367,12 +408,43 @@ func (r *ForkliftPopulatorReconciler) reconcileTargetPVC(pvc, pvcPrime *corev1.P
 }
 
 func (r *ForkliftPopulatorReconciler) updatePVCPrime(pvc, pvcPrime *corev1.PersistentVolumeClaim) error {
-	_, err := r.updatePVCWithPVCPrimeAnnotations(pvc, pvcPrime, r.updateAnnotations)
+	updatedPVC, err := r.updatePVCWithPVCPrimeAnnotations(pvc, pvcPrime, r.updateAnnotations)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return r.updatePopulatorStatus(updatedPVC)
+}
+
+// updatePopulatorStatus reports the progress, throughput and estimated time remaining of the target PVC on the status
+// of the populator CR
+func (r *ForkliftPopulatorReconciler) updatePopulatorStatus(pvc *corev1.PersistentVolumeClaim) error {
+	source, err := r.getPopulationSource(pvc)
+	if err != nil {
+		return err
+	}
+	found, err := cc.GetResource(context.TODO(), r.client, pvc.Namespace, pvc.Spec.DataSourceRef.Name, source)
+	if err != nil || !found {
+		return err
+	}
+
+	var progress *string
+	if value, ok := pvc.Annotations[cc.AnnPopulatorProgress]; ok {
+		progress = &value
+	}
+	throughput, eta := cc.GetTransferStats(pvc)
+
+	sourceCopy := source.DeepCopyObject().(client.Object)
+	switch cr := sourceCopy.(type) {
+	case *v1beta1.OvirtVolumePopulator:
+		cr.Status.Progress, cr.Status.Throughput, cr.Status.EstimatedTimeRemaining = progress, throughput, eta
+	case *v1beta1.OpenstackVolumePopulator:
+		cr.Status.Progress, cr.Status.Throughput, cr.Status.EstimatedTimeRemaining = progress, throughput, eta
+	}
+	if apiequality.Semantic.DeepEqual(source, sourceCopy) {
+		return nil
+	}
+	return r.client.Update(context.TODO(), sourceCopy)
 }
 
 func (r *ForkliftPopulatorReconciler) updateAnnotations(pvc, pvcPrime *corev1.PersistentVolumeClaim) {
@@ -387,17 +459,59 @@ func (r *ForkliftPopulatorReconciler) updatePVCForPopulation(pvc *corev1.Persist
 	annotations[cc.AnnUsePopulator] = "true"
 	cc.AddAnnotation(pvc, cc.AnnPopulatorKind, "forklift")
 	cc.AddAnnotation(pvc, cc.AnnUsePopulator, "true")
+
+	checkpoints, isFinal := getPopulatorCheckpoints(source)
+	args := &cc.CheckpointArgs{Checkpoints: checkpoints, IsFinal: isFinal, Client: r.client, Log: r.log}
+	if checkpoint := cc.GetNextCheckpoint(pvc, args); checkpoint != nil {
+		annotations[cc.AnnCurrentCheckpoint] = checkpoint.Current
+		annotations[cc.AnnPreviousCheckpoint] = checkpoint.Previous
+		annotations[cc.AnnFinalCheckpoint] = strconv.FormatBool(checkpoint.IsFinal)
+	}
+}
+
+// getCheckpointArgs returns the checkpoints of the populator CR of the PVC. The population of the current
+// checkpoint can finish even if the CR was deleted, it is only required to move to the next checkpoint.
+func (r *ForkliftPopulatorReconciler) getCheckpointArgs(pvc *corev1.PersistentVolumeClaim) (*cc.CheckpointArgs, error) {
+	args := &cc.CheckpointArgs{Client: r.client, Log: r.log}
+	source, err := r.getPopulationSource(pvc)
+	if err != nil {
+		return nil, err
+	}
+	found, err := cc.GetResource(context.TODO(), r.client, pvc.Namespace, pvc.Spec.DataSourceRef.Name, source)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		args.Checkpoints, args.IsFinal = getPopulatorCheckpoints(source)
+	}
+	return args, nil
+}
+
+func getPopulatorCheckpoints(source client.Object) ([]cdiv1.DataVolumeCheckpoint, bool) {
+	var checkpoints []v1beta1.PopulatorCheckpoint
+	var finalCheckpoint *bool
+	switch cr := source.(type) {
+	case *v1beta1.OvirtVolumePopulator:
+		checkpoints, finalCheckpoint = cr.Spec.Checkpoints, cr.Spec.FinalCheckpoint
+	case *v1beta1.OpenstackVolumePopulator:
+		checkpoints, finalCheckpoint = cr.Spec.Checkpoints, cr.Spec.FinalCheckpoint
+	}
+	result := []cdiv1.DataVolumeCheckpoint{}
+	for _, checkpoint := range checkpoints {
+		result = append(result, cdiv1.DataVolumeCheckpoint{Previous: checkpoint.Previous, Current: checkpoint.Current})
+	}
+	return result, ptr.Deref(finalCheckpoint, false)
 }
 
 func (r *ForkliftPopulatorReconciler) updateImportProgress(podPhase string, pvc, pvcPrime *corev1.PersistentVolumeClaim) error {
 	// Just set 100.0% if pod is succeeded
 	if podPhase == string(corev1.PodSucceeded) {
 		cc.AddAnnotation(pvc, cc.AnnPopulatorProgress, "100.0%")
+		cc.ClearTransferAnnotations(pvc)
 		return nil
 	}
 
-	importPodName := fmt.Sprintf("%s-%s", populatorPodPrefix, pvc.UID)
-	importPod, err := r.getImportPod(pvcPrime, importPodName)
+	importPod, err := r.getImportPod(pvcPrime, populatorPodName(pvc, pvcPrime))
 	if err != nil {
 		return err
 	}
@@ -423,15 +537,16 @@ func (r *ForkliftPopulatorReconciler) updateImportProgress(podPhase string, pvc,
 
 	// We fetch the import progress from the import pod metrics
 	httpClient = cc.BuildHTTPClient(httpClient)
-	progressReport, err := cc.GetProgressReportFromURL(context.TODO(), url, httpClient,
+	report, err := cc.GetTransferReportFromURL(context.TODO(), url, httpClient,
 		fmt.Sprintf("%s|%s", openstackMetric.OpenStackPopulatorProgressMetricName, ovirtMetric.OvirtPopulatorProgressMetricName),
 		string(pvc.UID))
 	if err != nil {
 		return err
 	}
 
-	if progressReport != "" {
-		if f, err := strconv.ParseFloat(progressReport, 64); err == nil {
+	cc.UpdateTransferAnnotations(pvc, report)
+	if report.Progress != "" {
+		if f, err := strconv.ParseFloat(report.Progress, 64); err == nil {
 			cc.AddAnnotation(pvc, cc.AnnPopulatorProgress, fmt.Sprintf("%.2f%%", f))
 		}
 	}
@@ -491,6 +606,9 @@ func (r *ForkliftPopulatorReconciler) createPopulatorPod(pvcPrime, pvc *corev1.P
 		if !found {
 			return errCrNotFound
 		}
+		if err := validateOpenstackPopulatorSpec(&crInstance.Spec); err != nil {
+			return err
+		}
 		executable = "openstack-populator"
 		args = getOpenstackPopulatorPodArgs(rawBlock, crInstance)
 		secretName = crInstance.Spec.SecretRef
@@ -504,6 +622,13 @@ func (r *ForkliftPopulatorReconciler) createPopulatorPod(pvcPrime, pvc *corev1.P
 
 	args = append(args, fmt.Sprintf("--owner-uid=%s", string(pvc.UID)))
 	args = append(args, fmt.Sprintf("--pvc-size=%d", pvc.Spec.Resources.Requests.Storage().Value()))
+	if checkpoint := pvcPrime.Annotations[cc.AnnCurrentCheckpoint]; checkpoint != "" {
+		args = append(args, "--current-checkpoint="+checkpoint)
+		// Each OpenStack stage is a full copy of its snapshot, only the oVirt incremental backups use the previous one
+		if executable == "ovirt-populator" {
+			args = append(args, "--previous-checkpoint="+pvcPrime.Annotations[cc.AnnPreviousCheckpoint])
+		}
+	}
 
 	annotations := map[string]string{
 		cc.AnnPopulatorKind: "forklift",
@@ -515,7 +640,7 @@ func (r *ForkliftPopulatorReconciler) createPopulatorPod(pvcPrime, pvc *corev1.P
 
 	pod := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-%s", populatorPodPrefix, pvc.UID),
+			Name:      populatorPodName(pvc, pvcPrime),
 			Namespace: pvc.Namespace,
 			OwnerReferences: []metav1.OwnerReference{
 				{
@@ -590,6 +715,19 @@ func (r *ForkliftPopulatorReconciler) deletePopulatorPod(podName string, pvc *co
 	return reconcile.Result{}, nil
 }
 
+// populatorPodName returns the name of the populator pod of the PVC. Each checkpoint of a warm migration
+// gets its own pod, the pod of the last copied checkpoint is kept in the import pod annotation of the PVC prime.
+func populatorPodName(pvc, pvcPrime *corev1.PersistentVolumeClaim) string {
+	if podName := pvcPrime.Annotations[cc.AnnImportPod]; podName != "" {
+		return podName
+	}
+	podName := fmt.Sprintf("%s-%s", populatorPodPrefix, pvc.UID)
+	if checkpoint := pvcPrime.Annotations[cc.AnnCurrentCheckpoint]; checkpoint != "" {
+		podName += "-checkpoint-" + checkpoint
+	}
+	return podName
+}
+
 func makePopulatePodSpec(pvcPrimeName, secretName string) corev1.PodSpec {
 	return corev1.PodSpec{
 		Containers: []corev1.Container{
@@ -650,6 +788,23 @@ func getOvirtPopulatorPodArgs(rawBlock bool, ovirtCR *v1beta1.OvirtVolumePopulat
 	return args
 }
 
+// validateOpenstackPopulatorSpec enforces the CRD validation rules of the OpenstackVolumePopulator spec
+func validateOpenstackPopulatorSpec(spec *v1beta1.OpenstackVolumePopulatorSpec) error {
+	sources := 0
+	for _, id := range []string{spec.ImageID, spec.VolumeID, spec.SnapshotID} {
+		if id != "" {
+			sources++
+		}
+	}
+	switch {
+	case sources > 1:
+		return fmt.Errorf("%w: only one of imageId, volumeId or snapshotId can be set", errInvalidPopulatorSpec)
+	case sources == 0 && len(spec.Checkpoints) == 0:
+		return fmt.Errorf("%w: one of imageId, volumeId, snapshotId or checkpoints must be set", errInvalidPopulatorSpec)
+	}
+	return nil
+}
+
 func getOpenstackPopulatorPodArgs(rawBlock bool, openstackCR *v1beta1.OpenstackVolumePopulator) []string {
 	args := []string{}
 	if rawBlock {
@@ -660,7 +815,15 @@ func getOpenstackPopulatorPodArgs(rawBlock bool, openstackCR *v1beta1.OpenstackV
 
 	args = append(args, "--endpoint="+openstackCR.Spec.IdentityURL)
 	args = append(args, "--secret-name="+openstackCR.Spec.SecretRef)
-	args = append(args, "--image-id="+openstackCR.Spec.ImageID)
+	if openstackCR.Spec.ImageID != "" {
+		args = append(args, "--image-id="+openstackCR.Spec.ImageID)
+	}
+	if openstackCR.Spec.VolumeID != "" {
+		args = append(args, "--volume-id="+openstackCR.Spec.VolumeID)
+	}
+	if openstackCR.Spec.SnapshotID != "" {
+		args = append(args, "--snapshot-id="+openstackCR.Spec.SnapshotID)
+	}
 
 	return args
 }
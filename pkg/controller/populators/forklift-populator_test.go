@@ -16,6 +16,7 @@ import (
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -423,6 +425,49 @@ var _ = Describe("Forklift populator tests", func() {
 			Expect(targetPvc.Annotations[AnnPopulatorProgress]).To(BeEquivalentTo("13.45%"))
 		})
 
+		It("should report throughput and ETA in target PVC and populator CR status", func() {
+			targetPvc := CreatePvcInStorageClass(targetPvcName, metav1.NamespaceDefault, &sc.Name, nil, nil, corev1.ClaimPending)
+			targetPvc.SetUID("b856691e-1038-11e9-a5ab-525500d15501")
+			targetPvc.Spec.DataSourceRef = dataSourceRef
+			pvcPrime := getPVCPrime(targetPvc, nil)
+			importPodName := fmt.Sprintf("%s-%s", populatorPodPrefix, targetPvc.UID)
+			pvcPrime.Annotations = map[string]string{AnnImportPod: importPodName, AnnPodPhase: string(corev1.PodRunning)}
+
+			ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprintf(w, "kubevirt_cdi_ovirt_progress_total{ownerUID=\"%v\"} 25\n", targetPvc.GetUID())
+				_, _ = fmt.Fprintf(w, "kubevirt_cdi_transfer_throughput_bytes{ownerUID=\"%v\"} 1.048576e+06\n", targetPvc.GetUID())
+				_, _ = fmt.Fprintf(w, "kubevirt_cdi_transfer_eta_seconds{ownerUID=\"%v\"} 90\n", targetPvc.GetUID())
+			}))
+			defer ts.Close()
+			ep, err := url.Parse(ts.URL)
+			Expect(err).ToNot(HaveOccurred())
+			port, err := strconv.ParseInt(ep.Port(), 10, 32)
+			Expect(err).ToNot(HaveOccurred())
+
+			pod := getPopulatorPod(targetPvc, pvcPrime)
+			pod.Spec.Containers[0].Ports[0].ContainerPort = int32(port)
+			pod.Status.PodIP = ep.Hostname()
+			pod.Status.Phase = corev1.PodRunning
+
+			cr := &v1beta1.OvirtVolumePopulator{
+				ObjectMeta: metav1.ObjectMeta{Name: dataSourceRef.Name, Namespace: metav1.NamespaceDefault},
+			}
+			reconciler = createForkliftPopulatorReconciler(targetPvc, pvcPrime, pod, cr)
+			err = reconciler.updatePVCPrime(targetPvc, pvcPrime)
+			Expect(err).ToNot(HaveOccurred())
+
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: targetPvcName, Namespace: metav1.NamespaceDefault}, pvc)).To(Succeed())
+			Expect(pvc.Annotations).To(HaveKeyWithValue(AnnPopulatorThroughput, "1Mi"))
+			Expect(pvc.Annotations).To(HaveKeyWithValue(AnnPopulatorETA, "1m30s"))
+
+			Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr)).To(Succeed())
+			Expect(cr.Status.Progress).To(HaveValue(Equal("25.00%")))
+			Expect(cr.Status.Throughput).ToNot(BeNil())
+			Expect(cr.Status.Throughput.Value()).To(Equal(int64(1 << 20)))
+			Expect(cr.Status.EstimatedTimeRemaining).To(HaveValue(Equal(metav1.Duration{Duration: 90 * time.Second})))
+		})
+
 		It("should remove the populator pod after pvcPrime is marked for deletion", func() {
 			targetPvc := CreatePvcInStorageClass(targetPvcName, metav1.NamespaceDefault, &sc.Name, nil, nil, corev1.ClaimPending)
 			targetPvc.Spec.DataSourceRef = dataSourceRef
@@ -479,6 +524,56 @@ var _ = Describe("Forklift populator tests", func() {
 			))
 		})
 
+		It("should create the populator pod of the current checkpoint", func() {
+			targetPvc := CreatePvcInStorageClass(targetPvcName, metav1.NamespaceDefault, &sc.Name, nil, nil, corev1.ClaimPending)
+			targetPvc.Spec.DataSourceRef = dataSourceRef
+			pvcPrime := getPVCPrime(targetPvc, map[string]string{
+				AnnCurrentCheckpoint:  "backup-2",
+				AnnPreviousCheckpoint: "checkpoint-1",
+			})
+
+			reconciler = createForkliftPopulatorReconciler(targetPvc, pvcPrime, sc, ovirtCr)
+			err := reconciler.createPopulatorPod(pvcPrime, targetPvc)
+			Expect(err).To(Not(HaveOccurred()))
+
+			pod := &corev1.Pod{}
+			podName := fmt.Sprintf("%s-%s-checkpoint-backup-2", populatorPodPrefix, targetPvc.UID)
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: podName, Namespace: targetPvc.Namespace}, pod)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(pod.Spec.Containers[0].Args).To(ContainElements(
+				"--current-checkpoint=backup-2",
+				"--previous-checkpoint=checkpoint-1",
+			))
+		})
+
+		DescribeTable("should pass the Cinder source to the OpenStack populator pod", func(spec v1beta1.OpenstackVolumePopulatorSpec, expected string) {
+			spec.IdentityURL = "https://keystone.example.com"
+			spec.SecretRef = "openstack-secret"
+			args := getOpenstackPopulatorPodArgs(false, &v1beta1.OpenstackVolumePopulator{Spec: spec})
+			Expect(args).To(ContainElement(expected))
+			Expect(args).ToNot(ContainElement(HavePrefix("--image-id=")))
+		},
+			Entry("with a volume", v1beta1.OpenstackVolumePopulatorSpec{VolumeID: "volume-1"}, "--volume-id=volume-1"),
+			Entry("with a snapshot", v1beta1.OpenstackVolumePopulatorSpec{SnapshotID: "snapshot-1"}, "--snapshot-id=snapshot-1"),
+		)
+
+		DescribeTable("should validate the source of the OpenStack populator", func(spec v1beta1.OpenstackVolumePopulatorSpec, expected string) {
+			err := validateOpenstackPopulatorSpec(&spec)
+			if expected == "" {
+				Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(errInvalidPopulatorSpec))
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+			Entry("with an image", v1beta1.OpenstackVolumePopulatorSpec{ImageID: "image-1"}, ""),
+			Entry("with a volume", v1beta1.OpenstackVolumePopulatorSpec{VolumeID: "volume-1"}, ""),
+			Entry("with a snapshot", v1beta1.OpenstackVolumePopulatorSpec{SnapshotID: "snapshot-1"}, ""),
+			Entry("with checkpoints", v1beta1.OpenstackVolumePopulatorSpec{Checkpoints: []v1beta1.PopulatorCheckpoint{{Current: "snap-1"}}}, ""),
+			Entry("without source", v1beta1.OpenstackVolumePopulatorSpec{}, "one of imageId, volumeId, snapshotId or checkpoints must be set"),
+			Entry("with several sources", v1beta1.OpenstackVolumePopulatorSpec{VolumeID: "volume-1", SnapshotID: "snapshot-1"}, "only one of imageId, volumeId or snapshotId can be set"),
+		)
+
 		It("should correctly identify a PVC as Forklift kind", func() {
 			validPVC := &corev1.PersistentVolumeClaim{
 				ObjectMeta: metav1.ObjectMeta{
@@ -541,6 +636,189 @@ var _ = Describe("Forklift populator tests", func() {
 	})
 })
 
+var _ = Describe("Forklift populator warm migration", func() {
+	const (
+		populatorName = "forklift-populator-warm"
+		targetPvcName = "test-forklift-warm-pvc"
+	)
+
+	var (
+		sc         *storagev1.StorageClass
+		targetPvc  *corev1.PersistentVolumeClaim
+		pvcPrime   *corev1.PersistentVolumeClaim
+		pv         *corev1.PersistentVolume
+		cr         *v1beta1.OpenstackVolumePopulator
+		reconciler *ForkliftPopulatorReconciler
+	)
+
+	BeforeEach(func() {
+		sc = CreateStorageClassWithProvisioner("testsc", map[string]string{AnnDefaultStorageClass: "true"}, map[string]string{}, "csi-plugin")
+		apiGroup := "forklift.cdi.kubevirt.io"
+		targetPvc = CreatePvcInStorageClass(targetPvcName, metav1.NamespaceDefault, &sc.Name, nil, nil, corev1.ClaimPending)
+		targetPvc.UID = "target-uid"
+		targetPvc.Spec.DataSourceRef = &corev1.TypedObjectReference{
+			APIGroup: &apiGroup,
+			Kind:     v1beta1.OpenstackVolumePopulatorKind,
+			Name:     populatorName,
+		}
+		cr = &v1beta1.OpenstackVolumePopulator{
+			ObjectMeta: metav1.ObjectMeta{Name: populatorName, Namespace: metav1.NamespaceDefault},
+			Spec: v1beta1.OpenstackVolumePopulatorSpec{
+				IdentityURL: "https://keystone.example.com",
+				SecretRef:   "openstack-secret",
+				Checkpoints: []v1beta1.PopulatorCheckpoint{{Previous: "", Current: "snap-1"}},
+			},
+		}
+		pvcPrime = CreatePvcInStorageClass(PVCPrimeName(targetPvc), metav1.NamespaceDefault, &sc.Name, nil, nil, corev1.ClaimBound)
+		pvcPrime.UID = "prime-uid"
+		pvcPrime.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(targetPvc, corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))}
+		pv = &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv"},
+			Spec: corev1.PersistentVolumeSpec{
+				ClaimRef: &corev1.ObjectReference{Namespace: pvcPrime.Namespace, Name: pvcPrime.Name, UID: pvcPrime.UID},
+			},
+		}
+		pvcPrime.Spec.VolumeName = pv.Name
+	})
+
+	stagePod := func(checkpoint string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            fmt.Sprintf("%s-%s-checkpoint-%s", populatorPodPrefix, targetPvc.UID, checkpoint),
+				Namespace:       metav1.NamespaceDefault,
+				UID:             types.UID("pod-" + checkpoint),
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(pvcPrime, corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"))},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{{}},
+			},
+		}
+	}
+
+	reconcileTarget := func() {
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: targetPvcName, Namespace: metav1.NamespaceDefault}})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	getPVC := func(name string) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: metav1.NamespaceDefault}, pvc)).To(Succeed())
+		return pvc
+	}
+
+	It("should report an invalid populator spec on the target PVC until it is fixed", func() {
+		cr.Spec.Checkpoints = nil
+		cr.Spec.ImageID = "image-1"
+		cr.Spec.VolumeID = "volume-1"
+		reconciler = createForkliftPopulatorReconciler(targetPvc, pvcPrime, pv, sc, cr)
+
+		By("Not creating the populator pod of an invalid spec")
+		reconcileTarget()
+		podName := fmt.Sprintf("%s-%s", populatorPodPrefix, targetPvc.UID)
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: podName, Namespace: metav1.NamespaceDefault}, &corev1.Pod{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		target := getPVC(targetPvcName)
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnRunningCondition, "false"))
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnRunningConditionReason, invalidPopulatorSpec))
+		Expect(target.Annotations[AnnRunningConditionMessage]).To(ContainSubstring("only one of imageId, volumeId or snapshotId can be set"))
+		Expect(<-reconciler.recorder.(*record.FakeRecorder).Events).To(ContainSubstring(invalidPopulatorSpec))
+
+		By("Creating the populator pod once the spec is fixed")
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr)).To(Succeed())
+		cr.Spec.ImageID = ""
+		Expect(reconciler.client.Update(context.TODO(), cr)).To(Succeed())
+		reconcileTarget()
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: podName, Namespace: metav1.NamespaceDefault}, &corev1.Pod{})).To(Succeed())
+		target = getPVC(targetPvcName)
+		Expect(target.Annotations).ToNot(HaveKey(AnnRunningCondition))
+		Expect(target.Annotations).ToNot(HaveKey(AnnRunningConditionReason))
+		Expect(target.Annotations).ToNot(HaveKey(AnnRunningConditionMessage))
+	})
+
+	It("should set the first checkpoint on the PVC prime", func() {
+		cr.Spec.Checkpoints = append(cr.Spec.Checkpoints, v1beta1.PopulatorCheckpoint{Previous: "snap-1", Current: "snap-2"})
+		reconciler = createForkliftPopulatorReconciler()
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+		reconciler.updatePVCForPopulation(pvc, cr)
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnCurrentCheckpoint, "snap-1"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnPreviousCheckpoint, ""))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnFinalCheckpoint, "false"))
+	})
+
+	It("should not set checkpoints for a cold migration", func() {
+		cr.Spec.Checkpoints = nil
+		reconciler = createForkliftPopulatorReconciler()
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}
+		reconciler.updatePVCForPopulation(pvc, cr)
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnCurrentCheckpoint))
+	})
+
+	It("should pause after a checkpoint and continue with the next one", func() {
+		pvcPrime.Annotations = map[string]string{
+			AnnPopulatorKind:      "forklift",
+			AnnCurrentCheckpoint:  "snap-1",
+			AnnPreviousCheckpoint: "",
+			AnnFinalCheckpoint:    "false",
+		}
+		reconciler = createForkliftPopulatorReconciler(targetPvc, pvcPrime, pv, sc, cr, stagePod("snap-1"))
+
+		By("Marking the checkpoint copied and pausing")
+		reconcileTarget()
+		prime := getPVC(pvcPrime.Name)
+		Expect(prime.Annotations).To(HaveKeyWithValue(AnnCheckpointsCopied+".snap-1", "pod-snap-1"))
+		Expect(prime.Annotations).To(HaveKeyWithValue(AnnCurrentCheckpoint, "snap-1"))
+		target := getPVC(targetPvcName)
+		Expect(target.Annotations).To(HaveKeyWithValue(AnnCurrentCheckpoint, "snap-1"))
+		Expect(getPVC(pvcPrime.Name).Spec.VolumeName).To(Equal(pv.Name))
+		Expect(pv.Spec.ClaimRef.Name).To(Equal(pvcPrime.Name))
+
+		By("Moving to the next checkpoint once it is added")
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr)).To(Succeed())
+		Expect(cr.Status.Progress).To(HaveValue(Equal("100.0%")))
+		cr.Spec.Checkpoints = append(cr.Spec.Checkpoints, v1beta1.PopulatorCheckpoint{Previous: "snap-1", Current: "snap-2"})
+		cr.Spec.FinalCheckpoint = ptr.To(true)
+		Expect(reconciler.client.Update(context.TODO(), cr)).To(Succeed())
+		reconcileTarget()
+		prime = getPVC(pvcPrime.Name)
+		Expect(prime.Annotations).To(HaveKeyWithValue(AnnCurrentCheckpoint, "snap-2"))
+		Expect(prime.Annotations).To(HaveKeyWithValue(AnnPreviousCheckpoint, "snap-1"))
+		Expect(prime.Annotations).To(HaveKeyWithValue(AnnFinalCheckpoint, "true"))
+		Expect(prime.Annotations).ToNot(HaveKey(AnnImportPod))
+
+		By("Creating the pod of the next checkpoint")
+		reconcileTarget()
+		pod := &corev1.Pod{}
+		podName := fmt.Sprintf("%s-%s-checkpoint-snap-2", populatorPodPrefix, targetPvc.UID)
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: podName, Namespace: metav1.NamespaceDefault}, pod)).To(Succeed())
+		Expect(pod.Spec.Containers[0].Args).To(ContainElement("--current-checkpoint=snap-2"))
+		Expect(pod.Spec.Containers[0].Args).ToNot(ContainElement("--previous-checkpoint=snap-1"))
+	})
+
+	It("should rebind the PV once the final checkpoint is copied", func() {
+		cr.Spec.FinalCheckpoint = ptr.To(true)
+		pvcPrime.Annotations = map[string]string{
+			AnnPopulatorKind:      "forklift",
+			AnnCurrentCheckpoint:  "snap-1",
+			AnnPreviousCheckpoint: "",
+			AnnFinalCheckpoint:    "true",
+		}
+		reconciler = createForkliftPopulatorReconciler(targetPvc, pvcPrime, pv, sc, cr, stagePod("snap-1"))
+
+		reconcileTarget()
+		Expect(getPVC(pvcPrime.Name).Annotations).ToNot(HaveKey(AnnMultiStageImportDone))
+		reconcileTarget()
+		prime := getPVC(pvcPrime.Name)
+		Expect(prime.Annotations).To(HaveKeyWithValue(AnnMultiStageImportDone, "true"))
+		Expect(prime.Annotations).ToNot(HaveKey(AnnCurrentCheckpoint))
+		reconcileTarget()
+
+		updatedPV := &corev1.PersistentVolume{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: pv.Name}, updatedPV)).To(Succeed())
+		Expect(updatedPV.Spec.ClaimRef.Name).To(Equal(targetPvcName))
+	})
+})
+
 func createForkliftPopulatorReconciler(objects ...runtime.Object) *ForkliftPopulatorReconciler {
 	cdiConfig := MakeEmptyCDIConfigSpec(common.ConfigName)
 	cdiConfig.Status = cdiv1.CDIConfigStatus{}
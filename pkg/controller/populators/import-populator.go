@@ -224,13 +224,16 @@ func (r *ImportPopulatorReconciler) updatePVCForPopulation(pvc *corev1.Persisten
 	annotations := pvc.Annotations
 	annotations[cc.AnnPopulatorKind] = cdiv1.VolumeImportSourceRef
 	annotations[cc.AnnContentType] = string(cc.GetContentType(volumeImportSource.Spec.ContentType))
-	annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, volumeImportSource.Spec.Preallocation))
+	annotations[cc.AnnPreallocationRequested] = strconv.FormatBool(cc.GetPreallocation(context.TODO(), r.client, volumeImportSource.Spec.Preallocation, pvc.Spec.StorageClassName))
 
 	if checkpoint := cc.GetNextCheckpoint(pvc, r.getCheckpointArgs(source)); checkpoint != nil {
 		annotations[cc.AnnCurrentCheckpoint] = checkpoint.Current
 		annotations[cc.AnnPreviousCheckpoint] = checkpoint.Previous
 		annotations[cc.AnnFinalCheckpoint] = strconv.FormatBool(checkpoint.IsFinal)
 	}
+	if customization := volumeImportSource.Spec.Customization; customization != nil {
+		annotations[cc.AnnCustomizeConfigMap] = customization.ConfigMap
+	}
 
 	if http := volumeImportSource.Spec.Source.HTTP; http != nil {
 		cc.UpdateHTTPAnnotations(annotations, http)
@@ -244,6 +247,10 @@ func (r *ImportPopulatorReconciler) updatePVCForPopulation(pvc *corev1.Persisten
 		cc.UpdateGCSAnnotations(annotations, gcs)
 		return
 	}
+	if blob := volumeImportSource.Spec.Source.AzureBlob; blob != nil {
+		cc.UpdateAzureBlobAnnotations(annotations, blob)
+		return
+	}
 	if registry := volumeImportSource.Spec.Source.Registry; registry != nil {
 		cc.UpdateRegistryAnnotations(annotations, registry)
 		return
@@ -256,6 +263,10 @@ func (r *ImportPopulatorReconciler) updatePVCForPopulation(pvc *corev1.Persisten
 		cc.UpdateVDDKAnnotations(annotations, vddk)
 		return
 	}
+	if backup := volumeImportSource.Spec.Source.CDIBackup; backup != nil {
+		cc.UpdateCDIBackupAnnotations(annotations, backup)
+		return
+	}
 	// Our webhook doesn't allow VolumeImportSources without source, so this should never happen.
 	// Defaulting to Blank source anyway to avoid unexpected behavior.
 	annotations[cc.AnnSource] = cc.SourceNone
@@ -287,6 +298,7 @@ func (r *ImportPopulatorReconciler) updateImportProgress(podPhase string, pvc, p
 	// Just set 100.0% if pod is succeeded
 	if podPhase == string(corev1.PodSucceeded) {
 		cc.AddAnnotation(pvc, cc.AnnPopulatorProgress, "100.0%")
+		cc.ClearTransferAnnotations(pvc)
 		return nil
 	}
 
@@ -321,16 +333,17 @@ func (r *ImportPopulatorReconciler) updateImportProgress(podPhase string, pvc, p
 
 	// We fetch the import progress from the import pod metrics
 	httpClient = cc.BuildHTTPClient(httpClient)
-	progressReport, err := cc.GetProgressReportFromURL(context.TODO(), url, httpClient, importMetrics.ImportProgressMetricName, string(pvc.UID))
+	report, err := cc.GetTransferReportFromURL(context.TODO(), url, httpClient, importMetrics.ImportProgressMetricName, string(pvc.UID))
 	if err != nil {
 		return err
 	}
-	if progressReport != "" {
-		if strings.HasPrefix(progressReport, "100") {
+	cc.UpdateTransferAnnotations(pvc, report)
+	if report.Progress != "" {
+		if strings.HasPrefix(report.Progress, "100") {
 			// Hold on with reporting 100% since that may not be accounting for resize/convert etc
 			return nil
 		}
-		if f, err := strconv.ParseFloat(progressReport, 64); err == nil {
+		if f, err := strconv.ParseFloat(report.Progress, 64); err == nil {
 			cc.AddAnnotation(pvc, cc.AnnPopulatorProgress, fmt.Sprintf("%.2f%%", f))
 		}
 	}
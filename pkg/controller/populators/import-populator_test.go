@@ -506,8 +506,12 @@ var _ = Describe("Import populator tests", func() {
 			targetPvc := CreatePvcInStorageClass(targetPvcName, metav1.NamespaceDefault, &sc.Name, map[string]string{}, nil, corev1.ClaimPending)
 			targetPvc.Spec.DataSourceRef = dataSourceRef
 			targetPvc.Annotations[AnnVddkExtraArgs] = "vddk-extras"
+			targetPvc.Annotations[AnnExpandPartition] = "true"
+			targetPvc.Annotations[AnnSparsify] = "true"
+			targetPvc.Annotations[AnnCDIBackupManifest] = "true"
 
 			volumeImportSource := getVolumeImportSource(true, metav1.NamespaceDefault)
+			volumeImportSource.Spec.Customization = &cdiv1.DataVolumeCustomization{ConfigMap: "golden-recipe"}
 			volumeImportSource.Spec.Source = &cdiv1.ImportSourceType{
 				VDDK: &cdiv1.DataVolumeSourceVDDK{
 					BackingFile: "testBackingFile",
@@ -555,6 +559,38 @@ var _ = Describe("Import populator tests", func() {
 			Expect(pvcPrime.GetLabels()[LabelExcludeFromVeleroBackup]).To(Equal("true"))
 
 			Expect(pvcPrime.GetAnnotations()[AnnVddkExtraArgs]).To(Equal("vddk-extras"))
+			Expect(pvcPrime.GetAnnotations()[AnnCustomizeConfigMap]).To(Equal("golden-recipe"))
+			Expect(pvcPrime.GetAnnotations()[AnnExpandPartition]).To(Equal("true"))
+			Expect(pvcPrime.GetAnnotations()[AnnSparsify]).To(Equal("true"))
+			Expect(pvcPrime.GetAnnotations()[AnnCDIBackupManifest]).To(Equal("true"))
+		})
+
+		It("Should create PVC prime with proper CDI backup import annotations", func() {
+			targetPvc := CreatePvcInStorageClass(targetPvcName, metav1.NamespaceDefault, &sc.Name, map[string]string{}, nil, corev1.ClaimPending)
+			targetPvc.Spec.DataSourceRef = dataSourceRef
+
+			volumeImportSource := getVolumeImportSource(true, metav1.NamespaceDefault)
+			volumeImportSource.Spec.Source = &cdiv1.ImportSourceType{
+				CDIBackup: &cdiv1.DataVolumeSourceCDIBackup{
+					URL:           "http://s3.example.com/bucket/backup/manifest.json",
+					SecretRef:     "testSecret",
+					CertConfigMap: "testCert",
+				},
+			}
+
+			By("Reconcile")
+			reconciler = createImportPopulatorReconciler(targetPvc, volumeImportSource, sc)
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: targetPvcName, Namespace: metav1.NamespaceDefault}})
+			Expect(err).To(Not(HaveOccurred()))
+
+			By("Checking PVC' annotations")
+			pvcPrime, err := reconciler.getPVCPrime(targetPvc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pvcPrime).ToNot(BeNil())
+			Expect(pvcPrime.GetAnnotations()[AnnEndpoint]).To(Equal("http://s3.example.com/bucket/backup/manifest.json"))
+			Expect(pvcPrime.GetAnnotations()[AnnSecret]).To(Equal("testSecret"))
+			Expect(pvcPrime.GetAnnotations()[AnnCertConfigMap]).To(Equal("testCert"))
+			Expect(pvcPrime.GetAnnotations()[AnnSource]).To(Equal(SourceCDIBackup))
 		})
 
 	})
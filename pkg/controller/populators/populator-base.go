@@ -203,6 +203,15 @@ func (r *ReconcilerBase) createPVCPrime(pvc *corev1.PersistentVolumeClaim, sourc
 	if vddkExtraArgs, ok := pvc.Annotations[cc.AnnVddkExtraArgs]; ok && vddkExtraArgs != "" {
 		annotations[cc.AnnVddkExtraArgs] = vddkExtraArgs
 	}
+	if expandPartition, ok := pvc.Annotations[cc.AnnExpandPartition]; ok {
+		annotations[cc.AnnExpandPartition] = expandPartition
+	}
+	if sparsify, ok := pvc.Annotations[cc.AnnSparsify]; ok {
+		annotations[cc.AnnSparsify] = sparsify
+	}
+	if backupManifest, ok := pvc.Annotations[cc.AnnCDIBackupManifest]; ok {
+		annotations[cc.AnnCDIBackupManifest] = backupManifest
+	}
 
 	// Assemble PVC' spec
 	pvcPrime := &corev1.PersistentVolumeClaim{
@@ -245,7 +254,7 @@ func (r *ReconcilerBase) createPVCPrime(pvc *corev1.PersistentVolumeClaim, sourc
 type updatePVCAnnotationsFunc func(pvc, pvcPrime *corev1.PersistentVolumeClaim)
 
 var desiredAnnotations = []string{cc.AnnPodPhase, cc.AnnPodReady, cc.AnnPodRestarts,
-	cc.AnnPreallocationRequested, cc.AnnPreallocationApplied, cc.AnnCurrentCheckpoint, cc.AnnMultiStageImportDone,
+	cc.AnnPreallocationRequested, cc.AnnPreallocationApplied, cc.AnnReclaimedBytes, cc.AnnCurrentCheckpoint, cc.AnnMultiStageImportDone,
 	cc.AnnRunningCondition, cc.AnnRunningConditionMessage, cc.AnnRunningConditionReason, cc.AnnPodSchedulable}
 
 func (r *ReconcilerBase) updatePVCWithPVCPrimeAnnotations(pvc, pvcPrime *corev1.PersistentVolumeClaim, updateFunc updatePVCAnnotationsFunc) (*corev1.PersistentVolumeClaim, error) {
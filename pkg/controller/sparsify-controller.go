@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	sparsifyControllerName = "sparsify-controller"
+	sparsifyPodPrefix      = "cdi-sparsify-"
+	// sparsifyInUseCheckPeriod is how often the PVC is checked for other users, before and while it is sparsified
+	sparsifyInUseCheckPeriod = 10 * time.Second
+
+	// SparsifyInUse provides a const to indicate the PVC to sparsify is in use by another pod
+	SparsifyInUse = "SparsifyInUse"
+	// SparsifyIdleNotConfirmed provides a const to indicate the ReadWriteMany block PVC to sparsify was not confirmed idle
+	SparsifyIdleNotConfirmed = "SparsifyIdleNotConfirmed"
+	// SparsifySucceeded provides a const to indicate the sparsify of the PVC completed
+	SparsifySucceeded = "SparsifySucceeded"
+	// SparsifyFailed provides a const to indicate the sparsify pod failed
+	SparsifyFailed = "SparsifyFailed"
+)
+
+// SparsifyReconciler members
+type SparsifyReconciler struct {
+	client          client.Client
+	recorder        record.EventRecorder
+	log             logr.Logger
+	image           string
+	pullPolicy      string
+	verbose         string
+	installerLabels map[string]string
+}
+
+// Reconcile loop for SparsifyReconciler, it sparsifies the disk of a populated PVC once for every new value of its sparsify request annotation
+func (r *SparsifyReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.client.Get(ctx, req.NamespacedName, pvc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log := r.log.WithValues("PVC", req.NamespacedName)
+
+	pod := &corev1.Pod{}
+	podExists := true
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: pvc.Namespace, Name: getSparsifyPodName(pvc)}, pod); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		podExists = false
+	}
+
+	request, ok := pvc.Annotations[cc.AnnSparsifyRequest]
+	if !ok || pvc.Annotations[cc.AnnSparsifyCompleted] == request || pvc.DeletionTimestamp != nil {
+		return reconcile.Result{}, r.deletePod(ctx, pod, podExists)
+	}
+	if podExists && pod.Annotations[cc.AnnSparsifyRequest] != request {
+		// The pod sparsified an earlier request
+		return reconcile.Result{Requeue: true}, r.deletePod(ctx, pod, podExists)
+	}
+
+	if !podExists {
+		if !cc.IsBound(pvc) {
+			return reconcile.Result{}, nil
+		}
+		if populated, err := cc.IsPopulated(pvc, r.client); err != nil || !populated {
+			return reconcile.Result{}, err
+		}
+		if isSharedBlockVolume(pvc) && pvc.Annotations[cc.AnnSparsifyIdleConfirmed] != request {
+			// Users of a shared block volume, like a cluster filesystem, may live outside of the cluster
+			r.recorder.Eventf(pvc, corev1.EventTypeWarning, SparsifyIdleNotConfirmed,
+				"Not sparsifying ReadWriteMany block PVC %s until %s confirms request %s", pvc.Name, cc.AnnSparsifyIdleConfirmed, request)
+			return reconcile.Result{}, nil
+		}
+		pods, err := r.getOtherPodsUsingPVC(ctx, pvc)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(pods) > 0 {
+			r.recorder.Eventf(pvc, corev1.EventTypeWarning, SparsifyInUse, "Not sparsifying PVC %s, it is in use by pod %s", pvc.Name, pods[0].Name)
+			return reconcile.Result{RequeueAfter: sparsifyInUseCheckPeriod}, nil
+		}
+		_, err = r.createSparsifyPod(ctx, pvc, request)
+		return reconcile.Result{RequeueAfter: sparsifyInUseCheckPeriod}, err
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		termMsg, err := parseTerminationMessage(pod)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		pvcCopy := pvc.DeepCopy()
+		message := fmt.Sprintf("Sparsified PVC %s", pvc.Name)
+		if termMsg != nil && termMsg.ReclaimedBytes != nil {
+			cc.AddAnnotation(pvcCopy, cc.AnnReclaimedBytes, strconv.FormatInt(*termMsg.ReclaimedBytes, 10))
+			message = fmt.Sprintf("%s, reclaimed %d bytes", message, *termMsg.ReclaimedBytes)
+		} else {
+			// The reclaimed space of a block device is unknown
+			delete(pvcCopy.Annotations, cc.AnnReclaimedBytes)
+		}
+		cc.AddAnnotation(pvcCopy, cc.AnnSparsifyCompleted, request)
+		if err := r.client.Update(ctx, pvcCopy); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.V(1).Info(message)
+		r.recorder.Event(pvc, corev1.EventTypeNormal, SparsifySucceeded, message)
+		return reconcile.Result{}, r.deletePod(ctx, pod, podExists)
+	case corev1.PodFailed:
+		// The failed pod is kept until the request changes
+		message := "Sparsify pod failed"
+		if termMsg := getBackupPodTerminationMessage(pod); termMsg != "" {
+			message = fmt.Sprintf("%s: %s", message, termMsg)
+		}
+		r.recorder.Event(pvc, corev1.EventTypeWarning, SparsifyFailed, message)
+	default:
+		// A pod started using the PVC while it is sparsified would see its writes of zeroes race with the sparsify
+		pods, err := r.getOtherPodsUsingPVC(ctx, pvc)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(pods) > 0 {
+			r.recorder.Eventf(pvc, corev1.EventTypeWarning, SparsifyInUse, "Stopped sparsifying PVC %s, it is in use by pod %s", pvc.Name, pods[0].Name)
+			return reconcile.Result{RequeueAfter: sparsifyInUseCheckPeriod}, r.deletePod(ctx, pod, podExists)
+		}
+		return reconcile.Result{RequeueAfter: sparsifyInUseCheckPeriod}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// getOtherPodsUsingPVC returns the pods using the PVC, other than its sparsify pod
+func (r *SparsifyReconciler) getOtherPodsUsingPVC(ctx context.Context, pvc *corev1.PersistentVolumeClaim) ([]corev1.Pod, error) {
+	pods, err := cc.GetPodsUsingPVCs(ctx, r.client, pvc.Namespace, sets.New(pvc.Name), false)
+	if err != nil {
+		return nil, err
+	}
+	var others []corev1.Pod
+	for _, pod := range pods {
+		if pod.Name != getSparsifyPodName(pvc) {
+			others = append(others, pod)
+		}
+	}
+	return others, nil
+}
+
+// isSharedBlockVolume returns true if the PVC is a block volume that can be attached to several nodes at once
+func isSharedBlockVolume(pvc *corev1.PersistentVolumeClaim) bool {
+	if util.ResolveVolumeMode(pvc.Spec.VolumeMode) != corev1.PersistentVolumeBlock {
+		return false
+	}
+	for _, mode := range pvc.Spec.AccessModes {
+		if mode == corev1.ReadWriteMany {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *SparsifyReconciler) deletePod(ctx context.Context, pod *corev1.Pod, podExists bool) error {
+	if !podExists || pod.DeletionTimestamp != nil {
+		return nil
+	}
+	if err := r.client.Delete(ctx, pod); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (r *SparsifyReconciler) createSparsifyPod(ctx context.Context, pvc *corev1.PersistentVolumeClaim, request string) (*corev1.Pod, error) {
+	resourceRequirements, err := cc.GetDefaultPodResourceRequirements(r.client)
+	if err != nil {
+		return nil, err
+	}
+	imagePullSecrets, err := cc.GetImagePullSecrets(r.client)
+	if err != nil {
+		return nil, err
+	}
+	workloadNodePlacement, err := cc.GetWorkloadNodePlacement(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+
+	pod := r.makeSparsifyPodSpec(pvc, request)
+	if resourceRequirements != nil {
+		pod.Spec.Containers[0].Resources = *resourceRequirements
+	}
+	pod.Spec.ImagePullSecrets = imagePullSecrets
+	pod.Spec.NodeSelector = workloadNodePlacement.NodeSelector
+	pod.Spec.Tolerations = workloadNodePlacement.Tolerations
+	pod.Spec.Affinity = workloadNodePlacement.Affinity
+	util.SetRecommendedLabels(pod, r.installerLabels, "cdi-controller")
+
+	if err := r.client.Create(ctx, pod); err != nil {
+		return nil, err
+	}
+	r.log.V(3).Info("sparsify pod created", "pod.Name", pod.Name, "pod.Namespace", pod.Namespace)
+	return pod, nil
+}
+
+func (r *SparsifyReconciler) makeSparsifyPodSpec(pvc *corev1.PersistentVolumeClaim, request string) *corev1.Pod {
+	env := append(makeImportEnv(&importPodEnvVar{}, pvc.UID), corev1.EnvVar{
+		Name:  common.ImporterSparsifyOnly,
+		Value: "true",
+	})
+
+	container := corev1.Container{
+		Name:                     common.ImporterPodName,
+		Image:                    r.image,
+		ImagePullPolicy:          corev1.PullPolicy(r.pullPolicy),
+		Args:                     []string{"-v=" + r.verbose},
+		Env:                      env,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	if util.ResolveVolumeMode(pvc.Spec.VolumeMode) == corev1.PersistentVolumeBlock {
+		container.VolumeDevices = cc.AddVolumeDevices()
+	} else {
+		container.VolumeMounts = cc.AddImportVolumeMounts()
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getSparsifyPodName(pvc),
+			Namespace: pvc.Namespace,
+			Annotations: map[string]string{
+				cc.AnnCreatedBy:       "yes",
+				cc.AnnSparsifyRequest: request,
+			},
+			Labels: map[string]string{
+				common.CDILabelKey:       common.CDILabelValue,
+				common.CDIComponentLabel: common.ImporterPodName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         "v1",
+					Kind:               "PersistentVolumeClaim",
+					Name:               pvc.Name,
+					UID:                pvc.UID,
+					BlockOwnerDeletion: ptr.To[bool](true),
+					Controller:         ptr.To[bool](true),
+				},
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{container},
+			Volumes: []corev1.Volume{
+				{
+					Name: cc.DataVolName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: pvc.Name,
+							ReadOnly:  false,
+						},
+					},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+	cc.SetRestrictedSecurityContext(&pod.Spec)
+	return pod
+}
+
+func getSparsifyPodName(pvc *corev1.PersistentVolumeClaim) string {
+	return sparsifyPodPrefix + string(pvc.UID)
+}
+
+// NewSparsifyController creates a new instance of the controller sparsifying the disks of existing PVCs on request
+func NewSparsifyController(mgr manager.Manager, log logr.Logger, importerImage, pullPolicy, verbose string, installerLabels map[string]string) (controller.Controller, error) {
+	reconciler := &SparsifyReconciler{
+		client:          mgr.GetClient(),
+		recorder:        mgr.GetEventRecorderFor(sparsifyControllerName),
+		log:             log.WithName(sparsifyControllerName),
+		image:           importerImage,
+		pullPolicy:      pullPolicy,
+		verbose:         verbose,
+		installerLabels: installerLabels,
+	}
+	sparsifyController, err := controller.New(sparsifyControllerName, mgr, controller.Options{
+		MaxConcurrentReconciles: 3,
+		Reconciler:              reconciler,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := addSparsifyControllerWatches(mgr, sparsifyController); err != nil {
+		return nil, err
+	}
+	log.Info("Initialized sparsify controller")
+	return sparsifyController, nil
+}
+
+func addSparsifyControllerWatches(mgr manager.Manager, c controller.Controller) error {
+	if err := c.Watch(source.Kind(mgr.GetCache(), &corev1.PersistentVolumeClaim{}, &handler.TypedEnqueueRequestForObject[*corev1.PersistentVolumeClaim]{},
+		predicate.NewTypedPredicateFuncs[*corev1.PersistentVolumeClaim](func(pvc *corev1.PersistentVolumeClaim) bool {
+			_, ok := pvc.Annotations[cc.AnnSparsifyRequest]
+			return ok
+		}),
+	)); err != nil {
+		return err
+	}
+
+	return c.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{},
+		handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, pod *corev1.Pod) []reconcile.Request {
+			if _, ok := pod.Annotations[cc.AnnSparsifyRequest]; !ok {
+				return nil
+			}
+			owner := metav1.GetControllerOf(pod)
+			if owner == nil || owner.Kind != "PersistentVolumeClaim" {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}}}
+		}),
+	))
+}
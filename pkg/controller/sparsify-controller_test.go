@@ -0,0 +1,233 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+)
+
+const sparsifyPvcName = "test-sparsify"
+
+var sparsifyLog = logf.Log.WithName("sparsify-controller-test")
+
+var _ = Describe("Sparsify controller reconcile loop", func() {
+	var reconciler *SparsifyReconciler
+
+	reconcileSparsify := func() (reconcile.Result, *corev1.PersistentVolumeClaim) {
+		res, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: sparsifyPvcName, Namespace: metav1.NamespaceDefault}})
+		Expect(err).ToNot(HaveOccurred())
+		pvc := &corev1.PersistentVolumeClaim{}
+		Expect(reconciler.client.Get(context.TODO(), types.NamespacedName{Name: sparsifyPvcName, Namespace: metav1.NamespaceDefault}, pvc)).To(Succeed())
+		return res, pvc
+	}
+
+	getSparsifyPod := func(pvc *corev1.PersistentVolumeClaim) (*corev1.Pod, error) {
+		pod := &corev1.Pod{}
+		err := reconciler.client.Get(context.TODO(), types.NamespacedName{Name: getSparsifyPodName(pvc), Namespace: metav1.NamespaceDefault}, pod)
+		return pod, err
+	}
+
+	It("Should do nothing without a sparsify request", func() {
+		reconciler = createSparsifyReconciler(createSparsifyPvc(nil))
+		_, pvc := reconcileSparsify()
+		_, err := getSparsifyPod(pvc)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should do nothing when the request is already completed", func() {
+		reconciler = createSparsifyReconciler(createSparsifyPvc(map[string]string{AnnSparsifyRequest: "1", AnnSparsifyCompleted: "1"}))
+		_, pvc := reconcileSparsify()
+		_, err := getSparsifyPod(pvc)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should wait while the PVC is in use", func() {
+		pvc := createSparsifyPvc(map[string]string{AnnSparsifyRequest: "1"})
+		user := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: metav1.NamespaceDefault},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "disk",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: sparsifyPvcName}},
+			}}},
+		}
+		reconciler = createSparsifyReconciler(pvc, user)
+		res, pvc := reconcileSparsify()
+		Expect(res.RequeueAfter).ToNot(BeZero())
+		_, err := getSparsifyPod(pvc)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(SparsifyInUse)))
+	})
+
+	It("Should not sparsify a ReadWriteMany block PVC until it is confirmed idle", func() {
+		pvc := createSparsifyPvc(map[string]string{AnnSparsifyRequest: "1"})
+		pvc.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeBlock)
+		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+		reconciler = createSparsifyReconciler(pvc)
+		_, pvc = reconcileSparsify()
+		_, err := getSparsifyPod(pvc)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(SparsifyIdleNotConfirmed)))
+
+		pvc.Annotations[AnnSparsifyIdleConfirmed] = "1"
+		Expect(reconciler.client.Update(context.TODO(), pvc)).To(Succeed())
+		_, pvc = reconcileSparsify()
+		pod, err := getSparsifyPod(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Spec.Containers[0].VolumeDevices).ToNot(BeEmpty())
+	})
+
+	It("Should stop sparsifying when a pod starts using the PVC", func() {
+		reconciler = createSparsifyReconciler(createSparsifyPvc(map[string]string{AnnSparsifyRequest: "1"}))
+		res, pvc := reconcileSparsify()
+		Expect(res.RequeueAfter).ToNot(BeZero())
+		pod, err := getSparsifyPod(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		pod.Status.Phase = corev1.PodRunning
+		Expect(reconciler.client.Status().Update(context.TODO(), pod)).To(Succeed())
+
+		By("Checking again while no other pod uses the PVC")
+		res, pvc = reconcileSparsify()
+		Expect(res.RequeueAfter).ToNot(BeZero())
+		_, err = getSparsifyPod(pvc)
+		Expect(err).ToNot(HaveOccurred())
+
+		user := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: metav1.NamespaceDefault},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "disk",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: sparsifyPvcName}},
+			}}},
+		}
+		Expect(reconciler.client.Create(context.TODO(), user)).To(Succeed())
+		res, pvc = reconcileSparsify()
+		Expect(res.RequeueAfter).ToNot(BeZero())
+		_, err = getSparsifyPod(pvc)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnSparsifyCompleted))
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("Stopped sparsifying PVC")))
+	})
+
+	It("Should drop the reclaimed space of a previous sparsify when it is unknown", func() {
+		reconciler = createSparsifyReconciler(createSparsifyPvc(map[string]string{AnnSparsifyRequest: "2", AnnReclaimedBytes: "4096"}))
+		_, pvc := reconcileSparsify()
+		pod, err := getSparsifyPod(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		pod.Status.Phase = corev1.PodSucceeded
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: `{"message":"Import Complete"}`}},
+		}}
+		Expect(reconciler.client.Status().Update(context.TODO(), pod)).To(Succeed())
+		_, pvc = reconcileSparsify()
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnSparsifyCompleted, "2"))
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnReclaimedBytes))
+	})
+
+	It("Should sparsify the PVC and record the reclaimed space", func() {
+		reconciler = createSparsifyReconciler(createSparsifyPvc(map[string]string{AnnSparsifyRequest: "1"}))
+		_, pvc := reconcileSparsify()
+		pod, err := getSparsifyPod(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.OwnerReferences[0].UID).To(Equal(pvc.UID))
+		Expect(pod.Annotations[AnnSparsifyRequest]).To(Equal("1"))
+		Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal(sparsifyPvcName))
+		Expect(pod.Spec.Containers[0].VolumeMounts).ToNot(BeEmpty())
+		Expect(pod.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: common.ImporterSparsifyOnly, Value: "true"}))
+
+		pod.Status.Phase = corev1.PodSucceeded
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: `{"message":"Import Complete","reclaimedBytes":1048576}`}},
+		}}
+		Expect(reconciler.client.Status().Update(context.TODO(), pod)).To(Succeed())
+		_, pvc = reconcileSparsify()
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnReclaimedBytes, "1048576"))
+		Expect(pvc.Annotations).To(HaveKeyWithValue(AnnSparsifyCompleted, "1"))
+		Expect(GetReclaimedSpace(pvc).Value()).To(Equal(int64(1048576)))
+		_, err = getSparsifyPod(pvc)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(SparsifySucceeded)))
+	})
+
+	It("Should keep a failed pod until the request changes", func() {
+		reconciler = createSparsifyReconciler(createSparsifyPvc(map[string]string{AnnSparsifyRequest: "1"}))
+		_, pvc := reconcileSparsify()
+		pod, err := getSparsifyPod(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		pod.Status.Phase = corev1.PodFailed
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "Unable to sparsify disk: read failed"}},
+		}}
+		Expect(reconciler.client.Status().Update(context.TODO(), pod)).To(Succeed())
+		_, pvc = reconcileSparsify()
+		Expect(pvc.Annotations).ToNot(HaveKey(AnnSparsifyCompleted))
+		Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("Sparsify pod failed: Unable to sparsify disk: read failed")))
+		_, err = getSparsifyPod(pvc)
+		Expect(err).ToNot(HaveOccurred())
+
+		pvc.Annotations[AnnSparsifyRequest] = "2"
+		Expect(reconciler.client.Update(context.TODO(), pvc)).To(Succeed())
+		res, pvc := reconcileSparsify()
+		Expect(res.Requeue).To(BeTrue())
+		_, err = getSparsifyPod(pvc)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		_, pvc = reconcileSparsify()
+		pod, err = getSparsifyPod(pvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pod.Annotations[AnnSparsifyRequest]).To(Equal("2"))
+	})
+})
+
+func createSparsifyReconciler(objects ...runtime.Object) *SparsifyReconciler {
+	objs := append([]runtime.Object{MakeEmptyCDICR(), MakeEmptyCDIConfigSpec(common.ConfigName)}, objects...)
+	s := scheme.Scheme
+	_ = cdiv1.AddToScheme(s)
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+	return &SparsifyReconciler{
+		client:     cl,
+		recorder:   record.NewFakeRecorder(10),
+		log:        sparsifyLog,
+		image:      "test/myimage",
+		pullPolicy: "Always",
+		verbose:    "5",
+	}
+}
+
+func createSparsifyPvc(annotations map[string]string) *corev1.PersistentVolumeClaim {
+	pvc := CreatePvcInStorageClass(sparsifyPvcName, metav1.NamespaceDefault, nil, annotations, nil, corev1.ClaimBound)
+	pvc.UID = types.UID("sparsify-uid")
+	pvc.Spec.VolumeMode = ptr.To(corev1.PersistentVolumeFilesystem)
+	return pvc
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
@@ -36,6 +37,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-controller"
 	"kubevirt.io/containerized-data-importer/pkg/operator"
 	"kubevirt.io/containerized-data-importer/pkg/storagecapabilities"
@@ -63,6 +65,7 @@ type StorageProfileReconciler struct {
 	scheme          *runtime.Scheme
 	log             logr.Logger
 	installerLabels map[string]string
+	featureGates    featuregates.FeatureGates
 }
 
 // Reconcile the reconcile.Reconciler implementation for the StorageProfileReconciler object.
@@ -98,11 +101,35 @@ func (r *StorageProfileReconciler) reconcileStorageProfile(sc *storagev1.Storage
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+
+	var probeRequeue time.Duration
+	var probe *cdiv1.StorageProfileProbeStatus
+	shouldProbe, err := r.shouldProbe(sc, storageProfile)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if shouldProbe {
+		if probeRequeue, err = r.reconcileProbe(context.TODO(), sc, storageProfile, snapClass); err != nil {
+			log.Error(err, "Unable to probe storage capabilities")
+			return reconcile.Result{}, err
+		}
+		if probe = getProbeResult(sc, storageProfile); probe != nil && probe.SnapshotClass == nil {
+			// The test volume could not be snapshotted
+			snapClass = ""
+		}
+	} else if storageProfile.Status.Probe != nil {
+		if err := r.deleteProbeObjects(context.TODO(), sc); err != nil {
+			return reconcile.Result{}, err
+		}
+		storageProfile.Status.Probe = nil
+	}
+
 	if snapClass != "" {
 		storageProfile.Status.SnapshotClass = &snapClass
 	}
-	storageProfile.Status.CloneStrategy = r.reconcileCloneStrategy(sc, storageProfile.Spec.CloneStrategy, snapClass)
+	storageProfile.Status.CloneStrategy = r.reconcileCloneStrategy(sc, storageProfile.Spec.CloneStrategy, snapClass, probe)
 	storageProfile.Status.DataImportCronSourceFormat = r.reconcileDataImportCronSourceFormat(sc, storageProfile.Spec.DataImportCronSourceFormat, snapClass)
+	storageProfile.Status.ImportTuning = storageProfile.Spec.ImportTuning
 
 	// Reconcile StorageProfile annotations based on provisioner capabilities
 	r.reconcileMinimumSupportedPVCSize(sc, storageProfile)
@@ -122,6 +149,9 @@ func (r *StorageProfileReconciler) reconcileStorageProfile(sc *storagev1.Storage
 		claimPropertySets = storageProfile.Spec.ClaimPropertySets
 	} else {
 		claimPropertySets = r.reconcilePropertySets(sc)
+		if len(claimPropertySets) == 0 && probe != nil {
+			claimPropertySets = probe.ClaimPropertySets
+		}
 	}
 
 	storageProfile.Status.ClaimPropertySets = claimPropertySets
@@ -131,7 +161,7 @@ func (r *StorageProfileReconciler) reconcileStorageProfile(sc *storagev1.Storage
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, r.computeMetrics(storageProfile, sc)
+	return reconcile.Result{RequeueAfter: probeRequeue}, r.computeMetrics(storageProfile, sc)
 }
 
 func (r *StorageProfileReconciler) updateStorageProfile(prevStorageProfile runtime.Object, storageProfile *cdiv1.StorageProfile, log logr.Logger) error {
@@ -213,7 +243,7 @@ func (r *StorageProfileReconciler) reconcilePropertySets(sc *storagev1.StorageCl
 	return claimPropertySets
 }
 
-func (r *StorageProfileReconciler) reconcileCloneStrategy(sc *storagev1.StorageClass, desiredCloneStrategy *cdiv1.CDICloneStrategy, snapClass string) *cdiv1.CDICloneStrategy {
+func (r *StorageProfileReconciler) reconcileCloneStrategy(sc *storagev1.StorageClass, desiredCloneStrategy *cdiv1.CDICloneStrategy, snapClass string, probe *cdiv1.StorageProfileProbeStatus) *cdiv1.CDICloneStrategy {
 	if desiredCloneStrategy != nil {
 		return desiredCloneStrategy
 	}
@@ -222,6 +252,10 @@ func (r *StorageProfileReconciler) reconcileCloneStrategy(sc *storagev1.StorageC
 		return r.getCloneStrategyFromStorageClass(annStrategyVal)
 	}
 
+	if probe != nil && probe.CloneStrategy != nil {
+		return probe.CloneStrategy
+	}
+
 	// Default to trying snapshot clone unless volume snapshot class missing
 	hostAssistedStrategy := cdiv1.CloneStrategyHostAssisted
 	strategy := hostAssistedStrategy
@@ -509,6 +543,7 @@ func NewStorageProfileController(mgr manager.Manager, log logr.Logger, installer
 		scheme:          mgr.GetScheme(),
 		log:             log.WithName(storageProfileControllerName),
 		installerLabels: installerLabels,
+		featureGates:    featuregates.NewFeatureGates(mgr.GetClient()),
 	}
 
 	storageProfileController, err := controller.New(
@@ -556,6 +591,19 @@ func addStorageProfileControllerWatches(mgr manager.Manager, c controller.Contro
 		return err
 	}
 
+	if err := c.Watch(source.Kind(mgr.GetCache(), &v1.PersistentVolumeClaim{}, handler.TypedEnqueueRequestsFromMapFunc[*v1.PersistentVolumeClaim](
+		func(_ context.Context, pvc *v1.PersistentVolumeClaim) []reconcile.Request {
+			if _, ok := pvc.Labels[labelStorageProfileProbe]; !ok || pvc.Spec.StorageClassName == nil {
+				return nil
+			}
+			return []reconcile.Request{{
+				NamespacedName: types.NamespacedName{Name: *pvc.Spec.StorageClassName},
+			}}
+		},
+	))); err != nil {
+		return err
+	}
+
 	mapSnapshotClassToProfile := func(ctx context.Context, vsc *snapshotv1.VolumeSnapshotClass) []reconcile.Request {
 		var scList storagev1.StorageClassList
 		if err := mgr.GetClient().List(ctx, &scList); err != nil {
@@ -47,8 +47,10 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	. "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	featuregates "kubevirt.io/containerized-data-importer/pkg/feature-gates"
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-controller"
 	"kubevirt.io/containerized-data-importer/pkg/storagecapabilities"
+	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
 const (
@@ -423,6 +425,34 @@ var _ = Describe("Storage profile controller reconcile loop", func() {
 		Expect(sp.Status.SnapshotClass).To(BeNil())
 	})
 
+	It("Should copy the import tuning to the status", func() {
+		storageClass := CreateStorageClassWithProvisioner(storageClassName, nil, nil, cephProvisioner)
+		reconciler = createStorageProfileReconciler(storageClass)
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: storageClassName}})
+		Expect(err).ToNot(HaveOccurred())
+
+		sp := &cdiv1.StorageProfile{}
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: storageClassName}, sp, &client.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sp.Status.ImportTuning).To(BeNil())
+
+		writeBlockSize := resource.MustParse("64Ki")
+		sp.Spec.ImportTuning = &cdiv1.ImportTuning{
+			Preallocation:  ptr.To(true),
+			CacheMode:      ptr.To(cdiv1.ImportCacheModeNone),
+			WriteBlockSize: &writeBlockSize,
+			ZeroDetection:  ptr.To(cdiv1.ImportZeroDetectionDisabled),
+		}
+		err = reconciler.client.Update(context.TODO(), sp, &client.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: storageClassName}})
+		Expect(err).ToNot(HaveOccurred())
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: storageClassName}, sp, &client.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sp.Status.ImportTuning).To(Equal(sp.Spec.ImportTuning))
+	})
+
 	DescribeTable("should set advised source format for dataimportcrons", func(provisioner string, expectedFormat cdiv1.DataImportCronSourceFormat, deploySnapClass bool) {
 		storageClass := CreateStorageClassWithProvisioner(storageClassName, map[string]string{AnnDefaultStorageClass: "true"}, map[string]string{}, provisioner)
 		reconciler = createStorageProfileReconciler(storageClass, createVolumeSnapshotContentCrd(), createVolumeSnapshotClassCrd(), createVolumeSnapshotCrd())
@@ -651,6 +681,127 @@ var _ = Describe("Storage profile controller reconcile loop", func() {
 		Entry("Without RWX, on SNO, not degraded", v1.ReadWriteOnce, true, false),
 	)
 
+	Context("StorageProfile probe", func() {
+		const unknownProvisioner = "probe.unknown.provisioner.csi.com"
+
+		createProbeReconciler := func(provisioner string, probeEnabled bool) *StorageProfileReconciler {
+			storageClass := CreateStorageClassWithProvisioner(storageClassName, map[string]string{AnnDefaultStorageClass: "true"}, map[string]string{}, provisioner)
+			storageClass.UID = "sc-uid"
+			r := createStorageProfileReconciler(storageClass)
+			r.featureGates = &FakeFeatureGates{storageProfileProbeEnabled: probeEnabled}
+			return r
+		}
+
+		reconcileProbe := func() (*cdiv1.StorageProfile, reconcile.Result) {
+			res, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: storageClassName}})
+			Expect(err).ToNot(HaveOccurred())
+			sp := &cdiv1.StorageProfile{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: storageClassName}, sp)
+			Expect(err).ToNot(HaveOccurred())
+			return sp, res
+		}
+
+		listProbePvcs := func() []v1.PersistentVolumeClaim {
+			pvcs := &v1.PersistentVolumeClaimList{}
+			err := reconciler.client.List(context.TODO(), pvcs, client.HasLabels{labelStorageProfileProbe})
+			Expect(err).ToNot(HaveOccurred())
+			return pvcs.Items
+		}
+
+		bindProbePvcs := func(accessMode v1.PersistentVolumeAccessMode) {
+			for _, pvc := range listProbePvcs() {
+				if pvc.Spec.AccessModes[0] != accessMode {
+					continue
+				}
+				pvc.Status.Phase = v1.ClaimBound
+				Expect(reconciler.client.Status().Update(context.TODO(), &pvc)).To(Succeed())
+			}
+		}
+
+		It("Should not probe if the feature gate is disabled", func() {
+			reconciler = createProbeReconciler(unknownProvisioner, false)
+			sp, res := reconcileProbe()
+			Expect(res.RequeueAfter).To(BeZero())
+			Expect(sp.Status.Probe).To(BeNil())
+			Expect(listProbePvcs()).To(BeEmpty())
+		})
+
+		It("Should not probe a provisioner with known capabilities", func() {
+			reconciler = createProbeReconciler("rook-ceph.rbd.csi.ceph.com", true)
+			sp, _ := reconcileProbe()
+			Expect(sp.Status.Probe).To(BeNil())
+			Expect(listProbePvcs()).To(BeEmpty())
+		})
+
+		It("Should probe the access and volume modes and the clone strategy of an unknown provisioner", func() {
+			reconciler = createProbeReconciler(unknownProvisioner, true)
+			sp, res := reconcileProbe()
+			Expect(res.RequeueAfter).To(Equal(storageProfileProbeRequeue))
+			Expect(sp.Status.Probe).ToNot(BeNil())
+			Expect(sp.Status.Probe.Phase).To(Equal(cdiv1.StorageProfileProbeRunning))
+			Expect(sp.Status.Probe.StorageClassUID).To(Equal(types.UID("sc-uid")))
+			Expect(sp.Status.ClaimPropertySets).To(BeEmpty())
+			pvcs := listProbePvcs()
+			Expect(pvcs).To(HaveLen(4))
+			for _, pvc := range pvcs {
+				Expect(pvc.Labels[labelStorageProfileProbe]).To(Equal("sc-uid"))
+				Expect(*pvc.Spec.StorageClassName).To(Equal(storageClassName))
+			}
+
+			// Only RWO binds, the RWX test volumes are given up on expiry
+			bindProbePvcs(v1.ReadWriteOnce)
+			sp.Status.Probe.StartTime = metav1.NewTime(sp.Status.Probe.StartTime.Add(-2 * storageProfileProbeTimeout))
+			Expect(reconciler.client.Status().Update(context.TODO(), sp)).To(Succeed())
+			sp, _ = reconcileProbe()
+			Expect(sp.Status.Probe.Phase).To(Equal(cdiv1.StorageProfileProbeRunning))
+			clone := &v1.PersistentVolumeClaim{}
+			err := reconciler.client.Get(context.TODO(), types.NamespacedName{Namespace: util.GetNamespace(), Name: probeObjectName(CreateStorageClass(storageClassName, nil), probeCloneSuffix)}, clone)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clone.Spec.DataSource).ToNot(BeNil())
+
+			clone.Status.Phase = v1.ClaimBound
+			Expect(reconciler.client.Status().Update(context.TODO(), clone)).To(Succeed())
+			sp, res = reconcileProbe()
+			Expect(res.RequeueAfter).To(BeZero())
+			Expect(sp.Status.Probe.Phase).To(Equal(cdiv1.StorageProfileProbeSucceeded))
+			Expect(sp.Status.Probe.CompletionTime).ToNot(BeNil())
+			Expect(*sp.Status.CloneStrategy).To(Equal(cdiv1.CloneStrategyCsiClone))
+			Expect(sp.Status.ClaimPropertySets).To(HaveLen(2))
+			for _, cps := range sp.Status.ClaimPropertySets {
+				Expect(cps.AccessModes).To(ConsistOf(v1.ReadWriteOnce))
+			}
+			Expect(listProbePvcs()).To(BeEmpty())
+		})
+
+		It("Should probe again when the StorageClass is recreated", func() {
+			reconciler = createProbeReconciler(unknownProvisioner, true)
+			sp, _ := reconcileProbe()
+			sp.Status.Probe = &cdiv1.StorageProfileProbeStatus{
+				StorageClassUID: "old-uid",
+				Phase:           cdiv1.StorageProfileProbeSucceeded,
+				ClaimPropertySets: []cdiv1.ClaimPropertySet{
+					{AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}, VolumeMode: &BlockMode},
+				},
+			}
+			Expect(reconciler.client.Status().Update(context.TODO(), sp)).To(Succeed())
+
+			sp, _ = reconcileProbe()
+			Expect(sp.Status.Probe.StorageClassUID).To(Equal(types.UID("sc-uid")))
+			Expect(sp.Status.Probe.Phase).To(Equal(cdiv1.StorageProfileProbeRunning))
+			Expect(sp.Status.ClaimPropertySets).To(BeEmpty())
+		})
+
+		It("Should clean up the probe when the feature gate gets disabled", func() {
+			reconciler = createProbeReconciler(unknownProvisioner, true)
+			reconcileProbe()
+			Expect(listProbePvcs()).To(HaveLen(4))
+
+			reconciler.featureGates = &FakeFeatureGates{}
+			sp, _ := reconcileProbe()
+			Expect(sp.Status.Probe).To(BeNil())
+			Expect(listProbePvcs()).To(BeEmpty())
+		})
+	})
 })
 
 func createStorageProfileReconciler(objects ...runtime.Object) *StorageProfileReconciler {
@@ -672,7 +823,7 @@ func createStorageProfileReconciler(objects ...runtime.Object) *StorageProfileRe
 	_ = ocpconfigv1.Install(s)
 
 	// Create a fake client to mock API calls.
-	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).WithStatusSubresource(&cdiv1.StorageProfile{}).Build()
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).WithStatusSubresource(&cdiv1.StorageProfile{}, &v1.PersistentVolumeClaim{}).Build()
 
 	rec := record.NewFakeRecorder(10)
 	// Create a ReconcileMemcached object with the scheme and fake client.
@@ -686,6 +837,7 @@ func createStorageProfileReconciler(objects ...runtime.Object) *StorageProfileRe
 			common.AppKubernetesPartOfLabel:  "testing",
 			common.AppKubernetesVersionLabel: "v0.0.0-tests",
 		},
+		featureGates: featuregates.NewFeatureGates(cl),
 	}
 	return r
 }
@@ -0,0 +1,376 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	storagehelpers "k8s.io/component-helpers/storage/volume"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	cc "kubevirt.io/containerized-data-importer/pkg/controller/common"
+	"kubevirt.io/containerized-data-importer/pkg/storagecapabilities"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/util/naming"
+)
+
+const (
+	// storageProfileProbeTimeout is how long the test volumes are given to bind, snapshot and clone
+	storageProfileProbeTimeout = 5 * time.Minute
+	// storageProfileProbeRequeue is the poll interval of a running probe
+	storageProfileProbeRequeue = 5 * time.Second
+	// storageProfileProbeDefaultSize is the test volume size if the StorageProfile has no minimum supported size
+	storageProfileProbeDefaultSize = "1Gi"
+	// labelStorageProfileProbe marks the test objects with the UID of the probed StorageClass
+	labelStorageProfileProbe = cc.AnnAPIGroup + "/storageProfileProbe"
+
+	probeSnapshotSuffix = "snapshot"
+	probeCloneSuffix    = "clone"
+)
+
+// probeClaimPropertySets returns the access and volume mode combinations a test volume is provisioned with
+func probeClaimPropertySets() []cdiv1.ClaimPropertySet {
+	var sets []cdiv1.ClaimPropertySet
+	for _, accessMode := range []v1.PersistentVolumeAccessMode{v1.ReadWriteMany, v1.ReadWriteOnce} {
+		for _, volumeMode := range []v1.PersistentVolumeMode{v1.PersistentVolumeBlock, v1.PersistentVolumeFilesystem} {
+			volumeMode := volumeMode
+			sets = append(sets, cdiv1.ClaimPropertySet{
+				AccessModes: []v1.PersistentVolumeAccessMode{accessMode},
+				VolumeMode:  &volumeMode,
+			})
+		}
+	}
+	return sets
+}
+
+func probeClaimPropertySetSuffix(cps cdiv1.ClaimPropertySet) string {
+	return strings.ToLower(string(cps.AccessModes[0]) + "-" + string(*cps.VolumeMode))
+}
+
+func probeObjectName(sc *storagev1.StorageClass, suffix string) string {
+	return naming.GetResourceName("cdi-probe-"+sc.Name, suffix)
+}
+
+// shouldProbe tells if the capabilities of the StorageClass provisioner are unknown and should be probed
+func (r *StorageProfileReconciler) shouldProbe(sc *storagev1.StorageClass, sp *cdiv1.StorageProfile) (bool, error) {
+	if len(sp.Spec.ClaimPropertySets) > 0 || sc.Provisioner == storagehelpers.NotSupportedProvisioner {
+		return false, nil
+	}
+	if _, found := storagecapabilities.UnsupportedProvisioners[sc.Provisioner]; found {
+		return false, nil
+	}
+	if _, found := storagecapabilities.GetCapabilities(r.client, sc); found {
+		return false, nil
+	}
+	return r.featureGates.StorageProfileProbeEnabled()
+}
+
+// getProbeResult returns the probe result if the probe completed for the current StorageClass
+func getProbeResult(sc *storagev1.StorageClass, sp *cdiv1.StorageProfile) *cdiv1.StorageProfileProbeStatus {
+	probe := sp.Status.Probe
+	if probe == nil || probe.StorageClassUID != sc.UID || probe.Phase != cdiv1.StorageProfileProbeSucceeded {
+		return nil
+	}
+	return probe
+}
+
+// reconcileProbe provisions short-lived test volumes for each access and volume mode combination, and checks
+// which of them bind, and if a bound one can be snapshotted and CSI cloned. It returns the requeue interval
+// while the probe is running.
+func (r *StorageProfileReconciler) reconcileProbe(ctx context.Context, sc *storagev1.StorageClass, sp *cdiv1.StorageProfile, snapClass string) (time.Duration, error) {
+	if getProbeResult(sc, sp) != nil {
+		return 0, nil
+	}
+
+	probe := sp.Status.Probe
+	if probe == nil || probe.StorageClassUID != sc.UID {
+		// Leftovers of a probe of a previous StorageClass with the same name
+		if probe != nil {
+			if err := r.deleteProbeObjects(ctx, sc); err != nil {
+				return 0, err
+			}
+		}
+		probe = &cdiv1.StorageProfileProbeStatus{
+			StorageClassUID: sc.UID,
+			Phase:           cdiv1.StorageProfileProbeRunning,
+			StartTime:       metav1.Now(),
+		}
+		sp.Status.Probe = probe
+		r.log.Info("Probing storage capabilities", "StorageClass.Name", sc.Name, "Provisioner", sc.Provisioner)
+	}
+
+	done, err := r.runProbe(ctx, sc, sp, probe, snapClass)
+	if err != nil || !done {
+		return storageProfileProbeRequeue, err
+	}
+
+	if err := r.deleteProbeObjects(ctx, sc); err != nil {
+		return 0, err
+	}
+	probe.Phase = cdiv1.StorageProfileProbeSucceeded
+	probe.CompletionTime = &metav1.Time{Time: time.Now()}
+	r.log.Info("Probed storage capabilities", "StorageClass.Name", sc.Name, "ClaimPropertySets", probe.ClaimPropertySets, "CloneStrategy", *probe.CloneStrategy)
+	return 0, nil
+}
+
+func (r *StorageProfileReconciler) runProbe(ctx context.Context, sc *storagev1.StorageClass, sp *cdiv1.StorageProfile, probe *cdiv1.StorageProfileProbeStatus, snapClass string) (bool, error) {
+	expired := time.Since(probe.StartTime.Time) > storageProfileProbeTimeout
+	size := storageProfileProbeDefaultSize
+	if minSize, ok := sp.Annotations[cc.AnnMinimumSupportedPVCSize]; ok {
+		size = minSize
+	}
+
+	var selectedNode string
+	if sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+		node, err := r.getProbeNode(ctx)
+		if err != nil {
+			return false, err
+		}
+		selectedNode = node
+	}
+
+	var sets []cdiv1.ClaimPropertySet
+	var source *v1.PersistentVolumeClaim
+	pending := false
+	for _, cps := range probeClaimPropertySets() {
+		pvc, err := r.getOrCreateProbePvc(ctx, sc, probeClaimPropertySetSuffix(cps), cps, size, selectedNode, nil)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case pvc != nil && pvc.Status.Phase == v1.ClaimBound:
+			sets = append(sets, cps)
+			if source == nil {
+				source = pvc
+			}
+		case !expired:
+			pending = true
+		}
+	}
+	if pending {
+		return false, nil
+	}
+
+	probe.ClaimPropertySets = sets
+	hostAssisted := cdiv1.CloneStrategyHostAssisted
+	probe.CloneStrategy = &hostAssisted
+	if source == nil {
+		return true, nil
+	}
+
+	snapshotDone, snapshotOk, err := r.probeSnapshot(ctx, sc, source, snapClass)
+	if err != nil {
+		return false, err
+	}
+	cloneDone, cloneOk, err := r.probeCsiClone(ctx, sc, source, size)
+	if err != nil || !snapshotDone || !cloneDone {
+		return false, err
+	}
+
+	if snapshotOk {
+		probe.SnapshotClass = &snapClass
+		strategy := cdiv1.CloneStrategySnapshot
+		probe.CloneStrategy = &strategy
+	}
+	if cloneOk {
+		strategy := cdiv1.CloneStrategyCsiClone
+		probe.CloneStrategy = &strategy
+	}
+	return true, nil
+}
+
+// probeSnapshot snapshots the bound test volume, it returns if the check is done and if the snapshot succeeded
+func (r *StorageProfileReconciler) probeSnapshot(ctx context.Context, sc *storagev1.StorageClass, source *v1.PersistentVolumeClaim, snapClass string) (bool, bool, error) {
+	if snapClass == "" {
+		return true, false, nil
+	}
+
+	snapshot := &snapshotv1.VolumeSnapshot{}
+	name := probeObjectName(sc, probeSnapshotSuffix)
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: source.Namespace, Name: name}, snapshot); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, false, err
+		}
+		snapshot = &snapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: source.Namespace,
+				Labels:    map[string]string{labelStorageProfileProbe: string(sc.UID)},
+			},
+			Spec: snapshotv1.VolumeSnapshotSpec{
+				Source: snapshotv1.VolumeSnapshotSource{
+					PersistentVolumeClaimName: &source.Name,
+				},
+				VolumeSnapshotClassName: &snapClass,
+			},
+		}
+		util.SetRecommendedLabels(snapshot, r.installerLabels, "cdi-controller")
+		if err := r.client.Create(ctx, snapshot); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return false, false, err
+		}
+		return false, false, nil
+	}
+
+	if snapshot.Labels[labelStorageProfileProbe] != string(sc.UID) {
+		return false, false, cc.IgnoreNotFound(r.client.Delete(ctx, snapshot))
+	}
+	if cc.IsSnapshotReady(snapshot) {
+		return true, true, nil
+	}
+	failed := snapshot.Status != nil && snapshot.Status.Error != nil
+	return failed || probeObjectExpired(snapshot), false, nil
+}
+
+// probeCsiClone clones the bound test volume, it returns if the check is done and if the clone bound
+func (r *StorageProfileReconciler) probeCsiClone(ctx context.Context, sc *storagev1.StorageClass, source *v1.PersistentVolumeClaim, size string) (bool, bool, error) {
+	cps := cdiv1.ClaimPropertySet{
+		AccessModes: source.Spec.AccessModes,
+		VolumeMode:  source.Spec.VolumeMode,
+	}
+	dataSource := &v1.TypedLocalObjectReference{
+		Kind: "PersistentVolumeClaim",
+		Name: source.Name,
+	}
+	clone, err := r.getOrCreateProbePvc(ctx, sc, probeCloneSuffix, cps, size, source.Annotations[cc.AnnSelectedNode], dataSource)
+	if err != nil {
+		return false, false, err
+	}
+	if clone == nil {
+		return false, false, nil
+	}
+	if clone.Status.Phase == v1.ClaimBound {
+		return true, true, nil
+	}
+	return probeObjectExpired(clone), false, nil
+}
+
+// probeObjectExpired tells if a snapshot or clone of the test volume was given up on
+func probeObjectExpired(obj metav1.Object) bool {
+	created := obj.GetCreationTimestamp()
+	return !created.IsZero() && time.Since(created.Time) > storageProfileProbeTimeout
+}
+
+// getOrCreateProbePvc returns the test volume, nil if it was just created or belongs to a previous probe
+func (r *StorageProfileReconciler) getOrCreateProbePvc(ctx context.Context, sc *storagev1.StorageClass, suffix string, cps cdiv1.ClaimPropertySet, size, selectedNode string, dataSource *v1.TypedLocalObjectReference) (*v1.PersistentVolumeClaim, error) {
+	pvc := &v1.PersistentVolumeClaim{}
+	name := probeObjectName(sc, suffix)
+	namespace := util.GetNamespace()
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pvc); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		if pvc.Labels[labelStorageProfileProbe] != string(sc.UID) {
+			return nil, cc.IgnoreNotFound(r.client.Delete(ctx, pvc, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+		}
+		return pvc, nil
+	}
+
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, err
+	}
+	pvc = &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{labelStorageProfileProbe: string(sc.UID)},
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes:      cps.AccessModes,
+			VolumeMode:       cps.VolumeMode,
+			StorageClassName: &sc.Name,
+			DataSource:       dataSource,
+			Resources: v1.VolumeResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: quantity,
+				},
+			},
+		},
+	}
+	if selectedNode != "" {
+		cc.AddAnnotation(pvc, cc.AnnSelectedNode, selectedNode)
+	}
+	util.SetRecommendedLabels(pvc, r.installerLabels, "cdi-controller")
+	if err := r.client.Create(ctx, pvc); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// getProbeNode returns a schedulable node for provisioning WaitForFirstConsumer test volumes
+func (r *StorageProfileReconciler) getProbeNode(ctx context.Context) (string, error) {
+	nodes := &v1.NodeList{}
+	if err := r.uncachedClient.List(ctx, nodes); err != nil {
+		return "", err
+	}
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == v1.NodeReady && condition.Status == v1.ConditionTrue {
+				return node.Name, nil
+			}
+		}
+	}
+	return "", errors.New("no schedulable node to provision WaitForFirstConsumer test volumes")
+}
+
+func (r *StorageProfileReconciler) deleteProbeObjects(ctx context.Context, sc *storagev1.StorageClass) error {
+	namespace := util.GetNamespace()
+	snapshot := &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      probeObjectName(sc, probeSnapshotSuffix),
+			Namespace: namespace,
+		},
+	}
+	if err := r.client.Delete(ctx, snapshot); cc.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+
+	suffixes := []string{probeCloneSuffix}
+	for _, cps := range probeClaimPropertySets() {
+		suffixes = append(suffixes, probeClaimPropertySetSuffix(cps))
+	}
+	for _, suffix := range suffixes {
+		pvc := &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      probeObjectName(sc, suffix),
+				Namespace: namespace,
+			},
+		}
+		if err := r.client.Delete(ctx, pvc, client.PropagationPolicy(metav1.DeletePropagationBackground)); cc.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
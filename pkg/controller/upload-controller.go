@@ -109,8 +109,11 @@ type UploadPodArgs struct {
 	FilesystemOverhead              string
 	ServerCert, ServerKey, ClientCA []byte
 	Preallocation                   string
+	ExpandPartition                 bool
+	Sparsify                        bool
 	CryptoEnvVars                   CryptoEnvVars
 	Deadline                        *time.Time
+	ImportTuning                    *cdiv1.ImportTuning
 }
 
 // CryptoEnvVars holds the TLS crypto-related configurables for the upload server
@@ -201,6 +204,10 @@ func (r *UploadReconciler) reconcilePVC(log logr.Logger, pvc *corev1.PersistentV
 		}
 
 		uploadClientName = fmt.Sprintf("%s/%s-%s/%s", source.Namespace, source.Name, pvc.Namespace, pvc.Name)
+		if batch := pvc.Annotations[cc.AnnCloneBatch]; batch != "" {
+			// The shared source pod of a batch uploads to all its targets with one client cert
+			uploadClientName = fmt.Sprintf("%s/%s-batch/%s", source.Namespace, source.Name, batch)
+		}
 		anno[AnnUploadClientName] = uploadClientName
 	} else {
 		uploadClientName = uploadServerClientName
@@ -421,6 +428,11 @@ func (r *UploadReconciler) createUploadPodForPvc(pvc *corev1.PersistentVolumeCla
 		preallocationRequested = preallocation
 	}
 
+	importTuning, err := cc.GetImportTuning(context.TODO(), r.client, pvc.Spec.StorageClassName)
+	if err != nil {
+		return nil, err
+	}
+
 	config := &cdiv1.CDIConfig{}
 	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: common.ConfigName}, config); err != nil {
 		return nil, err
@@ -444,8 +456,11 @@ func (r *UploadReconciler) createUploadPodForPvc(pvc *corev1.PersistentVolumeCla
 		ServerKey:          serverKey,
 		ClientCA:           clientCA,
 		Preallocation:      strconv.FormatBool(preallocationRequested),
+		ExpandPartition:    getValueFromAnnotation(pvc, cc.AnnExpandPartition) == "true",
+		Sparsify:           getValueFromAnnotation(pvc, cc.AnnSparsify) == "true",
 		CryptoEnvVars:      cryptoVars,
 		Deadline:           ptr.To(time.Now().Add(min(serverRefresh, clientRefresh))),
+		ImportTuning:       importTuning,
 	}
 
 	r.log.V(3).Info("Creating upload pod")
@@ -479,6 +494,10 @@ func (r *UploadReconciler) getOrCreateScratchPvc(pvc *corev1.PersistentVolumeCla
 		// Scratch PVC doesn't exist yet, create it.
 		scratchPvc, err = createScratchPersistentVolumeClaim(r.client, pvc, pod, name, storageClassName, map[string]string{}, r.recorder)
 		if err != nil {
+			setScratchFailureCondition(anno, err)
+			if updateErr := r.updatePVC(pvc); updateErr != nil {
+				r.log.Error(updateErr, "Unable to record the scratch space failure", "pvc.Name", pvc.Name)
+			}
 			return nil, err
 		}
 	} else {
@@ -885,6 +904,38 @@ func (r *UploadReconciler) makeUploadPodContainers(args UploadPodArgs, resourceR
 			Value: args.Deadline.Format(time.RFC3339),
 		})
 	}
+	if args.ExpandPartition {
+		containers[0].Env = append(containers[0].Env, corev1.EnvVar{
+			Name:  common.ExpandPartition,
+			Value: "true",
+		})
+	}
+	if args.Sparsify {
+		containers[0].Env = append(containers[0].Env, corev1.EnvVar{
+			Name:  common.Sparsify,
+			Value: "true",
+		})
+	}
+	if tuning := args.ImportTuning; tuning != nil {
+		if tuning.CacheMode != nil {
+			containers[0].Env = append(containers[0].Env, corev1.EnvVar{
+				Name:  common.CacheMode,
+				Value: string(*tuning.CacheMode),
+			})
+		}
+		if tuning.WriteBlockSize != nil {
+			containers[0].Env = append(containers[0].Env, corev1.EnvVar{
+				Name:  common.WriteBlockSize,
+				Value: strconv.FormatInt(tuning.WriteBlockSize.Value(), 10),
+			})
+		}
+		if tuning.ZeroDetection != nil {
+			containers[0].Env = append(containers[0].Env, corev1.EnvVar{
+				Name:  common.ZeroDetection,
+				Value: string(*tuning.ZeroDetection),
+			})
+		}
+	}
 	if cc.GetVolumeMode(args.PVC) == corev1.PersistentVolumeBlock {
 		containers[0].VolumeDevices = append(containers[0].VolumeDevices, corev1.VolumeDevice{
 			Name:       cc.DataVolName,
@@ -25,12 +25,14 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -315,6 +317,22 @@ var _ = Describe("Upload controller reconcile loop", func() {
 		Expect(or.Name).To(Equal(uploadPod.Name))
 		Expect(or.UID).To(Equal(uploadPod.UID))
 	})
+
+	DescribeTable("Should set the upload client name of a clone pvc", func(batch, expected string) {
+		testPvc := cc.CreatePvc("testPvc1", "default", map[string]string{cc.AnnCloneRequest: "default/testPvc2", AnnUploadPod: createUploadResourceName("testPvc1")}, nil)
+		if batch != "" {
+			testPvc.Annotations[cc.AnnCloneBatch] = batch
+		}
+		reconciler := createUploadReconciler(testPvc, cc.CreatePvc("testPvc2", "default", map[string]string{}, nil))
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "testPvc1", Namespace: "default"}})
+		Expect(err).ToNot(HaveOccurred())
+		err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: "testPvc1", Namespace: "default"}, testPvc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(testPvc.Annotations[AnnUploadClientName]).To(Equal(expected))
+	},
+		Entry("for its own source pod", "", "default/testPvc2-default/testPvc1"),
+		Entry("shared by its clone batch", "golden", "default/testPvc2-batch/golden"),
+	)
 })
 
 var _ = Describe("reconcilePVC loop", func() {
@@ -544,6 +562,59 @@ var _ = Describe("reconcilePVC loop", func() {
 			Entry("'Old' profile set", &cdiv1.TLSSecurityProfile{Type: cdiv1.TLSProfileOldType, Old: &cdiv1.OldTLSProfile{}}),
 		)
 
+		It("should pass the import tuning of the storage profile to created pod", func() {
+			storageClassName := "test-sc"
+			testPvc := cc.CreatePvcInStorageClass(testPvcName, "default", &storageClassName, map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: uploadResourceName}, nil, corev1.ClaimBound)
+			writeBlockSize := resource.MustParse("1Mi")
+			storageProfile := &cdiv1.StorageProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: storageClassName},
+				Status: cdiv1.StorageProfileStatus{
+					ImportTuning: &cdiv1.ImportTuning{
+						CacheMode:      ptr.To(cdiv1.ImportCacheModeWritethrough),
+						WriteBlockSize: &writeBlockSize,
+					},
+				},
+			}
+			reconciler := createUploadReconciler(testPvc, cc.CreateStorageClass(storageClassName, nil), storageProfile)
+
+			_, err := reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+			uploadPod := &corev1.Pod{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: uploadResourceName, Namespace: "default"}, uploadPod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uploadPod.Spec.Containers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: common.CacheMode, Value: "writethrough"},
+				corev1.EnvVar{Name: common.WriteBlockSize, Value: "1048576"},
+			))
+			for _, envVar := range uploadPod.Spec.Containers[0].Env {
+				Expect(envVar.Name).ToNot(Equal(common.ZeroDetection))
+			}
+		})
+
+		It("should request partition expansion in created pod", func() {
+			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: uploadResourceName, cc.AnnExpandPartition: "true"}, nil)
+			reconciler := createUploadReconciler(testPvc)
+
+			_, err := reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+			uploadPod := &corev1.Pod{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: uploadResourceName, Namespace: "default"}, uploadPod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uploadPod.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: common.ExpandPartition, Value: "true"}))
+		})
+
+		It("should request sparsify in created pod", func() {
+			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: uploadResourceName, cc.AnnSparsify: "true"}, nil)
+			reconciler := createUploadReconciler(testPvc)
+
+			_, err := reconciler.reconcilePVC(reconciler.log, testPvc, isClone)
+			Expect(err).ToNot(HaveOccurred())
+			uploadPod := &corev1.Pod{}
+			err = reconciler.client.Get(context.TODO(), types.NamespacedName{Name: uploadResourceName, Namespace: "default"}, uploadPod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uploadPod.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: common.Sparsify, Value: "true"}))
+		})
+
 		DescribeTable("Should use proper cert duration", func(expectedDuration time.Duration, setCertConfig bool) {
 			testPvc := cc.CreatePvc(testPvcName, "default", map[string]string{cc.AnnUploadRequest: "", AnnUploadPod: uploadResourceName}, nil)
 			reconciler := createUploadReconciler(testPvc)
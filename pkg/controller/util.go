@@ -215,6 +215,18 @@ func createScratchPersistentVolumeClaim(client client.Client, pvc *corev1.Persis
 	return scratchPvc, nil
 }
 
+// setScratchFailureCondition records on the running condition of the PVC why its scratch space could not be created,
+// the pod waiting for the scratch space has no container status to overwrite it
+func setScratchFailureCondition(anno map[string]string, err error) {
+	reason := common.FailureScratchUnavailable
+	if cc.ErrQuotaExceeded(err) {
+		reason = common.FailureQuotaExceeded
+	}
+	anno[cc.AnnRunningCondition] = "false"
+	anno[cc.AnnRunningConditionMessage] = withRemediationHint(fmt.Sprintf("Unable to create scratch space: %v", err), reason)
+	anno[cc.AnnRunningConditionReason] = string(reason)
+}
+
 // GetFilesystemOverhead determines the filesystem overhead defined in CDIConfig for this PVC's volumeMode and storageClass.
 func GetFilesystemOverhead(ctx context.Context, client client.Client, pvc *corev1.PersistentVolumeClaim) (cdiv1.Percent, error) {
 	if cc.GetVolumeMode(pvc) != corev1.PersistentVolumeFilesystem {
@@ -352,7 +364,7 @@ func setAnnotationsFromPodWithPrefix(anno map[string]string, pod *corev1.Pod, te
 			}
 			// Handle extended termination message
 			if termMsg.Message != nil {
-				anno[prefix+".message"] = *termMsg.Message
+				anno[prefix+".message"] = simplifyKnownMessage(*termMsg.Message)
 			}
 			if termMsg.VddkInfo != nil {
 				if termMsg.VddkInfo.Host != "" {
@@ -365,6 +377,14 @@ func setAnnotationsFromPodWithPrefix(anno map[string]string, pod *corev1.Pod, te
 			if termMsg.PreallocationApplied != nil && *termMsg.PreallocationApplied {
 				anno[cc.AnnPreallocationApplied] = "true"
 			}
+			if termMsg.ReclaimedBytes != nil {
+				anno[cc.AnnReclaimedBytes] = strconv.FormatInt(*termMsg.ReclaimedBytes, 10)
+			}
+			if termMsg.FailureReason != nil {
+				anno[prefix+".message"] = withRemediationHint(anno[prefix+".message"], *termMsg.FailureReason)
+				anno[prefix+".reason"] = string(*termMsg.FailureReason)
+				return
+			}
 		} else {
 			// Handle plain termination message (legacy)
 			anno[prefix+".message"] = simplifyKnownMessage(containerState.Terminated.Message)
@@ -407,18 +427,30 @@ func simplifyKnownMessage(msg string) string {
 	return msg
 }
 
+// withRemediationHint appends the action that usually fixes the failure to its message
+func withRemediationHint(msg string, reason common.FailureReason) string {
+	if hint := reason.RemediationHint(); hint != "" {
+		return fmt.Sprintf("%s. %s", strings.TrimSuffix(msg, "."), hint)
+	}
+	return msg
+}
+
 func parseTerminationMessage(pod *corev1.Pod) (*common.TerminationMessage, error) {
 	if pod == nil || pod.Status.ContainerStatuses == nil {
 		return nil, nil
 	}
 
 	state := pod.Status.ContainerStatuses[0].State
-	if state.Terminated == nil || state.Terminated.ExitCode != 0 {
+	if state.Terminated == nil {
 		return nil, nil
 	}
 
 	termMsg := &common.TerminationMessage{}
 	if err := json.Unmarshal([]byte(state.Terminated.Message), termMsg); err != nil {
+		if state.Terminated.ExitCode != 0 {
+			// Failures are reported with a plain message, or the logs of the pod, by the workers without a failure reason
+			return nil, nil
+		}
 		return nil, err
 	}
 
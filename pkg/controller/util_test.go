@@ -12,6 +12,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
@@ -212,6 +213,22 @@ var _ = Describe("setAnnotationsFromPod", func() {
 		Expect(result[AnnPreallocationApplied]).To(Equal("true"))
 	})
 
+	It("Should set the reclaimed bytes of the sparsify", func() {
+		result := make(map[string]string)
+		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
+		testPod.Status = v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{},
+					},
+				},
+			},
+		}
+		setAnnotationsFromPodWithPrefix(result, testPod, &common.TerminationMessage{ReclaimedBytes: ptr.To(int64(4096))}, AnnRunningCondition)
+		Expect(result[AnnReclaimedBytes]).To(Equal("4096"))
+	})
+
 	It("Should set scratch space required status", func() {
 		result := make(map[string]string)
 		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
@@ -279,6 +296,80 @@ in quay.io/myproject/myimage: manifest unknown`
 		Expect(result[AnnRunningConditionReason]).To(Equal(common.GenericError))
 		Expect(result[AnnRequiresScratch]).To(BeEmpty())
 	})
+
+	It("Should set the failure reason and remediation hint of the termination message", func() {
+		const errorMessage = "Unable to connect to http data source: expected status code 200, got 401. Status: 401 Unauthorized"
+
+		result := make(map[string]string)
+		testPod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
+		testPod.Status = v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							ExitCode: 1,
+							Reason:   common.GenericError,
+						},
+					},
+				},
+			},
+		}
+		termMsg := &common.TerminationMessage{Message: ptr.To(errorMessage), FailureReason: ptr.To(common.FailureAuthFailed)}
+		setAnnotationsFromPodWithPrefix(result, testPod, termMsg, AnnRunningCondition)
+		Expect(result[AnnRunningCondition]).To(Equal("false"))
+		Expect(result[AnnRunningConditionMessage]).To(Equal(errorMessage + ". " + common.FailureAuthFailed.RemediationHint()))
+		Expect(result[AnnRunningConditionReason]).To(Equal(string(common.FailureAuthFailed)))
+	})
+})
+
+var _ = Describe("parseTerminationMessage", func() {
+	createTerminatedPod := func(exitCode int32, message string) *v1.Pod {
+		pod := CreateImporterTestPod(CreatePvc("test", metav1.NamespaceDefault, nil, nil), "test", nil)
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{
+			{
+				State: v1.ContainerState{
+					Terminated: &v1.ContainerStateTerminated{
+						ExitCode: exitCode,
+						Message:  message,
+					},
+				},
+			},
+		}
+		return pod
+	}
+
+	It("Should parse the failure reason of a failed pod", func() {
+		termMsg, err := parseTerminationMessage(createTerminatedPod(1, `{"message":"Unable to process data","failureReason":"UnsupportedFormat"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(termMsg).ToNot(BeNil())
+		Expect(termMsg.Message).To(HaveValue(Equal("Unable to process data")))
+		Expect(termMsg.FailureReason).To(HaveValue(Equal(common.FailureUnsupportedFormat)))
+	})
+
+	It("Should ignore the plain message of a failed pod", func() {
+		termMsg, err := parseTerminationMessage(createTerminatedPod(1, "Unable to process data"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(termMsg).To(BeNil())
+	})
+
+	It("Should fail on the plain message of a completed pod", func() {
+		_, err := parseTerminationMessage(createTerminatedPod(0, "Import Complete"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("setScratchFailureCondition", func() {
+	DescribeTable("Should set the running condition with the reason of", func(errMsg string, reason common.FailureReason) {
+		anno := make(map[string]string)
+		setScratchFailureCondition(anno, errors.New(errMsg))
+		Expect(anno[AnnRunningCondition]).To(Equal("false"))
+		Expect(anno[AnnRunningConditionReason]).To(Equal(string(reason)))
+		Expect(anno[AnnRunningConditionMessage]).To(HavePrefix("Unable to create scratch space: " + errMsg))
+		Expect(anno[AnnRunningConditionMessage]).To(HaveSuffix(reason.RemediationHint()))
+	},
+		Entry("a quota error", "exceeded quota: storage", common.FailureQuotaExceeded),
+		Entry("another error", "admission webhook denied the request", common.FailureScratchUnavailable),
+	)
 })
 
 var _ = Describe("addLabelsFromTerminationMessage", func() {
@@ -342,39 +433,79 @@ var _ = Describe("GetPreallocation", func() {
 	It("Should return preallocation for DataVolume if specified", func() {
 		client := CreateClient()
 		dv := createDataVolumeWithPreallocation("test-dv", "test-ns", true)
-		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation, nil)
 		Expect(preallocation).To(BeTrue())
 
 		dv = createDataVolumeWithPreallocation("test-dv", "test-ns", false)
-		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation, nil)
 		Expect(preallocation).To(BeFalse())
 
 		// global: true, data volume overrides to false
 		client = CreateClient(createCDIConfigWithGlobalPreallocation(true))
 		dv = createDataVolumeWithStorageClassPreallocation("test-dv", "test-ns", "test-class", false)
-		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation, nil)
 		Expect(preallocation).To(BeFalse())
 	})
 
 	It("Should return global preallocation setting if not defined in DV or SC", func() {
 		client := CreateClient(createCDIConfigWithGlobalPreallocation(true))
 		dv := createDataVolumeWithStorageClass("test-dv", "test-ns", "test-class")
-		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation, nil)
 		Expect(preallocation).To(BeTrue())
 
 		client = CreateClient(createCDIConfigWithGlobalPreallocation(false))
-		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation, nil)
+		Expect(preallocation).To(BeFalse())
+	})
+
+	It("Should return the storage profile preallocation default if not defined in DV", func() {
+		storageProfile := &cdiv1.StorageProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-class"},
+			Status: cdiv1.StorageProfileStatus{
+				ImportTuning: &cdiv1.ImportTuning{Preallocation: ptr.To(true)},
+			},
+		}
+		client := CreateClient(createCDIConfigWithGlobalPreallocation(false), CreateStorageClass("test-class", nil), storageProfile)
+		dv := createDataVolumeWithStorageClass("test-dv", "test-ns", "test-class")
+		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation, dv.Spec.PVC.StorageClassName)
+		Expect(preallocation).To(BeTrue())
+
+		dv = createDataVolumeWithStorageClassPreallocation("test-dv", "test-ns", "test-class", false)
+		preallocation = GetPreallocation(context.Background(), client, dv.Spec.Preallocation, dv.Spec.PVC.StorageClassName)
 		Expect(preallocation).To(BeFalse())
 	})
 
 	It("Should be false when niether DV nor Config defines preallocation", func() {
 		client := CreateClient(createCDIConfig("test"))
 		dv := createDataVolumeWithStorageClass("test-dv", "test-ns", "test-class")
-		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation)
+		preallocation := GetPreallocation(context.Background(), client, dv.Spec.Preallocation, nil)
 		Expect(preallocation).To(BeFalse())
 	})
 })
 
+var _ = Describe("GetSparsify", func() {
+	storageProfile := &cdiv1.StorageProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-class"},
+		Status: cdiv1.StorageProfileStatus{
+			ImportTuning: &cdiv1.ImportTuning{Sparsify: ptr.To(true)},
+		},
+	}
+
+	DescribeTable("Should return the sparsify of the DV, then of the storage profile", func(sparsify *bool, withProfile, expected bool) {
+		objs := []runtime.Object{createCDIConfig("test"), CreateStorageClass("test-class", nil)}
+		if withProfile {
+			objs = append(objs, storageProfile)
+		}
+		client := CreateClient(objs...)
+		Expect(GetSparsify(context.Background(), client, sparsify, ptr.To("test-class"))).To(Equal(expected))
+	},
+		Entry("defined in DV", ptr.To(true), false, true),
+		Entry("disabled in DV", ptr.To(false), true, false),
+		Entry("defined in storage profile", nil, true, true),
+		Entry("not defined", nil, false, false),
+	)
+})
+
 var _ = Describe("HandleFailedPod", func() {
 	pvc := CreatePvc("test-pvc", "test-ns", nil, nil)
 	podName := "test-pod"
@@ -23,6 +23,13 @@ const (
 
 	// WebhookPvcRendering - if enabled will deploy PVC mutating webhook for PVC rendering instead of the DV controller
 	WebhookPvcRendering = "WebhookPvcRendering"
+
+	// StorageProfileProbe - if enabled will probe the capabilities of provisioners unknown to CDI with short-lived test volumes
+	StorageProfileProbe = "StorageProfileProbe"
+
+	// ImportChunkCacheHostPath - if enabled will let the importer pods mount the hostPath of the import chunk cache,
+	// which is shared by the namespaces of the node
+	ImportChunkCacheHostPath = "ImportChunkCacheHostPath"
 )
 
 // FeatureGates is a util for determining whether an optional feature is enabled or not.
@@ -35,6 +42,12 @@ type FeatureGates interface {
 
 	// WebhookPvcRenderingEnabled - see the WebhookPvcRendering const
 	WebhookPvcRenderingEnabled() (bool, error)
+
+	// StorageProfileProbeEnabled - see the StorageProfileProbe const
+	StorageProfileProbeEnabled() (bool, error)
+
+	// ImportChunkCacheHostPathEnabled - see the ImportChunkCacheHostPath const
+	ImportChunkCacheHostPathEnabled() (bool, error)
 }
 
 // CDIConfigFeatureGates is a util for determining whether an optional feature is enabled or not.
@@ -85,6 +98,16 @@ func (f *CDIConfigFeatureGates) WebhookPvcRenderingEnabled() (bool, error) {
 	return f.isFeatureGateEnabled(WebhookPvcRendering)
 }
 
+// StorageProfileProbeEnabled tells if storage capability probing is enabled
+func (f *CDIConfigFeatureGates) StorageProfileProbeEnabled() (bool, error) {
+	return f.isFeatureGateEnabled(StorageProfileProbe)
+}
+
+// ImportChunkCacheHostPathEnabled tells if the import chunk cache may use a hostPath
+func (f *CDIConfigFeatureGates) ImportChunkCacheHostPathEnabled() (bool, error) {
+	return f.isFeatureGateEnabled(ImportChunkCacheHostPath)
+}
+
 // IsWebhookPvcRenderingEnabled tells if webhook PVC rendering is enabled
 func IsWebhookPvcRenderingEnabled(c client.Client) (bool, error) {
 	gates := NewFeatureGates(c)
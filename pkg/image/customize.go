@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/yaml"
+
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+var customizeExecFunction = system.ExecWithLimits
+
+// CustomizeRecipe is the list of customizations run in order against a disk image
+type CustomizeRecipe struct {
+	Steps []CustomizeStep `json:"steps"`
+}
+
+// CustomizeStep is a single customization, exactly one of its fields must be set
+type CustomizeStep struct {
+	// SSHInject adds an SSH key to the authorized keys of a user
+	SSHInject *CustomizeSSHKey `json:"sshInject,omitempty"`
+	// Hostname sets the hostname of the guest
+	Hostname string `json:"hostname,omitempty"`
+	// Install installs packages with the package manager of the guest
+	Install []string `json:"install,omitempty"`
+	// Truncate truncates a file of the guest to zero size
+	Truncate string `json:"truncate,omitempty"`
+	// Delete deletes a file or directory of the guest
+	Delete string `json:"delete,omitempty"`
+	// WriteFile writes the content of a file of the guest
+	WriteFile *CustomizeFile `json:"writeFile,omitempty"`
+	// RunCommand runs a shell command in the guest
+	RunCommand string `json:"runCommand,omitempty"`
+	// Timezone sets the timezone of the guest
+	Timezone string `json:"timezone,omitempty"`
+	// SELinuxRelabel relabels the files of the guest on its next boot
+	SELinuxRelabel bool `json:"selinuxRelabel,omitempty"`
+}
+
+// CustomizeSSHKey is an SSH key authorized for a user of the guest
+type CustomizeSSHKey struct {
+	User string `json:"user"`
+	Key  string `json:"key"`
+}
+
+// CustomizeFile is a file written into the guest
+type CustomizeFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// ParseCustomizeRecipe parses and validates a customization recipe
+func ParseCustomizeRecipe(data []byte) (*CustomizeRecipe, error) {
+	recipe := &CustomizeRecipe{}
+	if err := yaml.UnmarshalStrict(data, recipe); err != nil {
+		return nil, errors.Wrap(err, "invalid customization recipe")
+	}
+	if len(recipe.Steps) == 0 {
+		return nil, errors.New("customization recipe has no steps")
+	}
+	for i := range recipe.Steps {
+		if _, err := recipe.Steps[i].args(); err != nil {
+			return nil, errors.Wrapf(err, "customization step %d", i+1)
+		}
+	}
+	return recipe, nil
+}
+
+// args returns the virt-customize arguments of the step
+func (s *CustomizeStep) args() ([]string, error) {
+	var args []string
+	options := 0
+	add := func(option string, value ...string) {
+		args = append(append(args, option), value...)
+		options++
+	}
+
+	if key := s.SSHInject; key != nil {
+		if key.User == "" || key.Key == "" {
+			return nil, errors.New("sshInject needs a user and a key")
+		}
+		add("--ssh-inject", key.User+":string:"+key.Key)
+	}
+	if s.Hostname != "" {
+		add("--hostname", s.Hostname)
+	}
+	if len(s.Install) > 0 {
+		for _, pkg := range s.Install {
+			if pkg == "" || strings.Contains(pkg, ",") {
+				return nil, errors.Errorf("invalid package name %q", pkg)
+			}
+		}
+		add("--install", strings.Join(s.Install, ","))
+	}
+	if s.Truncate != "" {
+		add("--truncate", s.Truncate)
+	}
+	if s.Delete != "" {
+		add("--delete", s.Delete)
+	}
+	if file := s.WriteFile; file != nil {
+		// virt-customize splits the argument at the first colon
+		if file.Path == "" || strings.Contains(file.Path, ":") {
+			return nil, errors.Errorf("invalid writeFile path %q", file.Path)
+		}
+		add("--write", file.Path+":"+file.Content)
+	}
+	if s.RunCommand != "" {
+		add("--run-command", s.RunCommand)
+	}
+	if s.Timezone != "" {
+		add("--timezone", s.Timezone)
+	}
+	if s.SELinuxRelabel {
+		add("--selinux-relabel")
+	}
+
+	if options != 1 {
+		return nil, errors.Errorf("exactly one customization must be set, found %d", options)
+	}
+	return args, nil
+}
+
+// Customize runs the steps of the recipe against the raw disk image in dest, stopping at the first failing step
+func Customize(dest string, recipe *CustomizeRecipe) error {
+	args := []string{"--add", dest, "--format", "raw"}
+	for i := range recipe.Steps {
+		stepArgs, err := recipe.Steps[i].args()
+		if err != nil {
+			return errors.Wrapf(err, "customization step %d", i+1)
+		}
+		args = append(args, stepArgs...)
+	}
+
+	if err := setLibguestfsBackend(); err != nil {
+		return err
+	}
+
+	klog.V(1).Infof("Customizing %s with %d steps", dest, len(recipe.Steps))
+	output, err := customizeExecFunction(nil, nil, "virt-customize", args...)
+	if err != nil {
+		return errors.Wrapf(err, "customization failed: %s", lastLine(output))
+	}
+	return nil
+}
+
+// setLibguestfsBackend runs the libguestfs appliance directly, there is no libvirt in the importer pod
+func setLibguestfsBackend() error {
+	if os.Getenv("LIBGUESTFS_BACKEND") == "" {
+		return os.Setenv("LIBGUESTFS_BACKEND", "direct")
+	}
+	return nil
+}
+
+// lastLine returns the last non empty line of the output of a command, where its error is reported
+func lastLine(output []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
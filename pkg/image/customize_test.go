@@ -0,0 +1,74 @@
+package image
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+const testCustomizeRecipe = `
+steps:
+- sshInject:
+    user: cloud-user
+    key: ssh-ed25519 AAAA test
+- hostname: golden
+- install: [qemu-guest-agent, vim]
+- truncate: /etc/machine-id
+- writeFile:
+    path: /etc/motd
+    content: "customized: yes"
+- selinuxRelabel: true
+`
+
+var _ = Describe("Customize", func() {
+	var origExecFunction = customizeExecFunction
+
+	AfterEach(func() {
+		customizeExecFunction = origExecFunction
+	})
+
+	It("should run the steps of the recipe in order", func() {
+		recipe, err := ParseCustomizeRecipe([]byte(testCustomizeRecipe))
+		Expect(err).ToNot(HaveOccurred())
+
+		var args []string
+		customizeExecFunction = func(limits *system.ProcessLimitValues, f func(string), cmd string, a ...string) ([]byte, error) {
+			Expect(cmd).To(Equal("virt-customize"))
+			args = a
+			return nil, nil
+		}
+		Expect(Customize("/data/disk.img", recipe)).To(Succeed())
+		Expect(args).To(Equal([]string{
+			"--add", "/data/disk.img", "--format", "raw",
+			"--ssh-inject", "cloud-user:string:ssh-ed25519 AAAA test",
+			"--hostname", "golden",
+			"--install", "qemu-guest-agent,vim",
+			"--truncate", "/etc/machine-id",
+			"--write", "/etc/motd:customized: yes",
+			"--selinux-relabel",
+		}))
+	})
+
+	It("should report the error of the failing step", func() {
+		recipe, err := ParseCustomizeRecipe([]byte("steps:\n- runCommand: 'false'\n"))
+		Expect(err).ToNot(HaveOccurred())
+
+		customizeExecFunction = mockExecFunction("[   1.0] Running: false\nvirt-customize: error: false: command exited with an error\n", "exit status 1", nil, "--run-command", "false")
+		err = Customize("/dev/cdi-block-volume", recipe)
+		Expect(err).To(MatchError(ContainSubstring("virt-customize: error: false: command exited with an error")))
+	})
+
+	DescribeTable("should reject a recipe", func(recipe string) {
+		_, err := ParseCustomizeRecipe([]byte(recipe))
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("without steps", "steps: []\n"),
+		Entry("with an unknown customization", "steps:\n- reboot: true\n"),
+		Entry("with a step of two customizations", "steps:\n- hostname: a\n  timezone: UTC\n"),
+		Entry("with an empty step", "steps:\n- {}\n"),
+		Entry("with an SSH key without user", "steps:\n- sshInject:\n    key: abc\n"),
+		Entry("with a package list in a package name", "steps:\n- install: ['a,b']\n"),
+		Entry("with a colon in a written path", "steps:\n- writeFile:\n    path: 'a:b'\n    content: c\n"),
+	)
+})
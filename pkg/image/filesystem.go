@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+const (
+	// FilesystemExt4 is the type of ext2, ext3 and ext4 filesystems
+	FilesystemExt4 = "ext4"
+	// FilesystemXFS is the type of xfs filesystems
+	FilesystemXFS = "xfs"
+)
+
+var filesystemExecFunction = system.ExecWithLimits
+
+// GrowFilesystem grows the filesystem of the given partition number of the raw disk image in dest to the size of the partition
+func GrowFilesystem(dest string, partition int, fsType string) error {
+	// The disk is the first and only one of the libguestfs appliance
+	device := fmt.Sprintf("/dev/sda%d", partition)
+	args := []string{"--rw", "--format=raw", "-a", dest, "run"}
+	switch fsType {
+	case FilesystemExt4:
+		args = append(args, ":", "e2fsck", device, "correct:true", ":", "resize2fs", device)
+	case FilesystemXFS:
+		// xfs is only grown while mounted
+		args = append(args, ":", "mount", device, "/", ":", "xfs-growfs", "/", "datasec:true")
+	default:
+		return errors.Errorf("unsupported filesystem %q", fsType)
+	}
+
+	if err := setLibguestfsBackend(); err != nil {
+		return err
+	}
+
+	klog.V(1).Infof("Growing the %s filesystem of %s in %s", fsType, device, dest)
+	output, err := filesystemExecFunction(nil, nil, "guestfish", args...)
+	if err != nil {
+		return errors.Wrapf(err, "growing filesystem failed: %s", lastLine(output))
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package image
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/system"
+)
+
+var _ = Describe("Grow filesystem", func() {
+	var origExecFunction = filesystemExecFunction
+
+	AfterEach(func() {
+		filesystemExecFunction = origExecFunction
+	})
+
+	DescribeTable("should grow the filesystem of the partition", func(fsType string, commands []string) {
+		var args []string
+		filesystemExecFunction = func(limits *system.ProcessLimitValues, f func(string), cmd string, a ...string) ([]byte, error) {
+			Expect(cmd).To(Equal("guestfish"))
+			args = a
+			return nil, nil
+		}
+		Expect(GrowFilesystem("/data/disk.img", 3, fsType)).To(Succeed())
+		Expect(args).To(Equal(append([]string{"--rw", "--format=raw", "-a", "/data/disk.img", "run"}, commands...)))
+	},
+		Entry("with ext4", FilesystemExt4, []string{":", "e2fsck", "/dev/sda3", "correct:true", ":", "resize2fs", "/dev/sda3"}),
+		Entry("with xfs", FilesystemXFS, []string{":", "mount", "/dev/sda3", "/", ":", "xfs-growfs", "/", "datasec:true"}),
+	)
+
+	It("should not grow an unsupported filesystem", func() {
+		filesystemExecFunction = func(limits *system.ProcessLimitValues, f func(string), cmd string, a ...string) ([]byte, error) {
+			Fail("guestfish should not run")
+			return nil, nil
+		}
+		Expect(GrowFilesystem("/data/disk.img", 1, "btrfs")).To(MatchError(ContainSubstring("unsupported filesystem")))
+	})
+
+	It("should report the error of guestfish", func() {
+		filesystemExecFunction = mockExecFunction("libguestfs: error: resize2fs: Please run 'e2fsck -f /dev/sda1' first.\n", "exit status 1", nil)
+		err := GrowFilesystem("/dev/cdi-block-volume", 1, FilesystemExt4)
+		Expect(err).To(MatchError(ContainSubstring("Please run 'e2fsck -f /dev/sda1' first.")))
+	})
+})
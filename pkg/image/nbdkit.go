@@ -304,6 +304,9 @@ func (n *Nbdkit) StartNbdkit(source string) error {
 			} else {
 				quotedArgs[index] = "'/secret redacted/'"
 			}
+		} else if source, query, found := strings.Cut(value, "?"); found && strings.HasPrefix(value, "url=") && query != "" {
+			// The query may hold credentials, like presigned or SAS urls
+			quotedArgs[index] = "'" + source + "?/secret redacted/'"
 		} else {
 			quotedArgs[index] = "'" + value + "'"
 		}
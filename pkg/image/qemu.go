@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
 
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
 	"kubevirt.io/containerized-data-importer/pkg/system"
@@ -44,6 +45,7 @@ const (
 	maxMemory          = 1 << 30 //value from OpenStack Nova
 	maxCPUSecs         = 30      //value from OpenStack Nova
 	matcherString      = "\\((\\d?\\d\\.\\d\\d)\\/100%\\)"
+	maxWriteBlockSize  = 2 << 20 //largest qemu-img convert -S value
 )
 
 // ImgInfo contains the virtual image information.
@@ -71,6 +73,16 @@ type QEMUOperations interface {
 
 type qemuOperations struct{}
 
+// UnsupportedFormatError indicates that the format of an image can't be converted by CDI
+type UnsupportedFormatError struct {
+	Format string
+	Image  string
+}
+
+func (e UnsupportedFormatError) Error() string {
+	return fmt.Sprintf("Invalid format %s for image %s", e.Format, e.Image)
+}
+
 var (
 	ErrLargerPVCRequired = errors.New("A larger PVC is required")
 
@@ -80,6 +92,8 @@ var (
 	re               = regexp.MustCompile(matcherString)
 
 	ownerUID                    string
+	writeBlockSize              int64
+	zeroDetection               string
 	convertPreallocationMethods = [][]string{
 		{"-o", "preallocation=falloc"},
 		{"-o", "preallocation=full"},
@@ -97,6 +111,38 @@ func init() {
 		klog.Errorf("Unable to create prometheus progress counter: %v", err)
 	}
 	ownerUID, _ = util.ParseEnvVar(common.OwnerUID, false)
+	zeroDetection, _ = util.ParseEnvVar(common.ZeroDetection, false)
+	if value, _ := util.ParseEnvVar(common.WriteBlockSize, false); value != "" {
+		size, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			klog.Errorf("Ignoring invalid write block size %q: %v", value, err)
+		} else {
+			writeBlockSize = normalizeWriteBlockSize(size)
+		}
+	}
+}
+
+// normalizeWriteBlockSize rounds the size down to a multiple of 512 bytes in the range qemu-img accepts
+func normalizeWriteBlockSize(size int64) int64 {
+	size = size / 512 * 512
+	if size < 512 {
+		return 512
+	}
+	if size > maxWriteBlockSize {
+		return maxWriteBlockSize
+	}
+	return size
+}
+
+// zeroDetectionArgs returns the qemu-img convert arguments for the configured zero detection and write block size
+func zeroDetectionArgs() []string {
+	if zeroDetection == string(cdiv1.ImportZeroDetectionDisabled) {
+		return []string{"-S", "0"}
+	}
+	if writeBlockSize > 0 {
+		return []string{"-S", strconv.FormatInt(writeBlockSize, 10)}
+	}
+	return nil
 }
 
 // NewQEMUOperations returns the default implementation of QEMUOperations
@@ -109,7 +155,9 @@ func convertToRaw(src, dest string, preallocate bool, cacheMode string) error {
 	if err != nil {
 		return err
 	}
-	args := []string{"convert", "-t", cacheMode, "-p", "-O", "raw", src, dest}
+	args := []string{"convert", "-t", cacheMode, "-p", "-O", "raw"}
+	args = append(args, zeroDetectionArgs()...)
+	args = append(args, src, dest)
 
 	if preallocate {
 		err = addPreallocation(args, convertPreallocationMethods, func(args []string) ([]byte, error) {
@@ -132,7 +180,12 @@ func convertToRaw(src, dest string, preallocate bool, cacheMode string) error {
 }
 
 func getCacheMode(path string, cacheMode string) (string, error) {
-	if cacheMode != common.CacheModeTryNone {
+	switch cacheMode {
+	case string(cdiv1.ImportCacheModeNone), string(cdiv1.ImportCacheModeWriteback), string(cdiv1.ImportCacheModeWritethrough),
+		string(cdiv1.ImportCacheModeDirectsync), string(cdiv1.ImportCacheModeUnsafe):
+		return cacheMode, nil
+	case common.CacheModeTryNone:
+	default:
 		return "writeback", nil
 	}
 
@@ -243,7 +296,7 @@ func isSupportedFormat(value string) bool {
 
 func checkIfURLIsValid(info *ImgInfo, availableSize int64, image string) error {
 	if !isSupportedFormat(info.Format) {
-		return errors.Errorf("Invalid format %s for image %s", info.Format, image)
+		return errors.WithStack(UnsupportedFormatError{Format: info.Format, Image: image})
 	}
 
 	if len(info.BackingFile) > 0 {
@@ -31,6 +31,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
 	"kubevirt.io/containerized-data-importer/pkg/system"
@@ -212,6 +213,52 @@ var _ = Describe("Convert to Raw", func() {
 		})
 	})
 
+	It("should use the requested cache mode", func() {
+		replaceExecFunction(mockExecFunctionStrict("", "", nil, "convert", "-t", "writethrough", "-p", "-O", "raw", "/somefile/somewhere", destPath), func() {
+			ep, err := url.Parse("/somefile/somewhere")
+			Expect(err).NotTo(HaveOccurred())
+			err = ConvertToRawStream(ep, destPath, false, string(cdiv1.ImportCacheModeWritethrough))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("zero detection", func() {
+		AfterEach(func() {
+			writeBlockSize = 0
+			zeroDetection = ""
+		})
+
+		It("should use the write block size for zero detection", func() {
+			writeBlockSize = 65536
+			replaceExecFunction(mockExecFunctionStrict("", "", nil, "convert", "-t", "writeback", "-p", "-O", "raw", "-S", "65536", "/somefile/somewhere", destPath), func() {
+				ep, err := url.Parse("/somefile/somewhere")
+				Expect(err).NotTo(HaveOccurred())
+				err = ConvertToRawStream(ep, destPath, false, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		It("should write zeroes when zero detection is disabled", func() {
+			writeBlockSize = 65536
+			zeroDetection = string(cdiv1.ImportZeroDetectionDisabled)
+			replaceExecFunction(mockExecFunctionStrict("", "", nil, "convert", "-t", "writeback", "-p", "-O", "raw", "-S", "0", "/somefile/somewhere", destPath), func() {
+				ep, err := url.Parse("/somefile/somewhere")
+				Expect(err).NotTo(HaveOccurred())
+				err = ConvertToRawStream(ep, destPath, false, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	DescribeTable("should normalize the write block size", func(size, expected int64) {
+		Expect(normalizeWriteBlockSize(size)).To(Equal(expected))
+	},
+		Entry("smaller than a sector", int64(100), int64(512)),
+		Entry("multiple of a sector", int64(4096), int64(4096)),
+		Entry("not a multiple of a sector", int64(5000), int64(4608)),
+		Entry("larger than the maximum", int64(8<<20), int64(2<<20)),
+	)
+
 	Context("cache mode adjusted according to O_DIRECT support", func() {
 		var tmpFsDir string
 		var originalODirectChecker DirectIOChecker
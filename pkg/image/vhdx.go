@@ -0,0 +1,421 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+// The layout constants below follow the MS-VHDX specification. qemu-img cannot open VHDX differencing
+// disks (.avhdx), so a chain of them is merged here instead.
+const (
+	vhdxHeader1Offset      = 64 * 1024
+	vhdxHeader2Offset      = 128 * 1024
+	vhdxHeaderSize         = 4 * 1024
+	vhdxRegionTable1Offset = 192 * 1024
+	vhdxRegionTableSize    = 64 * 1024
+	vhdxMetadataTableSize  = 64 * 1024
+	vhdxMaxRegionEntries   = 2047
+	vhdxMaxMetadataEntries = 2047
+	vhdxSectorsPerChunk    = 1 << 23
+	vhdxBATOffsetMask      = ^uint64(1<<20 - 1)
+	vhdxBlockStateMask     = 7
+
+	vhdxHasParent = 1 << 1
+	vhdxRequired  = 1 << 2
+
+	vhdxPayloadBlockNotPresent       = 0
+	vhdxPayloadBlockUndefined        = 1
+	vhdxPayloadBlockZero             = 2
+	vhdxPayloadBlockUnmapped         = 3
+	vhdxPayloadBlockFullyPresent     = 6
+	vhdxPayloadBlockPartiallyPresent = 7
+	vhdxSectorBitmapBlockPresent     = 6
+)
+
+var (
+	vhdxCastagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+	vhdxBATRegion      = vhdxGUID("2DC27766-F623-4200-9D64-115E9BFD4A08")
+	vhdxMetadataRegion = vhdxGUID("8B7CA206-4790-4B9A-B8FE-575F050F886E")
+
+	vhdxFileParameters    = vhdxGUID("CAA16737-FA36-4D43-B3B6-33F0AA44E76B")
+	vhdxVirtualDiskSize   = vhdxGUID("2FA54224-CD1B-4876-B211-5DBED83BF4B8")
+	vhdxLogicalSectorSize = vhdxGUID("8141BF1D-A96F-4709-BA47-F233A8FAAB5F")
+	vhdxKnownMetadata     = map[[16]byte]bool{
+		vhdxFileParameters:    true,
+		vhdxVirtualDiskSize:   true,
+		vhdxLogicalSectorSize: true,
+		// Page 83 data
+		vhdxGUID("BECA12AB-B2E6-4523-93EF-C309E000C746"): true,
+		// Physical sector size
+		vhdxGUID("CDA348C7-445D-4471-9CC9-E9885251C556"): true,
+		// Parent locator, the chain order is given by the caller instead
+		vhdxGUID("A8D35F2D-B30B-454D-ABF7-D3D84834AB0C"): true,
+	}
+)
+
+// VHDX is a VHDX or AVHDX (differencing) disk image opened for reading
+type VHDX struct {
+	file              *os.File
+	blockSize         uint64
+	logicalSectorSize uint64
+	virtualSize       uint64
+	hasParent         bool
+	chunkRatio        uint64
+	bat               []uint64
+	parent            *VHDX
+}
+
+// OpenVHDX opens and parses the VHDX image at path
+func OpenVHDX(path string) (*VHDX, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open %s", path)
+	}
+	v := &VHDX{file: file}
+	if err := v.parse(); err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "could not parse VHDX image %s", path)
+	}
+	klog.V(1).Infof("Opened VHDX %s: virtual size %d, block size %d, differencing %t", path, v.virtualSize, v.blockSize, v.hasParent)
+	return v, nil
+}
+
+// VirtualSize returns the size of the virtual disk
+func (v *VHDX) VirtualSize() uint64 {
+	return v.virtualSize
+}
+
+// HasParent returns true if the image is a differencing disk
+func (v *VHDX) HasParent() bool {
+	return v.hasParent
+}
+
+// Close closes the image file
+func (v *VHDX) Close() error {
+	return v.file.Close()
+}
+
+// NewVHDXChainReader returns a reader of the disk described by an ordered chain of VHDX images, the first image is
+// the base disk and each following image is a differencing disk of the image before it
+func NewVHDXChainReader(chain []*VHDX) (io.Reader, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("empty VHDX chain")
+	}
+	if chain[0].hasParent {
+		return nil, errors.New("the first image of the VHDX chain is a differencing disk")
+	}
+	for i := 1; i < len(chain); i++ {
+		child, parent := chain[i], chain[i-1]
+		if !child.hasParent {
+			return nil, errors.Errorf("image %d of the VHDX chain is not a differencing disk", i)
+		}
+		if child.virtualSize != parent.virtualSize || child.logicalSectorSize != parent.logicalSectorSize {
+			return nil, errors.Errorf("image %d of the VHDX chain does not match the geometry of its parent", i)
+		}
+		child.parent = parent
+	}
+	top := chain[len(chain)-1]
+	return &vhdxChainReader{top: top}, nil
+}
+
+type vhdxChainReader struct {
+	top    *VHDX
+	offset uint64
+}
+
+func (r *vhdxChainReader) Read(p []byte) (int, error) {
+	if r.offset >= r.top.virtualSize {
+		return 0, io.EOF
+	}
+	n := min(uint64(len(p)), r.top.virtualSize-r.offset)
+	// Keep reads sector aligned, the virtual size is a multiple of the sector size
+	n -= n % r.top.logicalSectorSize
+	if n == 0 {
+		return 0, io.ErrShortBuffer
+	}
+	if err := r.top.readAt(p[:n], r.offset); err != nil {
+		return 0, err
+	}
+	r.offset += n
+	return int(n), nil
+}
+
+func (v *VHDX) parse() error {
+	ident := make([]byte, 8)
+	if _, err := v.file.ReadAt(ident, 0); err != nil {
+		return err
+	}
+	if string(ident) != "vhdxfile" {
+		return errors.New("missing VHDX file identifier")
+	}
+	if err := v.checkHeaders(); err != nil {
+		return err
+	}
+	batOffset, batLength, metadataOffset, err := v.readRegionTable()
+	if err != nil {
+		return err
+	}
+	if err := v.readMetadata(metadataOffset); err != nil {
+		return err
+	}
+	return v.readBAT(batOffset, batLength)
+}
+
+// checkHeaders verifies that the current header has no pending log, replaying the log is not supported
+func (v *VHDX) checkHeaders() error {
+	var current []byte
+	var sequence uint64
+	for _, offset := range []int64{vhdxHeader1Offset, vhdxHeader2Offset} {
+		buf := make([]byte, vhdxHeaderSize)
+		if _, err := v.file.ReadAt(buf, offset); err != nil {
+			return err
+		}
+		if string(buf[0:4]) != "head" || !vhdxChecksumValid(buf, 4) {
+			continue
+		}
+		if seq := binary.LittleEndian.Uint64(buf[8:16]); current == nil || seq > sequence {
+			current, sequence = buf, seq
+		}
+	}
+	if current == nil {
+		return errors.New("no valid VHDX header")
+	}
+	if !bytes.Equal(current[48:64], make([]byte, 16)) {
+		return errors.New("the VHDX log is not empty, the image was not closed cleanly")
+	}
+	return nil
+}
+
+func (v *VHDX) readRegionTable() (uint64, uint64, uint64, error) {
+	buf := make([]byte, vhdxRegionTableSize)
+	if _, err := v.file.ReadAt(buf, vhdxRegionTable1Offset); err != nil {
+		return 0, 0, 0, err
+	}
+	if string(buf[0:4]) != "regi" || !vhdxChecksumValid(buf, 4) {
+		return 0, 0, 0, errors.New("invalid VHDX region table")
+	}
+	count := binary.LittleEndian.Uint32(buf[8:12])
+	if count > vhdxMaxRegionEntries {
+		return 0, 0, 0, errors.Errorf("too many VHDX region table entries: %d", count)
+	}
+	var batOffset, batLength, metadataOffset uint64
+	for i := uint32(0); i < count; i++ {
+		entry := buf[16+32*i : 16+32*(i+1)]
+		var guid [16]byte
+		copy(guid[:], entry[0:16])
+		offset := binary.LittleEndian.Uint64(entry[16:24])
+		length := uint64(binary.LittleEndian.Uint32(entry[24:28]))
+		switch guid {
+		case vhdxBATRegion:
+			batOffset, batLength = offset, length
+		case vhdxMetadataRegion:
+			metadataOffset = offset
+		default:
+			if binary.LittleEndian.Uint32(entry[28:32])&1 != 0 {
+				return 0, 0, 0, errors.Errorf("unknown required VHDX region %x", guid)
+			}
+		}
+	}
+	if batOffset == 0 || metadataOffset == 0 {
+		return 0, 0, 0, errors.New("missing VHDX BAT or metadata region")
+	}
+	return batOffset, batLength, metadataOffset, nil
+}
+
+func (v *VHDX) readMetadata(offset uint64) error {
+	buf := make([]byte, vhdxMetadataTableSize)
+	if _, err := v.file.ReadAt(buf, int64(offset)); err != nil {
+		return err
+	}
+	if string(buf[0:8]) != "metadata" {
+		return errors.New("invalid VHDX metadata table")
+	}
+	count := binary.LittleEndian.Uint16(buf[10:12])
+	if count > vhdxMaxMetadataEntries {
+		return errors.Errorf("too many VHDX metadata entries: %d", count)
+	}
+	items := map[[16]byte][]byte{}
+	for i := uint16(0); i < count; i++ {
+		entry := buf[32+32*int(i) : 32+32*(int(i)+1)]
+		var guid [16]byte
+		copy(guid[:], entry[0:16])
+		if !vhdxKnownMetadata[guid] {
+			if binary.LittleEndian.Uint32(entry[24:28])&vhdxRequired != 0 {
+				return errors.Errorf("unknown required VHDX metadata item %x", guid)
+			}
+			continue
+		}
+		length := binary.LittleEndian.Uint32(entry[20:24])
+		if length > 64*1024 {
+			return errors.Errorf("VHDX metadata item %x is too large", guid)
+		}
+		item := make([]byte, length)
+		if _, err := v.file.ReadAt(item, int64(offset)+int64(binary.LittleEndian.Uint32(entry[16:20]))); err != nil {
+			return err
+		}
+		items[guid] = item
+	}
+
+	params, size, sector := items[vhdxFileParameters], items[vhdxVirtualDiskSize], items[vhdxLogicalSectorSize]
+	if len(params) < 8 || len(size) < 8 || len(sector) < 4 {
+		return errors.New("missing required VHDX metadata")
+	}
+	v.blockSize = uint64(binary.LittleEndian.Uint32(params[0:4]))
+	v.hasParent = binary.LittleEndian.Uint32(params[4:8])&vhdxHasParent != 0
+	v.virtualSize = binary.LittleEndian.Uint64(size)
+	v.logicalSectorSize = uint64(binary.LittleEndian.Uint32(sector))
+
+	if v.blockSize < 1<<20 || v.blockSize > 256<<20 || v.blockSize&(v.blockSize-1) != 0 {
+		return errors.Errorf("invalid VHDX block size %d", v.blockSize)
+	}
+	if v.logicalSectorSize != 512 && v.logicalSectorSize != 4096 {
+		return errors.Errorf("invalid VHDX logical sector size %d", v.logicalSectorSize)
+	}
+	if v.virtualSize == 0 || v.virtualSize%v.logicalSectorSize != 0 {
+		return errors.Errorf("invalid VHDX virtual disk size %d", v.virtualSize)
+	}
+	v.chunkRatio = vhdxSectorsPerChunk * v.logicalSectorSize / v.blockSize
+	return nil
+}
+
+func (v *VHDX) readBAT(offset, length uint64) error {
+	dataBlocks := (v.virtualSize + v.blockSize - 1) / v.blockSize
+	entries := dataBlocks + (dataBlocks-1)/v.chunkRatio
+	if v.hasParent {
+		entries = (dataBlocks + v.chunkRatio - 1) / v.chunkRatio * (v.chunkRatio + 1)
+	}
+	if entries*8 > length {
+		return errors.Errorf("VHDX BAT region is too small for %d entries", entries)
+	}
+	buf := make([]byte, entries*8)
+	if _, err := v.file.ReadAt(buf, int64(offset)); err != nil {
+		return err
+	}
+	v.bat = make([]uint64, entries)
+	for i := range v.bat {
+		v.bat[i] = binary.LittleEndian.Uint64(buf[8*i:])
+	}
+	return nil
+}
+
+// readAt reads the virtual disk at offset, p and offset are sector aligned
+func (v *VHDX) readAt(p []byte, offset uint64) error {
+	for len(p) > 0 {
+		block, inBlock := offset/v.blockSize, offset%v.blockSize
+		n := min(uint64(len(p)), v.blockSize-inBlock)
+		if err := v.readBlock(p[:n], block, inBlock); err != nil {
+			return err
+		}
+		p, offset = p[n:], offset+n
+	}
+	return nil
+}
+
+func (v *VHDX) readBlock(p []byte, block, inBlock uint64) error {
+	entry := v.bat[block+block/v.chunkRatio]
+	switch entry & vhdxBlockStateMask {
+	case vhdxPayloadBlockFullyPresent:
+		return v.readPayload(p, entry, inBlock)
+	case vhdxPayloadBlockPartiallyPresent:
+		if v.parent == nil {
+			return errors.Errorf("VHDX block %d is partially present without a parent", block)
+		}
+		return v.readPartialBlock(p, block, entry, inBlock)
+	case vhdxPayloadBlockZero, vhdxPayloadBlockUnmapped:
+		clear(p)
+		return nil
+	case vhdxPayloadBlockNotPresent, vhdxPayloadBlockUndefined:
+		if v.parent != nil {
+			return v.parent.readAt(p, block*v.blockSize+inBlock)
+		}
+		clear(p)
+		return nil
+	default:
+		return errors.Errorf("invalid VHDX block state %d of block %d", entry&vhdxBlockStateMask, block)
+	}
+}
+
+func (v *VHDX) readPayload(p []byte, entry, inBlock uint64) error {
+	_, err := v.file.ReadAt(p, int64(entry&vhdxBATOffsetMask+inBlock))
+	return err
+}
+
+// readPartialBlock reads the sectors present in the block from the image and the others from the parent
+func (v *VHDX) readPartialBlock(p []byte, block, entry, inBlock uint64) error {
+	chunk := block / v.chunkRatio
+	bitmapEntry := v.bat[chunk*(v.chunkRatio+1)+v.chunkRatio]
+	if bitmapEntry&vhdxBlockStateMask != vhdxSectorBitmapBlockPresent {
+		return errors.Errorf("missing VHDX sector bitmap of block %d", block)
+	}
+	sectorSize := v.logicalSectorSize
+	first := (block%v.chunkRatio)*(v.blockSize/sectorSize) + inBlock/sectorSize
+	count := uint64(len(p)) / sectorSize
+	bitmap := make([]byte, (first%8+count+7)/8)
+	if _, err := v.file.ReadAt(bitmap, int64(bitmapEntry&vhdxBATOffsetMask+first/8)); err != nil {
+		return err
+	}
+	present := func(i uint64) bool {
+		bit := first%8 + i
+		return bitmap[bit/8]&(1<<(bit%8)) != 0
+	}
+	for i := uint64(0); i < count; {
+		j := i + 1
+		for j < count && present(j) == present(i) {
+			j++
+		}
+		run, runOffset := p[i*sectorSize:j*sectorSize], inBlock+i*sectorSize
+		var err error
+		if present(i) {
+			err = v.readPayload(run, entry, runOffset)
+		} else {
+			err = v.parent.readAt(run, block*v.blockSize+runOffset)
+		}
+		if err != nil {
+			return err
+		}
+		i = j
+	}
+	return nil
+}
+
+func vhdxChecksumValid(buf []byte, offset int) bool {
+	sum := binary.LittleEndian.Uint32(buf[offset:])
+	data := bytes.Clone(buf)
+	binary.LittleEndian.PutUint32(data[offset:], 0)
+	return crc32.Checksum(data, vhdxCastagnoli) == sum
+}
+
+// vhdxGUID converts a GUID string to its on disk form, the first three fields are little endian
+func vhdxGUID(s string) [16]byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(b) != 16 {
+		panic("invalid GUID " + s)
+	}
+	return [16]byte{b[3], b[2], b[1], b[0], b[5], b[4], b[7], b[6], b[8], b[9], b[10], b[11], b[12], b[13], b[14], b[15]}
+}
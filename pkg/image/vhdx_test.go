@@ -0,0 +1,205 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testVHDXBlockSize = 1 << 20
+	testVHDXBlocks    = 4
+	testVHDXSectors   = testVHDXBlockSize / 512
+)
+
+type testVHDXBlock struct {
+	state  uint64
+	data   []byte
+	bitmap []byte
+}
+
+// createTestVHDX writes a VHDX of four 1MiB blocks with 512 byte sectors, the metadata region is at 1MiB, the BAT
+// at 2MiB and the payload blocks start at 3MiB followed by the sector bitmap block
+func createTestVHDX(path string, hasParent bool, blocks []testVHDXBlock) {
+	buf := make([]byte, (4+len(blocks))<<20)
+	copy(buf, "vhdxfile")
+
+	header := buf[vhdxHeader1Offset : vhdxHeader1Offset+vhdxHeaderSize]
+	copy(header, "head")
+	binary.LittleEndian.PutUint64(header[8:], 1)
+	putTestVHDXChecksum(header)
+
+	region := buf[vhdxRegionTable1Offset : vhdxRegionTable1Offset+vhdxRegionTableSize]
+	copy(region, "regi")
+	binary.LittleEndian.PutUint32(region[8:], 2)
+	putTestVHDXEntry(region[16:], vhdxBATRegion, 2<<20, 1<<20)
+	putTestVHDXEntry(region[48:], vhdxMetadataRegion, 1<<20, 1<<20)
+	putTestVHDXChecksum(region)
+
+	metadata := buf[1<<20 : 2<<20]
+	copy(metadata, "metadata")
+	binary.LittleEndian.PutUint16(metadata[10:], 3)
+	copy(metadata[32:], vhdxFileParameters[:])
+	binary.LittleEndian.PutUint32(metadata[48:], 64<<10)
+	binary.LittleEndian.PutUint32(metadata[52:], 8)
+	copy(metadata[64:], vhdxVirtualDiskSize[:])
+	binary.LittleEndian.PutUint32(metadata[80:], 64<<10+8)
+	binary.LittleEndian.PutUint32(metadata[84:], 8)
+	copy(metadata[96:], vhdxLogicalSectorSize[:])
+	binary.LittleEndian.PutUint32(metadata[112:], 64<<10+16)
+	binary.LittleEndian.PutUint32(metadata[116:], 4)
+	binary.LittleEndian.PutUint32(metadata[64<<10:], testVHDXBlockSize)
+	if hasParent {
+		binary.LittleEndian.PutUint32(metadata[64<<10+4:], vhdxHasParent)
+	}
+	binary.LittleEndian.PutUint64(metadata[64<<10+8:], testVHDXBlocks*testVHDXBlockSize)
+	binary.LittleEndian.PutUint32(metadata[64<<10+16:], 512)
+
+	bat := buf[2<<20 : 3<<20]
+	bitmapOffset := uint64(3+len(blocks)) << 20
+	for i, block := range blocks {
+		entry := block.state
+		if block.data != nil {
+			offset := uint64(3+i) << 20
+			copy(buf[offset:], block.data)
+			entry |= offset
+		}
+		binary.LittleEndian.PutUint64(bat[8*i:], entry)
+		copy(buf[bitmapOffset+uint64(i*testVHDXSectors/8):], block.bitmap)
+	}
+	if hasParent {
+		// The sector bitmap entry follows the payload entries of the chunk
+		binary.LittleEndian.PutUint64(bat[8*4096:], bitmapOffset|vhdxSectorBitmapBlockPresent)
+	}
+	Expect(os.WriteFile(path, buf, 0600)).To(Succeed())
+}
+
+func putTestVHDXEntry(entry []byte, guid [16]byte, offset uint64, length uint32) {
+	copy(entry, guid[:])
+	binary.LittleEndian.PutUint64(entry[16:], offset)
+	binary.LittleEndian.PutUint32(entry[24:], length)
+	binary.LittleEndian.PutUint32(entry[28:], 1)
+}
+
+func putTestVHDXChecksum(buf []byte) {
+	binary.LittleEndian.PutUint32(buf[4:], 0)
+	binary.LittleEndian.PutUint32(buf[4:], crc32.Checksum(buf, crc32.MakeTable(crc32.Castagnoli)))
+}
+
+func filledBlock(b byte) []byte {
+	return bytes.Repeat([]byte{b}, testVHDXBlockSize)
+}
+
+func openTestVHDX(path string) *VHDX {
+	v, err := OpenVHDX(path)
+	Expect(err).ToNot(HaveOccurred())
+	DeferCleanup(v.Close)
+	return v
+}
+
+func readTestVHDXChain(chain ...*VHDX) []byte {
+	reader, err := NewVHDXChainReader(chain)
+	Expect(err).ToNot(HaveOccurred())
+	out := &bytes.Buffer{}
+	// An odd buffer size exercises the sector alignment of the reads
+	_, err = io.CopyBuffer(out, reader, make([]byte, 100000))
+	Expect(err).ToNot(HaveOccurred())
+	return out.Bytes()
+}
+
+var _ = Describe("VHDX", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should read a base image", func() {
+		path := filepath.Join(dir, "base.vhdx")
+		createTestVHDX(path, false, []testVHDXBlock{
+			{state: vhdxPayloadBlockFullyPresent, data: filledBlock('A')},
+			{state: vhdxPayloadBlockZero},
+			{state: vhdxPayloadBlockNotPresent},
+			{state: vhdxPayloadBlockFullyPresent, data: filledBlock('B')},
+		})
+		base := openTestVHDX(path)
+		Expect(base.VirtualSize()).To(Equal(uint64(testVHDXBlocks * testVHDXBlockSize)))
+		Expect(base.HasParent()).To(BeFalse())
+
+		expected := bytes.Join([][]byte{filledBlock('A'), filledBlock(0), filledBlock(0), filledBlock('B')}, nil)
+		Expect(bytes.Equal(readTestVHDXChain(base), expected)).To(BeTrue())
+	})
+
+	It("should flatten a chain of differencing disks", func() {
+		createTestVHDX(filepath.Join(dir, "base.vhdx"), false, []testVHDXBlock{
+			{state: vhdxPayloadBlockFullyPresent, data: filledBlock('A')},
+			{state: vhdxPayloadBlockFullyPresent, data: filledBlock('B')},
+			{state: vhdxPayloadBlockFullyPresent, data: filledBlock('C')},
+			{state: vhdxPayloadBlockFullyPresent, data: filledBlock('D')},
+		})
+		// The first half of block 0 is present in the first differencing disk
+		firstHalf := append(bytes.Repeat([]byte{0xff}, testVHDXSectors/16), make([]byte, testVHDXSectors/16)...)
+		createTestVHDX(filepath.Join(dir, "diff1.avhdx"), true, []testVHDXBlock{
+			{state: vhdxPayloadBlockPartiallyPresent, data: filledBlock('E'), bitmap: firstHalf},
+			{state: vhdxPayloadBlockNotPresent},
+			{state: vhdxPayloadBlockFullyPresent, data: filledBlock('F')},
+			{state: vhdxPayloadBlockNotPresent},
+		})
+		// Sectors 0-3 of every 8 sectors of block 1 are present in the second differencing disk
+		alternating := bytes.Repeat([]byte{0x0f}, testVHDXSectors/8)
+		createTestVHDX(filepath.Join(dir, "diff2.avhdx"), true, []testVHDXBlock{
+			{state: vhdxPayloadBlockNotPresent},
+			{state: vhdxPayloadBlockPartiallyPresent, data: filledBlock('G'), bitmap: alternating},
+			{state: vhdxPayloadBlockZero},
+			{state: vhdxPayloadBlockFullyPresent, data: filledBlock('H')},
+		})
+		base := openTestVHDX(filepath.Join(dir, "base.vhdx"))
+		diff1 := openTestVHDX(filepath.Join(dir, "diff1.avhdx"))
+		diff2 := openTestVHDX(filepath.Join(dir, "diff2.avhdx"))
+		Expect(diff1.HasParent()).To(BeTrue())
+
+		block0 := append(bytes.Repeat([]byte{'E'}, testVHDXBlockSize/2), bytes.Repeat([]byte{'A'}, testVHDXBlockSize/2)...)
+		block1 := bytes.Repeat(append(bytes.Repeat([]byte{'G'}, 4*512), bytes.Repeat([]byte{'B'}, 4*512)...), testVHDXSectors/8)
+		expected := bytes.Join([][]byte{block0, block1, filledBlock(0), filledBlock('H')}, nil)
+		Expect(bytes.Equal(readTestVHDXChain(base, diff1, diff2), expected)).To(BeTrue())
+	})
+
+	It("should reject a chain in the wrong order", func() {
+		createTestVHDX(filepath.Join(dir, "base.vhdx"), false, make([]testVHDXBlock, testVHDXBlocks))
+		createTestVHDX(filepath.Join(dir, "diff.avhdx"), true, make([]testVHDXBlock, testVHDXBlocks))
+		base := openTestVHDX(filepath.Join(dir, "base.vhdx"))
+		diff := openTestVHDX(filepath.Join(dir, "diff.avhdx"))
+
+		_, err := NewVHDXChainReader([]*VHDX{diff, base})
+		Expect(err).To(MatchError(ContainSubstring("first image of the VHDX chain is a differencing disk")))
+		_, err = NewVHDXChainReader([]*VHDX{base, base})
+		Expect(err).To(MatchError(ContainSubstring("is not a differencing disk")))
+	})
+
+	It("should reject an image with a pending log", func() {
+		path := filepath.Join(dir, "dirty.vhdx")
+		createTestVHDX(path, false, make([]testVHDXBlock, testVHDXBlocks))
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		header := data[vhdxHeader1Offset : vhdxHeader1Offset+vhdxHeaderSize]
+		header[48] = 1
+		putTestVHDXChecksum(header)
+		Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+
+		_, err = OpenVHDX(path)
+		Expect(err).To(MatchError(ContainSubstring("log is not empty")))
+	})
+
+	It("should reject a file that is not a VHDX", func() {
+		path := filepath.Join(dir, "disk.img")
+		Expect(os.WriteFile(path, make([]byte, 1<<20), 0600)).To(Succeed())
+		_, err := OpenVHDX(path)
+		Expect(err).To(MatchError(ContainSubstring("missing VHDX file identifier")))
+	})
+})
@@ -0,0 +1,475 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	azureBlobVersion       = "2021-08-06"
+	azureBlobTimeFormat    = "2006-01-02T15:04:05Z"
+	azureBlobSASLifetime   = 24 * time.Hour
+	azureBlobClockSkew     = 15 * time.Minute
+	azureBlobStorageScope  = "https://storage.azure.com/.default"
+	azureBlobAuthorityHost = "https://login.microsoftonline.com"
+
+	// Keys of the Azure Blob secret
+	azureBlobKeyAccountKey    = "accountKey"
+	azureBlobKeySASToken      = "sasToken"
+	azureBlobKeyTenantID      = "tenantId"
+	azureBlobKeyClientID      = "clientId"
+	azureBlobKeyClientSecret  = "clientSecret"
+	azureBlobKeyAuthorityHost = "authorityHost"
+)
+
+// may be overridden in tests
+var azureBlobNow = time.Now
+
+// AzureBlobDataSource is the struct containing the information needed to import from an Azure Blob Storage source.
+// Every authorization method is turned into a SAS url of the blob, which is read with ranged GETs, so qemu-img can
+// convert images directly through nbdkit.
+// Sequence of phases:
+// 1a. Info -> TransferDataFile if the blob is a raw image.
+// 1b. Info -> Convert if the blob can be converted by qemu-img through nbdkit.
+// 1c. Info -> TransferScratch in all other cases.
+// 2.  TransferScratch -> Convert
+type AzureBlobDataSource struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	cancelLock sync.Mutex
+	// the url of the blob, including the SAS query if any
+	blobURL *url.URL
+	// the size of the blob
+	size uint64
+	// true if the blob is read through the chunk cache
+	cached bool
+	// Reader
+	blobReader io.ReadCloser
+	// stack of readers
+	readers *FormatReaders
+	// The image file in scratch space, or the nbdkit socket.
+	url *url.URL
+
+	n image.NbdkitOperation
+}
+
+// azureBlobCredentials are the contents of the Azure Blob secret
+type azureBlobCredentials struct {
+	accountKey    string
+	sasToken      string
+	tenantID      string
+	clientID      string
+	clientSecret  string
+	authorityHost string
+}
+
+// azureBlobUserDelegationKey is the key returned by the blob service to sign user delegation SAS
+type azureBlobUserDelegationKey struct {
+	SignedOid     string `xml:"SignedOid"`
+	SignedTid     string `xml:"SignedTid"`
+	SignedStart   string `xml:"SignedStart"`
+	SignedExpiry  string `xml:"SignedExpiry"`
+	SignedService string `xml:"SignedService"`
+	SignedVersion string `xml:"SignedVersion"`
+	Value         string `xml:"Value"`
+}
+
+// NewAzureBlobDataSource creates a new instance of the AzureBlobDataSource from the url of the blob
+func NewAzureBlobDataSource(endpoint, account, credentialDir, certDir string) (*AzureBlobDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	serviceURL, container, blob := extractAzureBlobServiceContainerAndBlob(ep, account)
+	if container == "" || blob == "" {
+		return nil, errors.Errorf("endpoint %q has no container or blob", endpoint)
+	}
+	creds, err := readAzureBlobCredentials(credentialDir)
+	if err != nil {
+		return nil, err
+	}
+	client, err := createHTTPClient(certDir, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating http client for azure blob")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	blobURL, err := getAzureBlobSASURL(ctx, client, ep, serviceURL, account, container, blob, creds)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	ds := &AzureBlobDataSource{
+		ctx:     ctx,
+		cancel:  cancel,
+		blobURL: blobURL,
+	}
+	if err := ds.openBlob(client); err != nil {
+		cancel()
+		return nil, err
+	}
+	ds.n, err = createNbdkitCurl(nbdkitPid, "", "", certDir, nbdkitSocket, []string{"x-ms-version: " + azureBlobVersion}, nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return ds, nil
+}
+
+// Info is called to get initial information about the data.
+func (ad *AzureBlobDataSource) Info() (ProcessingPhase, error) {
+	var err error
+	ad.readers, err = NewFormatReaders(ad.blobReader, ad.size)
+	if err != nil {
+		klog.Errorf("Error creating readers: %v", err)
+		return ProcessingPhaseError, err
+	}
+	if !ad.readers.Convert {
+		// Downloading a raw file, we can write that directly to the target.
+		return ProcessingPhaseTransferDataFile, nil
+	}
+	if !ad.readers.Archived && !ad.cached {
+		ad.url, _ = url.Parse(fmt.Sprintf("nbd+unix:///?socket=%s", nbdkitSocket))
+		if err = ad.n.StartNbdkit(ad.blobURL.String()); err == nil {
+			return ProcessingPhaseConvert, nil
+		}
+		klog.Warningf("Unable to start nbdkit, downloading the blob to scratch space: %v", err)
+	}
+	ad.url = nil
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// Transfer is called to transfer the data from the source to a temporary location.
+func (ad *AzureBlobDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	file := filepath.Join(path, tempFile)
+	if err := CleanAll(file); err != nil {
+		return ProcessingPhaseError, err
+	}
+
+	size, _ := GetAvailableSpace(path)
+	if size <= int64(0) {
+		//Path provided is invalid.
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+
+	ad.readers.StartProgressUpdate()
+	_, _, err := StreamDataToFile(ad.readers.TopReader(), file, preallocation)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
+	ad.url, _ = url.Parse(file)
+	return ProcessingPhaseConvert, nil
+}
+
+// TransferFile is called to transfer the data from the source to the passed in file.
+func (ad *AzureBlobDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	if err := CleanAll(fileName); err != nil {
+		return ProcessingPhaseError, err
+	}
+
+	ad.readers.StartProgressUpdate()
+	_, _, err := StreamDataToFile(ad.readers.TopReader(), fileName, preallocation)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (ad *AzureBlobDataSource) GetURL() *url.URL {
+	return ad.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (ad *AzureBlobDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close closes any readers or other open resources.
+func (ad *AzureBlobDataSource) Close() error {
+	var err error
+	if ad.readers != nil {
+		err = ad.readers.Close()
+	}
+	ad.cancelLock.Lock()
+	if ad.cancel != nil {
+		ad.cancel()
+		ad.cancel = nil
+	}
+	ad.cancelLock.Unlock()
+	return err
+}
+
+// openBlob gets the properties of the blob and opens it for reading
+func (ad *AzureBlobDataSource) openBlob(client *http.Client) error {
+	req, err := http.NewRequestWithContext(ad.ctx, http.MethodHead, ad.blobURL.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "could not create HTTP request")
+	}
+	req.Header.Set("x-ms-version", azureBlobVersion)
+	klog.V(2).Infof("Attempting to get the properties of blob %q", ad.blobURL.Host+ad.blobURL.Path)
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "HTTP request errored")
+	}
+	resp.Body.Close()
+	if want := http.StatusOK; resp.StatusCode != want {
+		return util.NewHTTPStatusError(resp.StatusCode, errors.Errorf("could not get azure blob properties, expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status))
+	}
+	ad.size = parseHTTPHeader(resp)
+	klog.V(1).Infof("Blob size %d, ETag %s", ad.size, resp.Header.Get("ETag"))
+
+	open := func(offset int64) (io.ReadCloser, error) {
+		return getHTTPRange(ad.ctx, client, ad.blobURL, "", "", []string{"x-ms-version: " + azureBlobVersion}, resp.Header, offset)
+	}
+	if cache := GetChunkCache(); cache != nil && ad.size > 0 {
+		key := strings.Join([]string{"azure-blob", ad.blobURL.Scheme, ad.blobURL.Host, ad.blobURL.EscapedPath(), resp.Header.Get("ETag"), fmt.Sprint(ad.size)}, "\n")
+		// qemu-img reading the blob directly would bypass the cache
+		ad.cached = true
+		ad.blobReader = cache.NewCachingReader(key, nil, open, true)
+		return nil
+	}
+	ad.blobReader = &rangeReader{open: open, size: int64(ad.size)}
+	return nil
+}
+
+// extractAzureBlobServiceContainerAndBlob splits the url of a blob into the url of the blob service, the container and
+// the blob. Path style urls, like the ones of Azurite, start with the account name.
+func extractAzureBlobServiceContainerAndBlob(ep *url.URL, account string) (*url.URL, string, string) {
+	serviceURL := &url.URL{Scheme: ep.Scheme, Host: ep.Host}
+	path := strings.TrimPrefix(ep.Path, "/")
+	if prefix := account + "/"; !strings.HasPrefix(ep.Host, account+".") && strings.HasPrefix(path, prefix) {
+		serviceURL.Path = "/" + account
+		path = strings.TrimPrefix(path, prefix)
+	}
+	container, blob, _ := strings.Cut(path, "/")
+	return serviceURL, container, blob
+}
+
+// readAzureBlobCredentials reads the credentials from the mounted secret, none are returned if dir is empty
+func readAzureBlobCredentials(dir string) (*azureBlobCredentials, error) {
+	creds := &azureBlobCredentials{}
+	if dir == "" {
+		return creds, nil
+	}
+	for key, value := range map[string]*string{
+		azureBlobKeyAccountKey:    &creds.accountKey,
+		azureBlobKeySASToken:      &creds.sasToken,
+		azureBlobKeyTenantID:      &creds.tenantID,
+		azureBlobKeyClientID:      &creds.clientID,
+		azureBlobKeyClientSecret:  &creds.clientSecret,
+		azureBlobKeyAuthorityHost: &creds.authorityHost,
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, key))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "could not read %s from the azure blob secret", key)
+		}
+		*value = strings.TrimSpace(string(data))
+	}
+	return creds, nil
+}
+
+// getAzureBlobSASURL returns the url of the blob with a SAS authorizing to read it
+func getAzureBlobSASURL(ctx context.Context, client *http.Client, ep, serviceURL *url.URL, account, container, blob string, creds *azureBlobCredentials) (*url.URL, error) {
+	blobURL := *ep
+	blobURL.RawQuery = ""
+	start, expiry := azureBlobNow().UTC().Add(-azureBlobClockSkew), azureBlobNow().UTC().Add(azureBlobSASLifetime)
+	switch {
+	case creds.sasToken != "":
+		klog.V(1).Infoln("Using the shared access signature of the azure blob secret")
+		blobURL.RawQuery = strings.TrimPrefix(creds.sasToken, "?")
+	case creds.accountKey != "":
+		klog.V(1).Infoln("Using the shared key of the azure blob secret")
+		query, err := signAzureBlobServiceSAS(creds.accountKey, account, container, blob, start, expiry)
+		if err != nil {
+			return nil, err
+		}
+		blobURL.RawQuery = query.Encode()
+	case creds.tenantID != "" && creds.clientID != "" && creds.clientSecret != "":
+		klog.V(1).Infoln("Using the service principal of the azure blob secret")
+		token, err := getAzureADToken(ctx, client, creds)
+		if err != nil {
+			return nil, err
+		}
+		key, err := getAzureBlobUserDelegationKey(ctx, client, serviceURL, token, start, expiry)
+		if err != nil {
+			return nil, err
+		}
+		query, err := signAzureBlobUserDelegationSAS(key, account, container, blob, start, expiry)
+		if err != nil {
+			return nil, err
+		}
+		blobURL.RawQuery = query.Encode()
+	default:
+		klog.V(1).Infoln("No azure blob credentials, reading the blob anonymously")
+	}
+	return &blobURL, nil
+}
+
+// signAzureBlobServiceSAS returns the query of a read only service SAS of the blob, signed with the account key
+func signAzureBlobServiceSAS(accountKey, account, container, blob string, start, expiry time.Time) (url.Values, error) {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid azure storage account key")
+	}
+	st, se := start.Format(azureBlobTimeFormat), expiry.Format(azureBlobTimeFormat)
+	stringToSign := strings.Join([]string{
+		"r", st, se, azureBlobCanonicalName(account, container, blob),
+		"", "", "https,http", azureBlobVersion, "b", "", "",
+		"", "", "", "", "",
+	}, "\n")
+	return url.Values{
+		"sv":  {azureBlobVersion},
+		"sr":  {"b"},
+		"sp":  {"r"},
+		"st":  {st},
+		"se":  {se},
+		"spr": {"https,http"},
+		"sig": {azureBlobSign(key, stringToSign)},
+	}, nil
+}
+
+// signAzureBlobUserDelegationSAS returns the query of a read only user delegation SAS of the blob
+func signAzureBlobUserDelegationSAS(udk *azureBlobUserDelegationKey, account, container, blob string, start, expiry time.Time) (url.Values, error) {
+	key, err := base64.StdEncoding.DecodeString(udk.Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid azure blob user delegation key")
+	}
+	st, se := start.Format(azureBlobTimeFormat), expiry.Format(azureBlobTimeFormat)
+	stringToSign := strings.Join([]string{
+		"r", st, se, azureBlobCanonicalName(account, container, blob),
+		udk.SignedOid, udk.SignedTid, udk.SignedStart, udk.SignedExpiry, udk.SignedService, udk.SignedVersion,
+		"", "", "", "", "https,http", azureBlobVersion, "b", "", "",
+		"", "", "", "", "",
+	}, "\n")
+	return url.Values{
+		"sv":    {azureBlobVersion},
+		"sr":    {"b"},
+		"sp":    {"r"},
+		"st":    {st},
+		"se":    {se},
+		"spr":   {"https,http"},
+		"skoid": {udk.SignedOid},
+		"sktid": {udk.SignedTid},
+		"skt":   {udk.SignedStart},
+		"ske":   {udk.SignedExpiry},
+		"sks":   {udk.SignedService},
+		"skv":   {udk.SignedVersion},
+		"sig":   {azureBlobSign(key, stringToSign)},
+	}, nil
+}
+
+func azureBlobCanonicalName(account, container, blob string) string {
+	return fmt.Sprintf("/blob/%s/%s/%s", account, container, blob)
+}
+
+func azureBlobSign(key []byte, stringToSign string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// getAzureADToken gets an access token to the storage service with the client credentials of the service principal
+func getAzureADToken(ctx context.Context, client *http.Client, creds *azureBlobCredentials) (string, error) {
+	authority := azureBlobAuthorityHost
+	if creds.authorityHost != "" {
+		authority = strings.TrimSuffix(creds.authorityHost, "/")
+	}
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {creds.clientID},
+		"client_secret": {creds.clientSecret},
+		"scope":         {azureBlobStorageScope},
+	}
+	tokenURL := fmt.Sprintf("%s/%s/oauth2/v2.0/token", authority, url.PathEscape(creds.tenantID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.Wrap(err, "could not create HTTP request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get azure access token")
+	}
+	defer resp.Body.Close()
+	if want := http.StatusOK; resp.StatusCode != want {
+		return "", util.NewHTTPStatusError(resp.StatusCode, errors.Errorf("could not get azure access token, expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status))
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", errors.Wrap(err, "could not decode azure access token")
+	}
+	return token.AccessToken, nil
+}
+
+// getAzureBlobUserDelegationKey gets a key valid from start to expiry to sign user delegation SAS
+func getAzureBlobUserDelegationKey(ctx context.Context, client *http.Client, serviceURL *url.URL, token string, start, expiry time.Time) (*azureBlobUserDelegationKey, error) {
+	keyURL := *serviceURL
+	keyURL.Path += "/"
+	keyURL.RawQuery = "restype=service&comp=userdelegationkey"
+	body := fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?><KeyInfo><Start>%s</Start><Expiry>%s</Expiry></KeyInfo>`,
+		start.Format(azureBlobTimeFormat), expiry.Format(azureBlobTimeFormat))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, keyURL.String(), bytes.NewBufferString(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create HTTP request")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("x-ms-version", azureBlobVersion)
+	req.Header.Set("Content-Type", "application/xml")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get azure blob user delegation key")
+	}
+	defer resp.Body.Close()
+	if want := http.StatusOK; resp.StatusCode != want {
+		return nil, util.NewHTTPStatusError(resp.StatusCode, errors.Errorf("could not get azure blob user delegation key, expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status))
+	}
+	key := &azureBlobUserDelegationKey{}
+	if err := xml.NewDecoder(resp.Body).Decode(key); err != nil {
+		return nil, errors.Wrap(err, "could not decode azure blob user delegation key")
+	}
+	return key, nil
+}
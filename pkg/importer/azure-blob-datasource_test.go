@@ -0,0 +1,208 @@
+package importer
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+const (
+	testAzureAccount    = "devstoreaccount1"
+	testAzureBlobPath   = "/devstoreaccount1/images/disks/disk.img"
+	testAzureAccountKey = "c2VjcmV0LWFjY291bnQta2V5"
+)
+
+var _ = Describe("Azure Blob data source", func() {
+	var (
+		ts          *httptest.Server
+		ds          *AzureBlobDataSource
+		tmpDir      string
+		secretDir   string
+		blobData    []byte
+		blobQueries []url.Values
+		truncate    bool
+		err         error
+	)
+
+	BeforeEach(func() {
+		createNbdkitCurl = image.NewMockNbdkitCurl
+		azureBlobNow = func() time.Time {
+			return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		}
+		blobData = bytes.Repeat([]byte("0123456789abcdef"), 64*1024)
+		blobQueries = nil
+		truncate = false
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost && r.URL.Path == "/tenant/oauth2/v2.0/token":
+				Expect(r.ParseForm()).To(Succeed())
+				Expect(r.PostForm.Get("grant_type")).To(Equal("client_credentials"))
+				Expect(r.PostForm.Get("client_id")).To(Equal("client"))
+				Expect(r.PostForm.Get("client_secret")).To(Equal("secret"))
+				Expect(r.PostForm.Get("scope")).To(Equal(azureBlobStorageScope))
+				fmt.Fprint(w, `{"token_type":"Bearer","access_token":"token"}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/devstoreaccount1/":
+				Expect(r.URL.Query().Get("comp")).To(Equal("userdelegationkey"))
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer token"))
+				fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><UserDelegationKey><SignedOid>oid</SignedOid><SignedTid>tid</SignedTid>`+
+					`<SignedStart>2026-01-02T02:49:05Z</SignedStart><SignedExpiry>2026-01-03T03:04:05Z</SignedExpiry><SignedService>b</SignedService>`+
+					`<SignedVersion>%s</SignedVersion><Value>%s</Value></UserDelegationKey>`, azureBlobVersion, testAzureAccountKey)
+			case r.URL.Path == testAzureBlobPath:
+				blobQueries = append(blobQueries, r.URL.Query())
+				w.Header().Set("ETag", `"0x8D"`)
+				if r.Method == http.MethodGet && truncate {
+					// Close the connection in the middle of the blob
+					truncate = false
+					w.Header().Set("Content-Length", fmt.Sprint(len(blobData)))
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = w.Write(blobData[:len(blobData)/2])
+					return
+				}
+				http.ServeContent(w, r, "disk.img", time.Time{}, bytes.NewReader(blobData))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		tmpDir, err = os.MkdirTemp("", "scratch")
+		Expect(err).NotTo(HaveOccurred())
+		secretDir, err = os.MkdirTemp("", "azure")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		createNbdkitCurl = image.NewNbdkitCurl
+		azureBlobNow = time.Now
+		if ds != nil {
+			ds.Close()
+			ds = nil
+		}
+		ts.Close()
+		os.RemoveAll(tmpDir)
+		os.RemoveAll(secretDir)
+	})
+
+	writeSecret := func(values map[string]string) {
+		for key, value := range values {
+			Expect(os.WriteFile(filepath.Join(secretDir, key), []byte(value), 0600)).To(Succeed())
+		}
+	}
+
+	importBlob := func(credentialDir string) []byte {
+		ds, err = NewAzureBlobDataSource(ts.URL+testAzureBlobPath, testAzureAccount, credentialDir, "")
+		Expect(err).ToNot(HaveOccurred())
+		phase, err := ds.Info()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataFile))
+		target := filepath.Join(tmpDir, "disk.img")
+		phase, err = ds.TransferFile(target, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseResize))
+		data, err := os.ReadFile(target)
+		Expect(err).ToNot(HaveOccurred())
+		return data
+	}
+
+	It("should import a raw blob anonymously", func() {
+		Expect(importBlob("")).To(Equal(blobData))
+		for _, query := range blobQueries {
+			Expect(query).To(BeEmpty())
+		}
+	})
+
+	It("should use the SAS token of the secret", func() {
+		writeSecret(map[string]string{azureBlobKeySASToken: "?sv=2021-08-06&sp=r&sig=abc\n"})
+		Expect(importBlob(secretDir)).To(Equal(blobData))
+		Expect(blobQueries).ToNot(BeEmpty())
+		for _, query := range blobQueries {
+			Expect(query.Get("sig")).To(Equal("abc"))
+		}
+	})
+
+	It("should sign a service SAS with the account key of the secret", func() {
+		writeSecret(map[string]string{azureBlobKeyAccountKey: testAzureAccountKey})
+		Expect(importBlob(secretDir)).To(Equal(blobData))
+		query := blobQueries[0]
+		Expect(query.Get("sr")).To(Equal("b"))
+		Expect(query.Get("sp")).To(Equal("r"))
+		Expect(query.Get("st")).To(Equal("2026-01-02T02:49:05Z"))
+		Expect(query.Get("se")).To(Equal("2026-01-03T03:04:05Z"))
+		stringToSign := "r\n2026-01-02T02:49:05Z\n2026-01-03T03:04:05Z\n/blob/devstoreaccount1/images/disks/disk.img\n\n\nhttps,http\n" +
+			azureBlobVersion + "\nb\n\n\n\n\n\n\n"
+		Expect(query.Get("sig")).To(Equal(testAzureSign(stringToSign)))
+	})
+
+	It("should sign a user delegation SAS with the service principal of the secret", func() {
+		writeSecret(map[string]string{
+			azureBlobKeyTenantID:      "tenant",
+			azureBlobKeyClientID:      "client",
+			azureBlobKeyClientSecret:  "secret",
+			azureBlobKeyAuthorityHost: ts.URL,
+		})
+		Expect(importBlob(secretDir)).To(Equal(blobData))
+		query := blobQueries[0]
+		Expect(query.Get("skoid")).To(Equal("oid"))
+		Expect(query.Get("sktid")).To(Equal("tid"))
+		Expect(query.Get("sks")).To(Equal("b"))
+		stringToSign := "r\n2026-01-02T02:49:05Z\n2026-01-03T03:04:05Z\n/blob/devstoreaccount1/images/disks/disk.img\n" +
+			"oid\ntid\n2026-01-02T02:49:05Z\n2026-01-03T03:04:05Z\nb\n" + azureBlobVersion + "\n\n\n\n\nhttps,http\n" +
+			azureBlobVersion + "\nb\n\n\n\n\n\n\n"
+		Expect(query.Get("sig")).To(Equal(testAzureSign(stringToSign)))
+	})
+
+	It("should resume reading the blob when the connection is lost", func() {
+		truncate = true
+		Expect(importBlob("")).To(Equal(blobData))
+		Expect(blobQueries).To(HaveLen(3))
+	})
+
+	It("should convert qcow2 blobs directly through nbdkit", func() {
+		blobData, err = os.ReadFile(cirrosFilePath)
+		Expect(err).ToNot(HaveOccurred())
+		ds, err = NewAzureBlobDataSource(ts.URL+testAzureBlobPath, testAzureAccount, "", "")
+		Expect(err).ToNot(HaveOccurred())
+		phase, err := ds.Info()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		Expect(ds.GetURL().String()).To(Equal("nbd+unix:///?socket=" + nbdkitSocket))
+	})
+
+	It("should fail if the blob does not exist", func() {
+		_, err = NewAzureBlobDataSource(ts.URL+"/devstoreaccount1/images/missing.img", testAzureAccount, "", "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("404"))
+	})
+
+	DescribeTable("should extract the service, container and blob of", func(endpoint, service, container, blob string) {
+		ep, err := url.Parse(endpoint)
+		Expect(err).ToNot(HaveOccurred())
+		serviceURL, c, b := extractAzureBlobServiceContainerAndBlob(ep, "account")
+		Expect(serviceURL.String()).To(Equal(service))
+		Expect(c).To(Equal(container))
+		Expect(b).To(Equal(blob))
+	},
+		Entry("a virtual host url", "https://account.blob.core.windows.net/images/disk.img", "https://account.blob.core.windows.net", "images", "disk.img"),
+		Entry("a virtual host url with directories", "https://account.blob.core.windows.net/images/a/b/disk.img", "https://account.blob.core.windows.net", "images", "a/b/disk.img"),
+		Entry("a path style url", "http://azurite:10000/account/images/disk.img", "http://azurite:10000/account", "images", "disk.img"),
+		Entry("a url without blob", "https://account.blob.core.windows.net/images", "https://account.blob.core.windows.net", "images", ""),
+	)
+})
+
+func testAzureSign(stringToSign string) string {
+	key, _ := base64.StdEncoding.DecodeString(testAzureAccountKey)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,238 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+// backupChunkSize is the size of the uncompressed chunks of a backup. Every chunk is a separate object, so the size
+// of a backup is not limited by the number of parts of a multipart upload
+const backupChunkSize = 64 << 20
+
+// S3UploadClient is the interface to the S3 client used to upload backups.
+type S3UploadClient interface {
+	PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error)
+	DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error)
+}
+
+// may be overridden in tests
+var newUploadClientFunc = getS3UploadClient
+
+// BackupStore stores the objects of a backup in object storage
+type BackupStore interface {
+	// ManifestName is the name of the manifest object, the names of the other objects are relative to its directory
+	ManifestName() string
+	// Put writes the object name
+	Put(name string, data []byte) error
+	// Delete removes the object name
+	Delete(name string) error
+	// Close releases the client of the store
+	Close() error
+}
+
+// WriteBackup writes the content of src to dst as gzip compressed chunk objects and the manifest listing them, the
+// layout read by the CDIBackupDataSource. The manifest is written last so an incomplete backup can't be restored,
+// and the chunks written so far are deleted on failure
+func WriteBackup(dst BackupStore, src io.Reader) error {
+	manifest := &CDIBackupManifest{
+		Version:     cdiBackupManifestVersion,
+		Compression: cdiBackupCompressionGzip,
+	}
+	buf := make([]byte, backupChunkSize)
+	compressed := &bytes.Buffer{}
+	for {
+		n, err := io.ReadFull(src, buf)
+		if n > 0 {
+			chunk, putErr := putBackupChunk(dst, len(manifest.Chunks), buf[:n], compressed)
+			if putErr != nil {
+				return abortBackup(dst, manifest, putErr)
+			}
+			manifest.Chunks = append(manifest.Chunks, *chunk)
+			manifest.Size += chunk.Size
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return abortBackup(dst, manifest, errors.Wrap(err, "unable to read backup"))
+		}
+	}
+	if len(manifest.Chunks) == 0 {
+		return errors.New("no data to back up")
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return abortBackup(dst, manifest, errors.Wrap(err, "unable to encode backup manifest"))
+	}
+	if err := dst.Put(dst.ManifestName(), data); err != nil {
+		return abortBackup(dst, manifest, errors.Wrap(err, "unable to write backup manifest"))
+	}
+	klog.V(1).Infof("Backed up %d bytes in %d chunks", manifest.Size, len(manifest.Chunks))
+	return nil
+}
+
+// putBackupChunk compresses data and writes it as the chunk object index of the backup
+func putBackupChunk(dst BackupStore, index int, data []byte, compressed *bytes.Buffer) (*CDIBackupChunk, error) {
+	digest := sha256.Sum256(data)
+	chunk := &CDIBackupChunk{
+		Name:   fmt.Sprintf("%s.chunks/%08d", dst.ManifestName(), index),
+		Size:   int64(len(data)),
+		SHA256: hex.EncodeToString(digest[:]),
+	}
+
+	compressed.Reset()
+	gz := gzip.NewWriter(compressed)
+	if _, err := gz.Write(data); err != nil {
+		return nil, errors.Wrapf(err, "unable to compress backup chunk %q", chunk.Name)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrapf(err, "unable to compress backup chunk %q", chunk.Name)
+	}
+	if err := dst.Put(chunk.Name, compressed.Bytes()); err != nil {
+		return nil, errors.Wrapf(err, "unable to write backup chunk %q", chunk.Name)
+	}
+	klog.V(3).Infof("Wrote backup chunk %s, %d bytes compressed to %d", chunk.Name, chunk.Size, compressed.Len())
+	return chunk, nil
+}
+
+func abortBackup(dst BackupStore, manifest *CDIBackupManifest, err error) error {
+	for _, chunk := range manifest.Chunks {
+		if deleteErr := dst.Delete(chunk.Name); deleteErr != nil {
+			klog.Errorf("Unable to delete backup chunk %s: %v", chunk.Name, deleteErr)
+		}
+	}
+	return err
+}
+
+// S3BackupStore stores a backup in an S3 bucket
+type S3BackupStore struct {
+	client   S3UploadClient
+	bucket   string
+	dir      string
+	manifest string
+}
+
+// NewS3BackupStore creates a new instance of the S3BackupStore from the url of the backup manifest
+func NewS3BackupStore(endpoint, accessKey, secKey, certDir string, opts *S3Options) (*S3BackupStore, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	bucket, object := extractBucketAndObject(strings.Trim(ep.Path, "/"))
+	if bucket == "" || object == "" {
+		return nil, errors.Errorf("endpoint %q has no bucket or object", endpoint)
+	}
+	svc, err := newUploadClientFunc(ep.Host, accessKey, secKey, certDir, ep.Scheme, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not build s3 client for %q", ep.Host)
+	}
+	return &S3BackupStore{
+		client:   svc,
+		bucket:   bucket,
+		dir:      path.Dir(object),
+		manifest: path.Base(object),
+	}, nil
+}
+
+// ManifestName returns the name of the manifest object
+func (s *S3BackupStore) ManifestName() string {
+	return s.manifest
+}
+
+// Put uploads the object name
+func (s *S3BackupStore) Put(name string, data []byte) error {
+	_, err := s.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path.Join(s.dir, name)),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+// Delete deletes the object name
+func (s *S3BackupStore) Delete(name string) error {
+	_, err := s.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(path.Join(s.dir, name)),
+	})
+	return err
+}
+
+// Close does nothing, the S3 client holds no resources
+func (s *S3BackupStore) Close() error {
+	return nil
+}
+
+func getS3UploadClient(endpoint, accessKey, secKey string, certDir string, urlScheme string, opts *S3Options) (S3UploadClient, error) {
+	return newS3Service(endpoint, accessKey, secKey, certDir, urlScheme, opts)
+}
+
+// GCSBackupStore stores a backup in a GCS bucket
+type GCSBackupStore struct {
+	client   *storage.Client
+	bucket   *storage.BucketHandle
+	dir      string
+	manifest string
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// NewGCSBackupStore creates a new instance of the GCSBackupStore from the url of the backup manifest
+func NewGCSBackupStore(endpoint, keyFile string, opts *GCSOptions) (*GCSBackupStore, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client, bucket, object, err := newGcsBackupClient(ctx, endpoint, keyFile, opts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &GCSBackupStore{
+		client:   client,
+		bucket:   client.Bucket(bucket),
+		dir:      path.Dir(object),
+		manifest: path.Base(object),
+		ctx:      ctx,
+		cancel:   cancel,
+	}, nil
+}
+
+// ManifestName returns the name of the manifest object
+func (s *GCSBackupStore) ManifestName() string {
+	return s.manifest
+}
+
+// Put uploads the object name
+func (s *GCSBackupStore) Put(name string, data []byte) error {
+	w := s.bucket.Object(path.Join(s.dir, name)).NewWriter(s.ctx)
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// Delete deletes the object name
+func (s *GCSBackupStore) Delete(name string) error {
+	return s.bucket.Object(path.Join(s.dir, name)).Delete(s.ctx)
+}
+
+// Close closes the GCS client
+func (s *GCSBackupStore) Close() error {
+	defer s.cancel()
+	return s.client.Close()
+}
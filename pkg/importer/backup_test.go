@@ -0,0 +1,139 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Backup writer", func() {
+	const manifestKey = "bucket/dir/backup.json"
+
+	var (
+		mockClient *MockObjectStoreS3Client
+		endpoint   string
+	)
+
+	BeforeEach(func() {
+		mockClient = &MockObjectStoreS3Client{objects: map[string][]byte{}}
+		newUploadClientFunc = func(ep, accessKey, secKey string, certDir string, urlScheme string, opts *S3Options) (S3UploadClient, error) {
+			endpoint = ep
+			return mockClient, nil
+		}
+	})
+
+	AfterEach(func() {
+		newUploadClientFunc = getS3UploadClient
+	})
+
+	getManifest := func() *CDIBackupManifest {
+		manifest := &CDIBackupManifest{}
+		Expect(json.Unmarshal(mockClient.objects[manifestKey], manifest)).To(Succeed())
+		Expect(validateCDIBackupManifest(manifest)).To(Succeed())
+		return manifest
+	}
+
+	getChunk := func(name string) []byte {
+		gz, err := gzip.NewReader(bytes.NewReader(mockClient.objects["bucket/dir/"+name]))
+		Expect(err).ToNot(HaveOccurred())
+		data, err := io.ReadAll(gz)
+		Expect(err).ToNot(HaveOccurred())
+		return data
+	}
+
+	DescribeTable("NewS3BackupStore should fail with", func(endpoint string) {
+		_, err := NewS3BackupStore(endpoint, "", "", "", nil)
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("an invalid endpoint", "thisisinvalid#$%#ep"),
+		Entry("no object", "http://s3.example.com/bucket"),
+	)
+
+	It("WriteBackup should upload a compressed chunk and the manifest", func() {
+		store, err := NewS3BackupStore("http://s3.example.com/bucket/dir/backup.json", "", "", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(endpoint).To(Equal("s3.example.com"))
+
+		content := strings.Repeat("backup content ", 1024)
+		Expect(WriteBackup(store, strings.NewReader(content))).To(Succeed())
+		manifest := getManifest()
+		Expect(manifest.Compression).To(Equal(cdiBackupCompressionGzip))
+		Expect(manifest.Size).To(Equal(int64(len(content))))
+		Expect(manifest.Chunks).To(HaveLen(1))
+		Expect(manifest.Chunks[0].Name).To(Equal("backup.json.chunks/00000000"))
+		Expect(string(getChunk(manifest.Chunks[0].Name))).To(Equal(content))
+	})
+
+	It("WriteBackup should split the content in chunks", func() {
+		store, err := NewS3BackupStore("http://s3.example.com/bucket/dir/backup.json", "", "", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(WriteBackup(store, bytes.NewReader(make([]byte, backupChunkSize+10)))).To(Succeed())
+		manifest := getManifest()
+		Expect(manifest.Chunks).To(HaveLen(2))
+		Expect(manifest.Chunks[0].Size).To(Equal(int64(backupChunkSize)))
+		Expect(manifest.Chunks[1].Name).To(Equal("backup.json.chunks/00000001"))
+		Expect(manifest.Chunks[1].Size).To(Equal(int64(10)))
+		Expect(getChunk(manifest.Chunks[1].Name)).To(Equal(make([]byte, 10)))
+	})
+
+	It("WriteBackup should fail without content", func() {
+		store, err := NewS3BackupStore("http://s3.example.com/bucket/dir/backup.json", "", "", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(WriteBackup(store, strings.NewReader(""))).To(MatchError(ContainSubstring("no data to back up")))
+		Expect(mockClient.objects).To(BeEmpty())
+	})
+
+	It("WriteBackup should delete the chunks when the manifest can't be written", func() {
+		mockClient.failPut = manifestKey
+		store, err := NewS3BackupStore("http://s3.example.com/bucket/dir/backup.json", "", "", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		err = WriteBackup(store, bytes.NewReader(make([]byte, backupChunkSize+10)))
+		Expect(err).To(MatchError(ContainSubstring("unable to write backup manifest")))
+		Expect(mockClient.deleted).To(ConsistOf("bucket/dir/backup.json.chunks/00000000", "bucket/dir/backup.json.chunks/00000001"))
+		Expect(mockClient.objects).To(BeEmpty())
+	})
+})
+
+// MockObjectStoreS3Client is a mock AWS S3 client storing objects in a map keyed by bucket/key
+type MockObjectStoreS3Client struct {
+	objects map[string][]byte
+	deleted []string
+	// key of the object PutObject fails to write
+	failPut string
+}
+
+func (mc *MockObjectStoreS3Client) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	data, ok := mc.objects[*input.Bucket+"/"+*input.Key]
+	if !ok {
+		return nil, errors.New("NoSuchKey")
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (mc *MockObjectStoreS3Client) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	key := *input.Bucket + "/" + *input.Key
+	if key == mc.failPut {
+		return nil, errors.New("upload failed")
+	}
+	data, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	mc.objects[key] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (mc *MockObjectStoreS3Client) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	key := *input.Bucket + "/" + *input.Key
+	mc.deleted = append(mc.deleted, key)
+	delete(mc.objects, key)
+	return &s3.DeleteObjectOutput{}, nil
+}
@@ -0,0 +1,437 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const (
+	cdiBackupManifestVersion = 1
+	cdiBackupCompressionGzip = "gzip"
+)
+
+// CDIBackupManifest describes a backup stored as chunk objects next to the manifest, the chunks concatenated
+// in order make up the backed up image
+type CDIBackupManifest struct {
+	// Version is the version of the layout, only version 1 is supported
+	Version int `json:"version"`
+	// Size is the total size of the uncompressed chunks
+	Size int64 `json:"size"`
+	// Compression is the compression of the chunk objects, either empty or gzip
+	Compression string `json:"compression,omitempty"`
+	// Chunks are the chunk objects in order
+	Chunks []CDIBackupChunk `json:"chunks"`
+}
+
+// CDIBackupChunk is a chunk object of a CDI backup
+type CDIBackupChunk struct {
+	// Name is the name of the chunk object, relative to the manifest
+	Name string `json:"name"`
+	// Size is the size of the uncompressed chunk
+	Size int64 `json:"size"`
+	// SHA256 is the hex encoded sha256 digest of the uncompressed chunk
+	SHA256 string `json:"sha256"`
+}
+
+// CDIBackupDataSource is the struct containing the information needed to import a chunked CDI backup from an
+// S3 compatible object store or GCS.
+// Sequence of phases:
+// 1. Info -> Transfer
+// 2. Transfer -> Convert
+type CDIBackupDataSource struct {
+	// The backup manifest
+	manifest *CDIBackupManifest
+	// Reader of the concatenated chunks
+	chunkReader *cdiBackupChunkReader
+	// stack of readers
+	readers *FormatReaders
+	// The image file in scratch space.
+	url *url.URL
+}
+
+// backupObjects reads the objects of a backup, their names are relative to the directory of the manifest
+type backupObjects interface {
+	// Open opens the object name for reading
+	Open(name string) (io.ReadCloser, error)
+	// Location returns the bucket and key of the object name
+	Location(name string) string
+	// Close releases the client reading the objects
+	Close() error
+}
+
+// NewCDIBackupDataSource creates a new instance of the CDIBackupDataSource from the url of the backup manifest in an
+// S3 compatible object store
+func NewCDIBackupDataSource(endpoint, accessKey, secKey string, certDir string, opts *S3Options) (*CDIBackupDataSource, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	bucket, object := extractBucketAndObject(strings.Trim(ep.Path, "/"))
+	if bucket == "" || object == "" {
+		return nil, errors.Errorf("endpoint %q has no bucket or manifest object", endpoint)
+	}
+	svc, err := newClientFunc(ep.Host, accessKey, secKey, certDir, ep.Scheme, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not build s3 client for %q", ep.Host)
+	}
+	return newCDIBackupDataSource(&s3BackupObjects{client: svc, bucket: bucket, dir: path.Dir(object)}, path.Base(object))
+}
+
+// NewGCSCDIBackupDataSource creates a new instance of the CDIBackupDataSource from the url of the backup manifest in GCS
+func NewGCSCDIBackupDataSource(endpoint, keyFile string, opts *GCSOptions) (*CDIBackupDataSource, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client, bucket, object, err := newGcsBackupClient(ctx, endpoint, keyFile, opts)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	objects := &gcsBackupObjects{
+		client: client,
+		bucket: bucket,
+		dir:    path.Dir(object),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	return newCDIBackupDataSource(objects, path.Base(object))
+}
+
+func newCDIBackupDataSource(objects backupObjects, manifestName string) (*CDIBackupDataSource, error) {
+	manifest, err := getCDIBackupManifest(objects, manifestName)
+	if err != nil {
+		objects.Close()
+		return nil, err
+	}
+	klog.V(1).Infof("backup %s has %d chunks, %d bytes", objects.Location(manifestName), len(manifest.Chunks), manifest.Size)
+	return &CDIBackupDataSource{
+		manifest: manifest,
+		chunkReader: &cdiBackupChunkReader{
+			objects:  objects,
+			manifest: manifest,
+			cache:    GetChunkCache(),
+		},
+	}, nil
+}
+
+// Info is called to get initial information about the data.
+func (sd *CDIBackupDataSource) Info() (ProcessingPhase, error) {
+	var err error
+	sd.readers, err = NewFormatReaders(sd.chunkReader, uint64(sd.manifest.Size))
+	if err != nil {
+		klog.Errorf("Error creating readers: %v", err)
+		return ProcessingPhaseError, err
+	}
+	if !sd.readers.Convert {
+		// Downloading a raw file, we can write that directly to the target.
+		return ProcessingPhaseTransferDataFile, nil
+	}
+
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// Transfer is called to transfer the data from the source to a temporary location.
+func (sd *CDIBackupDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	file := filepath.Join(path, tempFile)
+	if err := CleanAll(file); err != nil {
+		return ProcessingPhaseError, err
+	}
+
+	size, _ := GetAvailableSpace(path)
+	if size <= int64(0) {
+		//Path provided is invalid.
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+
+	_, _, err := StreamDataToFile(sd.readers.TopReader(), file, preallocation)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	// If streaming succeeded, then parsing the file into URL will also succeed, no need to check error status
+	sd.url, _ = url.Parse(file)
+	return ProcessingPhaseConvert, nil
+}
+
+// TransferFile is called to transfer the data from the source to the passed in file.
+func (sd *CDIBackupDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	if err := CleanAll(fileName); err != nil {
+		return ProcessingPhaseError, err
+	}
+
+	_, _, err := StreamDataToFile(sd.readers.TopReader(), fileName, preallocation)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns the url that the data processor can use when converting the data.
+func (sd *CDIBackupDataSource) GetURL() *url.URL {
+	return sd.url
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (sd *CDIBackupDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close closes any readers or other open resources.
+func (sd *CDIBackupDataSource) Close() error {
+	var err error
+	if sd.readers != nil {
+		err = sd.readers.Close()
+	} else {
+		err = sd.chunkReader.Close()
+	}
+	if closeErr := sd.chunkReader.objects.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func getCDIBackupManifest(objects backupObjects, name string) (*CDIBackupManifest, error) {
+	body, err := objects.Open(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get backup manifest: \"%s\"", objects.Location(name))
+	}
+	defer body.Close()
+
+	manifest := &CDIBackupManifest{}
+	if err := json.NewDecoder(body).Decode(manifest); err != nil {
+		return nil, errors.Wrapf(err, "could not decode backup manifest: \"%s\"", objects.Location(name))
+	}
+	if err := validateCDIBackupManifest(manifest); err != nil {
+		return nil, errors.Wrapf(err, "invalid backup manifest: \"%s\"", objects.Location(name))
+	}
+	return manifest, nil
+}
+
+func validateCDIBackupManifest(manifest *CDIBackupManifest) error {
+	if manifest.Version != cdiBackupManifestVersion {
+		return errors.Errorf("unsupported version %d", manifest.Version)
+	}
+	if manifest.Compression != "" && manifest.Compression != cdiBackupCompressionGzip {
+		return errors.Errorf("unsupported compression %q", manifest.Compression)
+	}
+	if len(manifest.Chunks) == 0 {
+		return errors.New("no chunks")
+	}
+	var size int64
+	for i, chunk := range manifest.Chunks {
+		if chunk.Name == "" || chunk.Size <= 0 {
+			return errors.Errorf("chunk %d has no name or size", i)
+		}
+		if digest, err := hex.DecodeString(chunk.SHA256); err != nil || len(digest) != sha256.Size {
+			return errors.Errorf("chunk %q has an invalid sha256", chunk.Name)
+		}
+		size += chunk.Size
+	}
+	if size != manifest.Size {
+		return errors.Errorf("size %d does not match the %d bytes of the chunks", manifest.Size, size)
+	}
+	return nil
+}
+
+// cdiBackupChunkReader reads the chunks of a backup in order, verifying the size and digest of each chunk. The chunks
+// are read from the chunk cache when it is enabled and has them
+type cdiBackupChunkReader struct {
+	objects  backupObjects
+	manifest *CDIBackupManifest
+	cache    *ChunkCache
+	// index of the next chunk to open
+	next int
+	// current chunk
+	body   io.ReadCloser
+	chunk  io.Reader
+	digest hash.Hash
+	read   int64
+	// content of the current chunk to add to the cache
+	content *bytes.Buffer
+}
+
+func (r *cdiBackupChunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.chunk == nil {
+			if r.next == len(r.manifest.Chunks) {
+				return 0, io.EOF
+			}
+			if err := r.openChunk(); err != nil {
+				return 0, err
+			}
+		}
+		n, err := r.chunk.Read(p)
+		r.read += int64(n)
+		if err == io.EOF {
+			if err := r.closeChunk(); err != nil {
+				return n, err
+			}
+			if n == 0 {
+				continue
+			}
+			return n, nil
+		}
+		return n, err
+	}
+}
+
+// Close closes the current chunk
+func (r *cdiBackupChunkReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body, r.chunk = nil, nil
+	return err
+}
+
+func (r *cdiBackupChunkReader) openChunk() error {
+	chunk := r.manifest.Chunks[r.next]
+	r.digest = sha256.New()
+	r.read = 0
+	r.content = nil
+	if r.cache != nil {
+		if data := r.cache.GetChunk(chunk.SHA256); data != nil {
+			klog.V(3).Infof("Reading backup chunk %s from the chunk cache", chunk.Name)
+			r.body = io.NopCloser(bytes.NewReader(data))
+			r.chunk = io.TeeReader(io.LimitReader(r.body, chunk.Size+1), r.digest)
+			return nil
+		}
+	}
+
+	location := r.objects.Location(chunk.Name)
+	klog.V(3).Infof("Reading backup chunk %s", location)
+	body, err := r.objects.Open(chunk.Name)
+	if err != nil {
+		return errors.Wrapf(err, "could not get backup chunk: \"%s\"", location)
+	}
+	r.body = body
+	var reader io.Reader = body
+	if r.manifest.Compression == cdiBackupCompressionGzip {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			r.Close()
+			return errors.Wrapf(err, "could not decompress backup chunk: \"%s\"", location)
+		}
+		reader = gz
+	}
+	var w io.Writer = r.digest
+	if r.cache != nil {
+		r.content = &bytes.Buffer{}
+		w = io.MultiWriter(r.digest, r.content)
+	}
+	// Reading one byte past the expected size is enough to detect a larger chunk
+	r.chunk = io.TeeReader(io.LimitReader(reader, chunk.Size+1), w)
+	return nil
+}
+
+func (r *cdiBackupChunkReader) closeChunk() error {
+	chunk := r.manifest.Chunks[r.next]
+	r.next++
+	if err := r.Close(); err != nil {
+		return errors.Wrapf(err, "could not close backup chunk %q", chunk.Name)
+	}
+	if r.read != chunk.Size {
+		return errors.Errorf("backup chunk %q has %d bytes, expected %d", chunk.Name, r.read, chunk.Size)
+	}
+	if digest := hex.EncodeToString(r.digest.Sum(nil)); !strings.EqualFold(digest, chunk.SHA256) {
+		return ChecksumMismatchError{errors.Errorf("backup chunk %q has sha256 %s, expected %s", chunk.Name, digest, chunk.SHA256)}
+	}
+	if r.content != nil {
+		r.cache.PutChunk(r.content.Bytes())
+		r.content = nil
+	}
+	return nil
+}
+
+// s3BackupObjects reads the objects of a backup from an S3 bucket
+type s3BackupObjects struct {
+	client S3Client
+	bucket string
+	dir    string
+}
+
+func (o *s3BackupObjects) Open(name string) (io.ReadCloser, error) {
+	out, err := o.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(o.bucket),
+		Key:    aws.String(path.Join(o.dir, name)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+func (o *s3BackupObjects) Location(name string) string {
+	return o.bucket + "/" + path.Join(o.dir, name)
+}
+
+func (o *s3BackupObjects) Close() error {
+	return nil
+}
+
+// gcsBackupObjects reads the objects of a backup from a GCS bucket
+type gcsBackupObjects struct {
+	client *storage.Client
+	bucket string
+	dir    string
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (o *gcsBackupObjects) Open(name string) (io.ReadCloser, error) {
+	return newReaderFunc(o.ctx, o.client, o.bucket, path.Join(o.dir, name))
+}
+
+func (o *gcsBackupObjects) Location(name string) string {
+	return o.bucket + "/" + path.Join(o.dir, name)
+}
+
+func (o *gcsBackupObjects) Close() error {
+	defer o.cancel()
+	return o.client.Close()
+}
+
+// newGcsBackupClient creates the GCS client of the backup manifest at endpoint, and returns its bucket and object
+func newGcsBackupClient(ctx context.Context, endpoint, keyFile string, opts *GCSOptions) (*storage.Client, string, string, error) {
+	ep, err := ParseEndpoint(endpoint)
+	if err != nil {
+		return nil, "", "", errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
+	}
+	var bucket, object, host string
+	var options []option.ClientOption
+	switch ep.Scheme {
+	case gcsScheme:
+		bucket, object = extractGcsBucketAndObject(endpoint)
+	case "http", "https":
+		bucket, object, host = extractGcsBucketObjectAndHost(endpoint)
+		options = append(options, option.WithEndpoint(host))
+	default:
+		return nil, "", "", errors.Errorf("unsupported gcs endpoint %q", endpoint)
+	}
+	if bucket == "" || object == "" {
+		return nil, "", "", errors.Errorf("endpoint %q has no bucket or manifest object", endpoint)
+	}
+	client, err := getGcsClient(ctx, keyFile, opts, options...)
+	if err != nil {
+		return nil, "", "", errors.Wrap(err, "could not build gcs client")
+	}
+	return client, bucket, object, nil
+}
@@ -0,0 +1,209 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"cloud.google.com/go/storage"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+const testManifestEndpoint = "http://s3.example.com/bucket/backups/disk/manifest.json"
+
+var _ = Describe("CDI backup data source", func() {
+	var (
+		objects map[string][]byte
+		sd      *CDIBackupDataSource
+		tmpDir  string
+		err     error
+	)
+
+	BeforeEach(func() {
+		objects = map[string][]byte{}
+		newClientFunc = func(endpoint, accessKey, secKey string, certDir string, urlScheme string, opts *S3Options) (S3Client, error) {
+			return &MockObjectStoreS3Client{objects: objects}, nil
+		}
+		tmpDir, err = os.MkdirTemp("", "scratch")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		newClientFunc = getS3Client
+		if sd != nil {
+			sd.Close()
+			sd = nil
+		}
+		os.RemoveAll(tmpDir)
+	})
+
+	putBackup := func(compression string, chunks ...string) *CDIBackupManifest {
+		manifest := &CDIBackupManifest{Version: 1, Compression: compression}
+		for i, content := range chunks {
+			name := "chunks/" + string(rune('a'+i))
+			digest := sha256.Sum256([]byte(content))
+			manifest.Chunks = append(manifest.Chunks, CDIBackupChunk{
+				Name:   name,
+				Size:   int64(len(content)),
+				SHA256: hex.EncodeToString(digest[:]),
+			})
+			manifest.Size += int64(len(content))
+			data := []byte(content)
+			if compression == cdiBackupCompressionGzip {
+				var buf bytes.Buffer
+				gz := gzip.NewWriter(&buf)
+				_, err := gz.Write(data)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(gz.Close()).To(Succeed())
+				data = buf.Bytes()
+			}
+			objects["bucket/backups/disk/"+name] = data
+		}
+		putManifest(objects, manifest)
+		return manifest
+	}
+
+	importBackup := func() (string, error) {
+		sd, err = NewCDIBackupDataSource(testManifestEndpoint, "", "", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		// Small backups are read entirely while probing the image header
+		phase, err := sd.Info()
+		if err != nil {
+			return "", err
+		}
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataFile))
+		fileName := filepath.Join(tmpDir, "disk.img")
+		if _, err := sd.TransferFile(fileName, false); err != nil {
+			return "", err
+		}
+		content, err := os.ReadFile(fileName)
+		Expect(err).ToNot(HaveOccurred())
+		return string(content), nil
+	}
+
+	DescribeTable("should import the concatenated chunks", func(compression string) {
+		chunks := []string{strings.Repeat("a", 1000), strings.Repeat("b", 10), strings.Repeat("c", 4096)}
+		putBackup(compression, chunks...)
+		content, err := importBackup()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content).To(Equal(strings.Join(chunks, "")))
+	},
+		Entry("uncompressed", ""),
+		Entry("gzip compressed", cdiBackupCompressionGzip),
+	)
+
+	It("should import a backup written by WriteBackup", func() {
+		newUploadClientFunc = func(endpoint, accessKey, secKey string, certDir string, urlScheme string, opts *S3Options) (S3UploadClient, error) {
+			return &MockObjectStoreS3Client{objects: objects}, nil
+		}
+		defer func() {
+			newUploadClientFunc = getS3UploadClient
+		}()
+		// Spans two chunks, the content tells them apart
+		backup := make([]byte, backupChunkSize+4096)
+		for i := range backup {
+			backup[i] = byte(i % 251)
+		}
+		store, err := NewS3BackupStore(testManifestEndpoint, "", "", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(WriteBackup(store, bytes.NewReader(backup))).To(Succeed())
+		Expect(objects).To(HaveKey("bucket/backups/disk/manifest.json.chunks/00000001"))
+
+		content, err := importBackup()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content == string(backup)).To(BeTrue())
+	})
+
+	It("should import a backup from GCS", func() {
+		newReaderFunc = func(ctx context.Context, client *storage.Client, bucket, object string) (io.ReadCloser, error) {
+			data, ok := objects[bucket+"/"+object]
+			if !ok {
+				return nil, storage.ErrObjectNotExist
+			}
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+		defer func() {
+			newReaderFunc = getGcsObjectReader
+		}()
+		chunks := []string{strings.Repeat("a", 1000), strings.Repeat("b", 10)}
+		putBackup(cdiBackupCompressionGzip, chunks...)
+
+		sd, err = NewGCSCDIBackupDataSource("gs://bucket/backups/disk/manifest.json", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = sd.Info()
+		Expect(err).ToNot(HaveOccurred())
+		fileName := filepath.Join(tmpDir, "disk.img")
+		_, err = sd.TransferFile(fileName, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.ReadFile(fileName)).To(BeEquivalentTo(strings.Join(chunks, "")))
+	})
+
+	It("should fail when a chunk does not match its digest", func() {
+		putBackup("", "first chunk", "second chunk")
+		objects["bucket/backups/disk/chunks/b"] = []byte("second chunK")
+		_, err := importBackup()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("backup chunk \"chunks/b\" has sha256"))
+		Expect(GetFailureReason(err)).To(Equal(common.FailureChecksumMismatch))
+	})
+
+	It("should fail when a chunk does not match its size", func() {
+		putBackup("", "first chunk", "second chunk")
+		objects["bucket/backups/disk/chunks/a"] = []byte("first chunk and more")
+		_, err := importBackup()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("backup chunk \"chunks/a\" has 12 bytes, expected 11"))
+	})
+
+	It("should fail when a chunk is missing", func() {
+		putBackup("", "first chunk", "second chunk")
+		delete(objects, "bucket/backups/disk/chunks/b")
+		_, err := importBackup()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("could not get backup chunk: \"bucket/backups/disk/chunks/b\""))
+	})
+
+	It("should fail when the manifest is missing", func() {
+		sd, err = NewCDIBackupDataSource(testManifestEndpoint, "", "", "", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("could not get backup manifest"))
+	})
+
+	It("should fail when the endpoint has no manifest object", func() {
+		sd, err = NewCDIBackupDataSource("http://s3.example.com/bucket", "", "", "", nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("should reject an invalid manifest", func(update func(*CDIBackupManifest), expected string) {
+		manifest := putBackup("", "first chunk", "second chunk")
+		update(manifest)
+		putManifest(objects, manifest)
+		sd, err = NewCDIBackupDataSource(testManifestEndpoint, "", "", "", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(expected))
+	},
+		Entry("with an unknown version", func(m *CDIBackupManifest) { m.Version = 2 }, "unsupported version 2"),
+		Entry("with an unknown compression", func(m *CDIBackupManifest) { m.Compression = "zstd" }, "unsupported compression \"zstd\""),
+		Entry("without chunks", func(m *CDIBackupManifest) { m.Chunks = nil }, "no chunks"),
+		Entry("with an unnamed chunk", func(m *CDIBackupManifest) { m.Chunks[1].Name = "" }, "chunk 1 has no name or size"),
+		Entry("with an invalid digest", func(m *CDIBackupManifest) { m.Chunks[0].SHA256 = "abc" }, "chunk \"chunks/a\" has an invalid sha256"),
+		Entry("with a wrong size", func(m *CDIBackupManifest) { m.Size++ }, "size 24 does not match the 23 bytes of the chunks"),
+	)
+})
+
+func putManifest(objects map[string][]byte, manifest *CDIBackupManifest) {
+	data, err := json.Marshal(manifest)
+	Expect(err).ToNot(HaveOccurred())
+	objects["bucket/backups/disk/manifest.json"] = data
+}
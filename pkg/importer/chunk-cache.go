@@ -0,0 +1,444 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	// chunkCacheChunkSize is the size of the chunks the sources without chunk digests are split into
+	chunkCacheChunkSize = 4 << 20
+	chunkCacheChunksDir = "chunks"
+	chunkCacheIndexDir  = "index"
+)
+
+// ChunkCache is a content addressed store of data chunks shared by the imports of a node or of a namespace. Chunks
+// are stored by the sha256 digest of their content, indexes map the sources that do not provide chunk digests,
+// like HTTP endpoints or registry layers, to the digests of their fixed size chunks.
+type ChunkCache struct {
+	dir string
+	// scope restricts the indexes of the sources whose content can't be verified to the imports of the same scope,
+	// so that the imports of one namespace can't serve their content to the others
+	scope string
+}
+
+// chunkIndex lists the digests of the fixed size chunks of a source
+type chunkIndex struct {
+	Size   int64    `json:"size"`
+	Chunks []string `json:"chunks"`
+}
+
+// GetChunkCache returns the import chunk cache, or nil if it is not enabled
+func GetChunkCache() *ChunkCache {
+	dir, _ := util.ParseEnvVar(common.ImporterChunkCacheDirVar, false)
+	if dir == "" {
+		return nil
+	}
+	cache, err := NewChunkCache(dir)
+	if err != nil {
+		klog.Warningf("Unable to use the chunk cache, the import will not be cached: %v", err)
+		return nil
+	}
+	cache.scope, _ = util.ParseEnvVar(common.ImporterChunkCacheScope, false)
+	return cache
+}
+
+// PruneChunkCache prunes the import chunk cache to its configured limit, if any
+func PruneChunkCache() {
+	cache := GetChunkCache()
+	if cache == nil {
+		return
+	}
+	limitStr, _ := util.ParseEnvVar(common.ImporterChunkCacheLimit, false)
+	if limitStr == "" {
+		return
+	}
+	limit, err := strconv.ParseInt(limitStr, 10, 64)
+	if err != nil {
+		klog.Warningf("Invalid chunk cache limit %q: %v", limitStr, err)
+		return
+	}
+	if err := cache.Prune(limit); err != nil {
+		klog.Warningf("Unable to prune the chunk cache: %v", err)
+	}
+}
+
+// NewChunkCache creates a ChunkCache storing its chunks and indexes in dir
+func NewChunkCache(dir string) (*ChunkCache, error) {
+	for _, sub := range []string{chunkCacheChunksDir, chunkCacheIndexDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0750); err != nil {
+			return nil, errors.Wrapf(err, "unable to create chunk cache directory %s", dir)
+		}
+	}
+	return &ChunkCache{dir: dir}, nil
+}
+
+// GetChunk returns the content of the chunk with the passed sha256 digest, or nil if it is not cached
+func (c *ChunkCache) GetChunk(digest string) []byte {
+	digest = strings.ToLower(digest)
+	if !isSHA256Digest(digest) {
+		return nil
+	}
+	path := c.chunkPath(digest)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != digest {
+		klog.Warningf("Removing corrupted chunk %s from the chunk cache", digest)
+		_ = os.Remove(path)
+		return nil
+	}
+	touchChunk(path)
+	return data
+}
+
+// PutChunk stores data in the cache and returns its sha256 digest. The cache is best effort, failures are only logged
+func (c *ChunkCache) PutChunk(data []byte) string {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	path := c.chunkPath(digest)
+	if _, err := os.Stat(path); err == nil {
+		touchChunk(path)
+		return digest
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		klog.Warningf("Unable to add chunk %s to the chunk cache: %v", digest, err)
+	}
+	return digest
+}
+
+// Prune removes the least recently used chunks until the chunks use at most limit bytes. Indexes are kept, the
+// chunks they reference that were removed are downloaded again
+func (c *ChunkCache) Prune(limit int64) error {
+	type chunkFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var chunks []chunkFile
+	var total int64
+	err := filepath.WalkDir(filepath.Join(c.dir, chunkCacheChunksDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			// Removed by a concurrent prune
+			return nil
+		}
+		chunks = append(chunks, chunkFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if total <= limit {
+		return nil
+	}
+	sort.Slice(chunks, func(i, j int) bool {
+		return chunks[i].modTime.Before(chunks[j].modTime)
+	})
+	removed := 0
+	for _, chunk := range chunks {
+		if total <= limit {
+			break
+		}
+		if err := os.Remove(chunk.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		total -= chunk.size
+		removed++
+	}
+	klog.Infof("Pruned %d chunks from the chunk cache", removed)
+	return nil
+}
+
+func (c *ChunkCache) chunkPath(digest string) string {
+	return filepath.Join(c.dir, chunkCacheChunksDir, digest[:2], digest)
+}
+
+func (c *ChunkCache) indexPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, chunkCacheIndexDir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *ChunkCache) getIndex(key string) *chunkIndex {
+	data, err := os.ReadFile(c.indexPath(key))
+	if err != nil {
+		return nil
+	}
+	index := &chunkIndex{}
+	if err := json.Unmarshal(data, index); err != nil {
+		klog.Warningf("Ignoring invalid chunk cache index: %v", err)
+		return nil
+	}
+	return index
+}
+
+func (c *ChunkCache) removeIndex(key string) {
+	if err := os.Remove(c.indexPath(key)); err != nil && !os.IsNotExist(err) {
+		klog.Warningf("Unable to remove index from the chunk cache: %v", err)
+	}
+}
+
+func (c *ChunkCache) putIndex(key string, index *chunkIndex) {
+	data, err := json.Marshal(index)
+	if err == nil {
+		err = writeFileAtomic(c.indexPath(key), data)
+	}
+	if err != nil {
+		klog.Warningf("Unable to add index to the chunk cache: %v", err)
+	}
+}
+
+// hasChunks returns true if all the chunks of the index are cached
+func (c *ChunkCache) hasChunks(index *chunkIndex) bool {
+	for _, digest := range index.Chunks {
+		if !isSHA256Digest(digest) {
+			return false
+		}
+		if _, err := os.Stat(c.chunkPath(digest)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// NewCachingReader returns a reader of the source identified by key, the chunks already read by previous imports of
+// the same key and scope are read from the cache and the others are added to it. src is the source opened at offset
+// 0, it may be nil. open opens the source at offset when ranged is true, and at offset 0 otherwise.
+func (c *ChunkCache) NewCachingReader(key string, src io.ReadCloser, open func(offset int64) (io.ReadCloser, error), ranged bool) io.ReadCloser {
+	if c.scope != "" {
+		key = c.scope + "\n" + key
+	}
+	return c.newCachingReader(key, src, open, ranged, "")
+}
+
+// NewVerifiedCachingReader returns a reader of the content addressed source with the expected digest, opened at
+// offset 0 by open. The content is verified against the digest and only indexed once verified, so its index is
+// shared by all the scopes of the cache.
+func (c *ChunkCache) NewVerifiedCachingReader(expected digest.Digest, open func(offset int64) (io.ReadCloser, error)) (io.ReadCloser, error) {
+	if err := expected.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid digest %q", expected)
+	}
+	return c.newCachingReader("blob:"+expected.String(), nil, open, false, expected), nil
+}
+
+func (c *ChunkCache) newCachingReader(key string, src io.ReadCloser, open func(offset int64) (io.ReadCloser, error), ranged bool, expected digest.Digest) io.ReadCloser {
+	r := &cachingReader{
+		cache:    c,
+		key:      key,
+		src:      src,
+		open:     open,
+		ranged:   ranged,
+		buf:      make([]byte, chunkCacheChunkSize),
+		expected: expected,
+	}
+	if expected != "" {
+		r.verifier = expected.Verifier()
+	}
+	if index := c.getIndex(key); index != nil {
+		switch {
+		case c.hasChunks(index):
+			// Nothing to download
+			r.index = index
+			r.closeSource()
+		case ranged:
+			// Only the missing chunks are downloaded
+			r.index = index
+		}
+	}
+	return r
+}
+
+// cachingReader reads a source by chunks, from the cache when the chunk digest is known and from the source otherwise
+type cachingReader struct {
+	cache  *ChunkCache
+	key    string
+	index  *chunkIndex
+	src    io.ReadCloser
+	open   func(offset int64) (io.ReadCloser, error)
+	ranged bool
+	// offset of the source reader
+	srcOffset int64
+	// offset of the next chunk
+	offset  int64
+	digests []string
+	buf     []byte
+	chunk   []byte
+	eof     bool
+	// bytes read from the cache and from the source
+	cached     int64
+	downloaded int64
+	// verifier of the expected digest of the content, if known
+	expected digest.Digest
+	verifier digest.Verifier
+	err      error
+}
+
+func (r *cachingReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.eof {
+			if r.err != nil {
+				return 0, r.err
+			}
+			return 0, io.EOF
+		}
+		if err := r.nextChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+func (r *cachingReader) Close() error {
+	return r.closeSource()
+}
+
+func (r *cachingReader) nextChunk() error {
+	i := len(r.digests)
+	if r.index != nil && i < len(r.index.Chunks) {
+		if data := r.cache.GetChunk(r.index.Chunks[i]); data != nil {
+			if r.ranged {
+				r.closeSource()
+			}
+			r.cached += int64(len(data))
+			r.addChunk(data, r.index.Chunks[i], r.offset+int64(len(data)) >= r.index.Size)
+			return nil
+		}
+	}
+
+	if err := r.seekSource(); err != nil {
+		return err
+	}
+	n, err := io.ReadFull(r.src, r.buf)
+	r.srcOffset += int64(n)
+	eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	if err != nil && !eof {
+		return err
+	}
+	r.downloaded += int64(n)
+	if n == 0 {
+		r.addChunk(nil, "", true)
+		return nil
+	}
+	r.addChunk(r.buf[:n], r.cache.PutChunk(r.buf[:n]), eof)
+	return nil
+}
+
+func (r *cachingReader) addChunk(data []byte, digest string, eof bool) {
+	r.chunk = data
+	r.offset += int64(len(data))
+	if digest != "" {
+		r.digests = append(r.digests, digest)
+	}
+	if r.verifier != nil {
+		// Writes to a verifier never fail
+		_, _ = r.verifier.Write(data)
+	}
+	if eof {
+		r.eof = true
+		klog.Infof("Read %d bytes from the chunk cache and %d bytes from the source", r.cached, r.downloaded)
+		if r.verifier != nil && !r.verifier.Verified() {
+			// The next import downloads the source again instead of reading the same content from the cache
+			r.cache.removeIndex(r.key)
+			r.err = ChecksumMismatchError{errors.Errorf("content does not match digest %s", r.expected)}
+			return
+		}
+		r.cache.putIndex(r.key, &chunkIndex{Size: r.offset, Chunks: r.digests})
+	}
+}
+
+// seekSource makes sure the source is open at the offset of the next chunk
+func (r *cachingReader) seekSource() error {
+	if r.src != nil && r.srcOffset == r.offset {
+		return nil
+	}
+	if r.src != nil && (r.ranged || r.srcOffset > r.offset) {
+		r.closeSource()
+	}
+	if r.src == nil {
+		offset := int64(0)
+		if r.ranged {
+			offset = r.offset
+		}
+		src, err := r.open(offset)
+		if err != nil {
+			return errors.Wrap(err, "unable to open the source")
+		}
+		r.src, r.srcOffset = src, offset
+	}
+	if skip := r.offset - r.srcOffset; skip > 0 {
+		if _, err := io.CopyN(io.Discard, r.src, skip); err != nil {
+			return errors.Wrap(err, "unable to skip the cached data")
+		}
+		r.srcOffset = r.offset
+	}
+	return nil
+}
+
+func (r *cachingReader) closeSource() error {
+	if r.src == nil {
+		return nil
+	}
+	err := r.src.Close()
+	r.src = nil
+	return err
+}
+
+func isSHA256Digest(digest string) bool {
+	b, err := hex.DecodeString(digest)
+	return err == nil && len(b) == sha256.Size
+}
+
+func touchChunk(path string) {
+	// The modification time tracks the last use of the chunk for pruning
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
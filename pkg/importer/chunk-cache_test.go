@@ -0,0 +1,306 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+)
+
+var _ = Describe("Chunk cache", func() {
+	var (
+		cacheDir string
+		cache    *ChunkCache
+		content  []byte
+		// offsets the source was opened at
+		opened []int64
+	)
+
+	BeforeEach(func() {
+		var err error
+		cacheDir, err = os.MkdirTemp("", "chunk-cache")
+		Expect(err).ToNot(HaveOccurred())
+		cache, err = NewChunkCache(cacheDir)
+		Expect(err).ToNot(HaveOccurred())
+		content = make([]byte, 2*chunkCacheChunkSize+12345)
+		for i := range content {
+			content[i] = byte(i % 251)
+		}
+		opened = nil
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(cacheDir)
+	})
+
+	open := func(offset int64) (io.ReadCloser, error) {
+		opened = append(opened, offset)
+		return io.NopCloser(bytes.NewReader(content[offset:])), nil
+	}
+
+	failOpen := func(offset int64) (io.ReadCloser, error) {
+		return nil, errors.New("source unavailable")
+	}
+
+	readAll := func(r io.ReadCloser) []byte {
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		Expect(err).ToNot(HaveOccurred())
+		return data
+	}
+
+	chunkDigest := func(i int) string {
+		end := min((i+1)*chunkCacheChunkSize, len(content))
+		sum := sha256.Sum256(content[i*chunkCacheChunkSize : end])
+		return hex.EncodeToString(sum[:])
+	}
+
+	It("should store and verify chunks", func() {
+		digest := cache.PutChunk([]byte("chunk"))
+		Expect(cache.GetChunk(digest)).To(Equal([]byte("chunk")))
+		Expect(cache.GetChunk(strings.ToUpper(digest))).To(Equal([]byte("chunk")))
+		Expect(cache.GetChunk("invalid")).To(BeNil())
+
+		By("Removing corrupted chunks")
+		Expect(os.WriteFile(cache.chunkPath(digest), []byte("corrupted"), 0600)).To(Succeed())
+		Expect(cache.GetChunk(digest)).To(BeNil())
+		Expect(cache.chunkPath(digest)).ToNot(BeAnExistingFile())
+	})
+
+	It("should read the source once", func() {
+		Expect(readAll(cache.NewCachingReader("key", nil, open, false))).To(Equal(content))
+		Expect(opened).To(Equal([]int64{0}))
+
+		Expect(readAll(cache.NewCachingReader("key", nil, failOpen, false))).To(Equal(content))
+		Expect(readAll(cache.NewCachingReader("key", nil, failOpen, true))).To(Equal(content))
+	})
+
+	It("should close the opened source when all the chunks are cached", func() {
+		Expect(readAll(cache.NewCachingReader("key", nil, open, true))).To(Equal(content))
+		src := &closeRecorder{Reader: bytes.NewReader(content)}
+		r := cache.NewCachingReader("key", src, failOpen, true)
+		Expect(src.closed).To(BeTrue())
+		Expect(readAll(r)).To(Equal(content))
+	})
+
+	It("should only download the missing chunks of ranged sources", func() {
+		Expect(readAll(cache.NewCachingReader("key", nil, open, true))).To(Equal(content))
+		Expect(os.Remove(cache.chunkPath(chunkDigest(1)))).To(Succeed())
+
+		opened = nil
+		Expect(readAll(cache.NewCachingReader("key", nil, open, true))).To(Equal(content))
+		Expect(opened).To(Equal([]int64{chunkCacheChunkSize}))
+		Expect(cache.chunkPath(chunkDigest(1))).To(BeAnExistingFile())
+	})
+
+	It("should read sources without ranges from the start when chunks are missing", func() {
+		Expect(readAll(cache.NewCachingReader("key", nil, open, false))).To(Equal(content))
+		Expect(os.Remove(cache.chunkPath(chunkDigest(2)))).To(Succeed())
+
+		opened = nil
+		Expect(readAll(cache.NewCachingReader("key", nil, open, false))).To(Equal(content))
+		Expect(opened).To(Equal([]int64{0}))
+	})
+
+	It("should handle sources of a multiple of the chunk size", func() {
+		content = content[:2*chunkCacheChunkSize]
+		Expect(readAll(cache.NewCachingReader("key", nil, open, false))).To(Equal(content))
+		Expect(readAll(cache.NewCachingReader("key", nil, failOpen, false))).To(Equal(content))
+	})
+
+	It("should share chunks between keys", func() {
+		Expect(readAll(cache.NewCachingReader("key", nil, open, false))).To(Equal(content))
+		Expect(readAll(cache.NewCachingReader("other", nil, open, false))).To(Equal(content))
+		entries, err := os.ReadDir(filepath.Join(cacheDir, chunkCacheIndexDir))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+		Expect(cacheSize(cacheDir)).To(Equal(int64(len(content))))
+	})
+
+	It("should only share the indexes of unverified sources within a scope", func() {
+		cache.scope = "ns1"
+		Expect(readAll(cache.NewCachingReader("key", nil, open, false))).To(Equal(content))
+		Expect(readAll(cache.NewCachingReader("key", nil, failOpen, false))).To(Equal(content))
+
+		cache.scope = "ns2"
+		_, err := io.ReadAll(cache.NewCachingReader("key", nil, failOpen, false))
+		Expect(err).To(MatchError(ContainSubstring("source unavailable")))
+		// The chunks themselves are still shared
+		opened = nil
+		Expect(readAll(cache.NewCachingReader("key", nil, open, false))).To(Equal(content))
+		Expect(cacheSize(cacheDir)).To(Equal(int64(len(content))))
+	})
+
+	It("should share the indexes of verified sources between scopes", func() {
+		cache.scope = "ns1"
+		r, err := cache.NewVerifiedCachingReader(digest.FromBytes(content), open)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readAll(r)).To(Equal(content))
+
+		cache.scope = "ns2"
+		r, err = cache.NewVerifiedCachingReader(digest.FromBytes(content), failOpen)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readAll(r)).To(Equal(content))
+	})
+
+	It("should not index content that does not match its digest", func() {
+		expected := digest.FromString("other content")
+		r, err := cache.NewVerifiedCachingReader(expected, open)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = io.ReadAll(r)
+		Expect(err).To(MatchError(ContainSubstring("content does not match digest " + expected.String())))
+		Expect(errors.As(err, &ChecksumMismatchError{})).To(BeTrue())
+		Expect(cache.getIndex("blob:" + expected.String())).To(BeNil())
+
+		By("Removing an index of content that does not match its digest")
+		cache.putIndex("blob:"+expected.String(), &chunkIndex{
+			Size:   int64(len(content)),
+			Chunks: []string{chunkDigest(0), chunkDigest(1), chunkDigest(2)},
+		})
+		r, err = cache.NewVerifiedCachingReader(expected, failOpen)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = io.ReadAll(r)
+		Expect(errors.As(err, &ChecksumMismatchError{})).To(BeTrue())
+		Expect(cache.getIndex("blob:" + expected.String())).To(BeNil())
+	})
+
+	It("should reject an invalid digest", func() {
+		_, err := cache.NewVerifiedCachingReader("sha256:invalid", open)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should prune the least recently used chunks", func() {
+		Expect(readAll(cache.NewCachingReader("key", nil, open, false))).To(Equal(content))
+		old := time.Now().Add(-time.Hour)
+		Expect(os.Chtimes(cache.chunkPath(chunkDigest(0)), old, old)).To(Succeed())
+
+		Expect(cache.Prune(int64(len(content)))).To(Succeed())
+		Expect(cacheSize(cacheDir)).To(Equal(int64(len(content))))
+		Expect(cache.Prune(int64(len(content) - 1))).To(Succeed())
+		Expect(cache.chunkPath(chunkDigest(0))).ToNot(BeAnExistingFile())
+		Expect(cache.chunkPath(chunkDigest(1))).To(BeAnExistingFile())
+		Expect(cache.chunkPath(chunkDigest(2))).To(BeAnExistingFile())
+	})
+
+	Context("with an http source", func() {
+		var (
+			ts     *httptest.Server
+			served atomic.Int64
+		)
+
+		BeforeEach(func() {
+			served.Store(0)
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("ETag", `"v1"`)
+				cw := &countingResponseWriter{ResponseWriter: w, count: &served}
+				http.ServeContent(cw, r, "disk.img", time.Unix(0, 0), bytes.NewReader(content))
+			}))
+			os.Setenv(common.ImporterChunkCacheDirVar, cacheDir)
+		})
+
+		AfterEach(func() {
+			os.Unsetenv(common.ImporterChunkCacheDirVar)
+			ts.Close()
+		})
+
+		readEndpoint := func() []byte {
+			ep, err := url.Parse(ts.URL + "/disk.img")
+			Expect(err).ToNot(HaveOccurred())
+			reader, total, brokenForQemuImg, err := createHTTPReader(context.Background(), ep, "", "", "", nil, nil, cdiv1.DataVolumeKubeVirt)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(total).To(Equal(uint64(len(content))))
+			Expect(brokenForQemuImg).To(BeTrue())
+			return readAll(reader)
+		}
+
+		It("should not download cached chunks again", func() {
+			Expect(readEndpoint()).To(Equal(content))
+			Expect(served.Load()).To(BeNumerically(">=", len(content)))
+			Expect(os.Remove(cache.chunkPath(chunkDigest(2)))).To(Succeed())
+
+			served.Store(0)
+			Expect(readEndpoint()).To(Equal(content))
+			// The first response is closed as soon as the first chunk is read from the cache
+			Expect(served.Load()).To(BeNumerically("<", len(content)))
+		})
+	})
+
+	It("should read the chunks of a CDI backup from the cache", func() {
+		os.Setenv(common.ImporterChunkCacheDirVar, cacheDir)
+		defer os.Unsetenv(common.ImporterChunkCacheDirVar)
+		objects := map[string][]byte{}
+		newClientFunc = func(endpoint, accessKey, secKey string, certDir string, urlScheme string, opts *S3Options) (S3Client, error) {
+			return &MockObjectStoreS3Client{objects: objects}, nil
+		}
+		defer func() { newClientFunc = getS3Client }()
+		digest := sha256.Sum256(content)
+		manifest := &CDIBackupManifest{
+			Version: 1,
+			Size:    int64(len(content)),
+			Chunks:  []CDIBackupChunk{{Name: "chunk", Size: int64(len(content)), SHA256: hex.EncodeToString(digest[:])}},
+		}
+		putManifest(objects, manifest)
+		objects["bucket/backups/disk/chunk"] = content
+
+		for i := 0; i < 2; i++ {
+			sd, err := NewCDIBackupDataSource(testManifestEndpoint, "", "", "", nil)
+			Expect(err).ToNot(HaveOccurred())
+			data, err := io.ReadAll(sd.chunkReader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(Equal(content))
+			Expect(sd.Close()).To(Succeed())
+			delete(objects, "bucket/backups/disk/chunk")
+		}
+	})
+})
+
+func cacheSize(dir string) int64 {
+	var size int64
+	err := filepath.Walk(filepath.Join(dir, chunkCacheChunksDir), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return err
+	})
+	Expect(err).ToNot(HaveOccurred())
+	return size
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+type countingResponseWriter struct {
+	http.ResponseWriter
+	count *atomic.Int64
+}
+
+func (w *countingResponseWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.count.Add(int64(n))
+	return n, err
+}
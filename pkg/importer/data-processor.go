@@ -53,6 +53,10 @@ const (
 	ProcessingPhaseConvert ProcessingPhase = "Convert"
 	// ProcessingPhaseResize the disk image, this is only needed when the target contains a file system (block device do not need a resize)
 	ProcessingPhaseResize ProcessingPhase = "Resize"
+	// ProcessingPhaseCustomize is the phase in which the customization recipe is run against the target RAW disk image.
+	ProcessingPhaseCustomize ProcessingPhase = "Customize"
+	// ProcessingPhaseSparsify is the phase in which the blocks of zeroes of the resized and customized target RAW disk image are deallocated.
+	ProcessingPhaseSparsify ProcessingPhase = "Sparsify"
 	// ProcessingPhaseComplete is the phase where the entire process completed successfully and we can exit gracefully.
 	ProcessingPhaseComplete ProcessingPhase = "Complete"
 	// ProcessingPhasePause is the phase where we pause processing and end the loop, and expect something to call the process loop again.
@@ -66,6 +70,9 @@ const (
 // may be overridden in tests
 var getAvailableSpaceBlockFunc = GetAvailableSpaceBlock
 var getAvailableSpaceFunc = GetAvailableSpace
+var customizeFunc = image.Customize
+var expandPartitionFunc = ExpandPartition
+var sparsifyFunc = Sparsify
 
 // DataSourceInterface is the interface all data sources should implement.
 type DataSourceInterface interface {
@@ -116,6 +123,14 @@ type DataProcessor struct {
 	// cacheMode is the mode in which we choose the qemu-img cache mode:
 	// TRY_NONE = bypass page cache if the target supports it, otherwise, fall back to using page cache
 	cacheMode string
+	// customizeRecipe is the customization recipe run against the disk image once it is complete
+	customizeRecipe *image.CustomizeRecipe
+	// expandPartition grows the last partition of the disk image and its filesystem once the image is resized
+	expandPartition bool
+	// sparsify deallocates the blocks of zeroes of the disk image once it is resized and customized
+	sparsify bool
+	// reclaimedBytes is the size of the blocks of zeroes deallocated by the sparsify, nil when unknown
+	reclaimedBytes *int64
 }
 
 // NewDataProcessor create a new instance of a data processor using the passed in data provider.
@@ -137,6 +152,21 @@ func NewDataProcessor(dataSource DataSourceInterface, dataFile, dataDir, scratch
 	return dp
 }
 
+// SetCustomizeRecipe makes the data processor run the customization recipe against the disk image once it is complete
+func (dp *DataProcessor) SetCustomizeRecipe(recipe *image.CustomizeRecipe) {
+	dp.customizeRecipe = recipe
+}
+
+// SetExpandPartition makes the data processor grow the last partition of the disk image and its filesystem along with the image
+func (dp *DataProcessor) SetExpandPartition(expandPartition bool) {
+	dp.expandPartition = expandPartition
+}
+
+// SetSparsify makes the data processor deallocate the blocks of zeroes of the disk image once it is written
+func (dp *DataProcessor) SetSparsify(sparsify bool) {
+	dp.sparsify = sparsify
+}
+
 // RegisterPhaseExecutor registers an execution function for the given phase.
 // If there is already an function registered, override it with the new function.
 func (dp *DataProcessor) RegisterPhaseExecutor(pp ProcessingPhase, executor func() (ProcessingPhase, error)) {
@@ -228,6 +258,20 @@ func (dp *DataProcessor) initDefaultPhases() {
 		}
 		return pp, err
 	})
+	dp.RegisterPhaseExecutor(ProcessingPhaseCustomize, func() (ProcessingPhase, error) {
+		if err := customizeFunc(dp.dataFile, dp.customizeRecipe); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "Unable to customize disk image")
+		}
+		return dp.sparsifyPhase(), nil
+	})
+	dp.RegisterPhaseExecutor(ProcessingPhaseSparsify, func() (ProcessingPhase, error) {
+		reclaimed, err := sparsifyFunc(dp.dataFile)
+		if err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "Unable to sparsify disk image")
+		}
+		dp.reclaimedBytes = reclaimed
+		return ProcessingPhaseComplete, nil
+	})
 	dp.RegisterPhaseExecutor(ProcessingPhaseMergeDelta, func() (ProcessingPhase, error) {
 		pp, err := dp.merge()
 		if err != nil {
@@ -313,6 +357,11 @@ func (dp *DataProcessor) resize() (ProcessingPhase, error) {
 			return ProcessingPhaseError, err
 		}
 	}
+	if dp.expandPartition {
+		if err := expandPartitionFunc(dp.dataFile); err != nil {
+			return ProcessingPhaseError, errors.Wrap(err, "Unable to expand partition")
+		}
+	}
 	dp.preallocationApplied = dp.preallocation
 	if dp.dataFile != "" && !isBlockDev {
 		// Change permissions to 0660
@@ -322,7 +371,23 @@ func (dp *DataProcessor) resize() (ProcessingPhase, error) {
 		}
 	}
 
-	return ProcessingPhaseComplete, nil
+	if dp.customizeRecipe != nil {
+		return ProcessingPhaseCustomize, nil
+	}
+	return dp.sparsifyPhase(), nil
+}
+
+// sparsifyPhase returns the phase following the resize and the customization of the disk image, the sparsify
+// comes last so that it also deallocates the blocks zeroed by them
+func (dp *DataProcessor) sparsifyPhase() ProcessingPhase {
+	if !dp.sparsify {
+		return ProcessingPhaseComplete
+	}
+	if dp.preallocation {
+		klog.V(1).Infoln("Not sparsifying preallocated disk image")
+		return ProcessingPhaseComplete
+	}
+	return ProcessingPhaseSparsify
 }
 
 // ResizeImage resizes the images to match the requested size. Sometimes provisioners misbehave and the available space
@@ -393,6 +458,11 @@ func (dp *DataProcessor) PreallocationApplied() bool {
 	return dp.preallocationApplied
 }
 
+// ReclaimedBytes returns the size of the blocks of zeroes deallocated by the sparsify of the disk image
+func (dp *DataProcessor) ReclaimedBytes() *int64 {
+	return dp.reclaimedBytes
+}
+
 func (dp *DataProcessor) getUsableSpace() int64 {
 	return util.GetUsableSpace(dp.filesystemOverhead, dp.availableSpace)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/image"
@@ -467,6 +468,191 @@ var _ = Describe("Resize", func() {
 	})
 })
 
+var _ = Describe("Customize", func() {
+	var (
+		recipe   = &image.CustomizeRecipe{Steps: []image.CustomizeStep{{Hostname: "golden"}}}
+		origFunc = customizeFunc
+	)
+
+	AfterEach(func() {
+		customizeFunc = origFunc
+	})
+
+	It("Should customize after resize when a recipe is set", func() {
+		replaceAvailableSpaceBlockFunc(func(dataDir string) (int64, error) {
+			return int64(100000), nil
+		}, func() {
+			dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+			dp.SetCustomizeRecipe(recipe)
+			nextPhase, err := dp.resize()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseCustomize))
+		})
+	})
+
+	It("Should run the recipe against the data file and return complete", func() {
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		dp.SetCustomizeRecipe(recipe)
+		customizeFunc = func(dest string, r *image.CustomizeRecipe) error {
+			Expect(dest).To(Equal("dest"))
+			Expect(r).To(Equal(recipe))
+			return nil
+		}
+		nextPhase, err := dp.phaseExecutors[ProcessingPhaseCustomize]()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nextPhase).To(Equal(ProcessingPhaseComplete))
+	})
+
+	It("Should fail when a step of the recipe fails", func() {
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		dp.SetCustomizeRecipe(recipe)
+		customizeFunc = func(dest string, r *image.CustomizeRecipe) error {
+			return errors.New("virt-customize: error: no operating systems were found")
+		}
+		nextPhase, err := dp.phaseExecutors[ProcessingPhaseCustomize]()
+		Expect(err).To(MatchError(ContainSubstring("no operating systems were found")))
+		Expect(nextPhase).To(Equal(ProcessingPhaseError))
+	})
+})
+
+var _ = Describe("Expand partition", func() {
+	var origFunc = expandPartitionFunc
+
+	AfterEach(func() {
+		expandPartitionFunc = origFunc
+	})
+
+	DescribeTable("Should expand the partition of the data file on resize when requested", func(expandPartition bool) {
+		replaceAvailableSpaceBlockFunc(func(dataDir string) (int64, error) {
+			return int64(100000), nil
+		}, func() {
+			expanded := false
+			expandPartitionFunc = func(dest string) error {
+				Expect(dest).To(Equal("dest"))
+				expanded = true
+				return nil
+			}
+			dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+			dp.SetExpandPartition(expandPartition)
+			nextPhase, err := dp.resize()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextPhase).To(Equal(ProcessingPhaseComplete))
+			Expect(expanded).To(Equal(expandPartition))
+		})
+	},
+		Entry("expand", true),
+		Entry("not expand", false),
+	)
+
+	It("Should fail the resize when the partition can't be expanded", func() {
+		replaceAvailableSpaceBlockFunc(func(dataDir string) (int64, error) {
+			return int64(100000), nil
+		}, func() {
+			expandPartitionFunc = func(dest string) error {
+				return errors.New("growing filesystem failed")
+			}
+			dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+			dp.SetExpandPartition(true)
+			nextPhase, err := dp.resize()
+			Expect(err).To(MatchError(ContainSubstring("growing filesystem failed")))
+			Expect(nextPhase).To(Equal(ProcessingPhaseError))
+		})
+	})
+
+	It("Should resize an existing disk image before expanding its partition", func() {
+		replaceAvailableSpaceBlockFunc(func(dataDir string) (int64, error) {
+			return int64(-1), nil
+		}, func() {
+			expandPartitionFunc = func(dest string) error {
+				Expect(dest).To(Equal("dest"))
+				return nil
+			}
+			newSize := resource.MustParse("2Mi")
+			replaceQEMUOperations(NewFakeQEMUOperations(nil, nil, fakeInfoRet, nil, nil, &newSize), func() {
+				Expect(ExpandDisk("dest", "2Mi", 0.06, false)).To(Succeed())
+			})
+		})
+	})
+
+	It("Should only expand the partition of a disk on a block device", func() {
+		replaceAvailableSpaceBlockFunc(func(dataDir string) (int64, error) {
+			return int64(100000), nil
+		}, func() {
+			expanded := false
+			expandPartitionFunc = func(dest string) error {
+				expanded = true
+				return nil
+			}
+			replaceQEMUOperations(NewFakeQEMUOperations(nil, errors.New("should not resize"), fakeInfoRet, nil, nil, nil), func() {
+				Expect(ExpandDisk("dest", "2Mi", 0.06, false)).To(Succeed())
+			})
+			Expect(expanded).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Sparsify", func() {
+	var origFunc = sparsifyFunc
+
+	AfterEach(func() {
+		sparsifyFunc = origFunc
+	})
+
+	DescribeTable("Should sparsify the data file after resize when requested", func(sparsify, preallocation bool, expectedPhase ProcessingPhase) {
+		replaceAvailableSpaceBlockFunc(func(dataDir string) (int64, error) {
+			return int64(100000), nil
+		}, func() {
+			dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, preallocation, "")
+			dp.SetSparsify(sparsify)
+			nextPhase, err := dp.resize()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nextPhase).To(Equal(expectedPhase))
+		})
+	},
+		Entry("sparsify", true, false, ProcessingPhaseSparsify),
+		Entry("not sparsify", false, false, ProcessingPhaseComplete),
+		Entry("not sparsify a preallocated image", true, true, ProcessingPhaseComplete),
+	)
+
+	It("Should sparsify after the customization of the data file", func() {
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		dp.SetCustomizeRecipe(&image.CustomizeRecipe{Steps: []image.CustomizeStep{{Hostname: "golden"}}})
+		dp.SetSparsify(true)
+		origCustomize := customizeFunc
+		defer func() { customizeFunc = origCustomize }()
+		customizeFunc = func(dest string, r *image.CustomizeRecipe) error {
+			return nil
+		}
+		nextPhase, err := dp.phaseExecutors[ProcessingPhaseCustomize]()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nextPhase).To(Equal(ProcessingPhaseSparsify))
+	})
+
+	It("Should report the reclaimed space of the data file", func() {
+		sparsifyFunc = func(dest string) (*int64, error) {
+			Expect(dest).To(Equal("dest"))
+			return ptr.To[int64](4096), nil
+		}
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		dp.SetSparsify(true)
+		nextPhase, err := dp.phaseExecutors[ProcessingPhaseSparsify]()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nextPhase).To(Equal(ProcessingPhaseComplete))
+		Expect(dp.ReclaimedBytes()).To(HaveValue(Equal(int64(4096))))
+	})
+
+	It("Should fail when the data file can't be sparsified", func() {
+		sparsifyFunc = func(dest string) (*int64, error) {
+			return nil, errors.New("read failed")
+		}
+		dp := NewDataProcessor(&MockDataProvider{}, "dest", "dataDir", "scratchDataDir", "", 0.06, false, "")
+		dp.SetSparsify(true)
+		nextPhase, err := dp.phaseExecutors[ProcessingPhaseSparsify]()
+		Expect(err).To(MatchError(ContainSubstring("read failed")))
+		Expect(nextPhase).To(Equal(ProcessingPhaseError))
+	})
+})
+
 var _ = Describe("ResizeImage", func() {
 	//fakeInfoRet has info.VirtualSize=1024
 	DescribeTable("calling ResizeImage", func(qemuOperations image.QEMUOperations, imageSize string, totalSpace int64, wantErr bool) {
@@ -6,6 +6,8 @@ import (
 	"syscall"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
 // ValidationSizeError is an error indication size validation failure.
@@ -15,6 +17,13 @@ type ValidationSizeError struct {
 
 func (e ValidationSizeError) Error() string { return e.err.Error() }
 
+// ChecksumMismatchError indicates that the content read from the source does not match its expected checksum.
+type ChecksumMismatchError struct {
+	err error
+}
+
+func (e ChecksumMismatchError) Error() string { return e.err.Error() }
+
 // ErrRequiresScratchSpace indicates that we require scratch space.
 var ErrRequiresScratchSpace = fmt.Errorf(common.ScratchSpaceRequired)
 
@@ -48,3 +57,30 @@ func IsNoCapacityError(err error) bool {
 		errors.Is(err, syscall.EDQUOT) ||
 		errors.As(err, &ValidationSizeError{})
 }
+
+// GetFailureReason classifies an import error into the reason reported in the termination message of the importer,
+// empty if the cause is unknown
+func GetFailureReason(err error) common.FailureReason {
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &ChecksumMismatchError{}):
+		return common.FailureChecksumMismatch
+	case errors.As(err, &image.UnsupportedFormatError{}):
+		return common.FailureUnsupportedFormat
+	case errors.Is(err, image.ErrLargerPVCRequired), errors.As(err, &ValidationSizeError{}):
+		return common.FailureInsufficientSpace
+	}
+
+	reason := util.GetFailureReason(err)
+	var pullErr *ImagePullFailedError
+	if errors.As(err, &pullErr) {
+		// Only the causes fixed on the DataVolume itself are more specific than the pull failure
+		switch reason {
+		case common.FailureAuthFailed, common.FailureNotFound, common.FailureTLSError:
+			return reason
+		}
+		return common.FailureImagePullFailed
+	}
+	return reason
+}
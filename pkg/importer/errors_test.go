@@ -0,0 +1,34 @@
+package importer
+
+import (
+	"fmt"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+var _ = Describe("GetFailureReason", func() {
+	DescribeTable("should classify", func(err error, expected common.FailureReason) {
+		Expect(GetFailureReason(err)).To(Equal(expected))
+	},
+		Entry("no error", nil, common.FailureReason("")),
+		Entry("an unknown error", errors.New("something went wrong"), common.FailureReason("")),
+		Entry("a checksum mismatch", errors.Wrap(ChecksumMismatchError{errors.New("bad sha256")}, "read"), common.FailureChecksumMismatch),
+		Entry("an unsupported format", errors.WithStack(image.UnsupportedFormatError{Format: "raw2", Image: "disk"}), common.FailureUnsupportedFormat),
+		Entry("a too small volume", fmt.Errorf("validate: %w", image.ErrLargerPVCRequired), common.FailureInsufficientSpace),
+		Entry("a size validation failure", ValidationSizeError{errors.New("too small")}, common.FailureInsufficientSpace),
+		Entry("an exceeded disk quota", fmt.Errorf("write: %w", syscall.EDQUOT), common.FailureQuotaExceeded),
+		Entry("a not found status", util.NewHTTPStatusError(404, errors.New("got 404")), common.FailureNotFound),
+		Entry("an image pull failure with a missing image", NewImagePullFailedError(errors.New("manifest unknown")), common.FailureNotFound),
+		Entry("an image pull failure with bad credentials", NewImagePullFailedError(errors.New("unauthorized: authentication required")), common.FailureAuthFailed),
+		Entry("an image pull failure with an unreachable registry", NewImagePullFailedError(errors.New("dial tcp: lookup myregistry: no such host")), common.FailureImagePullFailed),
+		Entry("an image pull failure with an unknown cause", NewImagePullFailedError(errors.New("something went wrong")), common.FailureImagePullFailed),
+	)
+})
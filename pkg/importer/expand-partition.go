@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	"kubevirt.io/containerized-data-importer/pkg/util"
+)
+
+const (
+	gptMinHeaderSize = 92
+
+	mbrPartitionTableOffset = 446
+	mbrProtectiveType       = 0xee
+	mbrMaxSectors           = 0xffffffff
+
+	extSuperblockOffset     = 1024
+	extMagic                = 0xef53
+	extFeatureIncompat64Bit = 0x80
+	xfsMagic                = "XFSB"
+
+	// A filesystem is grown when its partition has at least this much more room
+	minFilesystemGrowth = 1024 * 1024
+)
+
+var growFilesystemFunc = image.GrowFilesystem
+
+// ExpandPartition grows the last partition of the GPT disk in diskFile up to the end of the disk, along with its ext4
+// or xfs filesystem. Disks of any other layout are left as they are.
+func ExpandPartition(diskFile string) error {
+	disk, err := os.OpenFile(diskFile, os.O_RDWR, 0)
+	if err != nil {
+		return errors.Wrapf(err, "could not open disk %q", diskFile)
+	}
+	defer disk.Close()
+	diskSize, err := disk.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	table, err := readGPT(disk)
+	if err == nil {
+		err = table.validate()
+	}
+	if err != nil {
+		klog.Warningf("Not expanding the partition of %s: %v", diskFile, err)
+		return nil
+	}
+	index := table.lastPartition()
+	if index < 0 {
+		klog.Warningf("Not expanding the partition of %s: disk has no partitions", diskFile)
+		return nil
+	}
+	first, last := gptEntryRange(table.entry(index))
+	fsType, fsSize, err := detectFilesystem(io.NewSectionReader(disk, first*table.sectorSize, (last-first+1)*table.sectorSize))
+	if err != nil {
+		klog.Warningf("Not expanding partition %d of %s: %v", index+1, diskFile, err)
+		return nil
+	}
+
+	expanded, err := table.expand(disk, diskSize, index)
+	if err != nil {
+		return err
+	}
+	if expanded {
+		if err := disk.Sync(); err != nil {
+			return err
+		}
+	}
+
+	first, last = gptEntryRange(table.entry(index))
+	partitionSize := (last - first + 1) * table.sectorSize
+	klog.V(1).Infof("Partition %d of %s holds %d bytes of %s filesystem in %d bytes", index+1, diskFile, fsSize, fsType, partitionSize)
+	if partitionSize-fsSize < minFilesystemGrowth {
+		return nil
+	}
+	return growFilesystemFunc(diskFile, index+1, fsType)
+}
+
+// ExpandDisk grows the existing raw disk image in dataFile to imageSize, within the usable space of its file system,
+// then its last partition and filesystem. A disk on a block device already spans the whole device and is not resized.
+func ExpandDisk(dataFile, imageSize string, filesystemOverhead float64, preallocation bool) error {
+	if size, _ := getAvailableSpaceBlockFunc(dataFile); size < 0 && imageSize != "" {
+		totalSpace, err := GetTotalSpace(filepath.Dir(dataFile))
+		if err != nil {
+			return err
+		}
+		if err := ResizeImage(dataFile, imageSize, util.GetUsableSpace(filesystemOverhead, totalSpace), preallocation); err != nil {
+			return errors.Wrap(err, "Resize of image failed")
+		}
+	}
+	return expandPartitionFunc(dataFile)
+}
+
+// validate checks the checksums of the header and the partition entries of the table before it is rewritten
+func (t *gpt) validate() error {
+	headerSize := int64(binary.LittleEndian.Uint32(t.header[12:16]))
+	if headerSize < gptMinHeaderSize || headerSize > t.sectorSize {
+		return errors.Errorf("invalid GPT header of %d bytes", headerSize)
+	}
+	if gptHeaderChecksum(t.header) != binary.LittleEndian.Uint32(t.header[16:20]) {
+		return errors.New("invalid GPT header checksum")
+	}
+	if crc32.ChecksumIEEE(t.entries) != binary.LittleEndian.Uint32(t.header[88:92]) {
+		return errors.New("invalid GPT partition entries checksum")
+	}
+	return nil
+}
+
+// lastPartition returns the index of the partition ending last on the disk, or -1 when there is none
+func (t *gpt) lastPartition() int {
+	index := -1
+	var end int64
+	for i := 0; i < t.numEntries(); i++ {
+		entry := t.entry(i)
+		if entry == nil {
+			continue
+		}
+		if _, last := gptEntryRange(entry); last > end {
+			index, end = i, last
+		}
+	}
+	return index
+}
+
+// expand moves the backup GPT to the end of a disk of diskSize bytes and grows the partition at index up to the last
+// usable sector, it returns false when the table already spans the whole disk
+func (t *gpt) expand(disk *os.File, diskSize int64, index int) (bool, error) {
+	sectorSize := t.sectorSize
+	entriesSectors := (int64(len(t.entries)) + sectorSize - 1) / sectorSize
+	backupLBA := diskSize/sectorSize - 1
+	backupEntriesLBA := backupLBA - entriesSectors
+	lastUsableLBA := backupEntriesLBA - 1
+
+	primaryLBA := int64(binary.LittleEndian.Uint64(t.header[24:32]))
+	oldBackupLBA := int64(binary.LittleEndian.Uint64(t.header[32:40]))
+	if lastUsableLBA < int64(binary.LittleEndian.Uint64(t.header[48:56])) {
+		return false, errors.Errorf("disk of %d bytes is smaller than its GPT", diskSize)
+	}
+	entry := t.entry(index)
+	if _, last := gptEntryRange(entry); last >= lastUsableLBA && oldBackupLBA == backupLBA {
+		return false, nil
+	}
+
+	klog.V(1).Infof("Expanding partition %d to sector %d", index+1, lastUsableLBA)
+	binary.LittleEndian.PutUint64(entry[40:48], uint64(lastUsableLBA))
+	binary.LittleEndian.PutUint64(t.header[32:40], uint64(backupLBA))
+	binary.LittleEndian.PutUint64(t.header[48:56], uint64(lastUsableLBA))
+	binary.LittleEndian.PutUint32(t.header[88:92], crc32.ChecksumIEEE(t.entries))
+	binary.LittleEndian.PutUint32(t.header[16:20], gptHeaderChecksum(t.header))
+
+	backup := append([]byte{}, t.header...)
+	binary.LittleEndian.PutUint64(backup[24:32], uint64(backupLBA))
+	binary.LittleEndian.PutUint64(backup[32:40], uint64(primaryLBA))
+	binary.LittleEndian.PutUint64(backup[72:80], uint64(backupEntriesLBA))
+	binary.LittleEndian.PutUint32(backup[16:20], gptHeaderChecksum(backup))
+
+	// The backup is written first, the primary table stays valid until it is rewritten last
+	type write struct {
+		data []byte
+		lba  int64
+	}
+	writes := []write{
+		{t.entries, backupEntriesLBA},
+		{backup, backupLBA},
+		{t.entries, int64(binary.LittleEndian.Uint64(t.header[72:80]))},
+		{t.header, primaryLBA},
+	}
+	if oldBackupLBA > primaryLBA && oldBackupLBA < backupEntriesLBA {
+		// Wipe the stale backup header now in the middle of the disk
+		writes = append(writes, write{make([]byte, sectorSize), oldBackupLBA})
+	}
+	for _, w := range writes {
+		if _, err := disk.WriteAt(w.data, w.lba*sectorSize); err != nil {
+			return false, errors.Wrap(err, "unable to write GPT")
+		}
+	}
+	return true, updateProtectiveMBR(disk, diskSize/sectorSize)
+}
+
+// gptHeaderChecksum returns the CRC32 of a GPT header, computed with its own checksum field zeroed
+func gptHeaderChecksum(header []byte) uint32 {
+	headerSize := binary.LittleEndian.Uint32(header[12:16])
+	h := append([]byte{}, header[:headerSize]...)
+	binary.LittleEndian.PutUint32(h[16:20], 0)
+	return crc32.ChecksumIEEE(h)
+}
+
+// updateProtectiveMBR grows the protective MBR partition of a GPT disk to cover its sectors, a hybrid MBR is left as is
+func updateProtectiveMBR(disk *os.File, sectors int64) error {
+	mbr := make([]byte, 512)
+	if _, err := disk.ReadAt(mbr, 0); err != nil {
+		return errors.Wrap(err, "unable to read MBR")
+	}
+	entry := mbr[mbrPartitionTableOffset:]
+	if mbr[510] != 0x55 || mbr[511] != 0xaa || entry[4] != mbrProtectiveType {
+		return nil
+	}
+	size := sectors - 1
+	if size > mbrMaxSectors {
+		size = mbrMaxSectors
+	}
+	binary.LittleEndian.PutUint32(entry[12:16], uint32(size))
+	if _, err := disk.WriteAt(entry[12:16], mbrPartitionTableOffset+12); err != nil {
+		return errors.Wrap(err, "unable to write MBR")
+	}
+	return nil
+}
+
+// detectFilesystem returns the type and size in bytes of the ext4 or xfs filesystem in a partition
+func detectFilesystem(r io.ReaderAt) (string, int64, error) {
+	sb := make([]byte, 1024)
+	if _, err := r.ReadAt(sb, extSuperblockOffset); err == nil && binary.LittleEndian.Uint16(sb[56:58]) == extMagic {
+		blocks := int64(binary.LittleEndian.Uint32(sb[4:8]))
+		if binary.LittleEndian.Uint32(sb[96:100])&extFeatureIncompat64Bit != 0 {
+			blocks |= int64(binary.LittleEndian.Uint32(sb[0x150:0x154])) << 32
+		}
+		blockSize := int64(1024) << binary.LittleEndian.Uint32(sb[24:28])
+		return image.FilesystemExt4, blocks * blockSize, nil
+	}
+	if _, err := r.ReadAt(sb[:16], 0); err == nil && string(sb[:len(xfsMagic)]) == xfsMagic {
+		blockSize := int64(binary.BigEndian.Uint32(sb[4:8]))
+		blocks := int64(binary.BigEndian.Uint64(sb[8:16]))
+		return image.FilesystemXFS, blocks * blockSize, nil
+	}
+	return "", 0, errors.New("no ext4 or xfs filesystem found")
+}
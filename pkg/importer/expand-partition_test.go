@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/containerized-data-importer/pkg/image"
+)
+
+const (
+	testRootFirst      = 10
+	testGrownSectors   = 4160
+	testEntriesSectors = 1
+)
+
+// createTestExpandableDisk returns a GPT disk of sectors with a boot partition, and a root partition up to the end of
+// the disk holding an ext4 superblock of fsBlocks 1KiB blocks
+func createTestExpandableDisk(sectors, fsBlocks int64) []byte {
+	disk := make([]byte, sectors*testSectorSize)
+	mbr := disk[mbrPartitionTableOffset:]
+	mbr[4] = mbrProtectiveType
+	binary.LittleEndian.PutUint32(mbr[12:16], uint32(sectors-1))
+	disk[510], disk[511] = 0x55, 0xaa
+
+	lastUsable := sectors - 2 - testEntriesSectors
+	entries := disk[2*testSectorSize : 3*testSectorSize]
+	for i, r := range [][2]int64{{4, testRootFirst - 1}, {testRootFirst, lastUsable}} {
+		entry := entries[i*gptMinEntrySize:]
+		copy(entry[:16], bytes.Repeat([]byte{0xaf}, 16))
+		binary.LittleEndian.PutUint64(entry[32:], uint64(r[0]))
+		binary.LittleEndian.PutUint64(entry[40:], uint64(r[1]))
+	}
+
+	header := disk[testSectorSize : 2*testSectorSize]
+	copy(header, gptSignature)
+	binary.LittleEndian.PutUint32(header[12:], gptMinHeaderSize)
+	binary.LittleEndian.PutUint64(header[24:], 1)
+	binary.LittleEndian.PutUint64(header[32:], uint64(sectors-1))
+	binary.LittleEndian.PutUint64(header[40:], 3)
+	binary.LittleEndian.PutUint64(header[48:], uint64(lastUsable))
+	binary.LittleEndian.PutUint64(header[72:], 2)
+	binary.LittleEndian.PutUint32(header[80:], 4)
+	binary.LittleEndian.PutUint32(header[84:], gptMinEntrySize)
+	binary.LittleEndian.PutUint32(header[88:], crc32.ChecksumIEEE(entries))
+	binary.LittleEndian.PutUint32(header[16:], gptHeaderChecksum(header))
+
+	backup := disk[(sectors-1)*testSectorSize:]
+	copy(backup, header)
+	binary.LittleEndian.PutUint64(backup[24:], uint64(sectors-1))
+	binary.LittleEndian.PutUint64(backup[32:], 1)
+	binary.LittleEndian.PutUint64(backup[72:], uint64(sectors-2))
+	binary.LittleEndian.PutUint32(backup[16:], gptHeaderChecksum(backup))
+	copy(disk[(sectors-2)*testSectorSize:], entries)
+
+	sb := disk[testRootFirst*testSectorSize+extSuperblockOffset:]
+	binary.LittleEndian.PutUint32(sb[4:], uint32(fsBlocks))
+	binary.LittleEndian.PutUint16(sb[56:], extMagic)
+	return disk
+}
+
+var _ = Describe("Expand partition", func() {
+	var (
+		diskFile         string
+		grownPartitions  []int
+		origGrowFunction = growFilesystemFunc
+	)
+
+	BeforeEach(func() {
+		diskFile = filepath.Join(GinkgoT().TempDir(), "disk.img")
+		grownPartitions = nil
+		growFilesystemFunc = func(dest string, partition int, fsType string) error {
+			Expect(dest).To(Equal(diskFile))
+			Expect(fsType).To(Equal(image.FilesystemExt4))
+			grownPartitions = append(grownPartitions, partition)
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		growFilesystemFunc = origGrowFunction
+	})
+
+	writeDisk := func(disk []byte, size int64) {
+		Expect(os.WriteFile(diskFile, disk, 0600)).To(Succeed())
+		Expect(os.Truncate(diskFile, size)).To(Succeed())
+	}
+
+	It("should grow the last partition and its filesystem up to the end of the disk", func() {
+		writeDisk(createTestExpandableDisk(64, 26), testGrownSectors*testSectorSize)
+		Expect(ExpandPartition(diskFile)).To(Succeed())
+		Expect(grownPartitions).To(Equal([]int{2}))
+
+		disk, err := os.ReadFile(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		table, err := readGPT(bytes.NewReader(disk))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(table.validate()).To(Succeed())
+		Expect(table.lastPartition()).To(Equal(1))
+		first, last := gptEntryRange(table.entry(1))
+		Expect(first).To(Equal(int64(testRootFirst)))
+		Expect(last).To(Equal(int64(testGrownSectors - 3)))
+		Expect(binary.LittleEndian.Uint64(table.header[32:])).To(Equal(uint64(testGrownSectors - 1)))
+
+		backup := disk[(testGrownSectors-1)*testSectorSize:]
+		Expect(string(backup[:len(gptSignature)])).To(Equal(gptSignature))
+		Expect(binary.LittleEndian.Uint64(backup[24:])).To(Equal(uint64(testGrownSectors - 1)))
+		Expect(binary.LittleEndian.Uint64(backup[32:])).To(Equal(uint64(1)))
+		Expect(binary.LittleEndian.Uint64(backup[72:])).To(Equal(uint64(testGrownSectors - 2)))
+		Expect(binary.LittleEndian.Uint32(backup[16:])).To(Equal(gptHeaderChecksum(backup)))
+		Expect(disk[(testGrownSectors-2)*testSectorSize : (testGrownSectors-1)*testSectorSize]).To(Equal(table.entries[:testSectorSize]))
+
+		Expect(disk[63*testSectorSize : 64*testSectorSize]).To(Equal(make([]byte, testSectorSize)))
+		Expect(binary.LittleEndian.Uint32(disk[mbrPartitionTableOffset+12:])).To(Equal(uint32(testGrownSectors - 1)))
+	})
+
+	It("should grow the filesystem of a partition already filling the disk", func() {
+		disk := createTestExpandableDisk(testGrownSectors, 26)
+		writeDisk(disk, testGrownSectors*testSectorSize)
+		Expect(ExpandPartition(diskFile)).To(Succeed())
+		Expect(grownPartitions).To(Equal([]int{2}))
+
+		expanded, err := os.ReadFile(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expanded).To(Equal(disk))
+	})
+
+	It("should not grow a filesystem filling its partition", func() {
+		writeDisk(createTestExpandableDisk(64, 26), 64*testSectorSize)
+		Expect(ExpandPartition(diskFile)).To(Succeed())
+		Expect(grownPartitions).To(BeEmpty())
+	})
+
+	DescribeTable("should leave a disk of an unknown layout as is", func(mutate func([]byte)) {
+		disk := createTestExpandableDisk(64, 26)
+		mutate(disk)
+		writeDisk(disk, testGrownSectors*testSectorSize)
+		Expect(ExpandPartition(diskFile)).To(Succeed())
+		Expect(grownPartitions).To(BeEmpty())
+
+		expanded, err := os.ReadFile(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expanded[:len(disk)]).To(Equal(disk))
+	},
+		Entry("without GPT", func(disk []byte) {
+			copy(disk[testSectorSize:], make([]byte, testSectorSize))
+		}),
+		Entry("with an invalid GPT checksum", func(disk []byte) {
+			disk[2*testSectorSize+gptEntryNameOffset] = 'x'
+		}),
+		Entry("without filesystem in the last partition", func(disk []byte) {
+			copy(disk[testRootFirst*testSectorSize:], make([]byte, 2*extSuperblockOffset))
+		}),
+	)
+
+	It("should detect an xfs filesystem", func() {
+		sb := make([]byte, 2*extSuperblockOffset)
+		copy(sb, xfsMagic)
+		binary.BigEndian.PutUint32(sb[4:], 4096)
+		binary.BigEndian.PutUint64(sb[8:], 10)
+		fsType, size, err := detectFilesystem(bytes.NewReader(sb))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fsType).To(Equal(image.FilesystemXFS))
+		Expect(size).To(Equal(int64(40960)))
+	})
+})
@@ -61,6 +61,17 @@ func GetAvailableSpace(path string) (int64, error) {
 	return int64(stat.Bavail) * int64(stat.Bsize), nil
 }
 
+// GetTotalSpace gets the size of the file system at the path specified.
+func GetTotalSpace(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return int64(-1), err
+	}
+	//nolint:unconvert
+	return int64(stat.Blocks) * int64(stat.Bsize), nil
+}
+
 // GetAvailableSpaceBlock gets the amount of available space at the block device path specified.
 func GetAvailableSpaceBlock(deviceName string) (int64, error) {
 	// Check if the file exists and is a device file.
@@ -32,6 +32,7 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/image"
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/transfer"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 	prometheusutil "kubevirt.io/containerized-data-importer/pkg/util/prometheus"
 )
@@ -44,6 +45,9 @@ func init() {
 	if err := metrics.SetupMetrics(); err != nil {
 		klog.Errorf("Unable to create prometheus progress counter: %v", err)
 	}
+	if err := transfer.SetupMetrics(); err != nil {
+		klog.Errorf("Unable to create prometheus transfer gauges: %v", err)
+	}
 	ownerUID, _ = util.ParseEnvVar(common.OwnerUID, false)
 }
 
@@ -85,7 +89,7 @@ func NewFormatReaders(stream io.ReadCloser, total uint64) (*FormatReaders, error
 		buf: make([]byte, image.MaxExpectedHdrSize),
 	}
 	if total > uint64(0) {
-		readers.progressReader = prometheusutil.NewProgressReader(stream, metrics.Progress(ownerUID), total)
+		readers.progressReader = prometheusutil.NewProgressReader(stream, metrics.Progress(ownerUID), transfer.Stats(ownerUID), total)
 		err = readers.constructReaders(readers.progressReader)
 	} else {
 		err = readers.constructReaders(stream)
@@ -2,9 +2,13 @@ package importer
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,35 +19,89 @@ import (
 	"k8s.io/klog/v2"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
 )
 
 const (
 	gcsFolderSep = "/"
 	gcsScheme    = "gs"
+	gcsXMLHost   = "storage.googleapis.com"
+
+	gcsSignedURLLifetime = 24 * time.Hour
+	gcsSubjectTokenType  = "urn:ietf:params:oauth:token-type:jwt"
 )
 
 // Helper for unit-testing
 var newReaderFunc = getGcsObjectReader
 
+// may be overridden in tests
+var (
+	gcsSTSTokenURL         = "https://sts.googleapis.com/v1/token"
+	gcsImpersonationURLFmt = "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken"
+)
+
+// GCSOptions are the optional settings of the GCS client
+type GCSOptions struct {
+	// WorkloadIdentity authenticates with the identity of the importer pod instead of anonymously, when there is no key file
+	WorkloadIdentity bool
+	// WorkloadIdentityProvider is the workload identity pool provider WorkloadIdentityTokenFile is exchanged with.
+	// The Application Default Credentials are used if empty.
+	WorkloadIdentityProvider string
+	// ServiceAccount is the service account impersonated with the federated token, if set
+	ServiceAccount string
+	// WorkloadIdentityTokenFile is the projected service account token exchanged with WorkloadIdentityProvider
+	WorkloadIdentityTokenFile string
+}
+
+// gcsExternalAccount is the credential configuration of a workload identity federation reading its token from a file
+type gcsExternalAccount struct {
+	Type                           string                      `json:"type"`
+	Audience                       string                      `json:"audience"`
+	SubjectTokenType               string                      `json:"subject_token_type"`
+	TokenURL                       string                      `json:"token_url"`
+	ServiceAccountImpersonationURL string                      `json:"service_account_impersonation_url,omitempty"`
+	CredentialSource               gcsExternalAccountTokenFile `json:"credential_source"`
+}
+
+type gcsExternalAccountTokenFile struct {
+	File string `json:"file"`
+}
+
 // GCSDataSource is the struct containing the information needed to import from a GCS data source.
+// qemu-img reads the objects of known generation directly through nbdkit, with ranged GETs.
 // Sequence of phases:
-// 1. Info -> Transfer
-// 2. Transfer -> Convert
+// 1a. Info -> TransferDataFile if the object is a raw image.
+// 1b. Info -> Convert if the object can be converted by qemu-img through nbdkit.
+// 1c. Info -> TransferScratch in all other cases.
+// 2.  TransferScratch -> Convert
 type GCSDataSource struct {
 	// GCS end point
 	ep *url.URL
 	// Key File
 	keyFile string
+	// true if the object is read without credentials
+	anonymous bool
+	client    *storage.Client
+	ctx       context.Context
+	cancel    context.CancelFunc
 	// Reader
 	gcsReader io.ReadCloser
+	bucket    string
+	object    string
+	// the generation of the object being read, 0 if unknown
+	generation int64
+	// the size of the object, 0 if unknown
+	size uint64
 	// stack of readers
 	readers *FormatReaders
-	// The image file in scratch space.
+	// The image file in scratch space, or the nbdkit socket.
 	url *url.URL
+
+	n image.NbdkitOperation
 }
 
 // NewGCSDataSource creates a new instance of the GCSDataSource
-func NewGCSDataSource(endpoint, keyFile string) (*GCSDataSource, error) {
+func NewGCSDataSource(endpoint, keyFile string, opts *GCSOptions) (*GCSDataSource, error) {
 	klog.V(3).Infoln("GCS Importer: New Data Source")
 
 	// Placeholders
@@ -56,9 +114,9 @@ func NewGCSDataSource(endpoint, keyFile string) (*GCSDataSource, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "GCS Importer: unable to parse endpoint %q", endpoint)
 	}
-
-	// Getting Context
-	ctx, _ := context.WithTimeout(context.Background(), time.Second*60) //nolint:govet // todo - solve this: the cancel function returned by context.WithTimeout should be called, not discarded, to avoid a context leak
+	if opts == nil {
+		opts = &GCSOptions{}
+	}
 
 	if ep.Scheme == "gs" {
 		// Using gs:// endpoint and extracting bucket and object name
@@ -69,32 +127,48 @@ func NewGCSDataSource(endpoint, keyFile string) (*GCSDataSource, error) {
 		options = append(options, option.WithEndpoint(host))
 	}
 
+	// The object is read with this context until the data source is closed
+	ctx, cancel := context.WithCancel(context.Background())
+
 	// Creating GCS Client
-	client, err := getGcsClient(ctx, keyFile, options...)
+	client, err := getGcsClient(ctx, keyFile, opts, options...)
 
 	if err != nil {
+		cancel()
 		klog.Errorf("GCS Importer: Error creating GCS Client")
 		return nil, err
 	}
 
+	sd := &GCSDataSource{
+		ep:        ep,
+		keyFile:   keyFile,
+		anonymous: keyFile == "" && !opts.WorkloadIdentity,
+		client:    client,
+		ctx:       ctx,
+		cancel:    cancel,
+		bucket:    bucket,
+		object:    object,
+	}
+
 	// Creating GCS Reader
-	gcsReader, err := newReaderFunc(ctx, client, bucket, object)
-	if err != nil {
-		klog.Errorf("GCS Importer: Error creating Reader")
+	if err := sd.openObject(); err != nil {
+		sd.Close()
 		return nil, err
 	}
-
-	return &GCSDataSource{
-		ep:        ep,
-		keyFile:   keyFile,
-		gcsReader: gcsReader,
-	}, nil
+	if sd.generation > 0 {
+		sd.n, err = createNbdkitCurl(nbdkitPid, "", "", "", nbdkitSocket, nil, nil)
+		if err != nil {
+			sd.Close()
+			return nil, err
+		}
+	}
+	return sd, nil
 }
 
 // Info is called to get initial information about the data.
 func (sd *GCSDataSource) Info() (ProcessingPhase, error) {
 	var err error
-	sd.readers, err = NewFormatReaders(sd.gcsReader, uint64(0))
+	sd.readers, err = NewFormatReaders(sd.gcsReader, sd.size)
 	if err != nil {
 		klog.Errorf("GCS Importer: Error creating readers: %v", err)
 		return ProcessingPhaseError, err
@@ -103,7 +177,17 @@ func (sd *GCSDataSource) Info() (ProcessingPhase, error) {
 		// Downloading a raw file, we can write that directly to the target.
 		return ProcessingPhaseTransferDataFile, nil
 	}
-
+	if !sd.readers.Archived && sd.n != nil {
+		var objectURL *url.URL
+		if objectURL, err = sd.getObjectURL(); err == nil {
+			sd.url, _ = url.Parse(fmt.Sprintf("nbd+unix:///?socket=%s", nbdkitSocket))
+			if err = sd.n.StartNbdkit(objectURL.String()); err == nil {
+				return ProcessingPhaseConvert, nil
+			}
+		}
+		klog.Warningf("GCS Importer: Unable to read the object through nbdkit, downloading it to scratch space: %v", err)
+	}
+	sd.url = nil
 	return ProcessingPhaseTransferScratch, nil
 }
 
@@ -162,20 +246,114 @@ func (sd *GCSDataSource) Close() error {
 	var err error
 	if sd.readers != nil {
 		err = sd.readers.Close()
+	} else if sd.gcsReader != nil {
+		err = sd.gcsReader.Close()
+	}
+	if sd.client != nil {
+		sd.client.Close()
+	}
+	if sd.cancel != nil {
+		sd.cancel()
 	}
 	return err
 }
 
+// openObject opens the object for reading, and records its size and generation when the reader knows them.
+// The reader resumes lost connections with ranged reads of the same generation.
+func (sd *GCSDataSource) openObject() error {
+	reader, err := newReaderFunc(sd.ctx, sd.client, sd.bucket, sd.object)
+	if err != nil {
+		klog.Errorf("GCS Importer: Error creating Reader")
+		return err
+	}
+	sd.gcsReader = reader
+	r, ok := reader.(*storage.Reader)
+	// Objects stored compressed are decompressed by the reader, nbdkit would read the compressed data
+	if !ok || r.Attrs.Size <= 0 || r.Attrs.Generation <= 0 || r.Attrs.ContentEncoding == "gzip" {
+		return nil
+	}
+	klog.V(1).Infof("GCS Importer: Object size %d, generation %d", r.Attrs.Size, r.Attrs.Generation)
+	sd.size = uint64(r.Attrs.Size)
+	sd.generation = r.Attrs.Generation
+	return nil
+}
+
+// getObjectURL returns the XML API url of the generation of the object being read. Unless the object is read
+// anonymously, the url is signed with the private key of the service account, or through the IAM API.
+func (sd *GCSDataSource) getObjectURL() (*url.URL, error) {
+	generation := url.Values{"generation": []string{strconv.FormatInt(sd.generation, 10)}}
+	if sd.anonymous {
+		objectURL := &url.URL{
+			Scheme:   "https",
+			Host:     gcsXMLHost,
+			Path:     "/" + sd.bucket + "/" + sd.object,
+			RawQuery: generation.Encode(),
+		}
+		if sd.ep.Scheme != gcsScheme {
+			objectURL.Scheme = sd.ep.Scheme
+			objectURL.Host = sd.ep.Host
+		}
+		return objectURL, nil
+	}
+	signed, err := sd.client.Bucket(sd.bucket).SignedURL(sd.object, &storage.SignedURLOptions{
+		Method:          http.MethodGet,
+		Expires:         time.Now().Add(gcsSignedURLLifetime),
+		Scheme:          storage.SigningSchemeV4,
+		QueryParameters: generation,
+		Insecure:        sd.ep.Scheme == "http",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to sign the url of the object")
+	}
+	return url.Parse(signed)
+}
+
 // Create a Cloud Storage Client
-func getGcsClient(ctx context.Context, keyFile string, options ...option.ClientOption) (*storage.Client, error) {
+func getGcsClient(ctx context.Context, keyFile string, opts *GCSOptions, options ...option.ClientOption) (*storage.Client, error) {
 	klog.V(3).Infoln("GCS Importer: Creating Client")
-	if keyFile == "" {
+	if opts == nil {
+		opts = &GCSOptions{}
+	}
+	switch {
+	case keyFile != "":
+		// The Application Default Credentials load the key file of GOOGLE_APPLICATION_CREDENTIALS
+		klog.V(3).Infoln("GCS Importer: Authentication: Key file")
+	case !opts.WorkloadIdentity:
 		options = append(options, option.WithoutAuthentication())
 		klog.V(3).Infoln("GCS Importer: Authentication: Anonymous")
+	case opts.WorkloadIdentityProvider != "":
+		config, err := getGcsExternalAccount(opts)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option.WithCredentialsJSON(config))
+		klog.V(3).Infoln("GCS Importer: Authentication: Workload identity federation with", opts.WorkloadIdentityProvider)
+	default:
+		klog.V(3).Infoln("GCS Importer: Authentication: Application Default Credentials")
 	}
 	return storage.NewClient(ctx, options...)
 }
 
+// getGcsExternalAccount returns the credential configuration exchanging the projected service account token with the
+// workload identity provider, and impersonating the service account if set
+func getGcsExternalAccount(opts *GCSOptions) ([]byte, error) {
+	account := &gcsExternalAccount{
+		Type:             "external_account",
+		Audience:         opts.WorkloadIdentityProvider,
+		SubjectTokenType: gcsSubjectTokenType,
+		TokenURL:         gcsSTSTokenURL,
+		CredentialSource: gcsExternalAccountTokenFile{File: opts.WorkloadIdentityTokenFile},
+	}
+	if opts.ServiceAccount != "" {
+		account.ServiceAccountImpersonationURL = fmt.Sprintf(gcsImpersonationURLFmt, opts.ServiceAccount)
+	}
+	config, err := json.Marshal(account)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to build the workload identity federation credentials")
+	}
+	return config, nil
+}
+
 // Create Cloud Storage Object Reader
 func getGcsObjectReader(ctx context.Context, client *storage.Client, bucket, object string) (io.ReadCloser, error) {
 	klog.V(3).Infoln("GCS Importer: Creating Reader for bucket:", bucket, "object:", object)
@@ -1,15 +1,28 @@
 package importer
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"cloud.google.com/go/storage"
+
+	"kubevirt.io/containerized-data-importer/pkg/image"
 )
 
 var _ = Describe("Google Cloud Storage data source", func() {
@@ -54,31 +67,31 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	})
 
 	It("NewGCSDataSource should Error, when passed in an invalid endpoint", func() {
-		sd, err = NewGCSDataSource("thisisinvalid#$%#ep", "")
+		sd, err = NewGCSDataSource("thisisinvalid#$%#ep", "", nil)
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("NewGCSDataSource should Pass, when passed in an valid https endpoint without authentication", func() {
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/Object.tmp", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/Object.tmp", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
 	It("NewGCSDataSource should Pass, when passed in an valid gs endpoint without authentication", func() {
-		sd, err = NewGCSDataSource("gs://Bucket1/Object.tmp", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/Object.tmp", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
 	It("NewGCSDataSource should Pass, when passed in an valid https endpoint with authentication", func() {
 		var sampleCredential = filepath.Join(imageDir, "gcs-secret.txt")
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/Object.tmp", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/Object.tmp", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
 	It("NewGCSDataSource should Pass, when passed in an valid gs endpoint with authentication", func() {
 		var sampleCredential = filepath.Join(imageDir, "gcs-secret.txt")
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
-		sd, err = NewGCSDataSource("gs://Bucket1/Object.tmp", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/Object.tmp", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -87,7 +100,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/content.tar", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/content.tar", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -98,7 +111,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("Info should return TransferDataFile, when passed in a valid RAW image using anonymous client and GCS endpoint", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -109,7 +122,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("Info should return TransferScratch, when passed in a valid QCOW2 image using anonymous client and GCS endpoint", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -124,7 +137,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/content.tar", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/content.tar", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -137,7 +150,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -150,7 +163,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -163,7 +176,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/content.tar", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/content.tar", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -174,7 +187,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("Info should return TransferDataFile, when passed in a valid RAW image using anonymous client and HTTP(s) endpoint", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -185,7 +198,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("Info should return TransferScratch, when passed in a valid QCOW2 image using anonymous client and HTTP(s) endpoint", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -200,7 +213,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/content.tar", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/content.tar", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -213,7 +226,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -226,7 +239,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -237,7 +250,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and GCS URL should succeed reading RAW image when writing to valid file", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -251,7 +264,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and GCS URL should succeed reading QCOW2 image when writing to valid file", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -265,7 +278,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and GCS should fail reading RAW image on streaming error", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -279,7 +292,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and GCS should fail reading QCOW2 image on streaming error", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -295,7 +308,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -311,7 +324,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -327,7 +340,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros.raw", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -343,7 +356,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("gs://Bucket1/cirros-qcow2.img", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -357,7 +370,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and HTTP(s) URL should succeed reading RAW image when writing to valid file", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -371,7 +384,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and HTTP(s) URL should succeed reading QCOW2 image when writing to valid file", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -385,7 +398,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and HTTP(s) should fail reading RAW image on streaming error", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -399,7 +412,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	It("TransferFile using anonymous client and HTTP(s) should fail reading QCOW2 image on streaming error", func() {
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -415,7 +428,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -431,7 +444,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -447,7 +460,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros.raw"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros.raw", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -463,7 +476,7 @@ var _ = Describe("Google Cloud Storage data source", func() {
 		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", sampleCredential)
 		file, err := os.Open(filepath.Join(imageDir, "cirros-qcow2.img"))
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret")
+		sd, err = NewGCSDataSource("https://storage.cloud.google.com/Bucket1/cirros-qcow2.img", "gcs-secret", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.gcsReader = file
 		result, err := sd.Info()
@@ -475,6 +488,183 @@ var _ = Describe("Google Cloud Storage data source", func() {
 	})
 })
 
+var _ = Describe("GCS data source with a fake GCS server", func() {
+	const (
+		testGcsObjectPath = "/images/disks/disk.img"
+		testGcsGeneration = "1700000000000001"
+		testGcsProvider   = "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/providers/cluster"
+	)
+
+	var (
+		ts             *httptest.Server
+		sd             *GCSDataSource
+		tmpDir         string
+		objectData     []byte
+		objectRequests []*http.Request
+		truncate       bool
+		err            error
+	)
+
+	BeforeEach(func() {
+		newReaderFunc = getGcsObjectReader
+		createNbdkitCurl = image.NewMockNbdkitCurl
+		objectData = bytes.Repeat([]byte("0123456789abcdef"), 64*1024)
+		objectRequests = nil
+		truncate = false
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost && r.URL.Path == "/token":
+				fmt.Fprint(w, `{"access_token":"key-token","token_type":"Bearer","expires_in":3600}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/sts":
+				Expect(r.ParseForm()).To(Succeed())
+				Expect(r.PostForm.Get("audience")).To(Equal(testGcsProvider))
+				Expect(r.PostForm.Get("subject_token")).To(Equal("projected-token"))
+				Expect(r.PostForm.Get("subject_token_type")).To(Equal(gcsSubjectTokenType))
+				fmt.Fprint(w, `{"access_token":"federated-token","issued_token_type":"urn:ietf:params:oauth:token-type:access_token",`+
+					`"token_type":"Bearer","expires_in":3600}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/iam/importer@project.iam.gserviceaccount.com:generateAccessToken":
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer federated-token"))
+				fmt.Fprintf(w, `{"accessToken":"impersonated-token","expireTime":"%s"}`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+			case r.Method == http.MethodGet && r.URL.Path == testGcsObjectPath:
+				objectRequests = append(objectRequests, r)
+				w.Header().Set("X-Goog-Generation", testGcsGeneration)
+				if truncate {
+					// Close the connection in the middle of the object
+					truncate = false
+					w.Header().Set("Content-Length", fmt.Sprint(len(objectData)))
+					_, _ = w.Write(objectData[:len(objectData)/2])
+					return
+				}
+				http.ServeContent(w, r, "disk.img", time.Time{}, bytes.NewReader(objectData))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		tmpDir, err = os.MkdirTemp("", "scratch")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		createNbdkitCurl = image.NewNbdkitCurl
+		gcsSTSTokenURL = "https://sts.googleapis.com/v1/token"
+		gcsImpersonationURLFmt = "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken"
+		if sd != nil {
+			sd.Close()
+			sd = nil
+		}
+		ts.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	importObject := func(keyFile string, opts *GCSOptions) []byte {
+		sd, err = NewGCSDataSource(ts.URL+testGcsObjectPath, keyFile, opts)
+		Expect(err).ToNot(HaveOccurred())
+		phase, err := sd.Info()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataFile))
+		target := filepath.Join(tmpDir, "disk.img")
+		phase, err = sd.TransferFile(target, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseResize))
+		data, err := os.ReadFile(target)
+		Expect(err).ToNot(HaveOccurred())
+		return data
+	}
+
+	It("should import an object of a public bucket anonymously", func() {
+		Expect(importObject("", nil)).To(Equal(objectData))
+		for _, r := range objectRequests {
+			Expect(r.Header.Get("Authorization")).To(BeEmpty())
+		}
+		objectURL, err := sd.getObjectURL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objectURL.String()).To(Equal(ts.URL + testGcsObjectPath + "?generation=" + testGcsGeneration))
+	})
+
+	It("should authenticate with the key file of the secret and sign the url of the object", func() {
+		keyFile := filepath.Join(tmpDir, "credentials.json")
+		Expect(os.WriteFile(keyFile, testGcsServiceAccountKey(ts.URL+"/token"), 0600)).To(Succeed())
+		previous, wasSet := os.LookupEnv("GOOGLE_APPLICATION_CREDENTIALS")
+		os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", keyFile)
+		defer func() {
+			if wasSet {
+				os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", previous)
+			} else {
+				os.Unsetenv("GOOGLE_APPLICATION_CREDENTIALS")
+			}
+		}()
+		Expect(importObject(keyFile, nil)).To(Equal(objectData))
+		Expect(objectRequests[0].Header.Get("Authorization")).To(Equal("Bearer key-token"))
+
+		objectURL, err := sd.getObjectURL()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(objectURL.Scheme).To(Equal("http"))
+		Expect(objectURL.Host).To(Equal(strings.TrimPrefix(ts.URL, "http://")))
+		Expect(objectURL.Path).To(Equal(testGcsObjectPath))
+		Expect(objectURL.Query().Get("generation")).To(Equal(testGcsGeneration))
+		Expect(objectURL.Query().Get("X-Goog-Credential")).To(HavePrefix("importer@project.iam.gserviceaccount.com/"))
+		Expect(objectURL.Query().Get("X-Goog-Signature")).ToNot(BeEmpty())
+	})
+
+	It("should exchange the projected token with the workload identity provider", func() {
+		gcsSTSTokenURL = ts.URL + "/sts"
+		gcsImpersonationURLFmt = ts.URL + "/iam/%s:generateAccessToken"
+		tokenFile := filepath.Join(tmpDir, "token")
+		Expect(os.WriteFile(tokenFile, []byte("projected-token"), 0600)).To(Succeed())
+		Expect(importObject("", &GCSOptions{
+			WorkloadIdentity:          true,
+			WorkloadIdentityProvider:  testGcsProvider,
+			ServiceAccount:            "importer@project.iam.gserviceaccount.com",
+			WorkloadIdentityTokenFile: tokenFile,
+		})).To(Equal(objectData))
+		Expect(objectRequests[0].Header.Get("Authorization")).To(Equal("Bearer impersonated-token"))
+	})
+
+	It("should resume reading the same generation when the connection is lost", func() {
+		truncate = true
+		Expect(importObject("", nil)).To(Equal(objectData))
+		Expect(len(objectRequests)).To(BeNumerically(">", 1))
+		resumed := objectRequests[len(objectRequests)-1]
+		Expect(resumed.Header.Get("Range")).To(HavePrefix("bytes="))
+		Expect(resumed.URL.Query().Get("generation")).To(Equal(testGcsGeneration))
+	})
+
+	It("should convert qcow2 objects directly through nbdkit", func() {
+		objectData, err = os.ReadFile(cirrosFilePath)
+		Expect(err).ToNot(HaveOccurred())
+		sd, err = NewGCSDataSource(ts.URL+testGcsObjectPath, "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		phase, err := sd.Info()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		Expect(sd.GetURL().String()).To(Equal("nbd+unix:///?socket=" + nbdkitSocket))
+	})
+
+	It("should fail if the object does not exist", func() {
+		_, err = NewGCSDataSource(ts.URL+"/images/missing.img", "", nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+// testGcsServiceAccountKey returns the key file of a service account getting its tokens from tokenURL
+func testGcsServiceAccountKey(tokenURL string) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ToNot(HaveOccurred())
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+	keyFile, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "project",
+		"private_key_id": "key",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   "importer@project.iam.gserviceaccount.com",
+		"client_id":      "1",
+		"token_uri":      tokenURL,
+	})
+	Expect(err).ToNot(HaveOccurred())
+	return keyFile
+}
+
 // Create Cloud Storage Object Reader pointing to a sample image
 func mockGcsObjectReader(ctx context.Context, client *storage.Client, bucket, object string) (io.ReadCloser, error) {
 	var sampleImage = filepath.Join(imageDir, "cirros.raw")
@@ -373,7 +373,7 @@ func createHTTPReader(ctx context.Context, ep *url.URL, accessKey, secKey, certD
 	}
 	if want := http.StatusOK; resp.StatusCode != want {
 		klog.Errorf("http: expected status code %d, got %d", want, resp.StatusCode)
-		return nil, uint64(0), true, errors.Errorf("expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status)
+		return nil, uint64(0), true, util.NewHTTPStatusError(resp.StatusCode, errors.Errorf("expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status))
 	}
 
 	if contentType == cdiv1.DataVolumeKubeVirt {
@@ -396,13 +396,63 @@ func createHTTPReader(ctx context.Context, ep *url.URL, accessKey, secKey, certD
 		// The total seems bogus. Let's try the GET Content-Length header
 		total = parseHTTPHeader(resp)
 	}
+	var reader io.ReadCloser = resp.Body
+	if cache := GetChunkCache(); cache != nil {
+		if key := httpChunkCacheKey(ep, resp, total); key != "" {
+			ranged := ok && acceptRanges[0] == "bytes"
+			open := func(offset int64) (io.ReadCloser, error) {
+				return getHTTPRange(ctx, client, ep, accessKey, secKey, allExtraHeaders, resp.Header, offset)
+			}
+			reader = cache.NewCachingReader(key, resp.Body, open, ranged)
+			// qemu-img reading the endpoint directly would bypass the cache
+			brokenForQemuImg = true
+		}
+	}
 	countingReader := &util.CountingReader{
-		Reader:  resp.Body,
+		Reader:  reader,
 		Current: 0,
 	}
 	return countingReader, total, brokenForQemuImg, nil
 }
 
+// httpChunkCacheKey identifies the content of the endpoint in the chunk cache, only endpoints with a validator can be cached
+func httpChunkCacheKey(ep *url.URL, resp *http.Response, total uint64) string {
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if (etag == "" && lastModified == "") || total == 0 {
+		return ""
+	}
+	return strings.Join([]string{"http", ep.String(), etag, lastModified, strconv.FormatUint(total, 10)}, "\n")
+}
+
+// getHTTPRange gets the content of the endpoint from offset, failing if it changed since the response with header
+func getHTTPRange(ctx context.Context, client *http.Client, ep *url.URL, accessKey, secKey string, extraHeaders []string, header http.Header, offset int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create HTTP request")
+	}
+	addExtraheaders(req, extraHeaders)
+	if len(accessKey) > 0 && len(secKey) > 0 {
+		req.SetBasicAuth(accessKey, secKey)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	if etag := header.Get("ETag"); etag != "" {
+		req.Header.Set("If-Match", etag)
+	} else {
+		req.Header.Set("If-Unmodified-Since", header.Get("Last-Modified"))
+	}
+	klog.V(2).Infof("Attempting to get object %q from offset %d via http client\n", ep.String(), offset)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "HTTP request errored")
+	}
+	if want := http.StatusPartialContent; resp.StatusCode != want {
+		resp.Body.Close()
+		return nil, util.NewHTTPStatusError(resp.StatusCode, errors.Errorf("expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status))
+	}
+	return resp.Body, nil
+}
+
 func (hs *HTTPDataSource) pollProgress(reader *util.CountingReader, idleTime, pollInterval time.Duration) {
 	count := reader.Current
 	lastUpdate := time.Now()
@@ -449,7 +499,7 @@ func getContentLength(client *http.Client, ep *url.URL, accessKey, secKey string
 
 	if want := http.StatusOK; resp.StatusCode != want {
 		klog.Errorf("http: expected status code %d, got %d", want, resp.StatusCode)
-		return uint64(0), errors.Errorf("expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status)
+		return uint64(0), util.NewHTTPStatusError(resp.StatusCode, errors.Errorf("expected status code %d, got %d. Status: %s", want, resp.StatusCode, resp.Status))
 	}
 
 	for k, v := range resp.Header {
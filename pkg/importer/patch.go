@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	gptSignature       = "EFI PART"
+	gptHeaderSize      = 92
+	gptMinEntrySize    = 128
+	gptMaxEntries      = 4096
+	gptEntryNameOffset = 56
+)
+
+// gptSectorSizes are the logical sector sizes a GPT header is looked up with
+var gptSectorSizes = []int64{512, 4096}
+
+// PatchDisk writes the raw image in patchFile into the existing disk in diskFile, at the byte offset or into
+// the GPT partition named partitionLabel when it is set. The image must fit in the disk, or in the partition.
+func PatchDisk(patchFile, diskFile string, offset int64, partitionLabel string) error {
+	patch, err := os.Open(patchFile)
+	if err != nil {
+		return errors.Wrapf(err, "could not open patch %q", patchFile)
+	}
+	defer patch.Close()
+	info, err := patch.Stat()
+	if err != nil {
+		return err
+	}
+	patchSize := info.Size()
+
+	// The disk is neither created nor truncated, the rest of it is kept as is
+	disk, err := os.OpenFile(diskFile, os.O_RDWR, 0)
+	if err != nil {
+		return errors.Wrapf(err, "could not open disk %q", diskFile)
+	}
+	defer disk.Close()
+	diskSize, err := disk.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	limit := diskSize - offset
+	if partitionLabel != "" {
+		offset, limit, err = findGPTPartition(disk, partitionLabel)
+		if err != nil {
+			return err
+		}
+		klog.V(1).Infof("Found partition %q at offset %d, %d bytes", partitionLabel, offset, limit)
+	}
+	if offset < 0 || offset > diskSize || patchSize > limit || offset+patchSize > diskSize {
+		return errors.Errorf("patch of %d bytes does not fit at offset %d of the %d bytes disk", patchSize, offset, diskSize)
+	}
+
+	klog.V(1).Infof("Writing %d bytes at offset %d of %s", patchSize, offset, diskFile)
+	if _, err := io.Copy(io.NewOffsetWriter(disk, offset), patch); err != nil {
+		return errors.Wrap(err, "unable to write patch into disk")
+	}
+	return disk.Sync()
+}
+
+// gpt is the primary GUID partition table of a disk
+type gpt struct {
+	sectorSize int64
+	header     []byte
+	entries    []byte
+	entrySize  int64
+}
+
+// readGPT reads the primary GPT of a disk, looking its header up with each of the logical sector sizes
+func readGPT(r io.ReaderAt) (*gpt, error) {
+	for _, sectorSize := range gptSectorSizes {
+		header := make([]byte, sectorSize)
+		if _, err := r.ReadAt(header, sectorSize); err != nil || string(header[:len(gptSignature)]) != gptSignature {
+			continue
+		}
+
+		entriesLBA := int64(binary.LittleEndian.Uint64(header[72:80]))
+		numEntries := int64(binary.LittleEndian.Uint32(header[80:84]))
+		entrySize := int64(binary.LittleEndian.Uint32(header[84:88]))
+		if entrySize < gptMinEntrySize || numEntries > gptMaxEntries {
+			return nil, errors.Errorf("invalid GPT with %d entries of %d bytes", numEntries, entrySize)
+		}
+
+		entries := make([]byte, numEntries*entrySize)
+		if _, err := r.ReadAt(entries, entriesLBA*sectorSize); err != nil {
+			return nil, errors.Wrap(err, "unable to read GPT partition entries")
+		}
+		return &gpt{sectorSize: sectorSize, header: header, entries: entries, entrySize: entrySize}, nil
+	}
+	return nil, errors.New("disk has no GPT")
+}
+
+// numEntries returns the number of partition entries of the table, used or not
+func (t *gpt) numEntries() int {
+	return int(int64(len(t.entries)) / t.entrySize)
+}
+
+// entry returns the partition entry at index i, or nil when it is unused
+func (t *gpt) entry(i int) []byte {
+	entry := t.entries[int64(i)*t.entrySize : int64(i+1)*t.entrySize]
+	if bytes.Equal(entry[:16], make([]byte, 16)) {
+		return nil
+	}
+	return entry
+}
+
+// gptEntryRange returns the first and last sectors of a partition entry
+func gptEntryRange(entry []byte) (int64, int64) {
+	return int64(binary.LittleEndian.Uint64(entry[32:40])), int64(binary.LittleEndian.Uint64(entry[40:48]))
+}
+
+// findGPTPartition returns the byte offset and size of the GPT partition named label
+func findGPTPartition(r io.ReaderAt, label string) (int64, int64, error) {
+	table, err := readGPT(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	for i := 0; i < table.numEntries(); i++ {
+		entry := table.entry(i)
+		if entry == nil || gptEntryName(entry[gptEntryNameOffset:gptMinEntrySize]) != label {
+			continue
+		}
+		first, last := gptEntryRange(entry)
+		return first * table.sectorSize, (last - first + 1) * table.sectorSize, nil
+	}
+	return 0, 0, errors.Errorf("partition %q not found", label)
+}
+
+// gptEntryName decodes the UTF-16LE name of a GPT partition entry
+func gptEntryName(b []byte) string {
+	name := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i:])
+		if c == 0 {
+			break
+		}
+		name = append(name, c)
+	}
+	return string(utf16.Decode(name))
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"unicode/utf16"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testSectorSize  = 512
+	testDiskSize    = 64 * testSectorSize
+	testPartFirst   = 34
+	testPartSectors = 8
+)
+
+// createTestGPTDisk returns a disk with a GPT holding a single partition named label
+func createTestGPTDisk(label string) []byte {
+	disk := make([]byte, testDiskSize)
+	header := disk[testSectorSize:]
+	copy(header, gptSignature)
+	binary.LittleEndian.PutUint64(header[72:], 2)
+	binary.LittleEndian.PutUint32(header[80:], 4)
+	binary.LittleEndian.PutUint32(header[84:], gptMinEntrySize)
+
+	entry := disk[2*testSectorSize:]
+	copy(entry[:16], bytes.Repeat([]byte{0xaf}, 16))
+	binary.LittleEndian.PutUint64(entry[32:], testPartFirst)
+	binary.LittleEndian.PutUint64(entry[40:], testPartFirst+testPartSectors-1)
+	for i, c := range utf16.Encode([]rune(label)) {
+		binary.LittleEndian.PutUint16(entry[gptEntryNameOffset+2*i:], c)
+	}
+	return disk
+}
+
+var _ = Describe("Disk patch", func() {
+	var tmpDir string
+
+	BeforeEach(func() {
+		tmpDir = GinkgoT().TempDir()
+	})
+
+	writeFile := func(name string, content []byte) string {
+		path := filepath.Join(tmpDir, name)
+		Expect(os.WriteFile(path, content, 0600)).To(Succeed())
+		return path
+	}
+
+	It("should find a GPT partition by its label", func() {
+		start, size, err := findGPTPartition(bytes.NewReader(createTestGPTDisk("EFI")), "EFI")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(start).To(Equal(int64(testPartFirst * testSectorSize)))
+		Expect(size).To(Equal(int64(testPartSectors * testSectorSize)))
+	})
+
+	It("should fail to find a missing GPT partition", func() {
+		_, _, err := findGPTPartition(bytes.NewReader(createTestGPTDisk("EFI")), "root")
+		Expect(err).To(MatchError(ContainSubstring("not found")))
+		_, _, err = findGPTPartition(bytes.NewReader(make([]byte, testDiskSize)), "EFI")
+		Expect(err).To(MatchError(ContainSubstring("no GPT")))
+	})
+
+	DescribeTable("should write the patch into the disk", func(offset int64, label string, expectedOffset int) {
+		disk := createTestGPTDisk("EFI")
+		diskFile := writeFile("disk.img", disk)
+		patch := bytes.Repeat([]byte{0xab}, 2*testSectorSize)
+		Expect(PatchDisk(writeFile("patch.img", patch), diskFile, offset, label)).To(Succeed())
+
+		patched, err := os.ReadFile(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patched).To(HaveLen(testDiskSize))
+		expected := append([]byte{}, disk...)
+		copy(expected[expectedOffset:], patch)
+		Expect(patched).To(Equal(expected))
+	},
+		Entry("at an offset", int64(40*testSectorSize), "", 40*testSectorSize),
+		Entry("into a partition", int64(0), "EFI", testPartFirst*testSectorSize),
+	)
+
+	DescribeTable("should not write a patch that does not fit", func(offset int64, label string) {
+		disk := createTestGPTDisk("EFI")
+		diskFile := writeFile("disk.img", disk)
+		patch := bytes.Repeat([]byte{0xab}, (testPartSectors+1)*testSectorSize)
+		Expect(PatchDisk(writeFile("patch.img", patch), diskFile, offset, label)).ToNot(Succeed())
+
+		patched, err := os.ReadFile(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(patched).To(Equal(disk))
+	},
+		Entry("past the end of the disk", int64(testDiskSize-testSectorSize), ""),
+		Entry("in a partition", int64(0), "EFI"),
+	)
+})
@@ -1,14 +1,19 @@
 package importer
 
 import (
+	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/pkg/errors"
@@ -16,11 +21,15 @@ import (
 	"k8s.io/klog/v2"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
 )
 
 const (
 	s3FolderSep = "/"
 	httpScheme  = "http"
+
+	s3PresignLifetime        = 24 * time.Hour
+	s3WebIdentitySessionName = "cdi-importer"
 )
 
 // S3Client is the interface to the used S3 client.
@@ -28,13 +37,36 @@ type S3Client interface {
 	GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
 }
 
+// s3ObjectPresigner is implemented by the S3 clients able to presign requests, so nbdkit can read objects directly
+type s3ObjectPresigner interface {
+	GetObjectRequest(input *s3.GetObjectInput) (*request.Request, *s3.GetObjectOutput)
+}
+
+// S3Options are the optional settings of the S3 client
+type S3Options struct {
+	// Region of the bucket, derived from the endpoint if empty
+	Region string
+	// VirtualHostStyle addresses the bucket in the host name instead of the path of the requests
+	VirtualHostStyle bool
+	// RoleARN is the IAM role assumed with the web identity token, instead of using the static keys
+	RoleARN string
+	// WebIdentityTokenFile is the projected service account token used to assume RoleARN
+	WebIdentityTokenFile string
+	// STSEndpoint is the url of the STS service, the AWS STS endpoint of the region if empty
+	STSEndpoint string
+}
+
 // may be overridden in tests
 var newClientFunc = getS3Client
 
 // S3DataSource is the struct containing the information needed to import from an S3 data source.
+// Objects are read with ranged GETs, and qemu-img reads them directly through nbdkit with a presigned url,
+// unless the web identity credentials are used.
 // Sequence of phases:
-// 1. Info -> Transfer
-// 2. Transfer -> Convert
+// 1a. Info -> TransferDataFile if the object is a raw image.
+// 1b. Info -> Convert if the object can be converted by qemu-img through nbdkit.
+// 1c. Info -> TransferScratch in all other cases.
+// 2.  TransferScratch -> Convert
 type S3DataSource struct {
 	// S3 end point
 	ep *url.URL
@@ -44,34 +76,48 @@ type S3DataSource struct {
 	secKey string
 	// Reader
 	s3Reader io.ReadCloser
+	// the size of the object, 0 if unknown
+	size uint64
+	// the presigned url of the object, nil if the client cannot presign requests
+	objectURL *url.URL
+	// true if the object is read through the chunk cache
+	cached bool
 	// stack of readers
 	readers *FormatReaders
-	// The image file in scratch space.
+	// The image file in scratch space, or the nbdkit socket.
 	url *url.URL
+
+	n image.NbdkitOperation
 }
 
 // NewS3DataSource creates a new instance of the S3DataSource
-func NewS3DataSource(endpoint, accessKey, secKey string, certDir string) (*S3DataSource, error) {
+func NewS3DataSource(endpoint, accessKey, secKey string, certDir string, opts *S3Options) (*S3DataSource, error) {
 	ep, err := ParseEndpoint(endpoint)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to parse endpoint %q", endpoint)
 	}
-	s3Reader, err := createS3Reader(ep, accessKey, secKey, certDir)
-	if err != nil {
-		return nil, err
-	}
-	return &S3DataSource{
+	sd := &S3DataSource{
 		ep:        ep,
 		accessKey: accessKey,
 		secKey:    secKey,
-		s3Reader:  s3Reader,
-	}, nil
+	}
+	if err := sd.openObject(certDir, opts); err != nil {
+		return nil, err
+	}
+	if sd.objectURL != nil {
+		sd.n, err = createNbdkitCurl(nbdkitPid, "", "", certDir, nbdkitSocket, nil, nil)
+		if err != nil {
+			sd.Close()
+			return nil, err
+		}
+	}
+	return sd, nil
 }
 
 // Info is called to get initial information about the data.
 func (sd *S3DataSource) Info() (ProcessingPhase, error) {
 	var err error
-	sd.readers, err = NewFormatReaders(sd.s3Reader, uint64(0))
+	sd.readers, err = NewFormatReaders(sd.s3Reader, sd.size)
 	if err != nil {
 		klog.Errorf("Error creating readers: %v", err)
 		return ProcessingPhaseError, err
@@ -80,7 +126,14 @@ func (sd *S3DataSource) Info() (ProcessingPhase, error) {
 		// Downloading a raw file, we can write that directly to the target.
 		return ProcessingPhaseTransferDataFile, nil
 	}
-
+	if !sd.readers.Archived && !sd.cached && sd.objectURL != nil {
+		sd.url, _ = url.Parse(fmt.Sprintf("nbd+unix:///?socket=%s", nbdkitSocket))
+		if err = sd.n.StartNbdkit(sd.objectURL.String()); err == nil {
+			return ProcessingPhaseConvert, nil
+		}
+		klog.Warningf("Unable to start nbdkit, downloading the object to scratch space: %v", err)
+	}
+	sd.url = nil
 	return ProcessingPhaseTransferScratch, nil
 }
 
@@ -134,24 +187,24 @@ func (sd *S3DataSource) Close() error {
 	var err error
 	if sd.readers != nil {
 		err = sd.readers.Close()
+	} else if sd.s3Reader != nil {
+		err = sd.s3Reader.Close()
 	}
 	return err
 }
 
-func createS3Reader(ep *url.URL, accessKey, secKey string, certDir string) (io.ReadCloser, error) {
+// openObject gets the object and opens it for reading, with ranged GETs when its size and ETag are known
+func (sd *S3DataSource) openObject(certDir string, opts *S3Options) error {
 	klog.V(3).Infoln("Using S3 client to get data")
 
-	endpoint := ep.Host
-	urlScheme := ep.Scheme
-	klog.Infof("Endpoint %s", endpoint)
-	path := strings.Trim(ep.Path, "/")
-	bucket, object := extractBucketAndObject(path)
+	klog.Infof("Endpoint %s", sd.ep.Host)
+	bucket, object := extractBucketAndObject(strings.Trim(sd.ep.Path, "/"))
 
 	klog.V(1).Infof("bucket %s", bucket)
 	klog.V(1).Infof("object %s", object)
-	svc, err := newClientFunc(endpoint, accessKey, secKey, certDir, urlScheme)
+	svc, err := newClientFunc(sd.ep.Host, sd.accessKey, sd.secKey, certDir, sd.ep.Scheme, opts)
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not build s3 client for %q", ep.Host)
+		return errors.Wrapf(err, "could not build s3 client for %q", sd.ep.Host)
 	}
 
 	objInput := &s3.GetObjectInput{
@@ -160,13 +213,62 @@ func createS3Reader(ep *url.URL, accessKey, secKey string, certDir string) (io.R
 	}
 	objOutput, err := svc.GetObject(objInput)
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not get s3 object: \"%s/%s\"", bucket, object)
+		return errors.Wrapf(err, "could not get s3 object: \"%s/%s\"", bucket, object)
+	}
+	size := aws.Int64Value(objOutput.ContentLength)
+	etag := aws.StringValue(objOutput.ETag)
+	if size <= 0 || etag == "" || objOutput.Body == nil {
+		// The object can only be read sequentially
+		sd.s3Reader = objOutput.Body
+		return nil
+	}
+	sd.size = uint64(size)
+	klog.V(1).Infof("Object size %d, ETag %s", size, etag)
+
+	if opts != nil && opts.RoleARN != "" {
+		// A presigned url expires with the STS session it was signed with, usually long before the import ends,
+		// the object is streamed through the client, which refreshes the session
+		klog.V(1).Infof("Not presigning the url of the object, it is read with the web identity of role %s", opts.RoleARN)
+	} else if presigner, ok := svc.(s3ObjectPresigner); ok {
+		req, _ := presigner.GetObjectRequest(objInput)
+		presigned, err := req.Presign(s3PresignLifetime)
+		if err == nil {
+			sd.objectURL, err = url.Parse(presigned)
+		}
+		if err != nil {
+			klog.Warningf("Unable to presign the url of the object, qemu-img will not read it directly: %v", err)
+		}
+	}
+
+	open := func(offset int64) (io.ReadCloser, error) {
+		klog.V(2).Infof("Attempting to get object %s/%s from offset %d", bucket, object, offset)
+		out, err := svc.GetObject(&s3.GetObjectInput{
+			Bucket:  aws.String(bucket),
+			Key:     aws.String(object),
+			IfMatch: aws.String(etag),
+			Range:   aws.String(fmt.Sprintf("bytes=%d-", offset)),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get s3 object: \"%s/%s\"", bucket, object)
+		}
+		return out.Body, nil
 	}
-	objectReader := objOutput.Body
-	return objectReader, nil
+	if cache := GetChunkCache(); cache != nil {
+		key := strings.Join([]string{"s3", sd.ep.Scheme, sd.ep.Host, bucket, object, etag, fmt.Sprint(size)}, "\n")
+		// qemu-img reading the object directly would bypass the cache
+		sd.cached = true
+		sd.s3Reader = cache.NewCachingReader(key, objOutput.Body, open, true)
+		return nil
+	}
+	sd.s3Reader = &rangeReader{open: open, size: size, body: objOutput.Body}
+	return nil
 }
 
-func getS3Client(endpoint, accessKey, secKey string, certDir string, urlScheme string) (S3Client, error) {
+func getS3Client(endpoint, accessKey, secKey string, certDir string, urlScheme string, opts *S3Options) (S3Client, error) {
+	return newS3Service(endpoint, accessKey, secKey, certDir, urlScheme, opts)
+}
+
+func newS3Service(endpoint, accessKey, secKey string, certDir string, urlScheme string, opts *S3Options) (*s3.S3, error) {
 	// Adding certs using CustomCABundle will overwrite the SystemCerts, so we opt by creating a custom HTTPClient
 	httpClient, err := createHTTPClient(certDir, false)
 
@@ -174,8 +276,26 @@ func getS3Client(endpoint, accessKey, secKey string, certDir string, urlScheme s
 		return nil, errors.Wrap(err, "Error creating http client for s3")
 	}
 
-	creds := credentials.NewStaticCredentials(accessKey, secKey, "")
-	region := extractRegion(endpoint)
+	if opts == nil {
+		opts = &S3Options{}
+	}
+	region := opts.Region
+	if region == "" {
+		region = extractRegion(endpoint)
+	}
+	var creds *credentials.Credentials
+	switch {
+	case opts.RoleARN != "":
+		klog.V(1).Infof("Assuming role %s with web identity", opts.RoleARN)
+		creds, err = newS3WebIdentityCredentials(httpClient, region, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error creating web identity credentials for s3")
+		}
+	case accessKey == "" && secKey == "":
+		creds = credentials.AnonymousCredentials
+	default:
+		creds = credentials.NewStaticCredentials(accessKey, secKey, "")
+	}
 	disableSSL := false
 	// Disable SSL for http endpoint. This should cause the s3 client to create http requests.
 	if urlScheme == httpScheme {
@@ -186,7 +306,7 @@ func getS3Client(endpoint, accessKey, secKey string, certDir string, urlScheme s
 		Region:           aws.String(region),
 		Endpoint:         aws.String(endpoint),
 		Credentials:      creds,
-		S3ForcePathStyle: aws.Bool(true),
+		S3ForcePathStyle: aws.Bool(!opts.VirtualHostStyle),
 		HTTPClient:       httpClient,
 		DisableSSL:       &disableSSL,
 	},
@@ -199,6 +319,23 @@ func getS3Client(endpoint, accessKey, secKey string, certDir string, urlScheme s
 	return svc, nil
 }
 
+// newS3WebIdentityCredentials returns credentials of opts.RoleARN, assumed with the web identity token file, and
+// refreshed before they expire. Urls presigned with these credentials expire with them.
+func newS3WebIdentityCredentials(httpClient *http.Client, region string, opts *S3Options) (*credentials.Credentials, error) {
+	config := &aws.Config{
+		Region:     aws.String(region),
+		HTTPClient: httpClient,
+	}
+	if opts.STSEndpoint != "" {
+		config.Endpoint = aws.String(opts.STSEndpoint)
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	return stscreds.NewWebIdentityCredentials(sess, opts.RoleARN, s3WebIdentitySessionName, opts.WebIdentityTokenFile), nil
+}
+
 func extractRegion(s string) string {
 	var region string
 	r, _ := regexp.Compile(`s3\.(.+)\.amazonaws\.com`)
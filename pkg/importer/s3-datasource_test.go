@@ -1,16 +1,25 @@
 package importer
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/pkg/errors"
+
+	"kubevirt.io/containerized-data-importer/pkg/image"
 )
 
 var _ = Describe("S3 data source", func() {
@@ -36,25 +45,25 @@ var _ = Describe("S3 data source", func() {
 	})
 
 	It("NewS3DataSource should Error, when passed in an invalid endpoint", func() {
-		sd, err = NewS3DataSource("thisisinvalid#$%#ep", "", "", "")
+		sd, err = NewS3DataSource("thisisinvalid#$%#ep", "", "", "", nil)
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("NewS3DataSource should Error, when failing to create S3 client", func() {
 		newClientFunc = failMockS3Client
-		sd, err = NewS3DataSource("http://amazon.com", "", "", "")
+		sd, err = NewS3DataSource("http://amazon.com", "", "", "", nil)
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("NewS3DataSource should Error, when failing to get object", func() {
 		newClientFunc = createErrMockS3Client
-		sd, err = NewS3DataSource("http://amazon.com", "", "", "")
+		sd, err = NewS3DataSource("http://amazon.com", "", "", "", nil)
 		Expect(err).To(HaveOccurred())
 	})
 
 	It("NewS3DataSource should fail when called with an invalid certdir", func() {
 		newClientFunc = getS3Client
-		sd, err = NewS3DataSource("http://amazon.com", "", "", "/invaliddir")
+		sd, err = NewS3DataSource("http://amazon.com", "", "", "/invaliddir", nil)
 		Expect(err).To(HaveOccurred())
 	})
 
@@ -64,7 +73,7 @@ var _ = Describe("S3 data source", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = file.Close()
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.s3Reader = file
 		result, err := sd.Info()
@@ -76,7 +85,7 @@ var _ = Describe("S3 data source", func() {
 		// Don't need to defer close, since ud.Close will close the reader
 		file, err := os.Open(cirrosFilePath)
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.s3Reader = file
 		result, err := sd.Info()
@@ -88,7 +97,7 @@ var _ = Describe("S3 data source", func() {
 		// Don't need to defer close, since ud.Close will close the reader
 		file, err := os.Open(tinyCoreFilePath)
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		sd.s3Reader = file
 		result, err := sd.Info()
@@ -103,7 +112,7 @@ var _ = Describe("S3 data source", func() {
 		sourceFile, err := os.Open(fileName)
 		Expect(err).NotTo(HaveOccurred())
 
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		// Replace minio.Object with a reader we can use.
 		sd.s3Reader = sourceFile
@@ -137,7 +146,7 @@ var _ = Describe("S3 data source", func() {
 		sourceFile, err := os.Open(cirrosFilePath)
 		Expect(err).NotTo(HaveOccurred())
 
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		// Replace minio.Object with a reader we can use.
 		sd.s3Reader = sourceFile
@@ -155,7 +164,7 @@ var _ = Describe("S3 data source", func() {
 		// Don't need to defer close, since ud.Close will close the reader
 		file, err := os.Open(tinyCoreFilePath)
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		// Replace minio.Object with a reader we can use.
 		sd.s3Reader = file
@@ -171,7 +180,7 @@ var _ = Describe("S3 data source", func() {
 		// Don't need to defer close, since ud.Close will close the reader
 		file, err := os.Open(tinyCoreFilePath)
 		Expect(err).NotTo(HaveOccurred())
-		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "")
+		sd, err = NewS3DataSource("http://region.amazon.com/bucket-1/object-1", "", "", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 		// Replace minio.Object with a reader we can use.
 		sd.s3Reader = file
@@ -184,7 +193,7 @@ var _ = Describe("S3 data source", func() {
 	})
 
 	It("GetS3Client should return a real client", func() {
-		_, err := getS3Client("", "", "", "", "")
+		_, err := getS3Client("", "", "", "", "", nil)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
@@ -199,6 +208,138 @@ var _ = Describe("S3 data source", func() {
 	})
 })
 
+var _ = Describe("S3 data source with an S3 server", func() {
+	var (
+		ts         *httptest.Server
+		sd         *S3DataSource
+		tmpDir     string
+		objectData []byte
+		objectReqs []*http.Request
+		truncate   bool
+		err        error
+	)
+
+	BeforeEach(func() {
+		createNbdkitCurl = image.NewMockNbdkitCurl
+		objectData = bytes.Repeat([]byte("0123456789abcdef"), 64*1024)
+		objectReqs = nil
+		truncate = false
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodPost && r.URL.Path == "/":
+				Expect(r.ParseForm()).To(Succeed())
+				Expect(r.PostForm.Get("Action")).To(Equal("AssumeRoleWithWebIdentity"))
+				Expect(r.PostForm.Get("RoleArn")).To(Equal("arn:aws:iam::123456789012:role/importer"))
+				Expect(r.PostForm.Get("WebIdentityToken")).To(Equal("service-account-token"))
+				fmt.Fprint(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">`+
+					`<AssumeRoleWithWebIdentityResult><Credentials><AccessKeyId>ASIAWEBIDENTITY</AccessKeyId>`+
+					`<SecretAccessKey>secret</SecretAccessKey><SessionToken>session</SessionToken>`+
+					`<Expiration>2099-01-01T00:00:00Z</Expiration></Credentials></AssumeRoleWithWebIdentityResult>`+
+					`</AssumeRoleWithWebIdentityResponse>`)
+			case r.URL.Path == "/bucket-1/dir/object-1":
+				objectReqs = append(objectReqs, r)
+				w.Header().Set("ETag", `"0123"`)
+				if truncate {
+					// Close the connection in the middle of the object
+					truncate = false
+					w.Header().Set("Content-Length", fmt.Sprint(len(objectData)))
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write(objectData[:len(objectData)/2])
+					return
+				}
+				http.ServeContent(w, r, "object-1", time.Time{}, bytes.NewReader(objectData))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		tmpDir, err = os.MkdirTemp("", "scratch")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		createNbdkitCurl = image.NewNbdkitCurl
+		if sd != nil {
+			sd.Close()
+			sd = nil
+		}
+		ts.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	importObject := func(accessKey, secKey string, opts *S3Options) []byte {
+		sd, err = NewS3DataSource(ts.URL+"/bucket-1/dir/object-1", accessKey, secKey, "", opts)
+		Expect(err).ToNot(HaveOccurred())
+		phase, err := sd.Info()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseTransferDataFile))
+		target := filepath.Join(tmpDir, "disk.img")
+		phase, err = sd.TransferFile(target, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseResize))
+		data, err := os.ReadFile(target)
+		Expect(err).ToNot(HaveOccurred())
+		return data
+	}
+
+	It("should read objects anonymously without keys", func() {
+		Expect(importObject("", "", nil)).To(Equal(objectData))
+		Expect(objectReqs[0].Header.Get("Authorization")).To(BeEmpty())
+	})
+
+	It("should sign requests with the keys and the region", func() {
+		Expect(importObject("access", "secret", &S3Options{Region: "eu-west-3"})).To(Equal(objectData))
+		Expect(objectReqs[0].Header.Get("Authorization")).To(ContainSubstring("Credential=access/"))
+		Expect(objectReqs[0].Header.Get("Authorization")).To(ContainSubstring("/eu-west-3/s3/aws4_request"))
+	})
+
+	It("should assume the role of the web identity", func() {
+		tokenFile := filepath.Join(tmpDir, "token")
+		Expect(os.WriteFile(tokenFile, []byte("service-account-token"), 0600)).To(Succeed())
+		opts := &S3Options{
+			RoleARN:              "arn:aws:iam::123456789012:role/importer",
+			WebIdentityTokenFile: tokenFile,
+			STSEndpoint:          ts.URL,
+		}
+		Expect(importObject("ignored", "ignored", opts)).To(Equal(objectData))
+		Expect(objectReqs[0].Header.Get("Authorization")).To(ContainSubstring("Credential=ASIAWEBIDENTITY/"))
+		Expect(objectReqs[0].Header.Get("X-Amz-Security-Token")).To(Equal("session"))
+		// Presigned urls would expire with the STS session
+		Expect(sd.objectURL).To(BeNil())
+	})
+
+	It("should resume reading the object with ranged GETs when the connection is lost", func() {
+		truncate = true
+		Expect(importObject("", "", nil)).To(Equal(objectData))
+		Expect(objectReqs).To(HaveLen(2))
+		Expect(objectReqs[1].Header.Get("Range")).To(Equal(fmt.Sprintf("bytes=%d-", len(objectData)/2)))
+		Expect(objectReqs[1].Header.Get("If-Match")).To(Equal(`"0123"`))
+	})
+
+	It("should convert qcow2 objects directly through nbdkit with a presigned url", func() {
+		objectData, err = os.ReadFile(cirrosFilePath)
+		Expect(err).ToNot(HaveOccurred())
+		sd, err = NewS3DataSource(ts.URL+"/bucket-1/dir/object-1", "access", "secret", "", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sd.objectURL.Query().Get("X-Amz-Signature")).ToNot(BeEmpty())
+		phase, err := sd.Info()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(phase).To(Equal(ProcessingPhaseConvert))
+		Expect(sd.GetURL().String()).To(Equal("nbd+unix:///?socket=" + nbdkitSocket))
+	})
+
+	DescribeTable("should address the bucket", func(opts *S3Options, host, path string) {
+		svc, err := newS3Service("s3.example.com", "", "", "", "https", opts)
+		Expect(err).ToNot(HaveOccurred())
+		req, _ := svc.GetObjectRequest(&s3.GetObjectInput{Bucket: aws.String("bucket-1"), Key: aws.String("dir/object-1")})
+		Expect(req.Build()).To(Succeed())
+		Expect(req.HTTPRequest.URL.Host).To(Equal(host))
+		Expect(strings.TrimPrefix(req.HTTPRequest.URL.Path, "/")).To(Equal(path))
+	},
+		Entry("in the path by default", nil, "s3.example.com", "bucket-1/dir/object-1"),
+		Entry("in the host name with virtual host style", &S3Options{VirtualHostStyle: true}, "bucket-1.s3.example.com", "dir/object-1"),
+	)
+})
+
 // MockS3Client is a mock AWS S3 client
 type MockS3Client struct {
 	endpoint string //nolint:unused // TODO: check if need to remove this field
@@ -208,11 +349,11 @@ type MockS3Client struct {
 	doErr    bool
 }
 
-func failMockS3Client(endpoint, accKey, secKey string, certDir string, urlScheme string) (S3Client, error) {
+func failMockS3Client(endpoint, accKey, secKey string, certDir string, urlScheme string, opts *S3Options) (S3Client, error) {
 	return nil, errors.New("Failed to create client")
 }
 
-func createMockS3Client(endpoint, accKey, secKey string, certDir string, urlScheme string) (S3Client, error) {
+func createMockS3Client(endpoint, accKey, secKey string, certDir string, urlScheme string, opts *S3Options) (S3Client, error) {
 	return &MockS3Client{
 		accKey:  accKey,
 		secKey:  secKey,
@@ -221,7 +362,7 @@ func createMockS3Client(endpoint, accKey, secKey string, certDir string, urlSche
 	}, nil
 }
 
-func createErrMockS3Client(endpoint, accKey, secKey string, certDir string, urlScheme string) (S3Client, error) {
+func createErrMockS3Client(endpoint, accKey, secKey string, certDir string, urlScheme string, opts *S3Options) (S3Client, error) {
 	return &MockS3Client{
 		doErr: true,
 	}, nil
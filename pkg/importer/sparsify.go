@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"io"
+	"os"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// sparsifyReadSize is the size of the reads scanning the disk image for blocks of zeroes
+	sparsifyReadSize = 1024 * 1024
+	// sparsifyFileBlockSize is the size of the blocks of zeroes deallocated from a file
+	sparsifyFileBlockSize = 4096
+	// sparsifyDeviceBlockSize is the size of the blocks of zeroes deallocated from a block device, larger than the
+	// discard granularity of most storage so that the deallocated blocks are actually unmapped
+	sparsifyDeviceBlockSize = 1024 * 1024
+)
+
+// Sparsify deallocates the blocks of zeroes of the raw disk image in dataFile and returns the number of bytes reclaimed,
+// or nil for a block device that can't tell the zeroes it unmapped from the ones that were never allocated.
+// Storage that can't deallocate blocks is left as it is.
+func Sparsify(dataFile string) (*int64, error) {
+	size, _ := getAvailableSpaceBlockFunc(dataFile)
+	isBlockDev := size >= 0
+	disk, err := os.OpenFile(dataFile, os.O_RDWR, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open disk %q", dataFile)
+	}
+	defer disk.Close()
+	diskSize, err := disk.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	var reclaimed *int64
+	if isBlockDev {
+		// Punching a hole in a block device zeroes the range, letting the device unmap it. BLKDISCARD doesn't guarantee
+		// the range reads back as zeroes and BLKZEROOUT never unmaps it. A block device does not report its allocated
+		// size, the zeroed ranges include the ones that were never allocated, so the reclaimed space is unknown.
+		_, err = sparsifyRanges(disk, [][2]int64{{0, diskSize}}, sparsifyDeviceBlockSize)
+	} else {
+		var before int64
+		if before, err = allocatedSize(disk); err != nil {
+			return nil, err
+		}
+		var ranges [][2]int64
+		if ranges, err = dataRanges(disk, diskSize); err != nil {
+			return nil, err
+		}
+		if _, err = sparsifyRanges(disk, ranges, sparsifyFileBlockSize); err == nil {
+			var after int64
+			after, err = allocatedSize(disk)
+			reclaimed = ptr.To(before - after)
+		}
+	}
+	if errors.Is(err, syscall.EOPNOTSUPP) {
+		klog.Warningf("Not sparsifying %s, deallocating blocks is not supported: %v", dataFile, err)
+		if isBlockDev {
+			return nil, nil
+		}
+		return ptr.To[int64](0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := disk.Sync(); err != nil {
+		return nil, err
+	}
+	if reclaimed != nil {
+		klog.V(1).Infof("Sparsified %s, reclaimed %d bytes", dataFile, *reclaimed)
+	} else {
+		klog.V(1).Infof("Sparsified %s", dataFile)
+	}
+	return reclaimed, nil
+}
+
+// sparsifyRanges deallocates the runs of zero blocks of blockSize in the ranges of disk, it returns the number of bytes
+// deallocated
+func sparsifyRanges(disk *os.File, ranges [][2]int64, blockSize int64) (int64, error) {
+	buf := make([]byte, sparsifyReadSize)
+	var punched int64
+	punch := func(start, end int64) error {
+		if end <= start {
+			return nil
+		}
+		flags := uint32(unix.FALLOC_FL_PUNCH_HOLE | unix.FALLOC_FL_KEEP_SIZE)
+		if err := syscall.Fallocate(int(disk.Fd()), flags, start, end-start); err != nil {
+			return errors.Wrapf(err, "unable to deallocate %d bytes at offset %d", end-start, start)
+		}
+		punched += end - start
+		return nil
+	}
+
+	for _, r := range ranges {
+		zeroStart := int64(-1)
+		for offset := r[0] - r[0]%blockSize; offset < r[1]; {
+			n, err := disk.ReadAt(buf, offset)
+			if n == 0 && err != nil {
+				if err == io.EOF {
+					break
+				}
+				return punched, errors.Wrapf(err, "unable to read disk at offset %d", offset)
+			}
+			for i := 0; i < n; i += int(blockSize) {
+				block := buf[i:min(i+int(blockSize), n)]
+				if isZero(block) {
+					if zeroStart < 0 {
+						zeroStart = offset + int64(i)
+					}
+				} else if zeroStart >= 0 {
+					if err := punch(zeroStart, offset+int64(i)); err != nil {
+						return punched, err
+					}
+					zeroStart = -1
+				}
+			}
+			offset += int64(n)
+		}
+		if zeroStart >= 0 {
+			if err := punch(zeroStart, r[1]); err != nil {
+				return punched, err
+			}
+		}
+	}
+	return punched, nil
+}
+
+// dataRanges returns the allocated ranges of a file of size bytes, or the whole file when its filesystem can't tell
+func dataRanges(f *os.File, size int64) ([][2]int64, error) {
+	var ranges [][2]int64
+	for offset := int64(0); offset < size; {
+		start, err := unix.Seek(int(f.Fd()), offset, unix.SEEK_DATA)
+		if errors.Is(err, syscall.ENXIO) {
+			break
+		} else if errors.Is(err, syscall.EINVAL) {
+			return [][2]int64{{0, size}}, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "unable to look up data of the disk")
+		}
+		end, err := unix.Seek(int(f.Fd()), start, unix.SEEK_HOLE)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to look up holes of the disk")
+		}
+		ranges = append(ranges, [2]int64{start, min(end, size)})
+		offset = end
+	}
+	return ranges, nil
+}
+
+// allocatedSize returns the number of bytes allocated to a file
+func allocatedSize(f *os.File) (int64, error) {
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(f.Fd()), &st); err != nil {
+		return 0, err
+	}
+	//nolint:unconvert
+	return int64(st.Blocks) * 512, nil
+}
+
+// isZero returns true when all the bytes of buf are zeroes
+func isZero(buf []byte) bool {
+	for _, b := range buf {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
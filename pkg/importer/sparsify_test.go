@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sparsify disk", func() {
+	var diskFile string
+
+	BeforeEach(func() {
+		diskFile = filepath.Join(GinkgoT().TempDir(), "disk.img")
+	})
+
+	// writeTestDisk writes a disk of allocated zeroes with data at the start and end of the disk
+	writeTestDisk := func() []byte {
+		disk := make([]byte, 4*sparsifyReadSize)
+		copy(disk, bytes.Repeat([]byte{0xaa}, 100))
+		copy(disk[len(disk)-sparsifyFileBlockSize:], bytes.Repeat([]byte{0x55}, sparsifyFileBlockSize))
+		Expect(os.WriteFile(diskFile, disk, 0600)).To(Succeed())
+		return disk
+	}
+
+	It("should deallocate the blocks of zeroes of a file", func() {
+		disk := writeTestDisk()
+		f, err := os.Open(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		before, err := allocatedSize(f)
+		Expect(err).ToNot(HaveOccurred())
+
+		reclaimed, err := Sparsify(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		after, err := allocatedSize(f)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reclaimed).To(HaveValue(Equal(before - after)))
+		if *reclaimed == 0 {
+			Skip("the temporary directory can't deallocate blocks")
+		}
+		Expect(after).To(BeNumerically("<=", 2*sparsifyFileBlockSize))
+
+		sparsified, err := os.ReadFile(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sparsified).To(Equal(disk))
+	})
+
+	It("should not report the reclaimed space of a block device", func() {
+		disk := writeTestDisk()
+		replaceAvailableSpaceBlockFunc(func(dataDir string) (int64, error) {
+			return int64(len(disk)), nil
+		}, func() {
+			reclaimed, err := Sparsify(diskFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reclaimed).To(BeNil())
+		})
+
+		sparsified, err := os.ReadFile(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sparsified).To(Equal(disk))
+	})
+
+	It("should deallocate the runs of zero blocks in whole ranges", func() {
+		disk := writeTestDisk()
+		f, err := os.OpenFile(diskFile, os.O_RDWR, 0)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+
+		punched, err := sparsifyRanges(f, [][2]int64{{0, int64(len(disk))}}, sparsifyDeviceBlockSize)
+		if err != nil {
+			Skip("the temporary directory can't deallocate blocks")
+		}
+		Expect(punched).To(Equal(int64(2 * sparsifyDeviceBlockSize)))
+
+		sparsified, err := os.ReadFile(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sparsified).To(Equal(disk))
+	})
+
+	It("should find the data ranges of a sparse file", func() {
+		Expect(os.WriteFile(diskFile, bytes.Repeat([]byte{1}, sparsifyFileBlockSize), 0600)).To(Succeed())
+		Expect(os.Truncate(diskFile, 4*sparsifyReadSize)).To(Succeed())
+		f, err := os.Open(diskFile)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+
+		ranges, err := dataRanges(f, 4*sparsifyReadSize)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ranges).ToNot(BeEmpty())
+		Expect(ranges[0][0]).To(BeZero())
+		Expect(ranges[len(ranges)-1][1]).To(BeNumerically("<=", 4*sparsifyReadSize))
+	})
+})
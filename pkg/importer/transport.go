@@ -129,8 +129,7 @@ func processLayer(ctx context.Context,
 	cache types.BlobInfoCache,
 	stopAtFirst,
 	preallocation bool) (bool, error) {
-	var reader io.ReadCloser
-	reader, _, err := src.GetBlob(ctx, layer, cache)
+	reader, err := getLayerReader(ctx, src, layer, cache)
 	if err != nil {
 		klog.Errorf("%v: %v", errReadingLayer, err)
 		return false, fmt.Errorf("%w: %v", errReadingLayer, err)
@@ -182,6 +181,25 @@ func processLayer(ctx context.Context,
 	return found, nil
 }
 
+// getLayerReader reads the layer blob through the chunk cache when it is enabled. Blobs are content addressed, so a
+// layer cached by the import of any image is not downloaded again once its content was verified against its digest
+func getLayerReader(ctx context.Context, src types.ImageSource, layer types.BlobInfo, cache types.BlobInfoCache) (io.ReadCloser, error) {
+	open := func(offset int64) (io.ReadCloser, error) {
+		reader, _, err := src.GetBlob(ctx, layer, cache)
+		return reader, err
+	}
+	chunkCache := GetChunkCache()
+	if chunkCache == nil || layer.Digest == "" {
+		return open(0)
+	}
+	reader, err := chunkCache.NewVerifiedCachingReader(layer.Digest, open)
+	if err != nil {
+		klog.Warningf("Unable to cache layer %s: %v", layer.Digest, err)
+		return open(0)
+	}
+	return reader, nil
+}
+
 // Sanitize archive file pathing from "G305: Zip Slip vulnerability"
 // https://security.snyk.io/research/zip-slip-vulnerability
 func safeJoinPaths(dir, path string) (v string, err error) {
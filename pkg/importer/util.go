@@ -1,6 +1,7 @@
 package importer
 
 import (
+	"io"
 	"net/url"
 	"os"
 	"os/signal"
@@ -10,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
 
 	"kubevirt.io/containerized-data-importer/pkg/common"
 	"kubevirt.io/containerized-data-importer/pkg/util"
@@ -18,6 +20,8 @@ import (
 const (
 	kubevirtEnvPrefix   = "KUBEVIRT_IO_"
 	kubevirtLabelPrefix = "kubevirt.io/"
+
+	rangeReaderMaxRetries = 5
 )
 
 // ParseEndpoint parses the required endpoint and return the url struct.
@@ -93,3 +97,50 @@ func envToLabel(env string) string {
 
 	return strings.ToLower(label)
 }
+
+// rangeReader reads a source of known size with ranged requests, reopening it at the current offset when a read fails
+type rangeReader struct {
+	open    func(offset int64) (io.ReadCloser, error)
+	size    int64
+	offset  int64
+	retries int
+	body    io.ReadCloser
+}
+
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.open(r.offset)
+		if err != nil {
+			return 0, err
+		}
+		r.body = body
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if n > 0 {
+		r.retries = 0
+	}
+	if err == io.EOF && r.offset < r.size {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil && err != io.EOF {
+		r.body.Close()
+		r.body = nil
+		if r.retries < rangeReaderMaxRetries {
+			r.retries++
+			klog.Warningf("Reading failed at offset %d, retrying: %v", r.offset, err)
+			return n, nil
+		}
+	}
+	return n, err
+}
+
+func (r *rangeReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	return r.body.Close()
+}
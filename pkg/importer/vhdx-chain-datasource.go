@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The CDI Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/klog/v2"
+
+	cdiv1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"kubevirt.io/containerized-data-importer/pkg/common"
+	"kubevirt.io/containerized-data-importer/pkg/image"
+	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-importer"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/transfer"
+	prometheusutil "kubevirt.io/containerized-data-importer/pkg/util/prometheus"
+)
+
+// VHDXChainDataSource imports a Hyper-V disk made of a base VHDX and an ordered chain of AVHDX differencing disks
+// from http endpoints. qemu-img cannot open differencing disks, so the chain is merged by the importer.
+// Sequence of phases:
+// 1. Info -> TransferScratch
+// 2. TransferScratch -> TransferDataFile, every image of the chain is downloaded to the scratch space.
+// 3. TransferDataFile -> Resize, the merged chain is written to the target.
+type VHDXChainDataSource struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	cancelLock sync.Mutex
+	// endpoints of the base image followed by the differencing disks
+	endpoints []*url.URL
+	accessKey string
+	secKey    string
+	certDir   string
+	// the total size of the images, 0 if unknown
+	total uint64
+	// the images of the chain in scratch space
+	files []string
+}
+
+// NewVHDXChainDataSource creates a new instance of the VHDX chain data source
+func NewVHDXChainDataSource(endpoint string, differencingDisks []string, accessKey, secKey, certDir string) (*VHDXChainDataSource, error) {
+	var endpoints []*url.URL
+	for _, e := range append([]string{endpoint}, differencingDisks...) {
+		ep, err := ParseEndpoint(e)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse endpoint %q", e)
+		}
+		endpoints = append(endpoints, ep)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &VHDXChainDataSource{
+		ctx:       ctx,
+		cancel:    cancel,
+		endpoints: endpoints,
+		accessKey: accessKey,
+		secKey:    secKey,
+		certDir:   certDir,
+	}, nil
+}
+
+// Info is called to get initial information about the data.
+func (vs *VHDXChainDataSource) Info() (ProcessingPhase, error) {
+	client, err := createHTTPClient(vs.certDir, false)
+	if err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "Error creating http client")
+	}
+	extraHeaders, secretExtraHeaders, err := getExtraHeaders()
+	if err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "Error getting extra headers for HTTP client")
+	}
+	vs.total = 0
+	for _, ep := range vs.endpoints {
+		length, err := getContentLength(client, ep, vs.accessKey, vs.secKey, append(extraHeaders, secretExtraHeaders...))
+		if err != nil || length == 0 {
+			// Progress is only reported when the size of every image is known
+			klog.V(2).Infof("Unable to get the size of %q, not reporting progress", ep.String())
+			vs.total = 0
+			break
+		}
+		vs.total += length
+	}
+	return ProcessingPhaseTransferScratch, nil
+}
+
+// Transfer is called to download the images of the chain to the scratch space.
+func (vs *VHDXChainDataSource) Transfer(path string, preallocation bool) (ProcessingPhase, error) {
+	size, err := GetAvailableSpace(path)
+	if err != nil || size <= 0 {
+		return ProcessingPhaseError, ErrInvalidPath
+	}
+	extraHeaders, secretExtraHeaders, err := getExtraHeaders()
+	if err != nil {
+		return ProcessingPhaseError, errors.Wrap(err, "Error getting extra headers for HTTP client")
+	}
+
+	var progressReader *prometheusutil.ProgressReader
+	vs.files = nil
+	for i, ep := range vs.endpoints {
+		reader, _, _, err := createHTTPReader(vs.ctx, ep, vs.accessKey, vs.secKey, vs.certDir, extraHeaders, secretExtraHeaders, cdiv1.DataVolumeKubeVirt)
+		if err != nil {
+			return ProcessingPhaseError, errors.Wrapf(err, "unable to download %q", ep.String())
+		}
+		final := i == len(vs.endpoints)-1
+		if progressReader == nil {
+			progressReader = prometheusutil.NewProgressReader(reader, metrics.Progress(ownerUID), transfer.Stats(ownerUID), vs.total)
+			progressReader.SetNextReader(reader, final)
+			progressReader.StartTimedUpdate()
+		} else {
+			progressReader.SetNextReader(reader, final)
+		}
+		file := filepath.Join(path, fmt.Sprintf("disk-%d.vhdx", i))
+		if err := CleanAll(file); err != nil {
+			reader.Close()
+			return ProcessingPhaseError, err
+		}
+		klog.V(1).Infof("Downloading %q to %s", ep.String(), file)
+		_, _, err = StreamDataToFile(progressReader, file, preallocation)
+		reader.Close()
+		if err != nil {
+			return ProcessingPhaseError, err
+		}
+		vs.files = append(vs.files, file)
+	}
+	return ProcessingPhaseTransferDataFile, nil
+}
+
+// TransferFile is called to write the merged chain to the passed in file.
+func (vs *VHDXChainDataSource) TransferFile(fileName string, preallocation bool) (ProcessingPhase, error) {
+	var chain []*image.VHDX
+	defer func() {
+		for _, v := range chain {
+			v.Close()
+		}
+	}()
+	for _, file := range vs.files {
+		v, err := image.OpenVHDX(file)
+		if err != nil {
+			return ProcessingPhaseError, err
+		}
+		chain = append(chain, v)
+	}
+	reader, err := image.NewVHDXChainReader(chain)
+	if err != nil {
+		return ProcessingPhaseError, err
+	}
+	if err := CleanAll(fileName); err != nil {
+		return ProcessingPhaseError, err
+	}
+	klog.V(1).Infof("Writing the merged chain of %d images to %s", len(chain), fileName)
+	if _, _, err := StreamDataToFile(reader, fileName, preallocation); err != nil {
+		return ProcessingPhaseError, err
+	}
+	return ProcessingPhaseResize, nil
+}
+
+// GetURL returns nil, the merged chain is written to the target without conversion.
+func (vs *VHDXChainDataSource) GetURL() *url.URL {
+	return nil
+}
+
+// GetTerminationMessage returns data to be serialized and used as the termination message of the importer.
+func (vs *VHDXChainDataSource) GetTerminationMessage() *common.TerminationMessage {
+	return nil
+}
+
+// Close cancels the downloads in progress.
+func (vs *VHDXChainDataSource) Close() error {
+	vs.cancelLock.Lock()
+	if vs.cancel != nil {
+		vs.cancel()
+		vs.cancel = nil
+	}
+	vs.cancelLock.Unlock()
+	return nil
+}
@@ -0,0 +1,38 @@
+package cdicontroller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+)
+
+const (
+	cloneLabelSourceStorageClass = "source_storageclass"
+	cloneLabelTargetStorageClass = "target_storageclass"
+	cloneLabelStrategy           = "strategy"
+)
+
+var (
+	cloneMetrics = []operatormetrics.Metric{
+		cloneDuration,
+	}
+
+	cloneDuration = operatormetrics.NewHistogramVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_cdi_clone_duration_seconds",
+			Help: "Duration of the completed clones by `source_storageclass`, `target_storageclass` and clone `strategy`",
+		},
+		prometheus.HistogramOpts{
+			Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200, 14400},
+		},
+		[]string{
+			cloneLabelSourceStorageClass,
+			cloneLabelTargetStorageClass,
+			cloneLabelStrategy,
+		},
+	)
+)
+
+// ObserveCloneDuration records the duration of a completed clone
+func ObserveCloneDuration(sourceStorageClass, targetStorageClass, strategy string, seconds float64) {
+	cloneDuration.WithLabelValues(sourceStorageClass, targetStorageClass, strategy).Observe(seconds)
+}
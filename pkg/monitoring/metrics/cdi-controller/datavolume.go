@@ -1,12 +1,18 @@
 package cdicontroller
 
 import (
+	ioprometheusclient "github.com/prometheus/client_model/go"
 	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
 )
 
+const (
+	dataVolumeLabelReason = "reason"
+)
+
 var (
 	dataVolumeMetrics = []operatormetrics.Metric{
 		dataVolumePending,
+		dataVolumeFailures,
 	}
 
 	dataVolumePending = operatormetrics.NewGauge(
@@ -15,9 +21,29 @@ var (
 			Help: "Number of DataVolumes pending for default storage class to be configured",
 		},
 	)
+
+	dataVolumeFailures = operatormetrics.NewCounterVec(
+		operatormetrics.MetricOpts{
+			Name: "kubevirt_cdi_datavolume_failures_total",
+			Help: "Total number of DataVolume transfer failures by `reason`",
+		},
+		[]string{dataVolumeLabelReason},
+	)
 )
 
 // SetDataVolumePending sets dataVolumePending value
 func SetDataVolumePending(value int) {
 	dataVolumePending.Set(float64(value))
 }
+
+// IncDataVolumeFailures increments the number of DataVolume failures with the reason
+func IncDataVolumeFailures(reason string) {
+	dataVolumeFailures.WithLabelValues(reason).Inc()
+}
+
+// GetDataVolumeFailures returns the number of DataVolume failures with the reason
+func GetDataVolumeFailures(reason string) float64 {
+	dto := &ioprometheusclient.Metric{}
+	_ = dataVolumeFailures.WithLabelValues(reason).Write(dto)
+	return dto.Counter.GetValue()
+}
@@ -13,5 +13,6 @@ func SetupMetrics() error {
 		dataImportCronMetrics,
 		storageMetrics,
 		dataVolumeMetrics,
+		cloneMetrics,
 	)
 }
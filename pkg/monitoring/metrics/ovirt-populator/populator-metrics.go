@@ -37,3 +37,28 @@ func GetPopulatorProgress(labelValue string) (float64, error) {
 	}
 	return dto.Counter.GetValue(), nil
 }
+
+// PopulatorProgress is the progress metric of a volume population
+type PopulatorProgress struct {
+	ownerUID string
+}
+
+// Progress returns the progress metric of the passed owner
+func Progress(ownerUID string) *PopulatorProgress {
+	return &PopulatorProgress{ownerUID}
+}
+
+// Add adds value to the populatorProgress metric
+func (pp *PopulatorProgress) Add(value float64) {
+	AddPopulatorProgress(pp.ownerUID, value)
+}
+
+// Get returns the populatorProgress value
+func (pp *PopulatorProgress) Get() (float64, error) {
+	return GetPopulatorProgress(pp.ownerUID)
+}
+
+// Delete removes the populatorProgress metric with the passed label
+func (pp *PopulatorProgress) Delete() {
+	populatorProgress.DeleteLabelValues(pp.ownerUID)
+}
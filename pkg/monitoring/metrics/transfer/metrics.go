@@ -0,0 +1,12 @@
+package transfer
+
+import (
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+)
+
+// SetupMetrics register prometheus metrics, workers call it after registering their progress metric
+func SetupMetrics() error {
+	return operatormetrics.RegisterMetrics(
+		transferMetrics,
+	)
+}
@@ -0,0 +1,93 @@
+package transfer
+
+import (
+	"github.com/rhobs/operator-observability-toolkit/pkg/operatormetrics"
+)
+
+const (
+	// ThroughputMetricName is the name of the data transfer throughput metric
+	ThroughputMetricName = "kubevirt_cdi_transfer_throughput_bytes"
+	// ETAMetricName is the name of the data transfer estimated time remaining metric
+	ETAMetricName = "kubevirt_cdi_transfer_eta_seconds"
+	// TransferredBytesMetricName is the name of the transferred bytes metric
+	TransferredBytesMetricName = "kubevirt_cdi_transfer_transferred_bytes"
+	// TotalBytesMetricName is the name of the total bytes to transfer metric
+	TotalBytesMetricName = "kubevirt_cdi_transfer_total_bytes"
+)
+
+var (
+	transferMetrics = []operatormetrics.Metric{
+		throughput,
+		eta,
+		transferred,
+		total,
+	}
+
+	throughput = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: ThroughputMetricName,
+			Help: "The data transfer rate of a worker pod in bytes per second",
+		},
+		[]string{"ownerUID"},
+	)
+
+	eta = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: ETAMetricName,
+			Help: "The estimated time remaining until a worker pod completes the data transfer in seconds",
+		},
+		[]string{"ownerUID"},
+	)
+
+	transferred = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: TransferredBytesMetricName,
+			Help: "The number of bytes a worker pod transferred",
+		},
+		[]string{"ownerUID"},
+	)
+
+	total = operatormetrics.NewGaugeVec(
+		operatormetrics.MetricOpts{
+			Name: TotalBytesMetricName,
+			Help: "The number of bytes a worker pod transfers, when known",
+		},
+		[]string{"ownerUID"},
+	)
+)
+
+// StatsMetric publishes the throughput and the estimated time remaining of a data transfer
+type StatsMetric struct {
+	ownerUID string
+}
+
+// Stats returns the transfer metrics of the passed owner
+func Stats(ownerUID string) *StatsMetric {
+	return &StatsMetric{ownerUID}
+}
+
+// SetThroughput sets the throughput metric
+func (ts *StatsMetric) SetThroughput(bytesPerSecond float64) {
+	throughput.WithLabelValues(ts.ownerUID).Set(bytesPerSecond)
+}
+
+// SetETA sets the estimated time remaining metric
+func (ts *StatsMetric) SetETA(seconds float64) {
+	eta.WithLabelValues(ts.ownerUID).Set(seconds)
+}
+
+// SetBytes sets the transferred bytes metric, and the total bytes metric when the total is known
+func (ts *StatsMetric) SetBytes(current, size uint64) {
+	transferred.WithLabelValues(ts.ownerUID).Set(float64(current))
+	if size > 0 {
+		total.WithLabelValues(ts.ownerUID).Set(float64(size))
+	}
+}
+
+// Delete removes the transfer metrics with the passed label
+func (ts *StatsMetric) Delete() {
+	throughput.DeleteLabelValues(ts.ownerUID)
+	eta.DeleteLabelValues(ts.ownerUID)
+	transferred.DeleteLabelValues(ts.ownerUID)
+	total.DeleteLabelValues(ts.ownerUID)
+}
@@ -114,6 +114,19 @@ func getAPIServerClusterPolicyRules() []rbacv1.PolicyRule {
 			},
 		},
 
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"pods",
+			},
+			Verbs: []string{
+				"list",
+				"watch",
+			},
+		},
+
 		{
 			APIGroups: []string{
 				"storage.k8s.io",
@@ -162,6 +175,7 @@ func getAPIServerClusterPolicyRules() []rbacv1.PolicyRule {
 			Verbs: []string{
 				"list",
 				"get",
+				"watch",
 			},
 		},
 		{
@@ -173,6 +187,7 @@ func getAPIServerClusterPolicyRules() []rbacv1.PolicyRule {
 			},
 			Verbs: []string{
 				"get",
+				"list",
 			},
 		},
 
@@ -89,6 +89,18 @@ func getControllerClusterPolicyRules() []rbacv1.PolicyRule {
 				"update",
 			},
 		},
+		{
+			APIGroups: []string{
+				"",
+			},
+			Resources: []string{
+				"nodes",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+			},
+		},
 		{
 			APIGroups: []string{
 				"",
@@ -284,6 +296,18 @@ func getControllerClusterPolicyRules() []rbacv1.PolicyRule {
 				"update",
 			},
 		},
+		{
+			APIGroups: []string{
+				"subresources.kubevirt.io",
+			},
+			Resources: []string{
+				"virtualmachineinstances/freeze",
+				"virtualmachineinstances/unfreeze",
+			},
+			Verbs: []string{
+				"update",
+			},
+		},
 		{
 			APIGroups: []string{
 				"authorization.k8s.io",
@@ -36,3 +36,15 @@ func createDataVolumeCRD() *extv1.CustomResourceDefinition {
 	_ = k8syaml.NewYAMLToJSONDecoder(strings.NewReader(resources.CDICRDs["datavolume"])).Decode(&crd)
 	return &crd
 }
+
+// NewDataVolumeBackupCrd - provides DataVolumeBackup CRD
+func NewDataVolumeBackupCrd() *extv1.CustomResourceDefinition {
+	return createDataVolumeBackupCRD()
+}
+
+// createDataVolumeBackupCRD creates the DataVolumeBackup schema
+func createDataVolumeBackupCRD() *extv1.CustomResourceDefinition {
+	crd := extv1.CustomResourceDefinition{}
+	_ = k8syaml.NewYAMLToJSONDecoder(strings.NewReader(resources.CDICRDs["datavolumebackup"])).Decode(&crd)
+	return &crd
+}
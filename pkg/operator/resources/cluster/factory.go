@@ -56,6 +56,7 @@ var dynamicFactoryFunctions = factoryFuncMap{
 func createCRDResources(args *FactoryArgs) []client.Object {
 	return []client.Object{
 		createDataVolumeCRD(),
+		createDataVolumeBackupCRD(),
 		createCDIConfigCRD(),
 		createStorageProfileCRD(),
 		createDataSourceCRD(),
@@ -43,6 +43,7 @@ func getAdminPolicyRules() []rbacv1.PolicyRule {
 			},
 			Resources: []string{
 				"datavolumes",
+				"datavolumebackups",
 				"dataimportcrons",
 				"datasources",
 				"volumeimportsources",
@@ -75,6 +76,18 @@ func getAdminPolicyRules() []rbacv1.PolicyRule {
 				"*",
 			},
 		},
+		{
+			APIGroups: []string{
+				"upload.cdi.kubevirt.io",
+			},
+			Resources: []string{
+				"datavolumeprogressreports",
+			},
+			Verbs: []string{
+				"get",
+				"watch",
+			},
+		},
 		{
 			APIGroups: []string{
 				"forklift.cdi.kubevirt.io",
@@ -107,6 +120,7 @@ func getViewPolicyRules() []rbacv1.PolicyRule {
 				"dataimportcrons",
 				"datasources",
 				"datavolumes",
+				"datavolumebackups",
 				"objecttransfers",
 				"storageprofiles",
 				"volumeimportsources",
@@ -133,6 +147,18 @@ func getViewPolicyRules() []rbacv1.PolicyRule {
 				"watch",
 			},
 		},
+		{
+			APIGroups: []string{
+				"upload.cdi.kubevirt.io",
+			},
+			Resources: []string{
+				"datavolumeprogressreports",
+			},
+			Verbs: []string{
+				"get",
+				"watch",
+			},
+		},
 		{
 			APIGroups: []string{
 				"cdi.kubevirt.io",
@@ -163,6 +189,17 @@ func createConfigReaderClusterRole(name string) *rbacv1.ClusterRole {
 				"watch",
 			},
 		},
+		{
+			APIGroups: []string{
+				"upload.cdi.kubevirt.io",
+			},
+			Resources: []string{
+				"datasourcecatalogentries",
+			},
+			Verbs: []string{
+				"list",
+			},
+		},
 	}
 
 	return utils.ResourceBuilder.CreateClusterRole(name, rules)
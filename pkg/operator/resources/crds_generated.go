@@ -114,6 +114,11 @@ spec:
               config:
                 description: CDIConfig at CDI level
                 properties:
+                  consistentCloneHook:
+                    description: |-
+                      ConsistentCloneHook is the http(s) endpoint freezing and thawing the workloads using the source of a consistent
+                      clone, instead of the freeze subresources of the KubeVirt VMIs
+                    type: string
                   dataVolumeTTLSeconds:
                     description: |-
                       DataVolumeTTLSeconds is the time in seconds after DataVolume completion it can be garbage collected. Disabled by default.
@@ -131,6 +136,11 @@ spec:
                       overhead when using Filesystem volumes. A value is between 0
                       and 1, if not defined it is 0.06 (6% overhead)
                     properties:
+                      applyCalibration:
+                        description: ApplyCalibration uses the overhead measured by
+                          CDI for storage classes without a specific value, instead
+                          of the global value
+                        type: boolean
                       global:
                         description: Global is how much space of a Filesystem volume
                           should be reserved for overhead. This value is used unless
@@ -171,6 +181,32 @@ spec:
                       type: object
                       x-kubernetes-map-type: atomic
                     type: array
+                  importChunkCache:
+                    description: ImportChunkCache enables a content addressed cache
+                      of the imported data, so data already imported on a node or
+                      in a namespace is not downloaded again
+                    properties:
+                      claimName:
+                        description: ClaimName is the name of a PVC shared by the
+                          imports of the namespaces where it exists, imports of other
+                          namespaces are not cached. It should be ReadWriteMany to
+                          be used by concurrent imports
+                        type: string
+                      hostPath:
+                        description: HostPath is a directory on the nodes, shared
+                          by the imports running on the node. It must be writable
+                          by the importer pods
+                        type: string
+                      limit:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Limit is the size the cache is pruned to at the
+                          end of each import, the least recently used chunks are removed
+                          first. The cache is not pruned if not set
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                    type: object
                   importProxy:
                     description: ImportProxy contains importer pod proxy configuration.
                     properties:
@@ -2646,6 +2682,11 @@ spec:
               config:
                 description: CDIConfig at CDI level
                 properties:
+                  consistentCloneHook:
+                    description: |-
+                      ConsistentCloneHook is the http(s) endpoint freezing and thawing the workloads using the source of a consistent
+                      clone, instead of the freeze subresources of the KubeVirt VMIs
+                    type: string
                   dataVolumeTTLSeconds:
                     description: |-
                       DataVolumeTTLSeconds is the time in seconds after DataVolume completion it can be garbage collected. Disabled by default.
@@ -2663,6 +2704,11 @@ spec:
                       overhead when using Filesystem volumes. A value is between 0
                       and 1, if not defined it is 0.06 (6% overhead)
                     properties:
+                      applyCalibration:
+                        description: ApplyCalibration uses the overhead measured by
+                          CDI for storage classes without a specific value, instead
+                          of the global value
+                        type: boolean
                       global:
                         description: Global is how much space of a Filesystem volume
                           should be reserved for overhead. This value is used unless
@@ -2703,6 +2749,32 @@ spec:
                       type: object
                       x-kubernetes-map-type: atomic
                     type: array
+                  importChunkCache:
+                    description: ImportChunkCache enables a content addressed cache
+                      of the imported data, so data already imported on a node or
+                      in a namespace is not downloaded again
+                    properties:
+                      claimName:
+                        description: ClaimName is the name of a PVC shared by the
+                          imports of the namespaces where it exists, imports of other
+                          namespaces are not cached. It should be ReadWriteMany to
+                          be used by concurrent imports
+                        type: string
+                      hostPath:
+                        description: HostPath is a directory on the nodes, shared
+                          by the imports running on the node. It must be writable
+                          by the importer pods
+                        type: string
+                      limit:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Limit is the size the cache is pruned to at the
+                          end of each import, the least recently used chunks are removed
+                          first. The cache is not pruned if not set
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                    type: object
                   importProxy:
                     description: ImportProxy contains importer pod proxy configuration.
                     properties:
@@ -5134,6 +5206,11 @@ spec:
           spec:
             description: CDIConfigSpec defines specification for user configuration
             properties:
+              consistentCloneHook:
+                description: |-
+                  ConsistentCloneHook is the http(s) endpoint freezing and thawing the workloads using the source of a consistent
+                  clone, instead of the freeze subresources of the KubeVirt VMIs
+                type: string
               dataVolumeTTLSeconds:
                 description: |-
                   DataVolumeTTLSeconds is the time in seconds after DataVolume completion it can be garbage collected. Disabled by default.
@@ -5150,6 +5227,11 @@ spec:
                   when using Filesystem volumes. A value is between 0 and 1, if not
                   defined it is 0.06 (6% overhead)
                 properties:
+                  applyCalibration:
+                    description: ApplyCalibration uses the overhead measured by CDI
+                      for storage classes without a specific value, instead of the
+                      global value
+                    type: boolean
                   global:
                     description: Global is how much space of a Filesystem volume should
                       be reserved for overhead. This value is used unless overridden
@@ -5190,6 +5272,33 @@ spec:
                   type: object
                   x-kubernetes-map-type: atomic
                 type: array
+              importChunkCache:
+                description: ImportChunkCache enables a content addressed cache of
+                  the imported data, so data already imported on a node or in a namespace
+                  is not downloaded again
+                properties:
+                  claimName:
+                    description: ClaimName is the name of a PVC shared by the imports
+                      of the namespaces where it exists, imports of other namespaces
+                      are not cached. It should be ReadWriteMany to be used by concurrent
+                      imports
+                    type: string
+                  hostPath:
+                    description: HostPath is a directory on the nodes, shared by the
+                      imports running on the node. It must be writable by the importer
+                      pods, and is only used when the ImportChunkCacheHostPath feature
+                      gate is enabled
+                    type: string
+                  limit:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: Limit is the size the cache is pruned to at the end
+                      of each import, the least recently used chunks are removed first.
+                      The cache is not pruned if not set
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                type: object
               importProxy:
                 description: ImportProxy contains importer pod proxy configuration.
                 properties:
@@ -5534,6 +5643,11 @@ spec:
                   when using Filesystem volumes. A percentage value is between 0 and
                   1
                 properties:
+                  applyCalibration:
+                    description: ApplyCalibration uses the overhead measured by CDI
+                      for storage classes without a specific value, instead of the
+                      global value
+                    type: boolean
                   global:
                     description: Global is how much space of a Filesystem volume should
                       be reserved for overhead. This value is used unless overridden
@@ -5553,6 +5667,33 @@ spec:
                       value
                     type: object
                 type: object
+              filesystemOverheadCalibration:
+                additionalProperties:
+                  description: FilesystemOverheadCalibration is the filesystem overhead
+                    CDI measured on freshly provisioned Filesystem volumes of a storage
+                    class
+                  properties:
+                    lastMeasurementTime:
+                      description: LastMeasurementTime is the time of the latest measurement
+                      format: date-time
+                      type: string
+                    recommended:
+                      description: Recommended is the highest overhead measured, rounded
+                        up
+                      pattern: ^(0(?:\.\d{1,3})?|1)$
+                      type: string
+                    samples:
+                      description: Samples is the number of volumes measured
+                      format: int32
+                      type: integer
+                  required:
+                  - lastMeasurementTime
+                  - recommended
+                  - samples
+                  type: object
+                description: FilesystemOverheadCalibration is the filesystem overhead
+                  measured by imports, the keys are the storageClass
+                type: object
               imagePullSecrets:
                 description: The imagePullSecrets used to pull the container images
                 items:
@@ -5762,10 +5903,40 @@ spec:
                         - kubevirt
                         - archive
                         type: string
+                      customization:
+                        description: Customization runs a recipe of customizations against
+                          the imported disk image
+                        properties:
+                          configMap:
+                            description: ConfigMap is the name of the ConfigMap holding the
+                              recipe under the "recipe" key, in the namespace of the DataVolume
+                            type: string
+                        required:
+                        - configMap
+                        type: object
+                      expandPartition:
+                        description: ExpandPartition grows the last GPT partition of
+                          the disk and its ext4 or xfs filesystem along with the disk
+                          image
+                        type: boolean
                       finalCheckpoint:
                         description: FinalCheckpoint indicates whether the current
                           DataVolumeCheckpoint is the final checkpoint.
                         type: boolean
+                      patch:
+                        description: Patch writes the import source into the existing PVC
+                          of the DataVolume instead of importing a whole disk
+                        properties:
+                          offset:
+                            description: Offset is the byte offset in the disk the source
+                              is written at
+                            format: int64
+                            type: integer
+                          partitionLabel:
+                            description: PartitionLabel is the name of the GPT partition
+                              of the disk the source is written into
+                            type: string
+                        type: object
                       preallocation:
                         description: Preallocation controls whether storage for DataVolumes
                           should be allocated in advance.
@@ -5975,6 +6146,51 @@ spec:
                         description: Source is the src of the data for the requested
                           DataVolume
                         properties:
+                          azureBlob:
+                            description: DataVolumeSourceAzureBlob provides the parameters
+                              to create a Data Volume from an Azure Blob Storage source
+                            properties:
+                              account:
+                                description: Account is the name of the storage account
+                                type: string
+                              blob:
+                                description: Blob is the name of the blob
+                                type: string
+                              certConfigMap:
+                                description: CertConfigMap is a configmap reference,
+                                  containing a Certificate Authority(CA) public key,
+                                  and a base64 encoded pem certificate
+                                type: string
+                              container:
+                                description: Container is the name of the blob container
+                                type: string
+                              endpoint:
+                                description: |-
+                                  Endpoint is the url of the blob service, https://<account>.blob.core.windows.net if empty.
+                                  Endpoints with a path, such as http://azurite:10000/devstoreaccount1, are used as is.
+                                type: string
+                              secretRef:
+                                description: |-
+                                  SecretRef provides the secret reference needed to access the Azure Blob source, the secret should contain
+                                  either accountKey (shared key), sasToken (shared access signature), or tenantId, clientId and clientSecret
+                                  (service principal). The blob is read anonymously if empty
+                                type: string
+                            required:
+                            - account
+                            - container
+                            - blob
+                            type: object
+                          backup:
+                            description: DataVolumeSourceBackup provides the parameters
+                              to create a Data Volume from a DataVolumeBackup
+                            properties:
+                              name:
+                                description: The name of the source DataVolumeBackup,
+                                  in the namespace of the Data Volume
+                                type: string
+                            required:
+                            - name
+                            type: object
                           blank:
                             description: DataVolumeBlankImage provides the parameters
                               to create a new raw blank image for the PVC
@@ -5990,6 +6206,28 @@ spec:
                               url:
                                 description: URL is the url of the GCS source
                                 type: string
+                              workloadIdentity:
+                                description: |-
+                                  WorkloadIdentity authenticates with the identity of the importer pod instead of SecretRef.
+                                  Objects are read anonymously, such as those of public buckets, when neither is set.
+                                properties:
+                                  audience:
+                                    description: Audience is the audience of the projected
+                                      service account token, the https url of the
+                                      provider if empty
+                                    type: string
+                                  provider:
+                                    description: |-
+                                      Provider is the workload identity pool provider exchanging a projected service account token of the importer pod,
+                                      such as //iam.googleapis.com/projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>.
+                                      The Application Default Credentials of the importer pod, such as those of GKE workload identity, are used if empty.
+                                    type: string
+                                  serviceAccount:
+                                    description: ServiceAccount is the email of the
+                                      service account impersonated with the federated
+                                      token
+                                    type: string
+                                type: object
                             required:
                             - url
                             type: object
@@ -6004,6 +6242,15 @@ spec:
                                   containing a Certificate Authority(CA) public key,
                                   and a base64 encoded pem certificate
                                 type: string
+                              differencingDisks:
+                                description: DifferencingDisks is an ordered list
+                                  of URLs of Hyper-V differencing disks (AVHDX) applied
+                                  on top of the VHDX at URL, the first entry is the
+                                  child of URL and the last entry is the current state
+                                  of the disk
+                                items:
+                                  type: string
+                                type: array
                               extraHeaders:
                                 description: ExtraHeaders is a list of strings containing
                                   extra headers to include with HTTP transfer requests
@@ -6106,11 +6353,23 @@ spec:
                             description: DataVolumeSourceS3 provides the parameters
                               to create a Data Volume from an S3 source
                             properties:
+                              addressingStyle:
+                                description: |-
+                                  AddressingStyle is how the bucket is addressed in the requests, path (default) or virtualHost.
+                                  The URL always has the bucket as first path segment.
+                                enum:
+                                - path
+                                - virtualHost
+                                type: string
                               certConfigMap:
                                 description: CertConfigMap is a configmap reference,
                                   containing a Certificate Authority(CA) public key,
                                   and a base64 encoded pem certificate
                                 type: string
+                              region:
+                                description: Region is the region of the bucket, derived
+                                  from the host of the URL if empty
+                                type: string
                               secretRef:
                                 description: SecretRef provides the secret reference
                                   needed to access the S3 source
@@ -6118,6 +6377,29 @@ spec:
                               url:
                                 description: URL is the url of the S3 source
                                 type: string
+                              webIdentity:
+                                description: WebIdentity assumes an IAM role with
+                                  a projected service account token of the importer
+                                  pod, instead of using the keys of SecretRef
+                                properties:
+                                  audience:
+                                    description: Audience is the audience of the projected
+                                      service account token, sts.amazonaws.com if
+                                      empty
+                                    type: string
+                                  roleARN:
+                                    description: RoleARN is the ARN of the role to
+                                      assume, it must trust the default service account
+                                      of the namespace
+                                    type: string
+                                  stsEndpoint:
+                                    description: STSEndpoint is the url of the STS
+                                      service, such as http://minio:9000. The AWS
+                                      STS endpoint of the region if empty.
+                                    type: string
+                                required:
+                                - roleARN
+                                type: object
                             required:
                             - url
                             type: object
@@ -6195,6 +6477,12 @@ spec:
                         - kind
                         - name
                         type: object
+                      sparsify:
+                        description: Sparsify deallocates the blocks of zeroes of
+                          the disk image once it is written, reclaiming thin provisioned
+                          storage. Defaults to the sparsify of the import tuning of
+                          the storage profile
+                        type: boolean
                       storage:
                         description: Storage is the requested storage specification
                         properties:
@@ -6558,6 +6846,47 @@ spec:
                     - name
                     - namespace
                     type: object
+                  http:
+                    description: HTTP is an external source lazily imported into a
+                      cache PVC in the DataSource namespace
+                    properties:
+                      certConfigMap:
+                        description: CertConfigMap is a configmap reference, containing
+                          a Certificate Authority(CA) public key, and a base64 encoded
+                          pem certificate
+                        type: string
+                      differencingDisks:
+                        description: DifferencingDisks is an ordered list of URLs
+                          of Hyper-V differencing disks (AVHDX) applied on top of
+                          the VHDX at URL, the first entry is the child of URL and
+                          the last entry is the current state of the disk
+                        items:
+                          type: string
+                        type: array
+                      extraHeaders:
+                        description: ExtraHeaders is a list of strings containing
+                          extra headers to include with HTTP transfer requests
+                        items:
+                          type: string
+                        type: array
+                      secretExtraHeaders:
+                        description: SecretExtraHeaders is a list of Secret references,
+                          each containing an extra HTTP header that may include sensitive
+                          information
+                        items:
+                          type: string
+                        type: array
+                      secretRef:
+                        description: SecretRef A Secret reference, the secret should
+                          contain accessKeyId (user name) base64 encoded, and secretKey
+                          (password) also base64 encoded
+                        type: string
+                      url:
+                        description: URL is the URL of the http(s) endpoint
+                        type: string
+                    required:
+                    - url
+                    type: object
                   pvc:
                     description: DataVolumeSourcePVC provides the parameters to create
                       a Data Volume from an existing PVC
@@ -6572,87 +6901,90 @@ spec:
                     - name
                     - namespace
                     type: object
-                  snapshot:
-                    description: DataVolumeSourceSnapshot provides the parameters
-                      to create a Data Volume from an existing VolumeSnapshot
+                  registry:
+                    description: Registry is an external source lazily imported into
+                      a cache PVC in the DataSource namespace
                     properties:
-                      name:
-                        description: The name of the source VolumeSnapshot
+                      certConfigMap:
+                        description: CertConfigMap provides a reference to the Registry
+                          certs
                         type: string
-                      namespace:
-                        description: The namespace of the source VolumeSnapshot
+                      imageStream:
+                        description: ImageStream is the name of image stream for import
                         type: string
-                    required:
-                    - name
-                    - namespace
-                    type: object
-                type: object
-            required:
-            - source
-            type: object
-          status:
-            description: DataSourceStatus provides the most recently observed status
-              of the DataSource
-            properties:
-              conditions:
-                items:
-                  description: DataSourceCondition represents the state of a data
-                    source condition
-                  properties:
-                    lastHeartbeatTime:
-                      format: date-time
-                      type: string
-                    lastTransitionTime:
-                      format: date-time
-                      type: string
-                    message:
-                      type: string
-                    reason:
-                      type: string
-                    status:
-                      type: string
-                    type:
-                      description: DataSourceConditionType is the string representation
-                        of known condition types
-                      type: string
-                  required:
-                  - status
-                  - type
-                  type: object
-                type: array
-              source:
-                description: Source is the current source of the data referenced by
-                  the DataSource
-                properties:
-                  dataSource:
-                    description: |-
-                      DataSourceRefSourceDataSource serves as a reference to another DataSource
-                      Can be resolved into a DataVolumeSourcePVC or a DataVolumeSourceSnapshot
-                      The maximum depth of a reference chain may not exceed 1.
-                    properties:
-                      name:
-                        description: The name of the source DataSource
+                      platform:
+                        description: Platform describes the minimum runtime requirements
+                          of the image
+                        properties:
+                          architecture:
+                            description: Architecture specifies the image target CPU
+                              architecture
+                            type: string
+                        type: object
+                      pullMethod:
+                        description: PullMethod can be either "pod" (default import),
+                          or "node" (node docker cache based import)
                         type: string
-                      namespace:
-                        description: The namespace of the source DataSource
+                      secretRef:
+                        description: SecretRef provides the secret reference needed
+                          to access the Registry source
+                        type: string
+                      url:
+                        description: 'URL is the url of the registry source (starting
+                          with the scheme: docker, oci-archive)'
                         type: string
-                    required:
-                    - name
-                    - namespace
                     type: object
-                  pvc:
-                    description: DataVolumeSourcePVC provides the parameters to create
-                      a Data Volume from an existing PVC
+                  s3:
+                    description: S3 is an external source lazily imported into a cache
+                      PVC in the DataSource namespace
                     properties:
-                      name:
-                        description: The name of the source PVC
+                      addressingStyle:
+                        description: |-
+                          AddressingStyle is how the bucket is addressed in the requests, path (default) or virtualHost.
+                          The URL always has the bucket as first path segment.
+                        enum:
+                        - path
+                        - virtualHost
                         type: string
-                      namespace:
-                        description: The namespace of the source PVC
+                      certConfigMap:
+                        description: CertConfigMap is a configmap reference, containing
+                          a Certificate Authority(CA) public key, and a base64 encoded
+                          pem certificate
+                        type: string
+                      region:
+                        description: Region is the region of the bucket, derived from
+                          the host of the URL if empty
+                        type: string
+                      secretRef:
+                        description: SecretRef provides the secret reference needed
+                          to access the S3 source
+                        type: string
+                      url:
+                        description: URL is the url of the S3 source
                         type: string
+                      webIdentity:
+                        description: WebIdentity assumes an IAM role with a projected
+                          service account token of the importer pod, instead of using
+                          the keys of SecretRef
+                        properties:
+                          audience:
+                            description: Audience is the audience of the projected
+                              service account token, sts.amazonaws.com if empty
+                            type: string
+                          roleARN:
+                            description: RoleARN is the ARN of the role to assume,
+                              it must trust the default service account of the namespace
+                            type: string
+                          stsEndpoint:
+                            description: STSEndpoint is the url of the STS service,
+                              such as http://minio:9000. The AWS STS endpoint of the
+                              region if empty.
+                            type: string
+                        required:
+                        - roleARN
+                        type: object
                     required:
-                    - name
-                    - namespace
+                    - url
                     type: object
                   snapshot:
                     description: DataVolumeSourceSnapshot provides the parameters
@@ -6669,29 +7001,392 @@ spec:
                     - namespace
                     type: object
                 type: object
-            type: object
-        required:
-        - spec
-        type: object
-    served: true
-    storage: true
-status:
-  acceptedNames:
-    kind: ""
-    plural: ""
-  conditions: null
-  storedVersions: null
-`,
-	"datavolume": `apiVersion: apiextensions.k8s.io/v1
-kind: CustomResourceDefinition
-metadata:
-  annotations:
-    controller-gen.kubebuilder.io/version: v0.14.0
-  creationTimestamp: null
-  name: datavolumes.cdi.kubevirt.io
-spec:
-  group: cdi.kubevirt.io
-  names:
+              storage:
+                description: Storage is the storage specification of the cache PVC
+                  the external source (http, registry, s3) is imported into
+                properties:
+                  accessModes:
+                    description: |-
+                      AccessModes contains the desired access modes the volume should have.
+                      More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1
+                    items:
+                      type: string
+                    type: array
+                  dataSource:
+                    description: |-
+                      This field can be used to specify either: * An existing VolumeSnapshot object (snapshot.storage.k8s.io/VolumeSnapshot) * An existing PVC (PersistentVolumeClaim) * An existing custom resource that implements data population (Alpha) In order to use custom resource types that implement data population, the AnyVolumeDataSource feature gate must be enabled. If the provisioner or an external controller can support the specified data source, it will create a new volume based on the contents of the specified data source.
+                      If the AnyVolumeDataSource feature gate is enabled, this field will always have the same contents as the DataSourceRef field.
+                    properties:
+                      apiGroup:
+                        description: |-
+                          APIGroup is the group for the resource being referenced.
+                          If APIGroup is not specified, the specified Kind must be in the core API group.
+                          For any other third-party types, APIGroup is required.
+                        type: string
+                      kind:
+                        description: Kind is the type of resource being referenced
+                        type: string
+                      name:
+                        description: Name is the name of resource being referenced
+                        type: string
+                    required:
+                    - kind
+                    - name
+                    type: object
+                    x-kubernetes-map-type: atomic
+                  dataSourceRef:
+                    description: |-
+                      Specifies the object from which to populate the volume with data, if a non-empty volume is desired. This may be any local object from a non-empty API group (non core object) or a PersistentVolumeClaim object. When this field is specified, volume binding will only succeed if the type of the specified object matches some installed volume populator or dynamic provisioner.
+                      This field will replace the functionality of the DataSource field and as such if both fields are non-empty, they must have the same value. For backwards compatibility, both fields (DataSource and DataSourceRef) will be set to the same value automatically if one of them is empty and the other is non-empty.
+                      There are two important differences between DataSource and DataSourceRef:
+                      * While DataSource only allows two specific types of objects, DataSourceRef allows any non-core object, as well as PersistentVolumeClaim objects.
+                      * While DataSource ignores disallowed values (dropping them), DataSourceRef preserves all values, and generates an error if a disallowed value is specified.
+                      (Beta) Using this field requires the AnyVolumeDataSource feature gate to be enabled.
+                    properties:
+                      apiGroup:
+                        description: |-
+                          APIGroup is the group for the resource being referenced.
+                          If APIGroup is not specified, the specified Kind must be in the core API group.
+                          For any other third-party types, APIGroup is required.
+                        type: string
+                      kind:
+                        description: Kind is the type of resource being referenced
+                        type: string
+                      name:
+                        description: Name is the name of resource being referenced
+                        type: string
+                      namespace:
+                        description: |-
+                          Namespace is the namespace of resource being referenced
+                          Note that when a namespace is specified, a gateway.networking.k8s.io/ReferenceGrant object is required in the referent namespace to allow that namespace's owner to accept the reference. See the ReferenceGrant documentation for details.
+                          (Alpha) This field requires the CrossNamespaceVolumeDataSource feature gate to be enabled.
+                        type: string
+                    required:
+                    - kind
+                    - name
+                    type: object
+                  resources:
+                    description: |-
+                      Resources represents the minimum resources the volume should have.
+                      More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#resources
+                    properties:
+                      limits:
+                        additionalProperties:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                        description: |-
+                          Limits describes the maximum amount of compute resources allowed.
+                          More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+                        type: object
+                      requests:
+                        additionalProperties:
+                          anyOf:
+                          - type: integer
+                          - type: string
+                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                          x-kubernetes-int-or-string: true
+                        description: |-
+                          Requests describes the minimum amount of compute resources required.
+                          If Requests is omitted for a container, it defaults to Limits if that is explicitly specified,
+                          otherwise to an implementation-defined value. Requests cannot exceed Limits.
+                          More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+                        type: object
+                    type: object
+                  selector:
+                    description: A label query over volumes to consider for binding.
+                    properties:
+                      matchExpressions:
+                        description: matchExpressions is a list of label selector
+                          requirements. The requirements are ANDed.
+                        items:
+                          description: |-
+                            A label selector requirement is a selector that contains values, a key, and an operator that
+                            relates the key and values.
+                          properties:
+                            key:
+                              description: key is the label key that the selector
+                                applies to.
+                              type: string
+                            operator:
+                              description: |-
+                                operator represents a key's relationship to a set of values.
+                                Valid operators are In, NotIn, Exists and DoesNotExist.
+                              type: string
+                            values:
+                              description: |-
+                                values is an array of string values. If the operator is In or NotIn,
+                                the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                                the values array must be empty. This array is replaced during a strategic
+                                merge patch.
+                              items:
+                                type: string
+                              type: array
+                              x-kubernetes-list-type: atomic
+                          required:
+                          - key
+                          - operator
+                          type: object
+                        type: array
+                        x-kubernetes-list-type: atomic
+                      matchLabels:
+                        additionalProperties:
+                          type: string
+                        description: |-
+                          matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                          map is equivalent to an element of matchExpressions, whose key field is "key", the
+                          operator is "In", and the values array contains only "value". The requirements are ANDed.
+                        type: object
+                    type: object
+                    x-kubernetes-map-type: atomic
+                  storageClassName:
+                    description: |-
+                      Name of the StorageClass required by the claim.
+                      More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1
+                    type: string
+                  volumeMode:
+                    description: |-
+                      volumeMode defines what type of volume is required by the claim.
+                      Value of Filesystem is implied when not included in claim spec.
+                    type: string
+                  volumeName:
+                    description: VolumeName is the binding reference to the PersistentVolume
+                      backing this claim.
+                    type: string
+                type: object
+            required:
+            - source
+            type: object
+          status:
+            description: DataSourceStatus provides the most recently observed status
+              of the DataSource
+            properties:
+              conditions:
+                items:
+                  description: DataSourceCondition represents the state of a data
+                    source condition
+                  properties:
+                    lastHeartbeatTime:
+                      format: date-time
+                      type: string
+                    lastTransitionTime:
+                      format: date-time
+                      type: string
+                    message:
+                      type: string
+                    reason:
+                      type: string
+                    status:
+                      type: string
+                    type:
+                      description: DataSourceConditionType is the string representation
+                        of known condition types
+                      type: string
+                  required:
+                  - status
+                  - type
+                  type: object
+                type: array
+              source:
+                description: Source is the current source of the data referenced by
+                  the DataSource
+                properties:
+                  dataSource:
+                    description: |-
+                      DataSourceRefSourceDataSource serves as a reference to another DataSource
+                      Can be resolved into a DataVolumeSourcePVC or a DataVolumeSourceSnapshot
+                      The maximum depth of a reference chain may not exceed 1.
+                    properties:
+                      name:
+                        description: The name of the source DataSource
+                        type: string
+                      namespace:
+                        description: The namespace of the source DataSource
+                        type: string
+                    required:
+                    - name
+                    - namespace
+                    type: object
+                  http:
+                    description: HTTP is an external source lazily imported into a
+                      cache PVC in the DataSource namespace
+                    properties:
+                      certConfigMap:
+                        description: CertConfigMap is a configmap reference, containing
+                          a Certificate Authority(CA) public key, and a base64 encoded
+                          pem certificate
+                        type: string
+                      differencingDisks:
+                        description: DifferencingDisks is an ordered list of URLs
+                          of Hyper-V differencing disks (AVHDX) applied on top of
+                          the VHDX at URL, the first entry is the child of URL and
+                          the last entry is the current state of the disk
+                        items:
+                          type: string
+                        type: array
+                      extraHeaders:
+                        description: ExtraHeaders is a list of strings containing
+                          extra headers to include with HTTP transfer requests
+                        items:
+                          type: string
+                        type: array
+                      secretExtraHeaders:
+                        description: SecretExtraHeaders is a list of Secret references,
+                          each containing an extra HTTP header that may include sensitive
+                          information
+                        items:
+                          type: string
+                        type: array
+                      secretRef:
+                        description: SecretRef A Secret reference, the secret should
+                          contain accessKeyId (user name) base64 encoded, and secretKey
+                          (password) also base64 encoded
+                        type: string
+                      url:
+                        description: URL is the URL of the http(s) endpoint
+                        type: string
+                    required:
+                    - url
+                    type: object
+                  pvc:
+                    description: DataVolumeSourcePVC provides the parameters to create
+                      a Data Volume from an existing PVC
+                    properties:
+                      name:
+                        description: The name of the source PVC
+                        type: string
+                      namespace:
+                        description: The namespace of the source PVC
+                        type: string
+                    required:
+                    - name
+                    - namespace
+                    type: object
+                  registry:
+                    description: Registry is an external source lazily imported into
+                      a cache PVC in the DataSource namespace
+                    properties:
+                      certConfigMap:
+                        description: CertConfigMap provides a reference to the Registry
+                          certs
+                        type: string
+                      imageStream:
+                        description: ImageStream is the name of image stream for import
+                        type: string
+                      platform:
+                        description: Platform describes the minimum runtime requirements
+                          of the image
+                        properties:
+                          architecture:
+                            description: Architecture specifies the image target CPU
+                              architecture
+                            type: string
+                        type: object
+                      pullMethod:
+                        description: PullMethod can be either "pod" (default import),
+                          or "node" (node docker cache based import)
+                        type: string
+                      secretRef:
+                        description: SecretRef provides the secret reference needed
+                          to access the Registry source
+                        type: string
+                      url:
+                        description: 'URL is the url of the registry source (starting
+                          with the scheme: docker, oci-archive)'
+                        type: string
+                    type: object
+                  s3:
+                    description: S3 is an external source lazily imported into a cache
+                      PVC in the DataSource namespace
+                    properties:
+                      addressingStyle:
+                        description: |-
+                          AddressingStyle is how the bucket is addressed in the requests, path (default) or virtualHost.
+                          The URL always has the bucket as first path segment.
+                        enum:
+                        - path
+                        - virtualHost
+                        type: string
+                      certConfigMap:
+                        description: CertConfigMap is a configmap reference, containing
+                          a Certificate Authority(CA) public key, and a base64 encoded
+                          pem certificate
+                        type: string
+                      region:
+                        description: Region is the region of the bucket, derived from
+                          the host of the URL if empty
+                        type: string
+                      secretRef:
+                        description: SecretRef provides the secret reference needed
+                          to access the S3 source
+                        type: string
+                      url:
+                        description: URL is the url of the S3 source
+                        type: string
+                      webIdentity:
+                        description: WebIdentity assumes an IAM role with a projected
+                          service account token of the importer pod, instead of using
+                          the keys of SecretRef
+                        properties:
+                          audience:
+                            description: Audience is the audience of the projected
+                              service account token, sts.amazonaws.com if empty
+                            type: string
+                          roleARN:
+                            description: RoleARN is the ARN of the role to assume,
+                              it must trust the default service account of the namespace
+                            type: string
+                          stsEndpoint:
+                            description: STSEndpoint is the url of the STS service,
+                              such as http://minio:9000. The AWS STS endpoint of the
+                              region if empty.
+                            type: string
+                        required:
+                        - roleARN
+                        type: object
+                    required:
+                    - url
+                    type: object
+                  snapshot:
+                    description: DataVolumeSourceSnapshot provides the parameters
+                      to create a Data Volume from an existing VolumeSnapshot
+                    properties:
+                      name:
+                        description: The name of the source VolumeSnapshot
+                        type: string
+                      namespace:
+                        description: The namespace of the source VolumeSnapshot
+                        type: string
+                    required:
+                    - name
+                    - namespace
+                    type: object
+                type: object
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: null
+  storedVersions: null
+`,
+	"datavolume": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.14.0
+  creationTimestamp: null
+  name: datavolumes.cdi.kubevirt.io
+spec:
+  group: cdi.kubevirt.io
+  names:
     categories:
     - all
     kind: DataVolume
@@ -6771,10 +7466,39 @@ spec:
                 - kubevirt
                 - archive
                 type: string
+              customization:
+                description: Customization runs a recipe of customizations against
+                  the imported disk image
+                properties:
+                  configMap:
+                    description: ConfigMap is the name of the ConfigMap holding the
+                      recipe under the "recipe" key, in the namespace of the DataVolume
+                    type: string
+                required:
+                - configMap
+                type: object
+              expandPartition:
+                description: ExpandPartition grows the last GPT partition of the
+                  disk and its ext4 or xfs filesystem along with the disk image
+                type: boolean
               finalCheckpoint:
                 description: FinalCheckpoint indicates whether the current DataVolumeCheckpoint
                   is the final checkpoint.
                 type: boolean
+              patch:
+                description: Patch writes the import source into the existing PVC
+                  of the DataVolume instead of importing a whole disk
+                properties:
+                  offset:
+                    description: Offset is the byte offset in the disk the source
+                      is written at
+                    format: int64
+                    type: integer
+                  partitionLabel:
+                    description: PartitionLabel is the name of the GPT partition
+                      of the disk the source is written into
+                    type: string
+                type: object
               preallocation:
                 description: Preallocation controls whether storage for DataVolumes
                   should be allocated in advance.
@@ -6982,6 +7706,51 @@ spec:
               source:
                 description: Source is the src of the data for the requested DataVolume
                 properties:
+                  azureBlob:
+                    description: DataVolumeSourceAzureBlob provides the parameters
+                      to create a Data Volume from an Azure Blob Storage source
+                    properties:
+                      account:
+                        description: Account is the name of the storage account
+                        type: string
+                      blob:
+                        description: Blob is the name of the blob
+                        type: string
+                      certConfigMap:
+                        description: CertConfigMap is a configmap reference, containing
+                          a Certificate Authority(CA) public key, and a base64 encoded
+                          pem certificate
+                        type: string
+                      container:
+                        description: Container is the name of the blob container
+                        type: string
+                      endpoint:
+                        description: |-
+                          Endpoint is the url of the blob service, https://<account>.blob.core.windows.net if empty.
+                          Endpoints with a path, such as http://azurite:10000/devstoreaccount1, are used as is.
+                        type: string
+                      secretRef:
+                        description: |-
+                          SecretRef provides the secret reference needed to access the Azure Blob source, the secret should contain
+                          either accountKey (shared key), sasToken (shared access signature), or tenantId, clientId and clientSecret
+                          (service principal). The blob is read anonymously if empty
+                        type: string
+                    required:
+                    - account
+                    - container
+                    - blob
+                    type: object
+                  backup:
+                    description: DataVolumeSourceBackup provides the parameters to
+                      create a Data Volume from a DataVolumeBackup
+                    properties:
+                      name:
+                        description: The name of the source DataVolumeBackup, in the
+                          namespace of the Data Volume
+                        type: string
+                    required:
+                    - name
+                    type: object
                   blank:
                     description: DataVolumeBlankImage provides the parameters to create
                       a new raw blank image for the PVC
@@ -6997,6 +7766,27 @@ spec:
                       url:
                         description: URL is the url of the GCS source
                         type: string
+                      workloadIdentity:
+                        description: |-
+                          WorkloadIdentity authenticates with the identity of the importer pod instead of SecretRef.
+                          Objects are read anonymously, such as those of public buckets, when neither is set.
+                        properties:
+                          audience:
+                            description: Audience is the audience of the projected
+                              service account token, the https url of the provider
+                              if empty
+                            type: string
+                          provider:
+                            description: |-
+                              Provider is the workload identity pool provider exchanging a projected service account token of the importer pod,
+                              such as //iam.googleapis.com/projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>.
+                              The Application Default Credentials of the importer pod, such as those of GKE workload identity, are used if empty.
+                            type: string
+                          serviceAccount:
+                            description: ServiceAccount is the email of the service
+                              account impersonated with the federated token
+                            type: string
+                        type: object
                     required:
                     - url
                     type: object
@@ -7010,6 +7800,14 @@ spec:
                           a Certificate Authority(CA) public key, and a base64 encoded
                           pem certificate
                         type: string
+                      differencingDisks:
+                        description: DifferencingDisks is an ordered list of URLs
+                          of Hyper-V differencing disks (AVHDX) applied on top of
+                          the VHDX at URL, the first entry is the child of URL and
+                          the last entry is the current state of the disk
+                        items:
+                          type: string
+                        type: array
                       extraHeaders:
                         description: ExtraHeaders is a list of strings containing
                           extra headers to include with HTTP transfer requests
@@ -7111,11 +7909,23 @@ spec:
                     description: DataVolumeSourceS3 provides the parameters to create
                       a Data Volume from an S3 source
                     properties:
+                      addressingStyle:
+                        description: |-
+                          AddressingStyle is how the bucket is addressed in the requests, path (default) or virtualHost.
+                          The URL always has the bucket as first path segment.
+                        enum:
+                        - path
+                        - virtualHost
+                        type: string
                       certConfigMap:
                         description: CertConfigMap is a configmap reference, containing
                           a Certificate Authority(CA) public key, and a base64 encoded
                           pem certificate
                         type: string
+                      region:
+                        description: Region is the region of the bucket, derived from
+                          the host of the URL if empty
+                        type: string
                       secretRef:
                         description: SecretRef provides the secret reference needed
                           to access the S3 source
@@ -7123,6 +7933,27 @@ spec:
                       url:
                         description: URL is the url of the S3 source
                         type: string
+                      webIdentity:
+                        description: WebIdentity assumes an IAM role with a projected
+                          service account token of the importer pod, instead of using
+                          the keys of SecretRef
+                        properties:
+                          audience:
+                            description: Audience is the audience of the projected
+                              service account token, sts.amazonaws.com if empty
+                            type: string
+                          roleARN:
+                            description: RoleARN is the ARN of the role to assume,
+                              it must trust the default service account of the namespace
+                            type: string
+                          stsEndpoint:
+                            description: STSEndpoint is the url of the STS service,
+                              such as http://minio:9000. The AWS STS endpoint of the
+                              region if empty.
+                            type: string
+                        required:
+                        - roleARN
+                        type: object
                     required:
                     - url
                     type: object
@@ -7198,6 +8029,11 @@ spec:
                 - kind
                 - name
                 type: object
+              sparsify:
+                description: Sparsify deallocates the blocks of zeroes of the disk
+                  image once it is written, reclaiming thin provisioned storage. Defaults
+                  to the sparsify of the import tuning of the storage profile
+                type: boolean
               storage:
                 description: Storage is the requested storage specification
                 properties:
@@ -7331,74 +8167,302 @@ spec:
                         additionalProperties:
                           type: string
                         description: |-
-                          matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
-                          map is equivalent to an element of matchExpressions, whose key field is "key", the
-                          operator is "In", and the values array contains only "value". The requirements are ANDed.
+                          matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                          map is equivalent to an element of matchExpressions, whose key field is "key", the
+                          operator is "In", and the values array contains only "value". The requirements are ANDed.
+                        type: object
+                    type: object
+                    x-kubernetes-map-type: atomic
+                  storageClassName:
+                    description: |-
+                      Name of the StorageClass required by the claim.
+                      More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1
+                    type: string
+                  volumeMode:
+                    description: |-
+                      volumeMode defines what type of volume is required by the claim.
+                      Value of Filesystem is implied when not included in claim spec.
+                    type: string
+                  volumeName:
+                    description: VolumeName is the binding reference to the PersistentVolume
+                      backing this claim.
+                    type: string
+                type: object
+            type: object
+          status:
+            description: DataVolumeStatus contains the current status of the DataVolume
+            properties:
+              claimName:
+                description: ClaimName is the name of the underlying PVC used by the
+                  DataVolume.
+                type: string
+              conditions:
+                items:
+                  description: DataVolumeCondition represents the state of a data
+                    volume condition.
+                  properties:
+                    lastHeartbeatTime:
+                      format: date-time
+                      type: string
+                    lastTransitionTime:
+                      format: date-time
+                      type: string
+                    message:
+                      type: string
+                    reason:
+                      type: string
+                    status:
+                      type: string
+                    type:
+                      description: DataVolumeConditionType is the string representation
+                        of known condition types
+                      type: string
+                  required:
+                  - status
+                  - type
+                  type: object
+                type: array
+              estimatedTimeRemaining:
+                description: EstimatedTimeRemaining is the estimated time until the
+                  data transfer of the population completes
+                type: string
+              phase:
+                description: Phase is the current phase of the data volume
+                type: string
+              progress:
+                description: DataVolumeProgress is the current progress of the DataVolume
+                  transfer operation. Value between 0 and 100 inclusive, N/A if not
+                  available
+                type: string
+              reclaimedSpace:
+                anyOf:
+                - type: integer
+                - type: string
+                description: ReclaimedSpace is the size of the blocks of zeroes deallocated
+                  by the last sparsify of the disk image
+                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                x-kubernetes-int-or-string: true
+              restartCount:
+                description: RestartCount is the number of times the pod populating
+                  the DataVolume has restarted
+                format: int32
+                type: integer
+              throughput:
+                anyOf:
+                - type: integer
+                - type: string
+                description: Throughput is the current data transfer rate of the population
+                  in bytes per second
+                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                x-kubernetes-int-or-string: true
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: null
+  storedVersions: null
+`,
+	"datavolumebackup": `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.14.0
+  creationTimestamp: null
+  name: datavolumebackups.cdi.kubevirt.io
+spec:
+  group: cdi.kubevirt.io
+  names:
+    categories:
+    - all
+    kind: DataVolumeBackup
+    listKind: DataVolumeBackupList
+    plural: datavolumebackups
+    shortNames:
+    - dvbackup
+    - dvbackups
+    singular: datavolumebackup
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - description: The phase the backup is in
+      jsonPath: .status.phase
+      name: Phase
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1beta1
+    schema:
+      openAPIV3Schema:
+        description: DataVolumeBackup snapshots a PVC and writes its content, compressed,
+          to object storage
+        properties:
+          apiVersion:
+            description: |-
+              APIVersion defines the versioned schema of this representation of an object.
+              Servers should convert recognized schemas to the latest internal value, and
+              may reject unrecognized values.
+              More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+            type: string
+          kind:
+            description: |-
+              Kind is a string value representing the REST resource this object represents.
+              Servers may infer this from the endpoint the client submits requests to.
+              Cannot be updated.
+              In CamelCase.
+              More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+            type: string
+          metadata:
+            type: object
+          spec:
+            description: DataVolumeBackupSpec defines specification for DataVolumeBackup
+            properties:
+              destination:
+                description: Destination is the object storage the backup is written
+                  to
+                properties:
+                  gcs:
+                    description: GCS is the GCS object the backup is written to
+                    properties:
+                      secretRef:
+                        description: SecretRef provides the secret reference needed
+                          to access the GCS source
+                        type: string
+                      url:
+                        description: URL is the url of the GCS source
+                        type: string
+                      workloadIdentity:
+                        description: |-
+                          WorkloadIdentity authenticates with the identity of the importer pod instead of SecretRef.
+                          Objects are read anonymously, such as those of public buckets, when neither is set.
+                        properties:
+                          audience:
+                            description: Audience is the audience of the projected
+                              service account token, the https url of the provider
+                              if empty
+                            type: string
+                          provider:
+                            description: |-
+                              Provider is the workload identity pool provider exchanging a projected service account token of the importer pod,
+                              such as //iam.googleapis.com/projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>.
+                              The Application Default Credentials of the importer pod, such as those of GKE workload identity, are used if empty.
+                            type: string
+                          serviceAccount:
+                            description: ServiceAccount is the email of the service
+                              account impersonated with the federated token
+                            type: string
                         type: object
+                    required:
+                    - url
                     type: object
-                    x-kubernetes-map-type: atomic
-                  storageClassName:
-                    description: |-
-                      Name of the StorageClass required by the claim.
-                      More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1
-                    type: string
-                  volumeMode:
-                    description: |-
-                      volumeMode defines what type of volume is required by the claim.
-                      Value of Filesystem is implied when not included in claim spec.
-                    type: string
-                  volumeName:
-                    description: VolumeName is the binding reference to the PersistentVolume
-                      backing this claim.
+                  s3:
+                    description: S3 is the S3 object the backup is written to
+                    properties:
+                      addressingStyle:
+                        description: |-
+                          AddressingStyle is how the bucket is addressed in the requests, path (default) or virtualHost.
+                          The URL always has the bucket as first path segment.
+                        enum:
+                        - path
+                        - virtualHost
+                        type: string
+                      certConfigMap:
+                        description: CertConfigMap is a configmap reference, containing
+                          a Certificate Authority(CA) public key, and a base64 encoded
+                          pem certificate
+                        type: string
+                      region:
+                        description: Region is the region of the bucket, derived from
+                          the host of the URL if empty
+                        type: string
+                      secretRef:
+                        description: SecretRef provides the secret reference needed
+                          to access the S3 source
+                        type: string
+                      url:
+                        description: URL is the url of the S3 source
+                        type: string
+                      webIdentity:
+                        description: WebIdentity assumes an IAM role with a projected
+                          service account token of the importer pod, instead of using
+                          the keys of SecretRef
+                        properties:
+                          audience:
+                            description: Audience is the audience of the projected
+                              service account token, sts.amazonaws.com if empty
+                            type: string
+                          roleARN:
+                            description: RoleARN is the ARN of the role to assume,
+                              it must trust the default service account of the namespace
+                            type: string
+                          stsEndpoint:
+                            description: STSEndpoint is the url of the STS service,
+                              such as http://minio:9000. The AWS STS endpoint of the
+                              region if empty.
+                            type: string
+                        required:
+                        - roleARN
+                        type: object
+                    required:
+                    - url
+                    type: object
+                type: object
+              source:
+                description: Source is the PVC to back up
+                properties:
+                  name:
+                    description: The name of the source PVC, in the namespace of the
+                      DataVolumeBackup
                     type: string
+                required:
+                - name
                 type: object
+              volumeSnapshotClassName:
+                description: VolumeSnapshotClassName is the VolumeSnapshotClass used
+                  to snapshot the source, the one of its StorageProfile if not set
+                type: string
+            required:
+            - destination
+            - source
             type: object
           status:
-            description: DataVolumeStatus contains the current status of the DataVolume
+            description: DataVolumeBackupStatus provides the most recently observed
+              status of the DataVolumeBackup
             properties:
-              claimName:
-                description: ClaimName is the name of the underlying PVC used by the
-                  DataVolume.
+              completionTime:
+                description: CompletionTime is the time the backup succeeded or failed
+                format: date-time
+                type: string
+              message:
+                description: Message is a human readable message about the current
+                  phase
                 type: string
-              conditions:
-                items:
-                  description: DataVolumeCondition represents the state of a data
-                    volume condition.
-                  properties:
-                    lastHeartbeatTime:
-                      format: date-time
-                      type: string
-                    lastTransitionTime:
-                      format: date-time
-                      type: string
-                    message:
-                      type: string
-                    reason:
-                      type: string
-                    status:
-                      type: string
-                    type:
-                      description: DataVolumeConditionType is the string representation
-                        of known condition types
-                      type: string
-                  required:
-                  - status
-                  - type
-                  type: object
-                type: array
               phase:
-                description: Phase is the current phase of the data volume
+                description: Phase is the current phase of the backup
                 type: string
-              progress:
-                description: DataVolumeProgress is the current progress of the DataVolume
-                  transfer operation. Value between 0 and 100 inclusive, N/A if not
-                  available
+              size:
+                anyOf:
+                - type: integer
+                - type: string
+                description: Size is the size of the backed up volume, volumes the
+                  backup is restored to must be at least as large
+                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                x-kubernetes-int-or-string: true
+              startTime:
+                description: StartTime is the time the backup started
+                format: date-time
+                type: string
+              volumeMode:
+                description: VolumeMode is the volume mode of the backed up volume
                 type: string
-              restartCount:
-                description: RestartCount is the number of times the pod populating
-                  the DataVolume has restarted
-                format: int32
-                type: integer
             type: object
         required:
         - spec
@@ -7601,27 +8665,85 @@ spec:
             description: OpenstackVolumePopulatorSpec is the spec of the OpenstackVolumePopulator
               CR
             properties:
+              checkpoints:
+                description: |-
+                  Checkpoints are the stages of a multi-stage population. Current is the ID of a Cinder snapshot of the volume,
+                  Previous is not used: Cinder does not report the blocks changed between snapshots.
+                  Each stage is a full copy of its snapshot, the image is not used when checkpoints are set.
+                items:
+                  description: PopulatorCheckpoint defines a stage in a warm migration.
+                  properties:
+                    current:
+                      description: Current is the identifier of the snapshot created
+                        for this checkpoint.
+                      type: string
+                    previous:
+                      description: Previous is the identifier of the snapshot from
+                        the previous checkpoint.
+                      type: string
+                  required:
+                  - current
+                  - previous
+                  type: object
+                type: array
+              finalCheckpoint:
+                description: FinalCheckpoint indicates whether the last checkpoint
+                  is the final stage of the warm migration.
+                type: boolean
               identityUrl:
                 type: string
               imageId:
+                description: |-
+                  ImageID is the Glance image to copy. Only one of ImageID, VolumeID or SnapshotID can be set, and one of them
+                  must be set unless Checkpoints are set.
                 type: string
               secretRef:
                 type: string
+              snapshotId:
+                description: |-
+                  SnapshotID is the Cinder volume snapshot to copy, the snapshot is uploaded to a temporary image
+                  through a temporary volume.
+                type: string
               transferNetwork:
                 description: The network attachment definition that should be used
                   for disk transfer.
                 type: string
+              volumeId:
+                description: VolumeID is the Cinder volume to copy, the volume is
+                  uploaded to a temporary image.
+                type: string
             required:
             - identityUrl
-            - imageId
             - secretRef
             type: object
+            x-kubernetes-validations:
+            - message: only one of imageId, volumeId or snapshotId can be set
+              rule: size([has(self.imageId) && size(self.imageId) > 0, has(self.volumeId)
+                && size(self.volumeId) > 0, has(self.snapshotId) && size(self.snapshotId)
+                > 0].filter(isSet, isSet)) <= 1
+            - message: one of imageId, volumeId, snapshotId or checkpoints must be
+                set
+              rule: (has(self.imageId) && size(self.imageId) > 0) || (has(self.volumeId)
+                && size(self.volumeId) > 0) || (has(self.snapshotId) && size(self.snapshotId)
+                > 0) || (has(self.checkpoints) && size(self.checkpoints) > 0)
           status:
             description: OpenstackVolumePopulatorStatus is the status of the OpenstackVolumePopulator
               CR
             properties:
+              estimatedTimeRemaining:
+                description: EstimatedTimeRemaining is the estimated time until the
+                  data transfer of the population completes
+                type: string
               progress:
                 type: string
+              throughput:
+                anyOf:
+                - type: integer
+                - type: string
+                description: Throughput is the current data transfer rate of the population
+                  in bytes per second
+                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                x-kubernetes-int-or-string: true
             type: object
         required:
         - spec
@@ -7681,10 +8803,35 @@ spec:
             description: OvirtVolumePopulatorSpec is the spec of the OvirtVolumePopulator
               CR
             properties:
+              checkpoints:
+                description: |-
+                  Checkpoints are the stages of a warm migration. Current is the ID of an oVirt VM backup including the disk,
+                  Previous is the checkpoint the backup was taken from, empty for the full backup of the first stage.
+                  Each stage only copies the extents that changed since Previous.
+                items:
+                  description: PopulatorCheckpoint defines a stage in a warm migration.
+                  properties:
+                    current:
+                      description: Current is the identifier of the snapshot created
+                        for this checkpoint.
+                      type: string
+                    previous:
+                      description: Previous is the identifier of the snapshot from
+                        the previous checkpoint.
+                      type: string
+                  required:
+                  - current
+                  - previous
+                  type: object
+                type: array
               diskId:
                 type: string
               engineUrl:
                 type: string
+              finalCheckpoint:
+                description: FinalCheckpoint indicates whether the last checkpoint
+                  is the final stage of the warm migration.
+                type: boolean
               secretRef:
                 type: string
               transferNetwork:
@@ -7700,8 +8847,20 @@ spec:
             description: OvirtVolumePopulatorStatus is the status of the OvirtVolumePopulator
               CR
             properties:
+              estimatedTimeRemaining:
+                description: EstimatedTimeRemaining is the estimated time until the
+                  data transfer of the population completes
+                type: string
               progress:
                 type: string
+              throughput:
+                anyOf:
+                - type: integer
+                - type: string
+                description: Throughput is the current data transfer rate of the population
+                  in bytes per second
+                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                x-kubernetes-int-or-string: true
             type: object
         required:
         - spec
@@ -7794,10 +8953,62 @@ spec:
                 description: CloneStrategy defines the preferred method for performing
                   a CDI clone
                 type: string
+              cloneStrategySelection:
+                description: CloneStrategySelection is how the clone strategy of
+                  the clones to this storage class is chosen. Capability, the default,
+                  uses the clone strategy of the status. Measured uses the fastest
+                  strategy of the clone statistics for the storage class of the source,
+                  if any
+                enum:
+                - Capability
+                - Measured
+                type: string
               dataImportCronSourceFormat:
                 description: DataImportCronSourceFormat defines the format of the
                   DataImportCron-created disk image sources
                 type: string
+              importTuning:
+                description: ImportTuning holds the settings used to write the data
+                  of the volumes of this storage class
+                properties:
+                  cacheMode:
+                    description: CacheMode is the qemu-img cache mode of the volume
+                      writes. If not set, writeback is used, or none after an import
+                      ran out of memory if the volume supports direct IO
+                    enum:
+                    - none
+                    - writeback
+                    - writethrough
+                    - directsync
+                    - unsafe
+                    type: string
+                  preallocation:
+                    description: Preallocation is the default preallocation of the
+                      volumes, it overrides the CDIConfig preallocation and is overridden
+                      by the DataVolume preallocation
+                    type: boolean
+                  sparsify:
+                    description: Sparsify is the default sparsify of the volumes,
+                      it is overridden by the DataVolume sparsify
+                    type: boolean
+                  writeBlockSize:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: WriteBlockSize is the granularity of the qemu-img
+                      writes and zero detection, a multiple of 512 bytes up to 2Mi.
+                      qemu-img defaults to 4Ki
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                  zeroDetection:
+                    description: ZeroDetection controls whether blocks of zeroes are
+                      discarded, leaving the volume sparse, or written out. Defaults
+                      to Discard
+                    enum:
+                    - Discard
+                    - Disabled
+                    type: string
+                type: object
               snapshotClass:
                 description: SnapshotClass is optional specific VolumeSnapshotClass
                   for CloneStrategySnapshot. If not set, a VolumeSnapshotClass is
@@ -7841,6 +9052,51 @@ spec:
                   type: object
                 maxItems: 8
                 type: array
+              cloneStatistics:
+                description: CloneStatistics are the measured durations of the completed
+                  clones to this storage class, per source storage class and clone
+                  strategy
+                items:
+                  description: CloneStatistics are the measured durations of the clones
+                    from a source storage class with a clone strategy
+                  properties:
+                    averageDuration:
+                      description: AverageDuration is the average duration of the
+                        recent clones
+                      type: string
+                    averageThroughput:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      description: AverageThroughput is the average number of bytes
+                        per second of the recent clones
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                    count:
+                      description: Count is the number of completed clones
+                      format: int64
+                      type: integer
+                    lastCompletionTime:
+                      description: LastCompletionTime is the time the last clone completed
+                      format: date-time
+                      type: string
+                    sourceStorageClass:
+                      description: SourceStorageClass is the storage class of the cloned
+                        PVCs
+                      type: string
+                    strategy:
+                      description: Strategy is the clone strategy used
+                      type: string
+                  required:
+                  - averageDuration
+                  - averageThroughput
+                  - count
+                  - lastCompletionTime
+                  - sourceStorageClass
+                  - strategy
+                  type: object
+                maxItems: 32
+                type: array
               cloneStrategy:
                 description: CloneStrategy defines the preferred method for performing
                   a CDI clone
@@ -7849,6 +9105,114 @@ spec:
                 description: DataImportCronSourceFormat defines the format of the
                   DataImportCron-created disk image sources
                 type: string
+              importTuning:
+                description: ImportTuning holds the settings used to write the data
+                  of the volumes of this storage class
+                properties:
+                  cacheMode:
+                    description: CacheMode is the qemu-img cache mode of the volume
+                      writes. If not set, writeback is used, or none after an import
+                      ran out of memory if the volume supports direct IO
+                    enum:
+                    - none
+                    - writeback
+                    - writethrough
+                    - directsync
+                    - unsafe
+                    type: string
+                  preallocation:
+                    description: Preallocation is the default preallocation of the
+                      volumes, it overrides the CDIConfig preallocation and is overridden
+                      by the DataVolume preallocation
+                    type: boolean
+                  sparsify:
+                    description: Sparsify is the default sparsify of the volumes,
+                      it is overridden by the DataVolume sparsify
+                    type: boolean
+                  writeBlockSize:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: WriteBlockSize is the granularity of the qemu-img
+                      writes and zero detection, a multiple of 512 bytes up to 2Mi.
+                      qemu-img defaults to 4Ki
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                  zeroDetection:
+                    description: ZeroDetection controls whether blocks of zeroes are
+                      discarded, leaving the volume sparse, or written out. Defaults
+                      to Discard
+                    enum:
+                    - Discard
+                    - Disabled
+                    type: string
+                type: object
+              probe:
+                description: Probe is the result of probing the capabilities of a
+                  provisioner unknown to CDI, when the StorageProfileProbe feature
+                  gate is enabled
+                properties:
+                  claimPropertySets:
+                    description: ClaimPropertySets are the access and volume mode
+                      combinations the test volumes were provisioned with
+                    items:
+                      description: ClaimPropertySet is a set of properties applicable
+                        to PVC
+                      properties:
+                        accessModes:
+                          description: |-
+                            AccessModes contains the desired access modes the volume should have.
+                            More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1
+                          items:
+                            type: string
+                          maxItems: 4
+                          type: array
+                          x-kubernetes-validations:
+                          - message: Illegal AccessMode
+                            rule: self.all(am, am in ['ReadWriteOnce', 'ReadOnlyMany',
+                              'ReadWriteMany', 'ReadWriteOncePod'])
+                        volumeMode:
+                          description: |-
+                            VolumeMode defines what type of volume is required by the claim.
+                            Value of Filesystem is implied when not included in claim spec.
+                          enum:
+                          - Block
+                          - Filesystem
+                          type: string
+                      required:
+                      - accessModes
+                      - volumeMode
+                      type: object
+                    maxItems: 8
+                    type: array
+                  cloneStrategy:
+                    description: CloneStrategy is the most efficient clone strategy
+                      supported by the test volumes
+                    type: string
+                  completionTime:
+                    description: CompletionTime is the time the probe completed
+                    format: date-time
+                    type: string
+                  phase:
+                    description: Phase is the current phase of the probe
+                    type: string
+                  snapshotClass:
+                    description: SnapshotClass is the VolumeSnapshotClass a test volume
+                      was successfully snapshotted with
+                    type: string
+                  startTime:
+                    description: StartTime is the time the probe started
+                    format: date-time
+                    type: string
+                  storageClassUID:
+                    description: StorageClassUID is the UID of the probed StorageClass,
+                      a recreated StorageClass is probed again
+                    type: string
+                required:
+                - phase
+                - startTime
+                - storageClassUID
+                type: object
               provisioner:
                 description: The Storage class provisioner plugin name
                 type: string
@@ -8027,6 +9391,17 @@ spec:
                 description: ContentType represents the type of the imported data
                   (Kubevirt or archive)
                 type: string
+              customization:
+                description: Customization runs a recipe of customizations against
+                  the imported disk image
+                properties:
+                  configMap:
+                    description: ConfigMap is the name of the ConfigMap holding the
+                      recipe under the "recipe" key, in the namespace of the DataVolume
+                    type: string
+                required:
+                - configMap
+                type: object
               finalCheckpoint:
                 description: FinalCheckpoint indicates whether the current DataVolumeCheckpoint
                   is the final checkpoint.
@@ -8039,10 +9414,63 @@ spec:
                 description: Source is the src of the data to be imported in the target
                   PVC
                 properties:
+                  azureBlob:
+                    description: AzureBlob imports a blob from Azure Blob Storage
+                    properties:
+                      account:
+                        description: Account is the name of the storage account
+                        type: string
+                      blob:
+                        description: Blob is the name of the blob
+                        type: string
+                      certConfigMap:
+                        description: CertConfigMap is a configmap reference, containing
+                          a Certificate Authority(CA) public key, and a base64 encoded
+                          pem certificate
+                        type: string
+                      container:
+                        description: Container is the name of the blob container
+                        type: string
+                      endpoint:
+                        description: |-
+                          Endpoint is the url of the blob service, https://<account>.blob.core.windows.net if empty.
+                          Endpoints with a path, such as http://azurite:10000/devstoreaccount1, are used as is.
+                        type: string
+                      secretRef:
+                        description: |-
+                          SecretRef provides the secret reference needed to access the Azure Blob source, the secret should contain
+                          either accountKey (shared key), sasToken (shared access signature), or tenantId, clientId and clientSecret
+                          (service principal). The blob is read anonymously if empty
+                        type: string
+                    required:
+                    - account
+                    - container
+                    - blob
+                    type: object
                   blank:
                     description: DataVolumeBlankImage provides the parameters to create
                       a new raw blank image for the PVC
                     type: object
+                  cdiBackup:
+                    description: CDIBackup imports a backup stored as a manifest and
+                      chunk objects in an S3 compatible object store
+                    properties:
+                      certConfigMap:
+                        description: CertConfigMap is a configmap reference, containing
+                          a Certificate Authority(CA) public key, and a base64 encoded
+                          pem certificate
+                        type: string
+                      secretRef:
+                        description: SecretRef provides the secret reference needed
+                          to access the object store
+                        type: string
+                      url:
+                        description: URL is the url of the backup manifest, the chunk
+                          objects it lists are resolved relative to it
+                        type: string
+                    required:
+                    - url
+                    type: object
                   gcs:
                     description: DataVolumeSourceGCS provides the parameters to create
                       a Data Volume from an GCS source
@@ -8054,6 +9482,27 @@ spec:
                       url:
                         description: URL is the url of the GCS source
                         type: string
+                      workloadIdentity:
+                        description: |-
+                          WorkloadIdentity authenticates with the identity of the importer pod instead of SecretRef.
+                          Objects are read anonymously, such as those of public buckets, when neither is set.
+                        properties:
+                          audience:
+                            description: Audience is the audience of the projected
+                              service account token, the https url of the provider
+                              if empty
+                            type: string
+                          provider:
+                            description: |-
+                              Provider is the workload identity pool provider exchanging a projected service account token of the importer pod,
+                              such as //iam.googleapis.com/projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>.
+                              The Application Default Credentials of the importer pod, such as those of GKE workload identity, are used if empty.
+                            type: string
+                          serviceAccount:
+                            description: ServiceAccount is the email of the service
+                              account impersonated with the federated token
+                            type: string
+                        type: object
                     required:
                     - url
                     type: object
@@ -8067,6 +9516,14 @@ spec:
                           a Certificate Authority(CA) public key, and a base64 encoded
                           pem certificate
                         type: string
+                      differencingDisks:
+                        description: DifferencingDisks is an ordered list of URLs
+                          of Hyper-V differencing disks (AVHDX) applied on top of
+                          the VHDX at URL, the first entry is the child of URL and
+                          the last entry is the current state of the disk
+                        items:
+                          type: string
+                        type: array
                       extraHeaders:
                         description: ExtraHeaders is a list of strings containing
                           extra headers to include with HTTP transfer requests
@@ -8154,11 +9611,23 @@ spec:
                     description: DataVolumeSourceS3 provides the parameters to create
                       a Data Volume from an S3 source
                     properties:
+                      addressingStyle:
+                        description: |-
+                          AddressingStyle is how the bucket is addressed in the requests, path (default) or virtualHost.
+                          The URL always has the bucket as first path segment.
+                        enum:
+                        - path
+                        - virtualHost
+                        type: string
                       certConfigMap:
                         description: CertConfigMap is a configmap reference, containing
                           a Certificate Authority(CA) public key, and a base64 encoded
                           pem certificate
                         type: string
+                      region:
+                        description: Region is the region of the bucket, derived from
+                          the host of the URL if empty
+                        type: string
                       secretRef:
                         description: SecretRef provides the secret reference needed
                           to access the S3 source
@@ -8166,6 +9635,27 @@ spec:
                       url:
                         description: URL is the url of the S3 source
                         type: string
+                      webIdentity:
+                        description: WebIdentity assumes an IAM role with a projected
+                          service account token of the importer pod, instead of using
+                          the keys of SecretRef
+                        properties:
+                          audience:
+                            description: Audience is the audience of the projected
+                              service account token, sts.amazonaws.com if empty
+                            type: string
+                          roleARN:
+                            description: RoleARN is the ARN of the role to assume,
+                              it must trust the default service account of the namespace
+                            type: string
+                          stsEndpoint:
+                            description: STSEndpoint is the url of the STS service,
+                              such as http://minio:9000. The AWS STS endpoint of the
+                              region if empty.
+                            type: string
+                        required:
+                        - roleARN
+                        type: object
                     required:
                     - url
                     type: object
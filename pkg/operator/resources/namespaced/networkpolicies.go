@@ -17,6 +17,7 @@ const (
 	allowUploadProxyCommunications = "cdi-allow-uploadproxy-communications"
 	allowIngressToCdiAPIWebhook    = "cdi-allow-cdi-api-webhook-server"
 	allowEgressToImporterMetrics   = "cdi-allow-cdi-deployment-importer-metrics"
+	allowAPIServerToWorkerMetrics  = "cdi-allow-cdi-api-worker-metrics"
 	allowEgressFromPoller          = "cdi-allow-egress-from-poller"
 )
 
@@ -26,6 +27,7 @@ func createNetworkPolicies(args *FactoryArgs) []client.Object {
 		newUploadProxyCommunicationsNP(args.Namespace),
 		newIngressToCdiAPIWebhookNP(args.Namespace),
 		newCdiDeploymentToImporterMetricsNP(args.Namespace),
+		newCdiAPIServerToWorkerMetricsNP(args.Namespace),
 		newEgressFromPollerNP(args.Namespace),
 	}
 }
@@ -166,6 +168,37 @@ func newCdiDeploymentToImporterMetricsNP(namespace string) *networkv1.NetworkPol
 	)
 }
 
+func newCdiAPIServerToWorkerMetricsNP(namespace string) *networkv1.NetworkPolicy {
+	return newNetworkPolicy(
+		namespace,
+		allowAPIServerToWorkerMetrics,
+		&networkv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{common.CDIComponentLabel: common.CDIApiServerResourceName},
+			},
+			PolicyTypes: []networkv1.PolicyType{networkv1.PolicyTypeEgress},
+			Egress: []networkv1.NetworkPolicyEgressRule{
+				{
+					To: []networkv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{common.PrometheusLabelKey: common.PrometheusLabelValue},
+							},
+							NamespaceSelector: &metav1.LabelSelector{},
+						},
+					},
+					Ports: []networkv1.NetworkPolicyPort{
+						{
+							Port:     ptr.To(intstr.FromInt32(8443)),
+							Protocol: ptr.To(corev1.ProtocolTCP),
+						},
+					},
+				},
+			},
+		},
+	)
+}
+
 func newEgressFromPollerNP(namespace string) *networkv1.NetworkPolicy {
 	return newNetworkPolicy(
 		namespace,
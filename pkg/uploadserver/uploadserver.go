@@ -63,6 +63,9 @@ type Config struct {
 	ImageSize          string
 	FilesystemOverhead float64
 	Preallocation      bool
+	ExpandPartition    bool
+	Sparsify           bool
+	CacheMode          string
 
 	Deadline *time.Time
 
@@ -74,6 +77,8 @@ type RunResult struct {
 	CloneTarget          bool
 	PreallocationApplied bool
 	DeadlinePassed       bool
+	// ReclaimedBytes is the size of the blocks of zeroes deallocated by the sparsify of the disk image
+	ReclaimedBytes *int64
 }
 
 // UploadServer is the interface to uploadServerApp
@@ -88,6 +93,7 @@ type uploadServerApp struct {
 	processing           bool
 	done                 bool
 	preallocationApplied bool
+	reclaimedBytes       *int64
 	cloneTarget          bool
 	doneChan             chan struct{}
 	errChan              chan error
@@ -239,6 +245,7 @@ func (app *uploadServerApp) Run() (*RunResult, error) {
 	result := &RunResult{
 		CloneTarget:          app.cloneTarget,
 		PreallocationApplied: app.preallocationApplied,
+		ReclaimedBytes:       app.reclaimedBytes,
 	}
 
 	return result, nil
@@ -365,7 +372,7 @@ func (app *uploadServerApp) uploadHandlerAsync(irc imageReadCloser) http.Handler
 			w.WriteHeader(http.StatusBadRequest)
 		}
 
-		processor, err := uploadProcessorFuncAsync(readCloser, app.config.Destination, app.config.ImageSize, app.config.FilesystemOverhead, app.config.Preallocation, cdiContentType)
+		processor, err := uploadProcessorFuncAsync(readCloser, app.config.Destination, app.config.ImageSize, app.config.FilesystemOverhead, app.config.Preallocation, app.config.ExpandPartition, app.config.Sparsify, app.config.CacheMode, cdiContentType)
 
 		app.mutex.Lock()
 		defer app.mutex.Unlock()
@@ -392,6 +399,9 @@ func (app *uploadServerApp) uploadHandlerAsync(irc imageReadCloser) http.Handler
 			defer close(app.doneChan)
 			app.done = true
 			app.preallocationApplied = processor.PreallocationApplied()
+			if app.config.Sparsify {
+				app.reclaimedBytes = processor.ReclaimedBytes()
+			}
 			app.cloneTarget = isCloneTarget(cdiContentType)
 			klog.Infof("Wrote data to %s", app.config.Destination)
 		}()
@@ -414,7 +424,7 @@ func (app *uploadServerApp) processUpload(irc imageReadCloser, w http.ResponseWr
 		w.WriteHeader(http.StatusBadRequest)
 	}
 
-	preallocationApplied, err := uploadProcessorFunc(readCloser, app.config.Destination, app.config.ImageSize, app.config.FilesystemOverhead, app.config.Preallocation, cdiContentType, dvContentType)
+	preallocationApplied, reclaimedBytes, err := uploadProcessorFunc(readCloser, app.config.Destination, app.config.ImageSize, app.config.FilesystemOverhead, app.config.Preallocation, app.config.ExpandPartition, app.config.Sparsify, app.config.CacheMode, cdiContentType, dvContentType)
 
 	app.mutex.Lock()
 	defer app.mutex.Unlock()
@@ -427,6 +437,9 @@ func (app *uploadServerApp) processUpload(irc imageReadCloser, w http.ResponseWr
 
 	app.done = true
 	app.preallocationApplied = preallocationApplied
+	if app.config.Sparsify {
+		app.reclaimedBytes = reclaimedBytes
+	}
 	app.cloneTarget = isCloneTarget(cdiContentType)
 	close(app.doneChan)
 
@@ -449,27 +462,40 @@ func (app *uploadServerApp) uploadArchiveHandler(irc imageReadCloser) http.Handl
 	}
 }
 
-func newAsyncUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType string) (*importer.DataProcessor, error) {
+func newAsyncUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation, expandPartition, sparsify bool, cacheMode, sourceContentType string) (*importer.DataProcessor, error) {
 	if isCloneTarget(sourceContentType) {
 		return nil, fmt.Errorf("async clone not supported")
 	}
 
 	uds := importer.NewAsyncUploadDataSource(newContentReader(stream, sourceContentType))
-	processor := importer.NewDataProcessor(uds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation, "")
+	processor := importer.NewDataProcessor(uds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation, cacheMode)
+	processor.SetExpandPartition(expandPartition)
+	processor.SetSparsify(sparsify)
 	return processor, processor.ProcessDataWithPause()
 }
 
-func newUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation bool, sourceContentType string, dvContentType cdiv1.DataVolumeContentType) (bool, error) {
+func newUploadStreamProcessor(stream io.ReadCloser, dest, imageSize string, filesystemOverhead float64, preallocation, expandPartition, sparsify bool, cacheMode, sourceContentType string, dvContentType cdiv1.DataVolumeContentType) (bool, *int64, error) {
 	stream = newContentReader(stream, sourceContentType)
 	if isCloneTarget(sourceContentType) {
-		return cloneProcessor(stream, sourceContentType, dest, preallocation)
+		var reclaimedBytes *int64
+		preallocationApplied, err := cloneProcessor(stream, sourceContentType, dest, preallocation)
+		if err == nil && expandPartition {
+			// The clone target may be larger than its source, grow the cloned disk into it
+			err = importer.ExpandDisk(dest, imageSize, filesystemOverhead, preallocation)
+		}
+		if err == nil && sparsify && !preallocationApplied {
+			reclaimedBytes, err = importer.Sparsify(dest)
+		}
+		return preallocationApplied, reclaimedBytes, err
 	}
 
 	// Clone block device to block device or file system
 	uds := importer.NewUploadDataSource(stream, dvContentType)
-	processor := importer.NewDataProcessor(uds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation, "")
+	processor := importer.NewDataProcessor(uds, dest, common.ImporterVolumePath, common.ScratchDataDir, imageSize, filesystemOverhead, preallocation, cacheMode)
+	processor.SetExpandPartition(expandPartition)
+	processor.SetSparsify(sparsify)
 	err := processor.ProcessData()
-	return processor.PreallocationApplied(), err
+	return processor.PreallocationApplied(), processor.ReclaimedBytes(), err
 }
 
 func cloneProcessor(stream io.ReadCloser, contentType, dest string, preallocate bool) (bool, error) {
@@ -0,0 +1,201 @@
+package progress
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// updateInterval is how often a started Tracker publishes its stats
+	updateInterval = time.Second
+	// smoothing is the weight of the latest sample in the throughput moving average
+	smoothing = 0.3
+)
+
+// Metric is the progress metric of a worker in percent
+type Metric interface {
+	Add(value float64)
+	Get() (float64, error)
+	Delete()
+}
+
+// TransferMetric publishes the throughput, the estimated time remaining and the byte counts of a worker
+type TransferMetric interface {
+	SetThroughput(bytesPerSecond float64)
+	SetETA(seconds float64)
+	SetBytes(current, total uint64)
+	Delete()
+}
+
+// Stats is a snapshot of a data transfer
+type Stats struct {
+	// Percent is the progress of the transfer, 0 to 100
+	Percent float64
+	// BytesPerSecond is the moving average of the transfer rate
+	BytesPerSecond float64
+	// ETA is the estimated time remaining, negative when unknown
+	ETA time.Duration
+	// Bytes is the number of bytes transferred
+	Bytes uint64
+	// Total is the number of bytes to transfer, 0 when unknown
+	Total uint64
+}
+
+// Meter computes the progress, throughput and estimated time remaining of a transfer from samples of the
+// transferred byte count
+type Meter struct {
+	total     uint64
+	lastTime  time.Time
+	lastBytes uint64
+	rate      float64
+	now       func() time.Time
+}
+
+// NewMeter creates a Meter of a transfer of total bytes, total is 0 when unknown
+func NewMeter(total uint64) *Meter {
+	return newMeter(total, time.Now)
+}
+
+func newMeter(total uint64, now func() time.Time) *Meter {
+	return &Meter{
+		total:    total,
+		lastTime: now(),
+		now:      now,
+	}
+}
+
+// Sample records the current byte count and returns the stats of the transfer
+func (m *Meter) Sample(current uint64) Stats {
+	now := m.now()
+	if elapsed := now.Sub(m.lastTime).Seconds(); elapsed > 0 && current >= m.lastBytes {
+		rate := float64(current-m.lastBytes) / elapsed
+		if m.rate == 0 {
+			m.rate = rate
+		} else {
+			m.rate = smoothing*rate + (1-smoothing)*m.rate
+		}
+		m.lastTime, m.lastBytes = now, current
+	}
+
+	stats := Stats{BytesPerSecond: m.rate, ETA: -1, Bytes: current, Total: m.total}
+	if m.total == 0 {
+		return stats
+	}
+	stats.Percent = 100
+	if current < m.total {
+		stats.Percent = float64(current) / float64(m.total) * 100
+		if m.rate > 0 {
+			stats.ETA = time.Duration(float64(m.total-current) / m.rate * float64(time.Second)).Round(time.Second)
+		}
+	} else {
+		stats.ETA = 0
+	}
+	return stats
+}
+
+// Tracker publishes the stats of a transfer to the worker metrics, the progress metric only increases
+type Tracker struct {
+	meter    *Meter
+	progress Metric
+	transfer TransferMetric
+	current  atomic.Uint64
+	mu       sync.Mutex
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// NewTracker creates a Tracker of a transfer of total bytes, the transfer metric is optional
+func NewTracker(progress Metric, transfer TransferMetric, total uint64) *Tracker {
+	return &Tracker{
+		meter:    NewMeter(total),
+		progress: progress,
+		transfer: transfer,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Add adds n transferred bytes
+func (r *Tracker) Add(n uint64) {
+	r.current.Add(n)
+}
+
+// Set sets the transferred byte count
+func (r *Tracker) Set(n uint64) {
+	r.current.Store(n)
+}
+
+// NewReader returns a reader counting the bytes read from reader
+func (r *Tracker) NewReader(reader io.Reader) io.Reader {
+	return &countingReader{reader: reader, tracker: r}
+}
+
+// Update publishes the current stats of the transfer
+func (r *Tracker) Update() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := r.meter.Sample(r.current.Load())
+	r.publish(stats, false)
+	return stats
+}
+
+// Start publishes the stats every second until Finish is called
+func (r *Tracker) Start() {
+	go func() {
+		ticker := time.NewTicker(updateInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				stats := r.Update()
+				klog.V(1).Infof("Progress: %.2f%%, %.0f bytes/s, ETA %v", stats.Percent, stats.BytesPerSecond, stats.ETA)
+			}
+		}
+	}()
+}
+
+// Finish stops the periodic updates and publishes the completed transfer
+func (r *Tracker) Finish() {
+	r.once.Do(func() {
+		close(r.stop)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		stats := r.meter.Sample(r.current.Load())
+		stats.Percent, stats.ETA = 100, 0
+		r.publish(stats, true)
+	})
+}
+
+func (r *Tracker) publish(stats Stats, final bool) {
+	if r.progress != nil && (r.meter.total > 0 || final) {
+		progress, err := r.progress.Get()
+		if err != nil {
+			klog.Errorf("Failed to read progress metric: %v", err)
+		} else if stats.Percent > progress {
+			r.progress.Add(stats.Percent - progress)
+		}
+	}
+	if r.transfer != nil {
+		r.transfer.SetThroughput(stats.BytesPerSecond)
+		if stats.ETA >= 0 {
+			r.transfer.SetETA(stats.ETA.Seconds())
+		}
+		r.transfer.SetBytes(stats.Bytes, stats.Total)
+	}
+}
+
+type countingReader struct {
+	reader  io.Reader
+	tracker *Tracker
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.reader.Read(p)
+	cr.tracker.Add(uint64(n))
+	return n, err
+}
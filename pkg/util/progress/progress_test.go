@@ -0,0 +1,156 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type fakeMetric struct {
+	value float64
+}
+
+func (m *fakeMetric) Add(value float64) {
+	m.value += value
+}
+
+func (m *fakeMetric) Get() (float64, error) {
+	return m.value, nil
+}
+
+func (m *fakeMetric) Delete() {
+	m.value = 0
+}
+
+type fakeTransferMetric struct {
+	throughput float64
+	eta        *float64
+	current    uint64
+	total      uint64
+}
+
+func (m *fakeTransferMetric) SetThroughput(bytesPerSecond float64) {
+	m.throughput = bytesPerSecond
+}
+
+func (m *fakeTransferMetric) SetETA(seconds float64) {
+	m.eta = &seconds
+}
+
+func (m *fakeTransferMetric) SetBytes(current, total uint64) {
+	m.current, m.total = current, total
+}
+
+func (m *fakeTransferMetric) Delete() {
+	m.throughput, m.eta = 0, nil
+	m.current, m.total = 0, 0
+}
+
+var _ = Describe("Meter", func() {
+	var clock *fakeClock
+
+	BeforeEach(func() {
+		clock = &fakeClock{now: time.Unix(0, 0)}
+	})
+
+	It("should compute the progress, throughput and ETA", func() {
+		meter := newMeter(1000, clock.Now)
+		clock.Advance(time.Second)
+		stats := meter.Sample(100)
+		Expect(stats.Percent).To(BeNumerically("~", 10))
+		Expect(stats.BytesPerSecond).To(BeNumerically("~", 100))
+		Expect(stats.ETA).To(Equal(9 * time.Second))
+		Expect(stats.Bytes).To(Equal(uint64(100)))
+		Expect(stats.Total).To(Equal(uint64(1000)))
+	})
+
+	It("should smooth the throughput", func() {
+		meter := newMeter(10000, clock.Now)
+		clock.Advance(time.Second)
+		meter.Sample(100)
+		clock.Advance(time.Second)
+		stats := meter.Sample(300)
+		Expect(stats.BytesPerSecond).To(BeNumerically("~", 0.3*200+0.7*100))
+	})
+
+	It("should not estimate the time remaining before any data is transferred", func() {
+		meter := newMeter(1000, clock.Now)
+		clock.Advance(time.Second)
+		stats := meter.Sample(0)
+		Expect(stats.Percent).To(BeZero())
+		Expect(stats.ETA).To(BeNumerically("<", 0))
+	})
+
+	It("should only report the throughput of a transfer of unknown size", func() {
+		meter := newMeter(0, clock.Now)
+		clock.Advance(2 * time.Second)
+		stats := meter.Sample(100)
+		Expect(stats.Percent).To(BeZero())
+		Expect(stats.BytesPerSecond).To(BeNumerically("~", 50))
+		Expect(stats.ETA).To(BeNumerically("<", 0))
+	})
+
+	It("should report a completed transfer", func() {
+		meter := newMeter(100, clock.Now)
+		clock.Advance(time.Second)
+		stats := meter.Sample(100)
+		Expect(stats.Percent).To(BeNumerically("~", 100))
+		Expect(stats.ETA).To(BeZero())
+	})
+})
+
+var _ = Describe("Tracker", func() {
+	It("should publish the stats of the bytes read", func() {
+		progress := &fakeMetric{}
+		transfer := &fakeTransferMetric{}
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		tracker := NewTracker(progress, transfer, 100)
+		tracker.meter = newMeter(100, clock.Now)
+
+		_, err := io.CopyN(io.Discard, tracker.NewReader(bytes.NewReader(make([]byte, 100))), 25)
+		Expect(err).ToNot(HaveOccurred())
+		clock.Advance(time.Second)
+		stats := tracker.Update()
+		Expect(stats.Percent).To(BeNumerically("~", 25))
+		Expect(progress.value).To(BeNumerically("~", 25))
+		Expect(transfer.throughput).To(BeNumerically("~", 25))
+		Expect(transfer.eta).To(HaveValue(BeNumerically("~", 3)))
+		Expect(transfer.current).To(Equal(uint64(25)))
+		Expect(transfer.total).To(Equal(uint64(100)))
+	})
+
+	It("should not decrease the progress", func() {
+		progress := &fakeMetric{value: 50}
+		tracker := NewTracker(progress, nil, 100)
+		tracker.Set(10)
+		tracker.Update()
+		Expect(progress.value).To(BeNumerically("~", 50))
+	})
+
+	It("should complete the progress on finish", func() {
+		progress := &fakeMetric{}
+		transfer := &fakeTransferMetric{}
+		tracker := NewTracker(progress, transfer, 0)
+		tracker.Start()
+		tracker.Add(10)
+		tracker.Finish()
+		tracker.Finish()
+		Expect(progress.value).To(BeNumerically("~", 100))
+		Expect(transfer.eta).To(HaveValue(BeZero()))
+	})
+})
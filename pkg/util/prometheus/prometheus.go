@@ -14,32 +14,29 @@ import (
 	"k8s.io/klog/v2"
 
 	"kubevirt.io/containerized-data-importer/pkg/util"
+	"kubevirt.io/containerized-data-importer/pkg/util/progress"
 )
 
-// ProgressReader is a counting reader that reports progress to prometheus.
+// ProgressReader is a counting reader that reports progress to prometheus through a progress.Tracker.
 type ProgressReader struct {
 	util.CountingReader
-	metric ProgressMetric
-	total  uint64
-	final  bool
+	tracker *progress.Tracker
+	final   bool
 }
 
-type ProgressMetric interface {
-	Add(value float64)
-	Get() (float64, error)
-	Delete()
-}
+// ProgressMetric is the progress metric updated by a ProgressReader
+type ProgressMetric = progress.Metric
 
-// NewProgressReader creates a new instance of a prometheus updating progress reader.
-func NewProgressReader(r io.ReadCloser, metric ProgressMetric, total uint64) *ProgressReader {
+// NewProgressReader creates a new instance of a prometheus updating progress reader. The transfer metric,
+// publishing the throughput, the estimated time remaining and the byte counts, is optional.
+func NewProgressReader(r io.ReadCloser, metric ProgressMetric, transfer progress.TransferMetric, total uint64) *ProgressReader {
 	promReader := &ProgressReader{
 		CountingReader: util.CountingReader{
 			Reader:  r,
 			Current: 0,
 		},
-		metric: metric,
-		total:  total,
-		final:  true,
+		tracker: progress.NewTracker(metric, transfer, total),
+		final:   true,
 	}
 
 	return promReader
@@ -60,25 +57,16 @@ func (r *ProgressReader) timedUpdateProgress() {
 	}
 }
 
+// updateProgress publishes the bytes read so far, it returns false once the final reader is done
 func (r *ProgressReader) updateProgress() bool {
-	if r.total > 0 {
-		finished := r.final && r.Done
-		currentProgress := 100.0
-		if !finished && r.Current < r.total {
-			currentProgress = float64(r.Current) / float64(r.total) * 100.0
-		}
-		progress, err := r.metric.Get()
-		if err != nil {
-			klog.Errorf("updateProgress: failed to read metric; %v", err)
-			return true // true ==> to try again // todo - how to avoid endless loop in case it's a constant error?
-		}
-		if currentProgress > progress {
-			r.metric.Add(currentProgress - progress)
-		}
-		klog.V(1).Infoln(fmt.Sprintf("%.2f", currentProgress))
-		return !finished
+	r.tracker.Set(r.Current)
+	if r.final && r.Done {
+		r.tracker.Finish()
+		return false
 	}
-	return false
+	stats := r.tracker.Update()
+	klog.V(1).Infoln(fmt.Sprintf("%.2f", stats.Percent))
+	return true
 }
 
 // SetNextReader replaces the current counting reader with a new one,
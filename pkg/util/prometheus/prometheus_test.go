@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	metrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/cdi-cloner"
+	"kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/transfer"
 	"kubevirt.io/containerized-data-importer/pkg/util"
 )
 
@@ -20,7 +21,7 @@ var _ = Describe("Timed update", func() {
 
 	It("Should start and stop when finished", func() {
 		r := io.NopCloser(bytes.NewReader([]byte("hello world")))
-		progressReader := NewProgressReader(r, metrics.Progress(ownerUID), uint64(11))
+		progressReader := NewProgressReader(r, metrics.Progress(ownerUID), transfer.Stats(ownerUID), uint64(11))
 		progressReader.StartTimedUpdate()
 		_, err := io.ReadAll(r)
 		Expect(err).ToNot(HaveOccurred())
@@ -47,14 +48,9 @@ var _ = Describe("Update Progress", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(progress).To(Equal(float64(0)))
 		By("Calling updateProgress with value")
-		promReader := &ProgressReader{
-			CountingReader: util.CountingReader{
-				Current: uint64(45),
-			},
-			metric: progressMetric,
-			total:  uint64(100),
-			final:  true,
-		}
+		promReader := newTestProgressReader(util.CountingReader{
+			Current: uint64(45),
+		}, progressMetric, uint64(100), true)
 		result := promReader.updateProgress()
 		Expect(true).To(Equal(result))
 		progress, err = progressMetric.Get()
@@ -62,18 +58,13 @@ var _ = Describe("Update Progress", func() {
 		Expect(progress).To(Equal(float64(45)))
 	})
 
-	It("0 total should return 0", func() {
+	It("0 total should keep the progress at 0", func() {
 		By("Calling updateProgress with value")
-		promReader := &ProgressReader{
-			CountingReader: util.CountingReader{
-				Current: uint64(45),
-			},
-			metric: progressMetric,
-			total:  uint64(0),
-			final:  true,
-		}
+		promReader := newTestProgressReader(util.CountingReader{
+			Current: uint64(45),
+		}, progressMetric, uint64(0), true)
 		result := promReader.updateProgress()
-		Expect(false).To(Equal(result))
+		Expect(true).To(Equal(result))
 		progress, err := progressMetric.Get()
 		Expect(err).ToNot(HaveOccurred())
 		Expect(progress).To(Equal(float64(0)))
@@ -81,15 +72,10 @@ var _ = Describe("Update Progress", func() {
 
 	It("current and total equals should return false", func() {
 		By("Calling updateProgress with value")
-		promReader := &ProgressReader{
-			CountingReader: util.CountingReader{
-				Current: uint64(1000),
-				Done:    true,
-			},
-			metric: metrics.Progress(ownerUID),
-			total:  uint64(1000),
-			final:  true,
-		}
+		promReader := newTestProgressReader(util.CountingReader{
+			Current: uint64(1000),
+			Done:    true,
+		}, metrics.Progress(ownerUID), uint64(1000), true)
 		result := promReader.updateProgress()
 		Expect(false).To(Equal(result))
 		progress, err := metrics.Progress(ownerUID).Get()
@@ -98,15 +84,10 @@ var _ = Describe("Update Progress", func() {
 	})
 
 	DescribeTable("update progress on non-final readers", func(readerDone, isFinal, expectedResult bool) {
-		promReader := &ProgressReader{
-			CountingReader: util.CountingReader{
-				Current: uint64(1000),
-				Done:    readerDone,
-			},
-			metric: progressMetric,
-			total:  uint64(1000),
-			final:  isFinal,
-		}
+		promReader := newTestProgressReader(util.CountingReader{
+			Current: uint64(1000),
+			Done:    readerDone,
+		}, progressMetric, uint64(1000), isFinal)
 		result := promReader.updateProgress()
 		Expect(expectedResult).To(Equal(result))
 	},
@@ -116,6 +97,19 @@ var _ = Describe("Update Progress", func() {
 		Entry("should return false when final reader is done", true, true, false),
 	)
 
+	It("should publish the bytes read to the transfer metric", func() {
+		transferMetric := &fakeTransferMetric{}
+		promReader := NewProgressReader(io.NopCloser(strings.NewReader("hello world")), progressMetric, transferMetric, uint64(11))
+		_, err := io.ReadAll(promReader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(promReader.updateProgress()).To(BeFalse())
+		Expect(transferMetric.current).To(Equal(uint64(11)))
+		Expect(transferMetric.total).To(Equal(uint64(11)))
+		progress, err := progressMetric.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(progress).To(Equal(float64(100)))
+	})
+
 	It("should continue to update progress after next reader is set", func() {
 		firstReader := util.CountingReader{
 			Reader: io.NopCloser(strings.NewReader("first")),
@@ -126,12 +120,7 @@ var _ = Describe("Update Progress", func() {
 		thirdReader := util.CountingReader{
 			Reader: io.NopCloser(strings.NewReader("third")),
 		}
-		promReader := &ProgressReader{
-			CountingReader: firstReader,
-			metric:         progressMetric,
-			total:          uint64(16),
-			final:          false,
-		}
+		promReader := newTestProgressReader(firstReader, progressMetric, uint64(16), false)
 
 		data := make([]byte, 10)
 		read, _ := promReader.Read(data)
@@ -163,3 +152,25 @@ var _ = Describe("Update Progress", func() {
 		Expect(false).To(Equal(result))
 	})
 })
+
+func newTestProgressReader(countingReader util.CountingReader, metric ProgressMetric, total uint64, final bool) *ProgressReader {
+	promReader := NewProgressReader(countingReader.Reader, metric, nil, total)
+	promReader.CountingReader = countingReader
+	promReader.final = final
+	return promReader
+}
+
+type fakeTransferMetric struct {
+	current uint64
+	total   uint64
+}
+
+func (f *fakeTransferMetric) SetThroughput(float64) {}
+
+func (f *fakeTransferMetric) SetETA(float64) {}
+
+func (f *fakeTransferMetric) SetBytes(current, total uint64) {
+	f.current, f.total = current, total
+}
+
+func (f *fakeTransferMetric) Delete() {}
@@ -4,16 +4,21 @@ import (
 	"bufio"
 	"bytes"
 	"crypto/md5" //nolint:gosec // This is not a security-sensitive use case
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"math"
 	"math/rand"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/pkg/errors"
@@ -130,6 +135,22 @@ func WriteTerminationMessage(message string) error {
 	return WriteTerminationMessageToFile(common.PodTerminationMessageFile, message)
 }
 
+// WriteFailureTerminationMessage writes the failure message with its reason to the default termination message file, so
+// the controller can set them on the conditions
+func WriteFailureTerminationMessage(message string, reason common.FailureReason) error {
+	termMsg := &common.TerminationMessage{Message: &message}
+	if reason != "" {
+		termMsg.FailureReason = &reason
+	}
+	msg, err := termMsg.String()
+	if err != nil {
+		// kubelet truncates a plain message that is too long for the structured one
+		klog.Errorf("%+v", err)
+		msg = message
+	}
+	return WriteTerminationMessage(msg)
+}
+
 // WriteTerminationMessageToFile writes the passed in message to the passed in message file
 func WriteTerminationMessageToFile(file, message string) error {
 	message = strings.ReplaceAll(message, "\n", " ")
@@ -145,6 +166,87 @@ func WriteTerminationMessageToFile(file, message string) error {
 	return nil
 }
 
+// HTTPStatusError indicates that a server answered a request with an unexpected status code
+type HTTPStatusError struct {
+	StatusCode int
+	err        error
+}
+
+// NewHTTPStatusError creates a HTTPStatusError for the status code, err is the message of the error
+func NewHTTPStatusError(statusCode int, err error) error {
+	return &HTTPStatusError{StatusCode: statusCode, err: err}
+}
+
+func (e *HTTPStatusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *HTTPStatusError) Unwrap() error {
+	return e.err
+}
+
+// failureMessagePatterns classify, in order, the errors of the libraries that only report their cause in the message
+var failureMessagePatterns = []struct {
+	reason   common.FailureReason
+	patterns []string
+}{
+	{common.FailureQuotaExceeded, []string{"disk quota exceeded", "exceeded quota:"}},
+	{common.FailureInsufficientSpace, []string{"no space left on device", "is larger than the reported available", "file largest block is bigger than maxblock"}},
+	{common.FailureTLSError, []string{"x509: ", "tls: "}},
+	{common.FailureAuthFailed, []string{"unauthorized", "authentication required", "access denied", "accessdenied",
+		"access to the resource is denied", "forbidden", "invalidaccesskeyid", "signaturedoesnotmatch", "authenticationfailed"}},
+	{common.FailureNotFound, []string{"not found", "notfound", "manifest unknown", "nosuchkey", "nosuchbucket", "object doesn't exist"}},
+	{common.FailureConnectionFailed, []string{"no such host", "connection refused", "i/o timeout", "network is unreachable", "no route to host"}},
+}
+
+// GetFailureReason classifies the error of a worker pod into the reason reported in its termination message, empty if
+// the cause is unknown
+func GetFailureReason(err error) common.FailureReason {
+	if err == nil {
+		return ""
+	}
+
+	var (
+		statusErr        *HTTPStatusError
+		unknownAuthority x509.UnknownAuthorityError
+		hostnameErr      x509.HostnameError
+		invalidCertErr   x509.CertificateInvalidError
+		verificationErr  *tls.CertificateVerificationError
+		dnsErr           *net.DNSError
+		execErr          *exec.Error
+	)
+	switch {
+	case errors.As(err, &execErr):
+		// A missing or unusable executable is a problem of the pod, not of the source
+		return ""
+	case errors.As(err, &statusErr):
+		switch statusErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return common.FailureAuthFailed
+		case http.StatusNotFound, http.StatusGone:
+			return common.FailureNotFound
+		}
+	case errors.Is(err, syscall.EDQUOT):
+		return common.FailureQuotaExceeded
+	case errors.Is(err, syscall.ENOSPC):
+		return common.FailureInsufficientSpace
+	case errors.As(err, &unknownAuthority), errors.As(err, &hostnameErr), errors.As(err, &invalidCertErr), errors.As(err, &verificationErr):
+		return common.FailureTLSError
+	case errors.As(err, &dnsErr), errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return common.FailureConnectionFailed
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, p := range failureMessagePatterns {
+		for _, pattern := range p.patterns {
+			if strings.Contains(msg, pattern) {
+				return p.reason
+			}
+		}
+	}
+	return ""
+}
+
 // RoundDown returns the number rounded down to the nearest multiple.
 func RoundDown(number, multiple int64) int64 {
 	return number / multiple * multiple
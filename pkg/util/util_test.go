@@ -1,15 +1,24 @@
 package util
 
 import (
+	"crypto/x509"
 	"encoding/base64"
+	"fmt"
+	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"syscall"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/pkg/errors"
+
 	"k8s.io/apimachinery/pkg/api/resource"
+
+	"kubevirt.io/containerized-data-importer/pkg/common"
 )
 
 const (
@@ -257,3 +266,27 @@ var _ = Describe("Merge Labels", func() {
 	)
 
 })
+
+var _ = Describe("GetFailureReason", func() {
+	DescribeTable("Should classify", func(err error, expected common.FailureReason) {
+		Expect(GetFailureReason(err)).To(Equal(expected))
+	},
+		Entry("no error", nil, common.FailureReason("")),
+		Entry("an unknown error", errors.New("something went wrong"), common.FailureReason("")),
+		Entry("an unauthorized status", NewHTTPStatusError(401, errors.New("got 401")), common.FailureAuthFailed),
+		Entry("a forbidden status", errors.Wrap(NewHTTPStatusError(403, errors.New("got 403")), "unable to connect"), common.FailureAuthFailed),
+		Entry("a not found status", NewHTTPStatusError(404, errors.New("got 404")), common.FailureNotFound),
+		Entry("a server error status", NewHTTPStatusError(500, errors.New("got 500")), common.FailureReason("")),
+		Entry("an exceeded disk quota", fmt.Errorf("write: %w", syscall.EDQUOT), common.FailureQuotaExceeded),
+		Entry("a full disk", fmt.Errorf("write: %w", syscall.ENOSPC), common.FailureInsufficientSpace),
+		Entry("an unknown certificate authority", errors.Wrap(x509.UnknownAuthorityError{}, "Get"), common.FailureTLSError),
+		Entry("an unknown host", errors.Wrap(&net.DNSError{Err: "no such host", Name: "example.com"}, "Get"), common.FailureConnectionFailed),
+		Entry("a refused connection", fmt.Errorf("dial: %w", syscall.ECONNREFUSED), common.FailureConnectionFailed),
+		Entry("a registry authentication message", errors.New("unauthorized: authentication required"), common.FailureAuthFailed),
+		Entry("a registry missing manifest message", errors.New("reading manifest latest: manifest unknown"), common.FailureNotFound),
+		Entry("a S3 missing key message", errors.New("NoSuchKey: The specified key does not exist."), common.FailureNotFound),
+		Entry("a TLS message", errors.New("tls: failed to verify certificate"), common.FailureTLSError),
+		Entry("a missing executable", errors.Wrap(&exec.Error{Name: "virt-customize", Err: exec.ErrNotFound}, "Couldn't start virt-customize"), common.FailureReason("")),
+		Entry("a too large image message", errors.New("virtual image size 2 is larger than the reported available storage 1"), common.FailureInsufficientSpace),
+	)
+})
@@ -47,10 +47,10 @@ func newCloneSourceHandler(dataVolume *DataVolume, dsGet dsGetFunc) (CloneSource
 		}
 		pvcSource = dataSource.Spec.Source.PVC
 		snapshotSource = dataSource.Spec.Source.Snapshot
-		if dataSource.Spec.Source.DataSource != nil {
+		if dataSource.Spec.Source.DataSource != nil || IsDataSourceImportSource(&dataSource.Spec.Source) {
 			pvcSource = dataSource.Status.Source.PVC
 			snapshotSource = dataSource.Status.Source.Snapshot
-		} 
+		}
 	}
 
 	switch {
@@ -57,6 +57,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VolumeUploadSourceList{},
 		&VolumeCloneSource{},
 		&VolumeCloneSourceList{},
+		&DataVolumeBackup{},
+		&DataVolumeBackupList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -18,7 +18,9 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/api"
 )
 
@@ -65,6 +67,34 @@ type DataVolumeSpec struct {
 	FinalCheckpoint bool `json:"finalCheckpoint,omitempty"`
 	// Preallocation controls whether storage for DataVolumes should be allocated in advance.
 	Preallocation *bool `json:"preallocation,omitempty"`
+	// Patch writes the import source into the existing PVC of the DataVolume instead of importing a whole disk
+	// +optional
+	Patch *DataVolumePatch `json:"patch,omitempty"`
+	// Customization runs a recipe of customizations against the imported disk image
+	// +optional
+	Customization *DataVolumeCustomization `json:"customization,omitempty"`
+	// ExpandPartition grows the last GPT partition of the disk and its ext4 or xfs filesystem along with the disk image
+	// +optional
+	ExpandPartition *bool `json:"expandPartition,omitempty"`
+	// Sparsify deallocates the blocks of zeroes of the disk image once it is written, reclaiming thin provisioned storage. Defaults to the sparsify of the import tuning of the storage profile
+	// +optional
+	Sparsify *bool `json:"sparsify,omitempty"`
+}
+
+// DataVolumeCustomization references the recipe of customizations run against an imported disk image
+type DataVolumeCustomization struct {
+	// ConfigMap is the name of the ConfigMap holding the recipe under the "recipe" key, in the namespace of the DataVolume
+	ConfigMap string `json:"configMap"`
+}
+
+// DataVolumePatch defines where the import source is written into an existing disk, exactly one of offset and partitionLabel must be set
+type DataVolumePatch struct {
+	// Offset is the byte offset in the disk the source is written at
+	// +optional
+	Offset *int64 `json:"offset,omitempty"`
+	// PartitionLabel is the name of the GPT partition of the disk the source is written into
+	// +optional
+	PartitionLabel string `json:"partitionLabel,omitempty"`
 }
 
 // StorageSpec defines the Storage type specification
@@ -126,18 +156,26 @@ const (
 	DataVolumeArchive DataVolumeContentType = "archive"
 )
 
-// DataVolumeSource represents the source for our Data Volume, this can be HTTP, Imageio, S3, GCS, Registry or an existing PVC
+// DataVolumeSource represents the source for our Data Volume, this can be HTTP, Imageio, S3, GCS, Azure Blob, Registry or an existing PVC
 type DataVolumeSource struct {
-	HTTP     *DataVolumeSourceHTTP     `json:"http,omitempty"`
-	S3       *DataVolumeSourceS3       `json:"s3,omitempty"`
-	GCS      *DataVolumeSourceGCS      `json:"gcs,omitempty"`
-	Registry *DataVolumeSourceRegistry `json:"registry,omitempty"`
-	PVC      *DataVolumeSourcePVC      `json:"pvc,omitempty"`
-	Upload   *DataVolumeSourceUpload   `json:"upload,omitempty"`
-	Blank    *DataVolumeBlankImage     `json:"blank,omitempty"`
-	Imageio  *DataVolumeSourceImageIO  `json:"imageio,omitempty"`
-	VDDK     *DataVolumeSourceVDDK     `json:"vddk,omitempty"`
-	Snapshot *DataVolumeSourceSnapshot `json:"snapshot,omitempty"`
+	HTTP      *DataVolumeSourceHTTP      `json:"http,omitempty"`
+	S3        *DataVolumeSourceS3        `json:"s3,omitempty"`
+	GCS       *DataVolumeSourceGCS       `json:"gcs,omitempty"`
+	AzureBlob *DataVolumeSourceAzureBlob `json:"azureBlob,omitempty"`
+	Registry  *DataVolumeSourceRegistry  `json:"registry,omitempty"`
+	PVC       *DataVolumeSourcePVC       `json:"pvc,omitempty"`
+	Upload    *DataVolumeSourceUpload    `json:"upload,omitempty"`
+	Blank     *DataVolumeBlankImage      `json:"blank,omitempty"`
+	Imageio   *DataVolumeSourceImageIO   `json:"imageio,omitempty"`
+	VDDK      *DataVolumeSourceVDDK      `json:"vddk,omitempty"`
+	Snapshot  *DataVolumeSourceSnapshot  `json:"snapshot,omitempty"`
+	Backup    *DataVolumeSourceBackup    `json:"backup,omitempty"`
+}
+
+// DataVolumeSourceBackup provides the parameters to create a Data Volume from a DataVolumeBackup
+type DataVolumeSourceBackup struct {
+	// The name of the source DataVolumeBackup, in the namespace of the Data Volume
+	Name string `json:"name"`
 }
 
 // DataVolumeSourcePVC provides the parameters to create a Data Volume from an existing PVC
@@ -182,6 +220,39 @@ type DataVolumeSourceS3 struct {
 	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
 	// +optional
 	CertConfigMap string `json:"certConfigMap,omitempty"`
+	// Region is the region of the bucket, derived from the host of the URL if empty
+	// +optional
+	Region string `json:"region,omitempty"`
+	// AddressingStyle is how the bucket is addressed in the requests, path (default) or virtualHost.
+	// The URL always has the bucket as first path segment.
+	// +optional
+	// +kubebuilder:validation:Enum=path;virtualHost
+	AddressingStyle S3AddressingStyle `json:"addressingStyle,omitempty"`
+	// WebIdentity assumes an IAM role with a projected service account token of the importer pod, instead of using the keys of SecretRef
+	// +optional
+	WebIdentity *S3WebIdentity `json:"webIdentity,omitempty"`
+}
+
+// S3AddressingStyle is how the bucket of an S3 source is addressed
+type S3AddressingStyle string
+
+const (
+	// S3AddressingStylePath sends the bucket in the path of the requests, as most S3 compatible stores expect
+	S3AddressingStylePath S3AddressingStyle = "path"
+	// S3AddressingStyleVirtualHost sends the bucket in the host name of the requests
+	S3AddressingStyleVirtualHost S3AddressingStyle = "virtualHost"
+)
+
+// S3WebIdentity provides the parameters to assume an IAM role with the web identity of the importer pod
+type S3WebIdentity struct {
+	// RoleARN is the ARN of the role to assume, it must trust the default service account of the namespace
+	RoleARN string `json:"roleARN"`
+	// Audience is the audience of the projected service account token, sts.amazonaws.com if empty
+	// +optional
+	Audience string `json:"audience,omitempty"`
+	// STSEndpoint is the url of the STS service, such as http://minio:9000. The AWS STS endpoint of the region if empty.
+	// +optional
+	STSEndpoint string `json:"stsEndpoint,omitempty"`
 }
 
 // DataVolumeSourceGCS provides the parameters to create a Data Volume from an GCS source
@@ -190,6 +261,47 @@ type DataVolumeSourceGCS struct {
 	URL string `json:"url"`
 	//SecretRef provides the secret reference needed to access the GCS source
 	SecretRef string `json:"secretRef,omitempty"`
+	// WorkloadIdentity authenticates with the identity of the importer pod instead of SecretRef.
+	// Objects are read anonymously, such as those of public buckets, when neither is set.
+	// +optional
+	WorkloadIdentity *GCSWorkloadIdentity `json:"workloadIdentity,omitempty"`
+}
+
+// GCSWorkloadIdentity provides the parameters to authenticate to GCS with the workload identity of the importer pod
+type GCSWorkloadIdentity struct {
+	// Provider is the workload identity pool provider exchanging a projected service account token of the importer pod,
+	// such as //iam.googleapis.com/projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>.
+	// The Application Default Credentials of the importer pod, such as those of GKE workload identity, are used if empty.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// ServiceAccount is the email of the service account impersonated with the federated token
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Audience is the audience of the projected service account token, the https url of the provider if empty
+	// +optional
+	Audience string `json:"audience,omitempty"`
+}
+
+// DataVolumeSourceAzureBlob provides the parameters to create a Data Volume from an Azure Blob Storage source
+type DataVolumeSourceAzureBlob struct {
+	// Account is the name of the storage account
+	Account string `json:"account"`
+	// Container is the name of the blob container
+	Container string `json:"container"`
+	// Blob is the name of the blob
+	Blob string `json:"blob"`
+	// Endpoint is the url of the blob service, https://<account>.blob.core.windows.net if empty.
+	// Endpoints with a path, such as http://azurite:10000/devstoreaccount1, are used as is.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// SecretRef provides the secret reference needed to access the Azure Blob source, the secret should contain
+	// either accountKey (shared key), sasToken (shared access signature), or tenantId, clientId and clientSecret
+	// (service principal). The blob is read anonymously if empty
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
 }
 
 // DataVolumeSourceRegistry provides the parameters to create a Data Volume from an registry source
@@ -253,6 +365,10 @@ type DataVolumeSourceHTTP struct {
 	// SecretExtraHeaders is a list of Secret references, each containing an extra HTTP header that may include sensitive information
 	// +optional
 	SecretExtraHeaders []string `json:"secretExtraHeaders,omitempty"`
+	// DifferencingDisks is an ordered list of URLs of Hyper-V differencing disks (AVHDX) applied on top of the VHDX at URL,
+	// the first entry is the child of URL and the last entry is the current state of the disk
+	// +optional
+	DifferencingDisks []string `json:"differencingDisks,omitempty"`
 }
 
 // DataVolumeSourceImageIO provides the parameters to create a Data Volume from an imageio source
@@ -313,6 +429,15 @@ type DataVolumeStatus struct {
 	// RestartCount is the number of times the pod populating the DataVolume has restarted
 	RestartCount int32                 `json:"restartCount,omitempty"`
 	Conditions   []DataVolumeCondition `json:"conditions,omitempty" optional:"true"`
+	// Throughput is the current data transfer rate of the population in bytes per second
+	// +optional
+	Throughput *resource.Quantity `json:"throughput,omitempty"`
+	// EstimatedTimeRemaining is the estimated time until the data transfer of the population completes
+	// +optional
+	EstimatedTimeRemaining *metav1.Duration `json:"estimatedTimeRemaining,omitempty"`
+	// ReclaimedSpace is the size of the blocks of zeroes deallocated by the last sparsify of the disk image
+	// +optional
+	ReclaimedSpace *resource.Quantity `json:"reclaimedSpace,omitempty"`
 }
 
 // DataVolumeList provides the needed parameters to do request a list of Data Volumes from the system
@@ -450,6 +575,13 @@ type StorageProfileSpec struct {
 	DataImportCronSourceFormat *DataImportCronSourceFormat `json:"dataImportCronSourceFormat,omitempty"`
 	// SnapshotClass is optional specific VolumeSnapshotClass for CloneStrategySnapshot. If not set, a VolumeSnapshotClass is chosen according to the provisioner.
 	SnapshotClass *string `json:"snapshotClass,omitempty"`
+	// ImportTuning holds the settings used to write the data of the volumes of this storage class
+	// +optional
+	ImportTuning *ImportTuning `json:"importTuning,omitempty"`
+	// CloneStrategySelection is how the clone strategy of the clones to this storage class is chosen. Capability, the default, uses the clone strategy of the status. Measured uses the fastest strategy of the clone statistics for the storage class of the source, if any
+	// +kubebuilder:validation:Enum=Capability;Measured
+	// +optional
+	CloneStrategySelection *CloneStrategySelection `json:"cloneStrategySelection,omitempty"`
 }
 
 // StorageProfileStatus provides the most recently observed status of the StorageProfile
@@ -467,8 +599,124 @@ type StorageProfileStatus struct {
 	DataImportCronSourceFormat *DataImportCronSourceFormat `json:"dataImportCronSourceFormat,omitempty"`
 	// SnapshotClass is optional specific VolumeSnapshotClass for CloneStrategySnapshot. If not set, a VolumeSnapshotClass is chosen according to the provisioner.
 	SnapshotClass *string `json:"snapshotClass,omitempty"`
+	// Probe is the result of probing the capabilities of a provisioner unknown to CDI, when the StorageProfileProbe feature gate is enabled
+	// +optional
+	Probe *StorageProfileProbeStatus `json:"probe,omitempty"`
+	// ImportTuning holds the settings used to write the data of the volumes of this storage class
+	// +optional
+	ImportTuning *ImportTuning `json:"importTuning,omitempty"`
+	// CloneStatistics are the measured durations of the completed clones to this storage class, per source storage class and clone strategy
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	CloneStatistics []CloneStatistics `json:"cloneStatistics,omitempty"`
+}
+
+// CloneStrategySelection is how the clone strategy is chosen
+type CloneStrategySelection string
+
+const (
+	// CloneStrategySelectionCapability chooses the most efficient clone strategy supported by the storage
+	CloneStrategySelectionCapability CloneStrategySelection = "Capability"
+	// CloneStrategySelectionMeasured chooses the clone strategy with the highest measured throughput
+	CloneStrategySelectionMeasured CloneStrategySelection = "Measured"
+)
+
+// CloneStatistics are the measured durations of the clones from a source storage class with a clone strategy
+type CloneStatistics struct {
+	// SourceStorageClass is the storage class of the cloned PVCs
+	SourceStorageClass string `json:"sourceStorageClass"`
+	// Strategy is the clone strategy used
+	Strategy CDICloneStrategy `json:"strategy"`
+	// Count is the number of completed clones
+	Count int64 `json:"count"`
+	// AverageDuration is the average duration of the recent clones
+	AverageDuration metav1.Duration `json:"averageDuration"`
+	// AverageThroughput is the average number of bytes per second of the recent clones
+	AverageThroughput resource.Quantity `json:"averageThroughput"`
+	// LastCompletionTime is the time the last clone completed
+	LastCompletionTime metav1.Time `json:"lastCompletionTime"`
+}
+
+// ImportTuning holds the settings the importer, upload and clone target pods use to write the data of a volume
+type ImportTuning struct {
+	// Preallocation is the default preallocation of the volumes, it overrides the CDIConfig preallocation and is overridden by the DataVolume preallocation
+	// +optional
+	Preallocation *bool `json:"preallocation,omitempty"`
+	// CacheMode is the qemu-img cache mode of the volume writes. If not set, writeback is used, or none after an import ran out of memory if the volume supports direct IO
+	// +kubebuilder:validation:Enum=none;writeback;writethrough;directsync;unsafe
+	// +optional
+	CacheMode *ImportCacheMode `json:"cacheMode,omitempty"`
+	// WriteBlockSize is the granularity of the qemu-img writes and zero detection, a multiple of 512 bytes up to 2Mi. qemu-img defaults to 4Ki
+	// +optional
+	WriteBlockSize *resource.Quantity `json:"writeBlockSize,omitempty"`
+	// ZeroDetection controls whether blocks of zeroes are discarded, leaving the volume sparse, or written out. Defaults to Discard
+	// +kubebuilder:validation:Enum=Discard;Disabled
+	// +optional
+	ZeroDetection *ImportZeroDetection `json:"zeroDetection,omitempty"`
+	// Sparsify is the default sparsify of the volumes, it is overridden by the DataVolume sparsify
+	// +optional
+	Sparsify *bool `json:"sparsify,omitempty"`
+}
+
+// ImportCacheMode is a qemu-img cache mode
+type ImportCacheMode string
+
+const (
+	// ImportCacheModeNone bypasses the host page cache
+	ImportCacheModeNone ImportCacheMode = "none"
+	// ImportCacheModeWriteback writes through the host page cache
+	ImportCacheModeWriteback ImportCacheMode = "writeback"
+	// ImportCacheModeWritethrough writes through the host page cache and flushes every write
+	ImportCacheModeWritethrough ImportCacheMode = "writethrough"
+	// ImportCacheModeDirectsync bypasses the host page cache and flushes every write
+	ImportCacheModeDirectsync ImportCacheMode = "directsync"
+	// ImportCacheModeUnsafe writes through the host page cache and never flushes
+	ImportCacheModeUnsafe ImportCacheMode = "unsafe"
+)
+
+// ImportZeroDetection controls how blocks of zeroes are written
+type ImportZeroDetection string
+
+const (
+	// ImportZeroDetectionDiscard leaves blocks of zeroes unallocated
+	ImportZeroDetectionDiscard ImportZeroDetection = "Discard"
+	// ImportZeroDetectionDisabled writes every block, fully allocating the written data
+	ImportZeroDetectionDisabled ImportZeroDetection = "Disabled"
+)
+
+// StorageProfileProbeStatus is the result of probing the storage capabilities with short-lived test volumes
+type StorageProfileProbeStatus struct {
+	// StorageClassUID is the UID of the probed StorageClass, a recreated StorageClass is probed again
+	StorageClassUID types.UID `json:"storageClassUID"`
+	// Phase is the current phase of the probe
+	Phase StorageProfileProbePhase `json:"phase"`
+	// StartTime is the time the probe started
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is the time the probe completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// ClaimPropertySets are the access and volume mode combinations the test volumes were provisioned with
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	ClaimPropertySets []ClaimPropertySet `json:"claimPropertySets,omitempty"`
+	// CloneStrategy is the most efficient clone strategy supported by the test volumes
+	// +optional
+	CloneStrategy *CDICloneStrategy `json:"cloneStrategy,omitempty"`
+	// SnapshotClass is the VolumeSnapshotClass a test volume was successfully snapshotted with
+	// +optional
+	SnapshotClass *string `json:"snapshotClass,omitempty"`
 }
 
+// StorageProfileProbePhase is the phase of a storage capability probe
+type StorageProfileProbePhase string
+
+const (
+	// StorageProfileProbeRunning means the test volumes are being provisioned, snapshotted and cloned
+	StorageProfileProbeRunning StorageProfileProbePhase = "Running"
+	// StorageProfileProbeSucceeded means the probe completed and the test volumes were removed
+	StorageProfileProbeSucceeded StorageProfileProbePhase = "Succeeded"
+)
+
 // ClaimPropertySet is a set of properties applicable to PVC
 type ClaimPropertySet struct {
 	// AccessModes contains the desired access modes the volume should have.
@@ -510,6 +758,9 @@ type DataSource struct {
 type DataSourceSpec struct {
 	// Source is the source of the data referenced by the DataSource
 	Source DataSourceSource `json:"source"`
+	// Storage is the storage specification of the cache PVC the external source (http, registry, s3) is imported into
+	// +optional
+	Storage *StorageSpec `json:"storage,omitempty"`
 }
 
 // DataSourceSource represents the source for our DataSource
@@ -520,6 +771,15 @@ type DataSourceSource struct {
 	Snapshot *DataVolumeSourceSnapshot `json:"snapshot,omitempty"`
 	// +optional
 	DataSource *DataSourceRefSourceDataSource `json:"dataSource,omitempty"`
+	// HTTP is an external source lazily imported into a cache PVC in the DataSource namespace
+	// +optional
+	HTTP *DataVolumeSourceHTTP `json:"http,omitempty"`
+	// Registry is an external source lazily imported into a cache PVC in the DataSource namespace
+	// +optional
+	Registry *DataVolumeSourceRegistry `json:"registry,omitempty"`
+	// S3 is an external source lazily imported into a cache PVC in the DataSource namespace
+	// +optional
+	S3 *DataVolumeSourceS3 `json:"s3,omitempty"`
 }
 
 // DataSourceStatus provides the most recently observed status of the DataSource
@@ -701,6 +961,9 @@ type VolumeImportSourceSpec struct {
 	Checkpoints []DataVolumeCheckpoint `json:"checkpoints,omitempty"`
 	// FinalCheckpoint indicates whether the current DataVolumeCheckpoint is the final checkpoint.
 	FinalCheckpoint *bool `json:"finalCheckpoint,omitempty"`
+	// Customization runs a recipe of customizations against the imported disk image
+	// +optional
+	Customization *DataVolumeCustomization `json:"customization,omitempty"`
 }
 
 // ImportSourceType contains each one of the source types allowed in a VolumeImportSource
@@ -712,6 +975,24 @@ type ImportSourceType struct {
 	Blank    *DataVolumeBlankImage     `json:"blank,omitempty"`
 	Imageio  *DataVolumeSourceImageIO  `json:"imageio,omitempty"`
 	VDDK     *DataVolumeSourceVDDK     `json:"vddk,omitempty"`
+	// AzureBlob imports a blob from Azure Blob Storage
+	// +optional
+	AzureBlob *DataVolumeSourceAzureBlob `json:"azureBlob,omitempty"`
+	// CDIBackup imports a backup stored as a manifest and chunk objects in an S3 compatible object store
+	// +optional
+	CDIBackup *DataVolumeSourceCDIBackup `json:"cdiBackup,omitempty"`
+}
+
+// DataVolumeSourceCDIBackup provides the parameters to import a chunked backup from an S3 compatible object store
+type DataVolumeSourceCDIBackup struct {
+	// URL is the url of the backup manifest, the chunk objects it lists are resolved relative to it
+	URL string `json:"url"`
+	// SecretRef provides the secret reference needed to access the object store
+	// +optional
+	SecretRef string `json:"secretRef,omitempty"`
+	// CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate
+	// +optional
+	CertConfigMap string `json:"certConfigMap,omitempty"`
 }
 
 // VolumeImportSourceStatus provides the most recently observed status of the VolumeImportSource
@@ -809,6 +1090,98 @@ type VolumeCloneSourceList struct {
 	Items []VolumeCloneSource `json:"items"`
 }
 
+// DataVolumeBackup snapshots a PVC and writes its content, compressed, to object storage
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=dvbackup;dvbackups,categories=all
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase the backup is in"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type DataVolumeBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DataVolumeBackupSpec `json:"spec"`
+	// +optional
+	Status DataVolumeBackupStatus `json:"status,omitempty"`
+}
+
+// DataVolumeBackupSpec defines specification for DataVolumeBackup
+type DataVolumeBackupSpec struct {
+	// Source is the PVC to back up
+	Source DataVolumeBackupSource `json:"source"`
+	// Destination is the object storage the backup is written to
+	Destination DataVolumeBackupDestination `json:"destination"`
+	// VolumeSnapshotClassName is the VolumeSnapshotClass used to snapshot the source, the one of its StorageProfile if not set
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// DataVolumeBackupSource is the PVC to back up
+type DataVolumeBackupSource struct {
+	// The name of the source PVC, in the namespace of the DataVolumeBackup
+	Name string `json:"name"`
+}
+
+// DataVolumeBackupDestination is the object storage a backup is written to, exactly one of the fields must be set
+type DataVolumeBackupDestination struct {
+	// S3 is the S3 object the backup is written to
+	// +optional
+	S3 *DataVolumeSourceS3 `json:"s3,omitempty"`
+	// GCS is the GCS object the backup is written to
+	// +optional
+	GCS *DataVolumeSourceGCS `json:"gcs,omitempty"`
+}
+
+// DataVolumeBackupStatus provides the most recently observed status of the DataVolumeBackup
+type DataVolumeBackupStatus struct {
+	// Phase is the current phase of the backup
+	Phase DataVolumeBackupPhase `json:"phase,omitempty"`
+	// Size is the size of the backed up volume, volumes the backup is restored to must be at least as large
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// VolumeMode is the volume mode of the backed up volume
+	// +optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+	// StartTime is the time the backup started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the backup succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Message is a human readable message about the current phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DataVolumeBackupPhase is the current phase of the DataVolumeBackup
+type DataVolumeBackupPhase string
+
+const (
+	// DataVolumeBackupPending represents a DataVolumeBackup waiting for its source
+	DataVolumeBackupPending DataVolumeBackupPhase = "Pending"
+	// DataVolumeBackupSnapshotInProgress represents a DataVolumeBackup snapshotting its source
+	DataVolumeBackupSnapshotInProgress DataVolumeBackupPhase = "SnapshotInProgress"
+	// DataVolumeBackupInProgress represents a DataVolumeBackup writing the snapshot content to object storage
+	DataVolumeBackupInProgress DataVolumeBackupPhase = "InProgress"
+	// DataVolumeBackupSucceeded represents a completed DataVolumeBackup
+	DataVolumeBackupSucceeded DataVolumeBackupPhase = "Succeeded"
+	// DataVolumeBackupFailed represents a failed DataVolumeBackup
+	DataVolumeBackupFailed DataVolumeBackupPhase = "Failed"
+)
+
+// DataVolumeBackupList provides the needed parameters to do request a list of DataVolumeBackups from the system
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataVolumeBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// Items provides a list of DataVolumeBackups
+	Items []DataVolumeBackup `json:"items"`
+}
+
 // this has to be here otherwise informer-gen doesn't recognize it
 // see https://github.com/kubernetes/code-generator/issues/59
 // +genclient:nonNamespaced
@@ -1018,6 +1391,19 @@ type FilesystemOverhead struct {
 	Global Percent `json:"global,omitempty"`
 	// StorageClass specifies how much space of a Filesystem volume should be reserved for safety. The keys are the storageClass and the values are the overhead. This value overrides the global value
 	StorageClass map[string]Percent `json:"storageClass,omitempty"`
+	// ApplyCalibration uses the overhead measured by CDI for storage classes without a specific value, instead of the global value
+	// +optional
+	ApplyCalibration bool `json:"applyCalibration,omitempty"`
+}
+
+// FilesystemOverheadCalibration is the filesystem overhead CDI measured on freshly provisioned Filesystem volumes of a storage class
+type FilesystemOverheadCalibration struct {
+	// Recommended is the highest overhead measured, rounded up
+	Recommended Percent `json:"recommended"`
+	// Samples is the number of volumes measured
+	Samples int32 `json:"samples"`
+	// LastMeasurementTime is the time of the latest measurement
+	LastMeasurementTime metav1.Time `json:"lastMeasurementTime"`
 }
 
 // CDIConfigSpec defines specification for user configuration
@@ -1050,6 +1436,31 @@ type CDIConfigSpec struct {
 	// LogVerbosity overrides the default verbosity level used to initialize loggers
 	// +optional
 	LogVerbosity *int32 `json:"logVerbosity,omitempty"`
+	// ImportChunkCache enables a content addressed cache of the imported data, so data already imported on a node
+	// or in a namespace is not downloaded again
+	// +optional
+	ImportChunkCache *ImportChunkCache `json:"importChunkCache,omitempty"`
+	// ConsistentCloneHook is the http(s) endpoint freezing and thawing the workloads using the source of a consistent
+	// clone, instead of the freeze subresources of the KubeVirt VMIs
+	// +optional
+	ConsistentCloneHook *string `json:"consistentCloneHook,omitempty"`
+}
+
+// ImportChunkCache configures where the importer pods cache the chunks of the imported data, either HostPath or
+// ClaimName must be set
+type ImportChunkCache struct {
+	// HostPath is a directory on the nodes, shared by the imports running on the node. It must be writable by the
+	// importer pods, and is only used when the ImportChunkCacheHostPath feature gate is enabled
+	// +optional
+	HostPath string `json:"hostPath,omitempty"`
+	// ClaimName is the name of a PVC shared by the imports of the namespaces where it exists, imports of other
+	// namespaces are not cached. It should be ReadWriteMany to be used by concurrent imports
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
+	// Limit is the size the cache is pruned to at the end of each import, the least recently used chunks are removed
+	// first. The cache is not pruned if not set
+	// +optional
+	Limit *resource.Quantity `json:"limit,omitempty"`
 }
 
 // CDIConfigStatus provides the most recently observed status of the CDI Config resource
@@ -1067,6 +1478,9 @@ type CDIConfigStatus struct {
 	DefaultPodResourceRequirements *corev1.ResourceRequirements `json:"defaultPodResourceRequirements,omitempty"`
 	// FilesystemOverhead describes the space reserved for overhead when using Filesystem volumes. A percentage value is between 0 and 1
 	FilesystemOverhead *FilesystemOverhead `json:"filesystemOverhead,omitempty"`
+	// FilesystemOverheadCalibration is the filesystem overhead measured by imports, the keys are the storageClass
+	// +optional
+	FilesystemOverheadCalibration map[string]FilesystemOverheadCalibration `json:"filesystemOverheadCalibration,omitempty"`
 	// Preallocation controls whether storage for DataVolumes should be allocated in advance.
 	Preallocation bool `json:"preallocation,omitempty"`
 	// The imagePullSecrets used to pull the container images
@@ -21,6 +21,25 @@ func (DataVolumeSpec) SwaggerDoc() map[string]string {
 		"checkpoints":       "Checkpoints is a list of DataVolumeCheckpoints, representing stages in a multistage import.",
 		"finalCheckpoint":   "FinalCheckpoint indicates whether the current DataVolumeCheckpoint is the final checkpoint.",
 		"preallocation":     "Preallocation controls whether storage for DataVolumes should be allocated in advance.",
+		"patch":             "Patch writes the import source into the existing PVC of the DataVolume instead of importing a whole disk\n+optional",
+		"customization":     "Customization runs a recipe of customizations against the imported disk image\n+optional",
+		"expandPartition":   "ExpandPartition grows the last GPT partition of the disk and its ext4 or xfs filesystem along with the disk image\n+optional",
+		"sparsify":          "Sparsify deallocates the blocks of zeroes of the disk image once it is written, reclaiming thin provisioned storage. Defaults to the sparsify of the import tuning of the storage profile\n+optional",
+	}
+}
+
+func (DataVolumeCustomization) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "DataVolumeCustomization references the recipe of customizations run against an imported disk image",
+		"configMap": "ConfigMap is the name of the ConfigMap holding the recipe under the \"recipe\" key, in the namespace of the DataVolume",
+	}
+}
+
+func (DataVolumePatch) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "DataVolumePatch defines where the import source is written into an existing disk, exactly one of offset and partitionLabel must be set",
+		"offset":         "Offset is the byte offset in the disk the source is written at\n+optional",
+		"partitionLabel": "PartitionLabel is the name of the GPT partition of the disk the source is written into\n+optional",
 	}
 }
 
@@ -48,7 +67,14 @@ func (DataVolumeCheckpoint) SwaggerDoc() map[string]string {
 
 func (DataVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "DataVolumeSource represents the source for our Data Volume, this can be HTTP, Imageio, S3, GCS, Registry or an existing PVC",
+		"": "DataVolumeSource represents the source for our Data Volume, this can be HTTP, Imageio, S3, GCS, Azure Blob, Registry or an existing PVC",
+	}
+}
+
+func (DataVolumeSourceBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "DataVolumeSourceBackup provides the parameters to create a Data Volume from a DataVolumeBackup",
+		"name": "The name of the source DataVolumeBackup, in the namespace of the Data Volume",
 	}
 }
 
@@ -90,18 +116,52 @@ func (DataVolumeSourceUpload) SwaggerDoc() map[string]string {
 
 func (DataVolumeSourceS3) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "DataVolumeSourceS3 provides the parameters to create a Data Volume from an S3 source",
-		"url":           "URL is the url of the S3 source",
-		"secretRef":     "SecretRef provides the secret reference needed to access the S3 source",
-		"certConfigMap": "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
+		"":                "DataVolumeSourceS3 provides the parameters to create a Data Volume from an S3 source",
+		"url":             "URL is the url of the S3 source",
+		"secretRef":       "SecretRef provides the secret reference needed to access the S3 source",
+		"certConfigMap":   "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
+		"region":          "Region is the region of the bucket, derived from the host of the URL if empty\n+optional",
+		"addressingStyle": "AddressingStyle is how the bucket is addressed in the requests, path (default) or virtualHost.\nThe URL always has the bucket as first path segment.\n+optional\n+kubebuilder:validation:Enum=path;virtualHost",
+		"webIdentity":     "WebIdentity assumes an IAM role with a projected service account token of the importer pod, instead of using the keys of SecretRef\n+optional",
+	}
+}
+
+func (S3WebIdentity) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "S3WebIdentity provides the parameters to assume an IAM role with the web identity of the importer pod",
+		"roleARN":     "RoleARN is the ARN of the role to assume, it must trust the default service account of the namespace",
+		"audience":    "Audience is the audience of the projected service account token, sts.amazonaws.com if empty\n+optional",
+		"stsEndpoint": "STSEndpoint is the url of the STS service, such as http://minio:9000. The AWS STS endpoint of the region if empty.\n+optional",
 	}
 }
 
 func (DataVolumeSourceGCS) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":          "DataVolumeSourceGCS provides the parameters to create a Data Volume from an GCS source",
-		"url":       "URL is the url of the GCS source",
-		"secretRef": "SecretRef provides the secret reference needed to access the GCS source",
+		"":                 "DataVolumeSourceGCS provides the parameters to create a Data Volume from an GCS source",
+		"url":              "URL is the url of the GCS source",
+		"secretRef":        "SecretRef provides the secret reference needed to access the GCS source",
+		"workloadIdentity": "WorkloadIdentity authenticates with the identity of the importer pod instead of SecretRef.\nObjects are read anonymously, such as those of public buckets, when neither is set.\n+optional",
+	}
+}
+
+func (GCSWorkloadIdentity) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "GCSWorkloadIdentity provides the parameters to authenticate to GCS with the workload identity of the importer pod",
+		"provider":       "Provider is the workload identity pool provider exchanging a projected service account token of the importer pod,\nsuch as //iam.googleapis.com/projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>.\nThe Application Default Credentials of the importer pod, such as those of GKE workload identity, are used if empty.\n+optional",
+		"serviceAccount": "ServiceAccount is the email of the service account impersonated with the federated token\n+optional",
+		"audience":       "Audience is the audience of the projected service account token, the https url of the provider if empty\n+optional",
+	}
+}
+
+func (DataVolumeSourceAzureBlob) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "DataVolumeSourceAzureBlob provides the parameters to create a Data Volume from an Azure Blob Storage source",
+		"account":       "Account is the name of the storage account",
+		"container":     "Container is the name of the blob container",
+		"blob":          "Blob is the name of the blob",
+		"endpoint":      "Endpoint is the url of the blob service, https://<account>.blob.core.windows.net if empty.\nEndpoints with a path, such as http://azurite:10000/devstoreaccount1, are used as is.\n+optional",
+		"secretRef":     "SecretRef provides the secret reference needed to access the Azure Blob source, the secret should contain\neither accountKey (shared key), sasToken (shared access signature), or tenantId, clientId and clientSecret\n(service principal). The blob is read anonymously if empty\n+optional",
+		"certConfigMap": "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
 	}
 }
 
@@ -131,6 +191,7 @@ func (DataVolumeSourceHTTP) SwaggerDoc() map[string]string {
 		"certConfigMap":      "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
 		"extraHeaders":       "ExtraHeaders is a list of strings containing extra headers to include with HTTP transfer requests\n+optional",
 		"secretExtraHeaders": "SecretExtraHeaders is a list of Secret references, each containing an extra HTTP header that may include sensitive information\n+optional",
+		"differencingDisks":  "DifferencingDisks is an ordered list of URLs of Hyper-V differencing disks (AVHDX) applied on top of the VHDX at URL,\nthe first entry is the child of URL and the last entry is the current state of the disk\n+optional",
 	}
 }
 
@@ -169,10 +230,13 @@ func (DataVolumeSourceRef) SwaggerDoc() map[string]string {
 
 func (DataVolumeStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":             "DataVolumeStatus contains the current status of the DataVolume",
-		"claimName":    "ClaimName is the name of the underlying PVC used by the DataVolume.",
-		"phase":        "Phase is the current phase of the data volume",
-		"restartCount": "RestartCount is the number of times the pod populating the DataVolume has restarted",
+		"":                       "DataVolumeStatus contains the current status of the DataVolume",
+		"claimName":              "ClaimName is the name of the underlying PVC used by the DataVolume.",
+		"phase":                  "Phase is the current phase of the data volume",
+		"restartCount":           "RestartCount is the number of times the pod populating the DataVolume has restarted",
+		"throughput":             "Throughput is the current data transfer rate of the population in bytes per second\n+optional",
+		"estimatedTimeRemaining": "EstimatedTimeRemaining is the estimated time until the data transfer of the population completes\n+optional",
+		"reclaimedSpace":         "ReclaimedSpace is the size of the blocks of zeroes deallocated by the last sparsify of the disk image\n+optional",
 	}
 }
 
@@ -202,6 +266,8 @@ func (StorageProfileSpec) SwaggerDoc() map[string]string {
 		"claimPropertySets":          "ClaimPropertySets is a provided set of properties applicable to PVC\n+kubebuilder:validation:MaxItems=8",
 		"dataImportCronSourceFormat": "DataImportCronSourceFormat defines the format of the DataImportCron-created disk image sources",
 		"snapshotClass":              "SnapshotClass is optional specific VolumeSnapshotClass for CloneStrategySnapshot. If not set, a VolumeSnapshotClass is chosen according to the provisioner.",
+		"importTuning":               "ImportTuning holds the settings used to write the data of the volumes of this storage class\n+optional",
+		"cloneStrategySelection":     "CloneStrategySelection is how the clone strategy of the clones to this storage class is chosen. Capability, the default, uses the clone strategy of the status. Measured uses the fastest strategy of the clone statistics for the storage class of the source, if any\n+kubebuilder:validation:Enum=Capability;Measured\n+optional",
 	}
 }
 
@@ -214,6 +280,45 @@ func (StorageProfileStatus) SwaggerDoc() map[string]string {
 		"claimPropertySets":          "ClaimPropertySets computed from the spec and detected in the system\n+kubebuilder:validation:MaxItems=8",
 		"dataImportCronSourceFormat": "DataImportCronSourceFormat defines the format of the DataImportCron-created disk image sources",
 		"snapshotClass":              "SnapshotClass is optional specific VolumeSnapshotClass for CloneStrategySnapshot. If not set, a VolumeSnapshotClass is chosen according to the provisioner.",
+		"probe":                      "Probe is the result of probing the capabilities of a provisioner unknown to CDI, when the StorageProfileProbe feature gate is enabled\n+optional",
+		"importTuning":               "ImportTuning holds the settings used to write the data of the volumes of this storage class\n+optional",
+		"cloneStatistics":            "CloneStatistics are the measured durations of the completed clones to this storage class, per source storage class and clone strategy\n+kubebuilder:validation:MaxItems=32\n+optional",
+	}
+}
+
+func (CloneStatistics) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "CloneStatistics are the measured durations of the clones from a source storage class with a clone strategy",
+		"sourceStorageClass": "SourceStorageClass is the storage class of the cloned PVCs",
+		"strategy":           "Strategy is the clone strategy used",
+		"count":              "Count is the number of completed clones",
+		"averageDuration":    "AverageDuration is the average duration of the recent clones",
+		"averageThroughput":  "AverageThroughput is the average number of bytes per second of the recent clones",
+		"lastCompletionTime": "LastCompletionTime is the time the last clone completed",
+	}
+}
+
+func (ImportTuning) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "ImportTuning holds the settings the importer, upload and clone target pods use to write the data of a volume",
+		"preallocation":  "Preallocation is the default preallocation of the volumes, it overrides the CDIConfig preallocation and is overridden by the DataVolume preallocation\n+optional",
+		"cacheMode":      "CacheMode is the qemu-img cache mode of the volume writes. If not set, writeback is used, or none after an import ran out of memory if the volume supports direct IO\n+kubebuilder:validation:Enum=none;writeback;writethrough;directsync;unsafe\n+optional",
+		"writeBlockSize": "WriteBlockSize is the granularity of the qemu-img writes and zero detection, a multiple of 512 bytes up to 2Mi. qemu-img defaults to 4Ki\n+optional",
+		"zeroDetection":  "ZeroDetection controls whether blocks of zeroes are discarded, leaving the volume sparse, or written out. Defaults to Discard\n+kubebuilder:validation:Enum=Discard;Disabled\n+optional",
+		"sparsify":       "Sparsify is the default sparsify of the volumes, it is overridden by the DataVolume sparsify\n+optional",
+	}
+}
+
+func (StorageProfileProbeStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "StorageProfileProbeStatus is the result of probing the storage capabilities with short-lived test volumes",
+		"storageClassUID":   "StorageClassUID is the UID of the probed StorageClass, a recreated StorageClass is probed again",
+		"phase":             "Phase is the current phase of the probe",
+		"startTime":         "StartTime is the time the probe started",
+		"completionTime":    "CompletionTime is the time the probe completed\n+optional",
+		"claimPropertySets": "ClaimPropertySets are the access and volume mode combinations the test volumes were provisioned with\n+kubebuilder:validation:MaxItems=8\n+optional",
+		"cloneStrategy":     "CloneStrategy is the most efficient clone strategy supported by the test volumes\n+optional",
+		"snapshotClass":     "SnapshotClass is the VolumeSnapshotClass a test volume was successfully snapshotted with\n+optional",
 	}
 }
 
@@ -240,8 +345,9 @@ func (DataSource) SwaggerDoc() map[string]string {
 
 func (DataSourceSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":       "DataSourceSpec defines specification for DataSource",
-		"source": "Source is the source of the data referenced by the DataSource",
+		"":        "DataSourceSpec defines specification for DataSource",
+		"source":  "Source is the source of the data referenced by the DataSource",
+		"storage": "Storage is the storage specification of the cache PVC the external source (http, registry, s3) is imported into\n+optional",
 	}
 }
 
@@ -251,6 +357,9 @@ func (DataSourceSource) SwaggerDoc() map[string]string {
 		"pvc":        "+optional",
 		"snapshot":   "+optional",
 		"dataSource": "+optional",
+		"http":       "HTTP is an external source lazily imported into a cache PVC in the DataSource namespace\n+optional",
+		"registry":   "Registry is an external source lazily imported into a cache PVC in the DataSource namespace\n+optional",
+		"s3":         "S3 is an external source lazily imported into a cache PVC in the DataSource namespace\n+optional",
 	}
 }
 
@@ -347,12 +456,24 @@ func (VolumeImportSourceSpec) SwaggerDoc() map[string]string {
 		"targetClaim":     "TargetClaim the name of the specific claim to be populated with a multistage import.",
 		"checkpoints":     "Checkpoints is a list of DataVolumeCheckpoints, representing stages in a multistage import.",
 		"finalCheckpoint": "FinalCheckpoint indicates whether the current DataVolumeCheckpoint is the final checkpoint.",
+		"customization":   "Customization runs a recipe of customizations against the imported disk image\n+optional",
 	}
 }
 
 func (ImportSourceType) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "ImportSourceType contains each one of the source types allowed in a VolumeImportSource",
+		"":          "ImportSourceType contains each one of the source types allowed in a VolumeImportSource",
+		"azureBlob": "AzureBlob imports a blob from Azure Blob Storage\n+optional",
+		"cdiBackup": "CDIBackup imports a backup stored as a manifest and chunk objects in an S3 compatible object store\n+optional",
+	}
+}
+
+func (DataVolumeSourceCDIBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "DataVolumeSourceCDIBackup provides the parameters to import a chunked backup from an S3 compatible object store",
+		"url":           "URL is the url of the backup manifest, the chunk objects it lists are resolved relative to it",
+		"secretRef":     "SecretRef provides the secret reference needed to access the object store\n+optional",
+		"certConfigMap": "CertConfigMap is a configmap reference, containing a Certificate Authority(CA) public key, and a base64 encoded pem certificate\n+optional",
 	}
 }
 
@@ -419,6 +540,56 @@ func (VolumeCloneSourceList) SwaggerDoc() map[string]string {
 	}
 }
 
+func (DataVolumeBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "DataVolumeBackup snapshots a PVC and writes its content, compressed, to object storage\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=dvbackup;dvbackups,categories=all\n+kubebuilder:subresource:status\n+kubebuilder:printcolumn:name=\"Phase\",type=\"string\",JSONPath=\".status.phase\",description=\"The phase the backup is in\"\n+kubebuilder:printcolumn:name=\"Age\",type=\"date\",JSONPath=\".metadata.creationTimestamp\"",
+		"status": "+optional",
+	}
+}
+
+func (DataVolumeBackupSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                        "DataVolumeBackupSpec defines specification for DataVolumeBackup",
+		"source":                  "Source is the PVC to back up",
+		"destination":             "Destination is the object storage the backup is written to",
+		"volumeSnapshotClassName": "VolumeSnapshotClassName is the VolumeSnapshotClass used to snapshot the source, the one of its StorageProfile if not set\n+optional",
+	}
+}
+
+func (DataVolumeBackupSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "DataVolumeBackupSource is the PVC to back up",
+		"name": "The name of the source PVC, in the namespace of the DataVolumeBackup",
+	}
+}
+
+func (DataVolumeBackupDestination) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":    "DataVolumeBackupDestination is the object storage a backup is written to, exactly one of the fields must be set",
+		"s3":  "S3 is the S3 object the backup is written to\n+optional",
+		"gcs": "GCS is the GCS object the backup is written to\n+optional",
+	}
+}
+
+func (DataVolumeBackupStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "DataVolumeBackupStatus provides the most recently observed status of the DataVolumeBackup",
+		"phase":          "Phase is the current phase of the backup",
+		"size":           "Size is the size of the backed up volume, volumes the backup is restored to must be at least as large\n+optional",
+		"volumeMode":     "VolumeMode is the volume mode of the backed up volume\n+optional",
+		"startTime":      "StartTime is the time the backup started\n+optional",
+		"completionTime": "CompletionTime is the time the backup succeeded or failed\n+optional",
+		"message":        "Message is a human readable message about the current phase\n+optional",
+	}
+}
+
+func (DataVolumeBackupList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "DataVolumeBackupList provides the needed parameters to do request a list of DataVolumeBackups from the system\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items provides a list of DataVolumeBackups",
+	}
+}
+
 func (CDI) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":       "CDI is the CDI Operator CRD\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+kubebuilder:object:root=true\n+kubebuilder:storageversion\n+kubebuilder:resource:shortName=cdi;cdis,scope=Cluster\n+kubebuilder:printcolumn:name=\"Age\",type=\"date\",JSONPath=\".metadata.creationTimestamp\"\n+kubebuilder:printcolumn:name=\"Phase\",type=\"string\",JSONPath=\".status.phase\"",
@@ -509,9 +680,19 @@ func (CDIConfig) SwaggerDoc() map[string]string {
 
 func (FilesystemOverhead) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":             "FilesystemOverhead defines the reserved size for PVCs with VolumeMode: Filesystem",
-		"global":       "Global is how much space of a Filesystem volume should be reserved for overhead. This value is used unless overridden by a more specific value (per storageClass)",
-		"storageClass": "StorageClass specifies how much space of a Filesystem volume should be reserved for safety. The keys are the storageClass and the values are the overhead. This value overrides the global value",
+		"":                 "FilesystemOverhead defines the reserved size for PVCs with VolumeMode: Filesystem",
+		"global":           "Global is how much space of a Filesystem volume should be reserved for overhead. This value is used unless overridden by a more specific value (per storageClass)",
+		"storageClass":     "StorageClass specifies how much space of a Filesystem volume should be reserved for safety. The keys are the storageClass and the values are the overhead. This value overrides the global value",
+		"applyCalibration": "ApplyCalibration uses the overhead measured by CDI for storage classes without a specific value, instead of the global value\n+optional",
+	}
+}
+
+func (FilesystemOverheadCalibration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "FilesystemOverheadCalibration is the filesystem overhead CDI measured on freshly provisioned Filesystem volumes of a storage class",
+		"recommended":         "Recommended is the highest overhead measured, rounded up",
+		"samples":             "Samples is the number of volumes measured",
+		"lastMeasurementTime": "LastMeasurementTime is the time of the latest measurement",
 	}
 }
 
@@ -530,6 +711,17 @@ func (CDIConfigSpec) SwaggerDoc() map[string]string {
 		"tlsSecurityProfile":       "TLSSecurityProfile is used by operators to apply cluster-wide TLS security settings to operands.",
 		"imagePullSecrets":         "The imagePullSecrets used to pull the container images",
 		"logVerbosity":             "LogVerbosity overrides the default verbosity level used to initialize loggers\n+optional",
+		"importChunkCache":         "ImportChunkCache enables a content addressed cache of the imported data, so data already imported on a node\nor in a namespace is not downloaded again\n+optional",
+		"consistentCloneHook":      "ConsistentCloneHook is the http(s) endpoint freezing and thawing the workloads using the source of a consistent\nclone, instead of the freeze subresources of the KubeVirt VMIs\n+optional",
+	}
+}
+
+func (ImportChunkCache) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "ImportChunkCache configures where the importer pods cache the chunks of the imported data, either HostPath or\nClaimName must be set",
+		"hostPath":  "HostPath is a directory on the nodes, shared by the imports running on the node. It must be writable by the\nimporter pods, and is only used when the ImportChunkCacheHostPath feature gate is enabled\n+optional",
+		"claimName": "ClaimName is the name of a PVC shared by the imports of the namespaces where it exists, imports of other\nnamespaces are not cached. It should be ReadWriteMany to be used by concurrent imports\n+optional",
+		"limit":     "Limit is the size the cache is pruned to at the end of each import, the least recently used chunks are removed\nfirst. The cache is not pruned if not set\n+optional",
 	}
 }
 
@@ -542,6 +734,7 @@ func (CDIConfigStatus) SwaggerDoc() map[string]string {
 		"scratchSpaceStorageClass":       "The calculated storage class to be used for scratch space",
 		"defaultPodResourceRequirements": "ResourceRequirements describes the compute resource requirements.",
 		"filesystemOverhead":             "FilesystemOverhead describes the space reserved for overhead when using Filesystem volumes. A percentage value is between 0 and 1",
+		"filesystemOverheadCalibration":  "FilesystemOverheadCalibration is the filesystem overhead measured by imports, the keys are the storageClass\n+optional",
 		"preallocation":                  "Preallocation controls whether storage for DataVolumes should be allocated in advance.",
 		"imagePullSecrets":               "The imagePullSecrets used to pull the container images",
 	}
@@ -77,3 +77,9 @@ func IsWaitForFirstConsumerBeforePopulating(pvc *corev1.PersistentVolumeClaim, g
 	}
 	return false, nil
 }
+
+// IsDataSourceImportSource indicates if the DataSource source is an external source (http, registry, s3)
+// which the DataSource controller imports into a cache PVC, whose reference is then found in the status source
+func IsDataSourceImportSource(source *DataSourceSource) bool {
+	return source.HTTP != nil || source.Registry != nil || source.S3 != nil
+}
@@ -210,6 +210,16 @@ func (in *CDIConfigSpec) DeepCopyInto(out *CDIConfigSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ImportChunkCache != nil {
+		in, out := &in.ImportChunkCache, &out.ImportChunkCache
+		*out = new(ImportChunkCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConsistentCloneHook != nil {
+		in, out := &in.ConsistentCloneHook, &out.ConsistentCloneHook
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -251,6 +261,13 @@ func (in *CDIConfigStatus) DeepCopyInto(out *CDIConfigStatus) {
 		*out = new(FilesystemOverhead)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FilesystemOverheadCalibration != nil {
+		in, out := &in.FilesystemOverheadCalibration, &out.FilesystemOverheadCalibration
+		*out = make(map[string]FilesystemOverheadCalibration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.ImagePullSecrets != nil {
 		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
 		*out = make([]v1.LocalObjectReference, len(*in))
@@ -415,6 +432,25 @@ func (in *ClaimPropertySet) DeepCopy() *ClaimPropertySet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneStatistics) DeepCopyInto(out *CloneStatistics) {
+	*out = *in
+	out.AverageDuration = in.AverageDuration
+	out.AverageThroughput = in.AverageThroughput.DeepCopy()
+	in.LastCompletionTime.DeepCopyInto(&out.LastCompletionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneStatistics.
+func (in *CloneStatistics) DeepCopy() *CloneStatistics {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneStatistics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentConfig) DeepCopyInto(out *ComponentConfig) {
 	*out = *in
@@ -797,6 +833,21 @@ func (in *DataSourceSource) DeepCopyInto(out *DataSourceSource) {
 		*out = new(DataSourceRefSourceDataSource)
 		**out = **in
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(DataVolumeSourceHTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(DataVolumeSourceRegistry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(DataVolumeSourceS3)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -814,6 +865,11 @@ func (in *DataSourceSource) DeepCopy() *DataSourceSource {
 func (in *DataSourceSpec) DeepCopyInto(out *DataSourceSpec) {
 	*out = *in
 	in.Source.DeepCopyInto(&out.Source)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -879,6 +935,166 @@ func (in *DataVolume) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeBackup) DeepCopyInto(out *DataVolumeBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeBackup.
+func (in *DataVolumeBackup) DeepCopy() *DataVolumeBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataVolumeBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeBackupDestination) DeepCopyInto(out *DataVolumeBackupDestination) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(DataVolumeSourceS3)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCS != nil {
+		in, out := &in.GCS, &out.GCS
+		*out = new(DataVolumeSourceGCS)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeBackupDestination.
+func (in *DataVolumeBackupDestination) DeepCopy() *DataVolumeBackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeBackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeBackupList) DeepCopyInto(out *DataVolumeBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataVolumeBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeBackupList.
+func (in *DataVolumeBackupList) DeepCopy() *DataVolumeBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataVolumeBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeBackupSource) DeepCopyInto(out *DataVolumeBackupSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeBackupSource.
+func (in *DataVolumeBackupSource) DeepCopy() *DataVolumeBackupSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeBackupSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeBackupSpec) DeepCopyInto(out *DataVolumeBackupSpec) {
+	*out = *in
+	out.Source = in.Source
+	in.Destination.DeepCopyInto(&out.Destination)
+	if in.VolumeSnapshotClassName != nil {
+		in, out := &in.VolumeSnapshotClassName, &out.VolumeSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeBackupSpec.
+func (in *DataVolumeBackupSpec) DeepCopy() *DataVolumeBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeBackupStatus) DeepCopyInto(out *DataVolumeBackupStatus) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(v1.PersistentVolumeMode)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeBackupStatus.
+func (in *DataVolumeBackupStatus) DeepCopy() *DataVolumeBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeBlankImage) DeepCopyInto(out *DataVolumeBlankImage) {
 	*out = *in
@@ -929,6 +1145,22 @@ func (in *DataVolumeCondition) DeepCopy() *DataVolumeCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeCustomization) DeepCopyInto(out *DataVolumeCustomization) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeCustomization.
+func (in *DataVolumeCustomization) DeepCopy() *DataVolumeCustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeCustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeList) DeepCopyInto(out *DataVolumeList) {
 	*out = *in
@@ -962,6 +1194,27 @@ func (in *DataVolumeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumePatch) DeepCopyInto(out *DataVolumePatch) {
+	*out = *in
+	if in.Offset != nil {
+		in, out := &in.Offset, &out.Offset
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumePatch.
+func (in *DataVolumePatch) DeepCopy() *DataVolumePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 	*out = *in
@@ -973,11 +1226,16 @@ func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 	if in.S3 != nil {
 		in, out := &in.S3, &out.S3
 		*out = new(DataVolumeSourceS3)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.GCS != nil {
 		in, out := &in.GCS, &out.GCS
 		*out = new(DataVolumeSourceGCS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureBlob != nil {
+		in, out := &in.AzureBlob, &out.AzureBlob
+		*out = new(DataVolumeSourceAzureBlob)
 		**out = **in
 	}
 	if in.Registry != nil {
@@ -1015,6 +1273,11 @@ func (in *DataVolumeSource) DeepCopyInto(out *DataVolumeSource) {
 		*out = new(DataVolumeSourceSnapshot)
 		**out = **in
 	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(DataVolumeSourceBackup)
+		**out = **in
+	}
 	return
 }
 
@@ -1028,9 +1291,62 @@ func (in *DataVolumeSource) DeepCopy() *DataVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceAzureBlob) DeepCopyInto(out *DataVolumeSourceAzureBlob) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceAzureBlob.
+func (in *DataVolumeSourceAzureBlob) DeepCopy() *DataVolumeSourceAzureBlob {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceAzureBlob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceBackup) DeepCopyInto(out *DataVolumeSourceBackup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceBackup.
+func (in *DataVolumeSourceBackup) DeepCopy() *DataVolumeSourceBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumeSourceCDIBackup) DeepCopyInto(out *DataVolumeSourceCDIBackup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumeSourceCDIBackup.
+func (in *DataVolumeSourceCDIBackup) DeepCopy() *DataVolumeSourceCDIBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolumeSourceCDIBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSourceGCS) DeepCopyInto(out *DataVolumeSourceGCS) {
 	*out = *in
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(GCSWorkloadIdentity)
+		**out = **in
+	}
 	return
 }
 
@@ -1057,6 +1373,11 @@ func (in *DataVolumeSourceHTTP) DeepCopyInto(out *DataVolumeSourceHTTP) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DifferencingDisks != nil {
+		in, out := &in.DifferencingDisks, &out.DifferencingDisks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1177,6 +1498,11 @@ func (in *DataVolumeSourceRegistry) DeepCopy() *DataVolumeSourceRegistry {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeSourceS3) DeepCopyInto(out *DataVolumeSourceS3) {
 	*out = *in
+	if in.WebIdentity != nil {
+		in, out := &in.WebIdentity, &out.WebIdentity
+		*out = new(S3WebIdentity)
+		**out = **in
+	}
 	return
 }
 
@@ -1271,6 +1597,26 @@ func (in *DataVolumeSpec) DeepCopyInto(out *DataVolumeSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = new(DataVolumePatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Customization != nil {
+		in, out := &in.Customization, &out.Customization
+		*out = new(DataVolumeCustomization)
+		**out = **in
+	}
+	if in.ExpandPartition != nil {
+		in, out := &in.ExpandPartition, &out.ExpandPartition
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Sparsify != nil {
+		in, out := &in.Sparsify, &out.Sparsify
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1294,6 +1640,21 @@ func (in *DataVolumeStatus) DeepCopyInto(out *DataVolumeStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Throughput != nil {
+		in, out := &in.Throughput, &out.Throughput
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EstimatedTimeRemaining != nil {
+		in, out := &in.EstimatedTimeRemaining, &out.EstimatedTimeRemaining
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ReclaimedSpace != nil {
+		in, out := &in.ReclaimedSpace, &out.ReclaimedSpace
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
@@ -1330,6 +1691,23 @@ func (in *FilesystemOverhead) DeepCopy() *FilesystemOverhead {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemOverheadCalibration) DeepCopyInto(out *FilesystemOverheadCalibration) {
+	*out = *in
+	in.LastMeasurementTime.DeepCopyInto(&out.LastMeasurementTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemOverheadCalibration.
+func (in *FilesystemOverheadCalibration) DeepCopy() *FilesystemOverheadCalibration {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemOverheadCalibration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Flags) DeepCopyInto(out *Flags) {
 	*out = *in
@@ -1367,6 +1745,43 @@ func (in *Flags) DeepCopy() *Flags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSWorkloadIdentity) DeepCopyInto(out *GCSWorkloadIdentity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSWorkloadIdentity.
+func (in *GCSWorkloadIdentity) DeepCopy() *GCSWorkloadIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSWorkloadIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportChunkCache) DeepCopyInto(out *ImportChunkCache) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportChunkCache.
+func (in *ImportChunkCache) DeepCopy() *ImportChunkCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportChunkCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImportProxy) DeepCopyInto(out *ImportProxy) {
 	*out = *in
@@ -1414,7 +1829,7 @@ func (in *ImportSourceType) DeepCopyInto(out *ImportSourceType) {
 	if in.S3 != nil {
 		in, out := &in.S3, &out.S3
 		*out = new(DataVolumeSourceS3)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Registry != nil {
 		in, out := &in.Registry, &out.Registry
@@ -1424,7 +1839,7 @@ func (in *ImportSourceType) DeepCopyInto(out *ImportSourceType) {
 	if in.GCS != nil {
 		in, out := &in.GCS, &out.GCS
 		*out = new(DataVolumeSourceGCS)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Blank != nil {
 		in, out := &in.Blank, &out.Blank
@@ -1441,6 +1856,16 @@ func (in *ImportSourceType) DeepCopyInto(out *ImportSourceType) {
 		*out = new(DataVolumeSourceVDDK)
 		**out = **in
 	}
+	if in.AzureBlob != nil {
+		in, out := &in.AzureBlob, &out.AzureBlob
+		*out = new(DataVolumeSourceAzureBlob)
+		**out = **in
+	}
+	if in.CDIBackup != nil {
+		in, out := &in.CDIBackup, &out.CDIBackup
+		*out = new(DataVolumeSourceCDIBackup)
+		**out = **in
+	}
 	return
 }
 
@@ -1470,6 +1895,47 @@ func (in *ImportStatus) DeepCopy() *ImportStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportTuning) DeepCopyInto(out *ImportTuning) {
+	*out = *in
+	if in.Preallocation != nil {
+		in, out := &in.Preallocation, &out.Preallocation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CacheMode != nil {
+		in, out := &in.CacheMode, &out.CacheMode
+		*out = new(ImportCacheMode)
+		**out = **in
+	}
+	if in.WriteBlockSize != nil {
+		in, out := &in.WriteBlockSize, &out.WriteBlockSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ZeroDetection != nil {
+		in, out := &in.ZeroDetection, &out.ZeroDetection
+		*out = new(ImportZeroDetection)
+		**out = **in
+	}
+	if in.Sparsify != nil {
+		in, out := &in.Sparsify, &out.Sparsify
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportTuning.
+func (in *ImportTuning) DeepCopy() *ImportTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntermediateTLSProfile) DeepCopyInto(out *IntermediateTLSProfile) {
 	*out = *in
@@ -1666,6 +2132,22 @@ func (in *PlatformOptions) DeepCopy() *PlatformOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3WebIdentity) DeepCopyInto(out *S3WebIdentity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3WebIdentity.
+func (in *S3WebIdentity) DeepCopy() *S3WebIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(S3WebIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageProfile) DeepCopyInto(out *StorageProfile) {
 	*out = *in
@@ -1727,6 +2209,44 @@ func (in *StorageProfileList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageProfileProbeStatus) DeepCopyInto(out *StorageProfileProbeStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ClaimPropertySets != nil {
+		in, out := &in.ClaimPropertySets, &out.ClaimPropertySets
+		*out = make([]ClaimPropertySet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CloneStrategy != nil {
+		in, out := &in.CloneStrategy, &out.CloneStrategy
+		*out = new(CDICloneStrategy)
+		**out = **in
+	}
+	if in.SnapshotClass != nil {
+		in, out := &in.SnapshotClass, &out.SnapshotClass
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageProfileProbeStatus.
+func (in *StorageProfileProbeStatus) DeepCopy() *StorageProfileProbeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageProfileProbeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageProfileSpec) DeepCopyInto(out *StorageProfileSpec) {
 	*out = *in
@@ -1752,6 +2272,16 @@ func (in *StorageProfileSpec) DeepCopyInto(out *StorageProfileSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ImportTuning != nil {
+		in, out := &in.ImportTuning, &out.ImportTuning
+		*out = new(ImportTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloneStrategySelection != nil {
+		in, out := &in.CloneStrategySelection, &out.CloneStrategySelection
+		*out = new(CloneStrategySelection)
+		**out = **in
+	}
 	return
 }
 
@@ -1800,6 +2330,23 @@ func (in *StorageProfileStatus) DeepCopyInto(out *StorageProfileStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Probe != nil {
+		in, out := &in.Probe, &out.Probe
+		*out = new(StorageProfileProbeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImportTuning != nil {
+		in, out := &in.ImportTuning, &out.ImportTuning
+		*out = new(ImportTuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloneStatistics != nil {
+		in, out := &in.CloneStatistics, &out.CloneStatistics
+		*out = make([]CloneStatistics, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2142,6 +2689,11 @@ func (in *VolumeImportSourceSpec) DeepCopyInto(out *VolumeImportSourceSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Customization != nil {
+		in, out := &in.Customization, &out.Customization
+		*out = new(DataVolumeCustomization)
+		**out = **in
+	}
 	return
 }
 
@@ -1,6 +1,7 @@
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,12 +29,26 @@ type OvirtVolumePopulatorSpec struct {
 	DiskID    string `json:"diskId"`
 	// The network attachment definition that should be used for disk transfer.
 	TransferNetwork *string `json:"transferNetwork,omitempty"`
+	// Checkpoints are the stages of a warm migration. Current is the ID of an oVirt VM backup including the disk,
+	// Previous is the checkpoint the backup was taken from, empty for the full backup of the first stage.
+	// Each stage only copies the extents that changed since Previous.
+	// +optional
+	Checkpoints []PopulatorCheckpoint `json:"checkpoints,omitempty"`
+	// FinalCheckpoint indicates whether the last checkpoint is the final stage of the warm migration.
+	// +optional
+	FinalCheckpoint *bool `json:"finalCheckpoint,omitempty"`
 }
 
 // OvirtVolumePopulatorStatus is the status of the OvirtVolumePopulator CR
 type OvirtVolumePopulatorStatus struct {
 	// +optional
 	Progress *string `json:"progress,omitempty"`
+	// Throughput is the current data transfer rate of the population in bytes per second
+	// +optional
+	Throughput *resource.Quantity `json:"throughput,omitempty"`
+	// EstimatedTimeRemaining is the estimated time until the data transfer of the population completes
+	// +optional
+	EstimatedTimeRemaining *metav1.Duration `json:"estimatedTimeRemaining,omitempty"`
 }
 
 // OvirtVolumePopulatorList contains a list of OvirtVolumePopulators
@@ -62,18 +77,52 @@ type OpenstackVolumePopulator struct {
 }
 
 // OpenstackVolumePopulatorSpec is the spec of the OpenstackVolumePopulator CR
+// +kubebuilder:validation:XValidation:rule="size([has(self.imageId) && size(self.imageId) > 0, has(self.volumeId) && size(self.volumeId) > 0, has(self.snapshotId) && size(self.snapshotId) > 0].filter(isSet, isSet)) <= 1",message="only one of imageId, volumeId or snapshotId can be set"
+// +kubebuilder:validation:XValidation:rule="(has(self.imageId) && size(self.imageId) > 0) || (has(self.volumeId) && size(self.volumeId) > 0) || (has(self.snapshotId) && size(self.snapshotId) > 0) || (has(self.checkpoints) && size(self.checkpoints) > 0)",message="one of imageId, volumeId, snapshotId or checkpoints must be set"
 type OpenstackVolumePopulatorSpec struct {
 	IdentityURL string `json:"identityUrl"`
 	SecretRef   string `json:"secretRef"`
-	ImageID     string `json:"imageId"`
+	// ImageID is the Glance image to copy. Only one of ImageID, VolumeID or SnapshotID can be set, and one of them
+	// must be set unless Checkpoints are set.
+	// +optional
+	ImageID string `json:"imageId,omitempty"`
+	// VolumeID is the Cinder volume to copy, the volume is uploaded to a temporary image.
+	// +optional
+	VolumeID string `json:"volumeId,omitempty"`
+	// SnapshotID is the Cinder volume snapshot to copy, the snapshot is uploaded to a temporary image
+	// through a temporary volume.
+	// +optional
+	SnapshotID string `json:"snapshotId,omitempty"`
 	// The network attachment definition that should be used for disk transfer.
 	TransferNetwork *string `json:"transferNetwork,omitempty"`
+	// Checkpoints are the stages of a multi-stage population. Current is the ID of a Cinder snapshot of the volume,
+	// Previous is not used: Cinder does not report the blocks changed between snapshots.
+	// Each stage is a full copy of its snapshot, the image is not used when checkpoints are set.
+	// +optional
+	Checkpoints []PopulatorCheckpoint `json:"checkpoints,omitempty"`
+	// FinalCheckpoint indicates whether the last checkpoint is the final stage of the warm migration.
+	// +optional
+	FinalCheckpoint *bool `json:"finalCheckpoint,omitempty"`
+}
+
+// PopulatorCheckpoint defines a stage in a warm migration.
+type PopulatorCheckpoint struct {
+	// Previous is the identifier of the snapshot from the previous checkpoint.
+	Previous string `json:"previous"`
+	// Current is the identifier of the snapshot created for this checkpoint.
+	Current string `json:"current"`
 }
 
 // OpenstackVolumePopulatorStatus is the status of the OpenstackVolumePopulator CR
 type OpenstackVolumePopulatorStatus struct {
 	// +optional
 	Progress *string `json:"progress,omitempty"`
+	// Throughput is the current data transfer rate of the population in bytes per second
+	// +optional
+	Throughput *resource.Quantity `json:"throughput,omitempty"`
+	// EstimatedTimeRemaining is the estimated time until the data transfer of the population completes
+	// +optional
+	EstimatedTimeRemaining *metav1.Duration `json:"estimatedTimeRemaining,omitempty"`
 }
 
 // OpenstackVolumePopulatorList contains a list of OpenstackVolumePopulators
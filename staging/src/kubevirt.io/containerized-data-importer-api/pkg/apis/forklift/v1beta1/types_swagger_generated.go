@@ -13,13 +13,17 @@ func (OvirtVolumePopulatorSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                "OvirtVolumePopulatorSpec is the spec of the OvirtVolumePopulator CR",
 		"transferNetwork": "The network attachment definition that should be used for disk transfer.",
+		"checkpoints":     "Checkpoints are the stages of a warm migration. Current is the ID of an oVirt VM backup including the disk,\nPrevious is the checkpoint the backup was taken from, empty for the full backup of the first stage.\nEach stage only copies the extents that changed since Previous.\n+optional",
+		"finalCheckpoint": "FinalCheckpoint indicates whether the last checkpoint is the final stage of the warm migration.\n+optional",
 	}
 }
 
 func (OvirtVolumePopulatorStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "OvirtVolumePopulatorStatus is the status of the OvirtVolumePopulator CR",
-		"progress": "+optional",
+		"":                       "OvirtVolumePopulatorStatus is the status of the OvirtVolumePopulator CR",
+		"progress":               "+optional",
+		"throughput":             "Throughput is the current data transfer rate of the population in bytes per second\n+optional",
+		"estimatedTimeRemaining": "EstimatedTimeRemaining is the estimated time until the data transfer of the population completes\n+optional",
 	}
 }
 
@@ -38,15 +42,30 @@ func (OpenstackVolumePopulator) SwaggerDoc() map[string]string {
 
 func (OpenstackVolumePopulatorSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                "OpenstackVolumePopulatorSpec is the spec of the OpenstackVolumePopulator CR",
+		"":                "OpenstackVolumePopulatorSpec is the spec of the OpenstackVolumePopulator CR\n+kubebuilder:validation:XValidation:rule=\"size([has(self.imageId) && size(self.imageId) > 0, has(self.volumeId) && size(self.volumeId) > 0, has(self.snapshotId) && size(self.snapshotId) > 0].filter(isSet, isSet)) <= 1\",message=\"only one of imageId, volumeId or snapshotId can be set\"\n+kubebuilder:validation:XValidation:rule=\"(has(self.imageId) && size(self.imageId) > 0) || (has(self.volumeId) && size(self.volumeId) > 0) || (has(self.snapshotId) && size(self.snapshotId) > 0) || (has(self.checkpoints) && size(self.checkpoints) > 0)\",message=\"one of imageId, volumeId, snapshotId or checkpoints must be set\"",
+		"imageId":         "ImageID is the Glance image to copy. Only one of ImageID, VolumeID or SnapshotID can be set, and one of them\nmust be set unless Checkpoints are set.\n+optional",
+		"volumeId":        "VolumeID is the Cinder volume to copy, the volume is uploaded to a temporary image.\n+optional",
+		"snapshotId":      "SnapshotID is the Cinder volume snapshot to copy, the snapshot is uploaded to a temporary image\nthrough a temporary volume.\n+optional",
 		"transferNetwork": "The network attachment definition that should be used for disk transfer.",
+		"checkpoints":     "Checkpoints are the stages of a multi-stage population. Current is the ID of a Cinder snapshot of the volume,\nPrevious is not used: Cinder does not report the blocks changed between snapshots.\nEach stage is a full copy of its snapshot, the image is not used when checkpoints are set.\n+optional",
+		"finalCheckpoint": "FinalCheckpoint indicates whether the last checkpoint is the final stage of the warm migration.\n+optional",
+	}
+}
+
+func (PopulatorCheckpoint) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "PopulatorCheckpoint defines a stage in a warm migration.",
+		"previous": "Previous is the identifier of the snapshot from the previous checkpoint.",
+		"current":  "Current is the identifier of the snapshot created for this checkpoint.",
 	}
 }
 
 func (OpenstackVolumePopulatorStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "OpenstackVolumePopulatorStatus is the status of the OpenstackVolumePopulator CR",
-		"progress": "+optional",
+		"":                       "OpenstackVolumePopulatorStatus is the status of the OpenstackVolumePopulator CR",
+		"progress":               "+optional",
+		"throughput":             "Throughput is the current data transfer rate of the population in bytes per second\n+optional",
+		"estimatedTimeRemaining": "EstimatedTimeRemaining is the estimated time until the data transfer of the population completes\n+optional",
 	}
 }
 
@@ -22,6 +22,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -94,6 +95,16 @@ func (in *OpenstackVolumePopulatorSpec) DeepCopyInto(out *OpenstackVolumePopulat
 		*out = new(string)
 		**out = **in
 	}
+	if in.Checkpoints != nil {
+		in, out := &in.Checkpoints, &out.Checkpoints
+		*out = make([]PopulatorCheckpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.FinalCheckpoint != nil {
+		in, out := &in.FinalCheckpoint, &out.FinalCheckpoint
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -115,6 +126,16 @@ func (in *OpenstackVolumePopulatorStatus) DeepCopyInto(out *OpenstackVolumePopul
 		*out = new(string)
 		**out = **in
 	}
+	if in.Throughput != nil {
+		in, out := &in.Throughput, &out.Throughput
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EstimatedTimeRemaining != nil {
+		in, out := &in.EstimatedTimeRemaining, &out.EstimatedTimeRemaining
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -197,6 +218,16 @@ func (in *OvirtVolumePopulatorSpec) DeepCopyInto(out *OvirtVolumePopulatorSpec)
 		*out = new(string)
 		**out = **in
 	}
+	if in.Checkpoints != nil {
+		in, out := &in.Checkpoints, &out.Checkpoints
+		*out = make([]PopulatorCheckpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.FinalCheckpoint != nil {
+		in, out := &in.FinalCheckpoint, &out.FinalCheckpoint
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -218,6 +249,16 @@ func (in *OvirtVolumePopulatorStatus) DeepCopyInto(out *OvirtVolumePopulatorStat
 		*out = new(string)
 		**out = **in
 	}
+	if in.Throughput != nil {
+		in, out := &in.Throughput, &out.Throughput
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EstimatedTimeRemaining != nil {
+		in, out := &in.EstimatedTimeRemaining, &out.EstimatedTimeRemaining
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -230,3 +271,19 @@ func (in *OvirtVolumePopulatorStatus) DeepCopy() *OvirtVolumePopulatorStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PopulatorCheckpoint) DeepCopyInto(out *PopulatorCheckpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PopulatorCheckpoint.
+func (in *PopulatorCheckpoint) DeepCopy() *PopulatorCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(PopulatorCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
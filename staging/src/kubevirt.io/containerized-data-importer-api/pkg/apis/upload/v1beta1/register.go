@@ -33,6 +33,9 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&UploadTokenRequest{},
 		&UploadTokenRequestList{},
+		&DataSourceCatalogEntry{},
+		&DataSourceCatalogEntryList{},
+		&DataVolumeProgressReport{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
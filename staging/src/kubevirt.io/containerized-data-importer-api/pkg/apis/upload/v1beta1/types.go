@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -55,3 +56,65 @@ type UploadTokenRequestList struct {
 	// Items contains a list of UploadTokenRequests
 	Items []UploadTokenRequest `json:"items"`
 }
+
+// DataSourceCatalogEntry describes a DataSource the requesting user is allowed to clone from
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataSourceCatalogEntry struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Status contains the catalog information of the DataSource
+	Status DataSourceCatalogEntryStatus `json:"status"`
+}
+
+// DataSourceCatalogEntryStatus provides the catalog information of a DataSource
+type DataSourceCatalogEntryStatus struct {
+	// OperatingSystem is the operating system of the image, taken from the DataSource labels
+	OperatingSystem string `json:"operatingSystem,omitempty"`
+	// Architecture is the architecture of the image, taken from the DataSource labels
+	Architecture string `json:"architecture,omitempty"`
+	// Version is the version of the image, taken from the DataSource labels
+	Version string `json:"version,omitempty"`
+	// Ready indicates the DataSource can be used as a clone source
+	Ready bool `json:"ready"`
+	// Size is the size of the DataSource source PVC or snapshot
+	Size *resource.Quantity `json:"size,omitempty"`
+	// LastUpdateTime is the last time the DataSource readiness changed
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// DataSourceCatalogEntryList contains a list of DataSourceCatalogEntries
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataSourceCatalogEntryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items contains a list of DataSourceCatalogEntries
+	Items []DataSourceCatalogEntry `json:"items"`
+}
+
+// DataVolumeProgressReport is a sample of the data transfer of a DataVolume, taken from its worker pod
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DataVolumeProgressReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Status contains the data transfer progress of the DataVolume
+	Status DataVolumeProgressReportStatus `json:"status"`
+}
+
+// DataVolumeProgressReportStatus provides the data transfer progress of a DataVolume
+type DataVolumeProgressReportStatus struct {
+	// Phase is the current phase of the DataVolume
+	Phase string `json:"phase,omitempty"`
+	// Progress is the percentage of the data transferred
+	Progress string `json:"progress,omitempty"`
+	// Transferred is the number of bytes transferred by the worker pod
+	Transferred *resource.Quantity `json:"transferred,omitempty"`
+	// Total is the number of bytes the worker pod transfers, unset when unknown
+	Total *resource.Quantity `json:"total,omitempty"`
+	// Throughput is the data transfer rate in bytes per second
+	Throughput *resource.Quantity `json:"throughput,omitempty"`
+	// EstimatedTimeRemaining is the estimated time until the data transfer completes
+	EstimatedTimeRemaining *metav1.Duration `json:"estimatedTimeRemaining,omitempty"`
+}
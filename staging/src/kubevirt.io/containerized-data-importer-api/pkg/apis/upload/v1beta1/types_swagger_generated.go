@@ -30,3 +30,48 @@ func (UploadTokenRequestList) SwaggerDoc() map[string]string {
 		"items": "Items contains a list of UploadTokenRequests",
 	}
 }
+
+func (DataSourceCatalogEntry) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "DataSourceCatalogEntry describes a DataSource the requesting user is allowed to clone from\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "Status contains the catalog information of the DataSource",
+	}
+}
+
+func (DataSourceCatalogEntryStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "DataSourceCatalogEntryStatus provides the catalog information of a DataSource",
+		"operatingSystem": "OperatingSystem is the operating system of the image, taken from the DataSource labels",
+		"architecture":    "Architecture is the architecture of the image, taken from the DataSource labels",
+		"version":         "Version is the version of the image, taken from the DataSource labels",
+		"ready":           "Ready indicates the DataSource can be used as a clone source",
+		"size":            "Size is the size of the DataSource source PVC or snapshot",
+		"lastUpdateTime":  "LastUpdateTime is the last time the DataSource readiness changed",
+	}
+}
+
+func (DataSourceCatalogEntryList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "DataSourceCatalogEntryList contains a list of DataSourceCatalogEntries\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"items": "Items contains a list of DataSourceCatalogEntries",
+	}
+}
+
+func (DataVolumeProgressReport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "DataVolumeProgressReport is a sample of the data transfer of a DataVolume, taken from its worker pod\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
+		"status": "Status contains the data transfer progress of the DataVolume",
+	}
+}
+
+func (DataVolumeProgressReportStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                       "DataVolumeProgressReportStatus provides the data transfer progress of a DataVolume",
+		"phase":                  "Phase is the current phase of the DataVolume",
+		"progress":               "Progress is the percentage of the data transferred",
+		"transferred":            "Transferred is the number of bytes transferred by the worker pod",
+		"total":                  "Total is the number of bytes the worker pod transfers, unset when unknown",
+		"throughput":             "Throughput is the data transfer rate in bytes per second",
+		"estimatedTimeRemaining": "EstimatedTimeRemaining is the estimated time until the data transfer completes",
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"kubevirt.io/containerized-data-importer/pkg/controller/clone"
 	controller "kubevirt.io/containerized-data-importer/pkg/controller/common"
 	dvc "kubevirt.io/containerized-data-importer/pkg/controller/datavolume"
+	"kubevirt.io/containerized-data-importer/pkg/image"
 	"kubevirt.io/containerized-data-importer/pkg/token"
 	"kubevirt.io/containerized-data-importer/pkg/util/cert"
 	"kubevirt.io/containerized-data-importer/tests/framework"
@@ -140,6 +141,54 @@ var _ = Describe("all clone tests", func() {
 			Expect(uploader.DeletionTimestamp).To(BeNil())
 		})
 
+		It("Should grow the partition and filesystem of a clone larger than its source", func() {
+			const guestfish = "LIBGUESTFS_BACKEND=direct LIBGUESTFS_CACHEDIR=/tmp HOME=/tmp guestfish --format=raw -a " + utils.DefaultImagePath
+
+			// getPartitionSizes returns the size of the first partition of the disk image and of its filesystem
+			getPartitionSizes := func(pvc *v1.PersistentVolumeClaim) (int64, int64) {
+				output, err := f.RunCommandAndCaptureOutput(pvc, guestfish+" --ro run : blockdev-getsize64 /dev/sda1 : mount-ro /dev/sda1 / : statvfs /", true)
+				Expect(err).ToNot(HaveOccurred())
+				lines := strings.Split(output, "\n")
+				partitionSize, err := strconv.ParseInt(strings.TrimSpace(lines[0]), 10, 64)
+				Expect(err).ToNot(HaveOccurred())
+				stats := map[string]int64{}
+				for _, line := range lines[1:] {
+					if key, value, ok := strings.Cut(line, ":"); ok {
+						stats[strings.TrimSpace(key)], _ = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+					}
+				}
+				Expect(stats).To(HaveKey("frsize"))
+				Expect(stats).To(HaveKey("blocks"))
+				return partitionSize, stats["frsize"] * stats["blocks"]
+			}
+
+			By("Creating a source disk with an ext4 filesystem in a GPT partition")
+			pvcDef := utils.NewPVCDefinition(sourcePVCName, "1Gi", nil, nil)
+			pvcDef.Namespace = f.Namespace.Name
+			sourcePvc = f.CreateAndPopulateSourcePVC(pvcDef, sourcePodFillerName, guestfish+" -N "+utils.DefaultImagePath+"=fs:ext4:256M:gpt exit")
+			sourcePartitionSize, sourceFilesystemSize := getPartitionSizes(sourcePvc)
+			Expect(sourcePartitionSize).To(BeNumerically("<", 256*1024*1024))
+
+			By("Cloning the source into a larger DataVolume expanding its partition")
+			targetDV := utils.NewCloningDataVolume("target-dv", "2Gi", sourcePvc)
+			targetDV.Spec.ExpandPartition = ptr.To(true)
+			targetDataVolume, err := utils.CreateDataVolumeFromDefinition(f.CdiClient, f.Namespace.Name, targetDV)
+			Expect(err).ToNot(HaveOccurred())
+			f.ForceBindPvcIfDvIsWaitForFirstConsumer(targetDataVolume)
+			Expect(utils.WaitForDataVolumePhaseWithTimeout(f, targetDataVolume.Namespace, cdiv1.Succeeded, targetDV.Name, cloneCompleteTimeout)).Should(Succeed())
+			targetPvc, err = f.K8sClient.CoreV1().PersistentVolumeClaims(targetDataVolume.Namespace).Get(context.TODO(), targetDataVolume.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Verifying the partition and its filesystem grew with the disk")
+			var info image.ImgInfo
+			Expect(f.GetImageInfo(f.Namespace, targetPvc, utils.DefaultImagePath, &info)).To(Succeed())
+			targetPartitionSize, targetFilesystemSize := getPartitionSizes(targetPvc)
+			// The partition starts at 1MiB and ends before the backup GPT at the end of the disk
+			Expect(targetPartitionSize).To(BeNumerically(">", info.VirtualSize-4*1024*1024))
+			Expect(targetPartitionSize).To(BeNumerically("<", info.VirtualSize))
+			Expect(targetFilesystemSize).To(BeNumerically(">", sourceFilesystemSize*4))
+		})
+
 		ClonerBehavior := func(storageClass string, cloneType string) {
 
 			DescribeTable("[test_id:1354]Should clone data within same namespace", func(targetSize string) {
@@ -510,7 +510,7 @@ var _ = Describe("[vendor:cnv-qe@redhat.com][level:component]DataVolume tests",
 				url:          func() string { return "http://i-made-this-up.kube-system/tinyCore.iso" },
 				dvFunc:       utils.NewDataVolumeWithHTTPImport,
 				errorMessage: "Unable to connect to http data source",
-				eventReason:  "Error",
+				eventReason:  "ConnectionFailed",
 				phase:        cdiv1.ImportInProgress,
 				readyCondition: &cdiv1.DataVolumeCondition{
 					Type:   cdiv1.DataVolumeReady,
@@ -532,7 +532,7 @@ var _ = Describe("[vendor:cnv-qe@redhat.com][level:component]DataVolume tests",
 					Type:    cdiv1.DataVolumeRunning,
 					Status:  v1.ConditionFalse,
 					Message: "Unable to connect to http data source",
-					Reason:  "Error",
+					Reason:  "ConnectionFailed",
 				}}),
 			Entry("[rfe_id:1115][crit:high][posneg:negative][test_id:1359]fail creating import dv due to file not found", dataVolumeTestArguments{
 				name:         "dv-http-import-404",
@@ -540,7 +540,7 @@ var _ = Describe("[vendor:cnv-qe@redhat.com][level:component]DataVolume tests",
 				url:          func() string { return tinyCoreIsoURL() + "not.real.file" },
 				dvFunc:       utils.NewDataVolumeWithHTTPImport,
 				errorMessage: "Unable to connect to http data source: expected status code 200, got 404. Status: 404 Not Found",
-				eventReason:  "Error",
+				eventReason:  "NotFound",
 				phase:        cdiv1.ImportInProgress,
 				readyCondition: &cdiv1.DataVolumeCondition{
 					Type:   cdiv1.DataVolumeReady,
@@ -562,7 +562,7 @@ var _ = Describe("[vendor:cnv-qe@redhat.com][level:component]DataVolume tests",
 					Type:    cdiv1.DataVolumeRunning,
 					Status:  v1.ConditionFalse,
 					Message: "Unable to connect to http data source: expected status code 200, got 404. Status: 404 Not Found",
-					Reason:  "Error",
+					Reason:  "NotFound",
 				}}),
 			Entry("[rfe_id:1120][crit:high][posneg:negative][test_id:2253]fail creating import dv: invalid qcow large memory", dataVolumeTestArguments{
 				name:             "dv-invalid-qcow-large-memory",
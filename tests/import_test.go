@@ -78,7 +78,7 @@ var _ = Describe("[rfe_id:1115][crit:high][vendor:cnv-qe@redhat.com][level:compo
 			Type:    cdiv1.DataVolumeRunning,
 			Status:  v1.ConditionFalse,
 			Message: "DataVolume too small to contain image",
-			Reason:  "Error",
+			Reason:  string(common.FailureInsufficientSpace),
 		}
 		utils.WaitForConditions(f, dv.Name, f.Namespace.Name, controllerSkipPVCCompleteTimeout, assertionPollInterval, runningCondition)
 	},
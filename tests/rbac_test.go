@@ -216,6 +216,7 @@ var _ = Describe("Aggregated role definition tests", Serial, func() {
 			},
 			Resources: []string{
 				"datavolumes",
+				"datavolumebackups",
 				"dataimportcrons",
 				"datasources",
 				"volumeimportsources",
@@ -262,6 +263,7 @@ var _ = Describe("Aggregated role definition tests", Serial, func() {
 				"dataimportcrons",
 				"datasources",
 				"datavolumes",
+				"datavolumebackups",
 				"objecttransfers",
 				"storageprofiles",
 				"volumeimportsources",
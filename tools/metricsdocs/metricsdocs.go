@@ -12,6 +12,7 @@ import (
 	openstackPopulatorMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/openstack-populator"
 	operatorMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/operator-controller"
 	ovirtPopulatorMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/ovirt-populator"
+	transferMetrics "kubevirt.io/containerized-data-importer/pkg/monitoring/metrics/transfer"
 	"kubevirt.io/containerized-data-importer/pkg/monitoring/rules"
 )
 
@@ -48,6 +49,11 @@ func main() {
 		panic(err)
 	}
 
+	err = transferMetrics.SetupMetrics()
+	if err != nil {
+		panic(err)
+	}
+
 	if err := rules.SetupRules("test"); err != nil {
 		panic(err)
 	}